@@ -33,6 +33,25 @@ type TargetedRefresher interface {
 	SessionByID(sessionID string) (*Session, error)
 }
 
+// Forker is an optional interface for adapters that can copy a session up to
+// a message into a new session in their native format, so the agent can
+// resume the conversation from that point.
+type Forker interface {
+	// ForkSession writes a copy of sessionID that ends after the message
+	// throughMessageID, set up to be resumed from workDir, and returns the
+	// new session's ID.
+	ForkSession(sessionID, throughMessageID, workDir string) (string, error)
+}
+
+// SessionDirProvider is an optional interface for adapters that keep each
+// session in a directory of its own next to Session.Path (e.g. Cline's task
+// directories). Archiving and deleting a session act on the whole directory.
+type SessionDirProvider interface {
+	// SessionDir returns the directory holding only sessionID's files, or ""
+	// if it can't be found.
+	SessionDir(sessionID string) string
+}
+
 // WatchScope indicates whether an adapter watches global or per-project paths.
 type WatchScope int
 
@@ -0,0 +1,263 @@
+package aider
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+const (
+	adapterID   = "aider"
+	adapterName = "Aider"
+	adapterIcon = "◈"
+
+	chatHistoryFile  = ".aider.chat.history.md"
+	inputHistoryFile = ".aider.input.history"
+
+	sessionNameMaxLen = 60
+)
+
+// Adapter implements adapter.Adapter for Aider chat history files.
+type Adapter struct {
+	mu sync.Mutex
+	// sessionIndex maps session ID -> project root, populated by Sessions()
+	sessionIndex map[string]string
+	// cache holds the last parse per chat history path, keyed on mtime/size
+	cache map[string]*parsedHistory
+}
+
+// New creates a new Aider adapter.
+func New() *Adapter {
+	return &Adapter{
+		sessionIndex: make(map[string]string),
+		cache:        make(map[string]*parsedHistory),
+	}
+}
+
+func (a *Adapter) ID() string   { return adapterID }
+func (a *Adapter) Name() string { return adapterName }
+func (a *Adapter) Icon() string { return adapterIcon }
+
+// Detect returns true when the project root has an Aider chat history.
+func (a *Adapter) Detect(projectRoot string) (bool, error) {
+	if projectRoot == "" {
+		return false, nil
+	}
+	_, err := os.Stat(filepath.Join(projectRoot, chatHistoryFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Capabilities returns the supported features.
+func (a *Adapter) Capabilities() adapter.CapabilitySet {
+	return adapter.CapabilitySet{
+		adapter.CapSessions: true,
+		adapter.CapMessages: true,
+		adapter.CapUsage:    true,
+		adapter.CapWatch:    true,
+	}
+}
+
+// WatchScope returns Project because Aider writes its history into each
+// project root.
+func (a *Adapter) WatchScope() adapter.WatchScope {
+	return adapter.WatchScopeProject
+}
+
+// Sessions returns one session per "aider chat started at" section of the
+// project's chat history, most recently updated first.
+func (a *Adapter) Sessions(projectRoot string) ([]adapter.Session, error) {
+	if projectRoot == "" {
+		return nil, nil
+	}
+	path := filepath.Join(projectRoot, chatHistoryFile)
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	parsed, err := a.load(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]adapter.Session, 0, len(parsed))
+	a.mu.Lock()
+	for id, root := range a.sessionIndex {
+		if root == projectRoot {
+			delete(a.sessionIndex, id)
+		}
+	}
+	for i, cs := range parsed {
+		a.sessionIndex[cs.ID] = projectRoot
+
+		updated := cs.UpdatedAt
+		// Aider only appends, so the file mtime dates the final session
+		if i == len(parsed)-1 && info.ModTime().After(updated) {
+			updated = info.ModTime()
+		}
+		var tokens int
+		for _, m := range cs.Messages {
+			tokens += m.InputTokens + m.OutputTokens + m.CacheRead + m.CacheWrite
+		}
+		sessions = append(sessions, adapter.Session{
+			ID:              cs.ID,
+			Name:            sessionName(cs),
+			AdapterID:       adapterID,
+			AdapterName:     adapterName,
+			AdapterIcon:     adapterIcon,
+			CreatedAt:       cs.StartedAt,
+			UpdatedAt:       updated,
+			Duration:        updated.Sub(cs.StartedAt),
+			IsActive:        i == len(parsed)-1 && time.Since(updated) < 5*time.Minute,
+			TotalTokens:     tokens,
+			EstCost:         cs.Cost,
+			MessageCount:    len(cs.Messages),
+			FileSize:        info.Size(),
+			Path:            path,
+			SessionCategory: adapter.SessionCategoryInteractive,
+			CWD:             projectRoot,
+		})
+	}
+	a.mu.Unlock()
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].UpdatedAt.After(sessions[j].UpdatedAt)
+	})
+	return sessions, nil
+}
+
+// Messages returns all messages for the given session. An unknown session
+// ID returns nil without error.
+func (a *Adapter) Messages(sessionID string) ([]adapter.Message, error) {
+	cs, err := a.session(sessionID)
+	if err != nil || cs == nil {
+		return nil, err
+	}
+	return cs.Messages, nil
+}
+
+// Usage returns aggregate usage stats for the given session.
+func (a *Adapter) Usage(sessionID string) (*adapter.UsageStats, error) {
+	cs, err := a.session(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	stats := &adapter.UsageStats{}
+	if cs == nil {
+		return stats, nil
+	}
+	for _, m := range cs.Messages {
+		stats.TotalInputTokens += m.InputTokens
+		stats.TotalOutputTokens += m.OutputTokens
+		stats.TotalCacheRead += m.CacheRead
+		stats.TotalCacheWrite += m.CacheWrite
+	}
+	stats.MessageCount = len(cs.Messages)
+	return stats, nil
+}
+
+// Watch watches the project root for chat and input history changes.
+func (a *Adapter) Watch(projectRoot string) (<-chan adapter.Event, io.Closer, error) {
+	return NewWatcher(projectRoot)
+}
+
+// session resolves a session ID through the index built by Sessions().
+func (a *Adapter) session(sessionID string) (*chatSession, error) {
+	a.mu.Lock()
+	root, ok := a.sessionIndex[sessionID]
+	a.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+	parsed, err := a.load(root)
+	if err != nil {
+		return nil, err
+	}
+	for i := range parsed {
+		if parsed[i].ID == sessionID {
+			return &parsed[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// load parses the project's history files, reusing the cached parse while
+// neither file has changed.
+func (a *Adapter) load(projectRoot string) ([]chatSession, error) {
+	path := filepath.Join(projectRoot, chatHistoryFile)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	inputPath := filepath.Join(projectRoot, inputHistoryFile)
+	var inputMod time.Time
+	if inputInfo, err := os.Stat(inputPath); err == nil {
+		inputMod = inputInfo.ModTime()
+	}
+
+	a.mu.Lock()
+	cached := a.cache[path]
+	a.mu.Unlock()
+	if cached != nil && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() && cached.inputMod.Equal(inputMod) {
+		return cached.sessions, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := parseChatHistory(f)
+	_ = f.Close()
+	// Keep what parsed before a scanner error rather than dropping the file
+	if err != nil && len(sessions) == 0 {
+		return nil, err
+	}
+
+	// The input history is optional; without it messages share the
+	// session start time.
+	entries, _ := parseInputHistory(inputPath)
+	assignTimestamps(sessions, entries)
+
+	a.mu.Lock()
+	a.cache[path] = &parsedHistory{
+		modTime:  info.ModTime(),
+		size:     info.Size(),
+		inputMod: inputMod,
+		sessions: sessions,
+	}
+	a.mu.Unlock()
+	return sessions, nil
+}
+
+// sessionName uses the first prompt as the session name, falling back to
+// the start time for sessions without prompts.
+func sessionName(cs chatSession) string {
+	for _, m := range cs.Messages {
+		if m.Role != "user" || m.Content == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(m.Content, "\n")
+		if runes := []rune(name); len(runes) > sessionNameMaxLen {
+			name = string(runes[:sessionNameMaxLen-3]) + "..."
+		}
+		return name
+	}
+	if cs.StartedAt.IsZero() {
+		return cs.ID
+	}
+	return "Aider " + cs.StartedAt.Format("2006-01-02 15:04")
+}
@@ -0,0 +1,255 @@
+package aider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+const chatFixture = `
+# aider chat started at 2025-03-01 10:00:00
+
+> /usr/local/bin/aider --model sonnet
+> Aider v0.75.1
+> Main model: claude-3-5-sonnet-20241022 with diff edit format
+> Git repo: .git with 12 files
+
+#### add a hello function
+#### with a docstring
+
+I'll add it to hello.py.
+
+hello.py
+` + "```python" + `
+def hello():
+    """Say hello."""
+` + "```" + `
+
+> Tokens: 2.5k sent, 1.1k cache write, 800 cache hit, 120 received. Cost: $0.01 message, $0.01 session.
+> Applied edit to hello.py
+> Commit abc1234 feat: add hello
+
+#### /add util.py
+
+> Added util.py to the chat.
+
+# aider chat started at 2025-03-02 09:30:00
+
+> Model: gpt-4o with diff edit format
+
+#### explain main
+
+main parses flags.
+
+> Tokens: 1,234 sent, 56 received. Cost: $0.0045 request, $0.0045 session.
+`
+
+const inputFixture = `
+# 2025-03-01 10:00:12.500000
++add a hello function
++with a docstring
+
+# 2025-03-01 10:02:00.000000
++/add util.py
+
+# 2025-03-02 09:30:40.000000
++explain main
+`
+
+func writeProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, chatHistoryFile), []byte(chatFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, inputHistoryFile), []byte(inputFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestParseChatHistory(t *testing.T) {
+	sessions, err := parseChatHistory(strings.NewReader(chatFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("got %d sessions, want 2", len(sessions))
+	}
+
+	s := sessions[0]
+	if s.ID != "aider-20250301-100000" {
+		t.Errorf("ID = %q", s.ID)
+	}
+	if len(s.Messages) != 4 {
+		t.Fatalf("got %d messages, want 4: %+v", len(s.Messages), s.Messages)
+	}
+
+	user := s.Messages[0]
+	if user.Role != "user" || user.Content != "add a hello function\nwith a docstring" {
+		t.Errorf("multi-line prompt = %q", user.Content)
+	}
+
+	reply := s.Messages[1]
+	if reply.Role != "assistant" || reply.Model != "claude-3-5-sonnet-20241022" {
+		t.Errorf("reply role/model = %q/%q", reply.Role, reply.Model)
+	}
+	if !strings.Contains(reply.Content, "def hello():") || !strings.Contains(reply.Content, "> Commit abc1234") {
+		t.Errorf("reply content = %q", reply.Content)
+	}
+	if strings.Contains(reply.Content, "Tokens:") || strings.Contains(reply.Content, "Aider v0.75.1") {
+		t.Errorf("token report or banner leaked into content: %q", reply.Content)
+	}
+	want := adapter.TokenUsage{InputTokens: 1400, OutputTokens: 120, CacheRead: 800, CacheWrite: 1100}
+	if reply.TokenUsage != want {
+		t.Errorf("usage = %+v, want %+v", reply.TokenUsage, want)
+	}
+
+	var edit *adapter.ContentBlock
+	for i := range reply.ContentBlocks {
+		if reply.ContentBlocks[i].Type == "tool_use" {
+			edit = &reply.ContentBlocks[i]
+		}
+	}
+	if edit == nil || edit.ToolName != "edit" || edit.ToolInput != `{"file_path":"hello.py"}` {
+		t.Errorf("edit tool block = %+v", edit)
+	}
+
+	added := s.Messages[3]
+	if added.Role != "assistant" || len(added.ContentBlocks) != 2 || added.ContentBlocks[0].ToolName != "add" {
+		t.Errorf("added message = %+v", added)
+	}
+
+	if sessions[1].Messages[1].Model != "gpt-4o" {
+		t.Errorf("second session model = %q", sessions[1].Messages[1].Model)
+	}
+	if sessions[0].Cost != 0.01 || sessions[1].Cost != 0.0045 {
+		t.Errorf("costs = %v/%v", sessions[0].Cost, sessions[1].Cost)
+	}
+	if got := sessions[1].Messages[1].InputTokens; got != 1234 {
+		t.Errorf("comma token count = %d, want 1234", got)
+	}
+}
+
+func TestParseTokenCount(t *testing.T) {
+	cases := map[string]int{"200": 200, "1,234": 1234, "2.5k": 2500, "12k": 12000, "1.2M": 1200000, "bad": 0}
+	for in, want := range cases {
+		if got := parseTokenCount(in); got != want {
+			t.Errorf("parseTokenCount(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestDuplicateSessionStarts(t *testing.T) {
+	history := "# aider chat started at 2025-03-01 10:00:00\n\n#### a\n\n# aider chat started at 2025-03-01 10:00:00\n\n#### b\n"
+	sessions, err := parseChatHistory(strings.NewReader(history))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 || sessions[0].ID == sessions[1].ID {
+		t.Fatalf("sessions not disambiguated: %+v", sessions)
+	}
+
+	path := filepath.Join(t.TempDir(), chatHistoryFile)
+	if err := os.WriteFile(path, []byte(history), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastSessionID(path); got != sessions[1].ID {
+		t.Errorf("lastSessionID = %q, want %q", got, sessions[1].ID)
+	}
+}
+
+func TestSessionsAndMessages(t *testing.T) {
+	dir := writeProject(t)
+	a := New()
+
+	if ok, err := a.Detect(dir); err != nil || !ok {
+		t.Fatalf("Detect = %v, %v", ok, err)
+	}
+	if ok, _ := a.Detect(t.TempDir()); ok {
+		t.Error("Detect should be false without a chat history")
+	}
+
+	sessions, err := a.Sessions(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("got %d sessions, want 2", len(sessions))
+	}
+	// The last session is dated by the file mtime, so it sorts first
+	if sessions[0].ID != "aider-20250302-093000" {
+		t.Errorf("first session = %q", sessions[0].ID)
+	}
+	older := sessions[1]
+	if older.Name != "add a hello function" {
+		t.Errorf("Name = %q", older.Name)
+	}
+	if older.EstCost != 0.01 || older.TotalTokens != 1400+120+800+1100 {
+		t.Errorf("cost/tokens = %v/%d", older.EstCost, older.TotalTokens)
+	}
+
+	msgs, err := a.Messages(older.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPrompt := time.Date(2025, 3, 1, 10, 0, 12, 500000000, time.Local)
+	if !msgs[0].Timestamp.Equal(wantPrompt) {
+		t.Errorf("prompt timestamp = %v, want %v", msgs[0].Timestamp, wantPrompt)
+	}
+	if !msgs[1].Timestamp.Equal(wantPrompt) {
+		t.Errorf("reply should inherit prompt timestamp, got %v", msgs[1].Timestamp)
+	}
+	if !msgs[2].Timestamp.Equal(time.Date(2025, 3, 1, 10, 2, 0, 0, time.Local)) {
+		t.Errorf("/add timestamp = %v", msgs[2].Timestamp)
+	}
+
+	usage, err := a.Usage(older.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.TotalInputTokens != 1400 || usage.TotalCacheRead != 800 || usage.MessageCount != 4 {
+		t.Errorf("usage = %+v", usage)
+	}
+
+	matches, err := a.SearchMessages(older.ID, "docstring", adapter.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) == 0 || matches[0].MessageIdx != 0 {
+		t.Errorf("search matches = %+v", matches)
+	}
+
+	if msgs, err := a.Messages("unknown"); err != nil || msgs != nil {
+		t.Errorf("unknown session = %v, %v", msgs, err)
+	}
+}
+
+func TestWatchEmitsLastSession(t *testing.T) {
+	dir := writeProject(t)
+	events, closer, err := New().Watch(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = closer.Close() }()
+
+	f, err := os.OpenFile(filepath.Join(dir, chatHistoryFile), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("\n#### and tests?\n")
+	_ = f.Close()
+
+	select {
+	case evt := <-events:
+		if evt.SessionID != "aider-20250302-093000" || evt.Type != adapter.EventSessionUpdated {
+			t.Errorf("event = %+v", evt)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("no watch event")
+	}
+}
@@ -0,0 +1,3 @@
+// Package aider provides an adapter for Aider that reads the
+// .aider.chat.history.md and .aider.input.history files in the project root.
+package aider
@@ -0,0 +1,383 @@
+package aider
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+const (
+	headerPrefix = "# aider chat started at "
+	headerLayout = "2006-01-02 15:04:05"
+	inputLayout  = "2006-01-02 15:04:05.999999"
+
+	// Aider prefixes user prompts with "#### " and its own notices
+	// (tool output, token reports, edit confirmations) with "> ".
+	userPrefix   = "####"
+	noticePrefix = ">"
+
+	// maxLineSize bounds a single history line (pasted files can be long).
+	maxLineSize = 4 * 1024 * 1024
+)
+
+var (
+	// modelRe matches the startup/model-switch notice, e.g.
+	// "Main model: claude-3-5-sonnet-20241022 with diff edit format".
+	modelRe = regexp.MustCompile(`^(?:Main model|Model): (\S+)`)
+	// costRe matches the cost half of a token report, e.g.
+	// "Cost: $0.01 message, $0.02 session." (older versions say "request").
+	costRe       = regexp.MustCompile(`Cost: \$([0-9.]+) (?:message|request)`)
+	appliedEdit  = "Applied edit to "
+	addedToChat  = " to the chat."
+	addedPrefix  = "Added "
+	tokensPrefix = "Tokens: "
+)
+
+// historyParser builds sessions from chat history lines.
+type historyParser struct {
+	sessions []chatSession
+	sess     *chatSession
+	model    string
+
+	role       string // role of the open message, "" when none
+	userClosed bool   // a blank line ended the open user prompt
+	text       []string
+	blocks     []adapter.ContentBlock
+	usage      adapter.TokenUsage
+	toolSeq    int
+}
+
+// parseChatHistory splits .aider.chat.history.md into sessions.
+func parseChatHistory(r io.Reader) ([]chatSession, error) {
+	p := &historyParser{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		p.line(strings.TrimRight(scanner.Text(), "\r"))
+	}
+	p.flushSession()
+	return p.sessions, scanner.Err()
+}
+
+func (p *historyParser) line(line string) {
+	if strings.HasPrefix(line, headerPrefix) {
+		p.startSession(strings.TrimSpace(strings.TrimPrefix(line, headerPrefix)))
+		return
+	}
+	if p.sess == nil {
+		return
+	}
+
+	switch {
+	case line == userPrefix || strings.HasPrefix(line, userPrefix+" "):
+		text := strings.TrimPrefix(strings.TrimPrefix(line, userPrefix), " ")
+		if p.role != "user" || p.userClosed {
+			p.flushMessage()
+			p.role = "user"
+		}
+		p.text = append(p.text, text)
+
+	case line == noticePrefix || strings.HasPrefix(line, noticePrefix+" "):
+		p.notice(strings.TrimPrefix(strings.TrimPrefix(line, noticePrefix), " "))
+
+	case strings.TrimSpace(line) == "":
+		if p.role == "user" {
+			p.userClosed = true
+		} else if p.role == "assistant" {
+			p.text = append(p.text, "")
+		}
+
+	default:
+		p.openAssistant()
+		p.text = append(p.text, line)
+	}
+}
+
+// notice handles a "> " line written by Aider itself.
+func (p *historyParser) notice(text string) {
+	if m := modelRe.FindStringSubmatch(text); m != nil {
+		p.model = m[1]
+		return
+	}
+	// Startup banner (version, repo, repo-map) before the first prompt
+	if p.role == "" && len(p.sess.Messages) == 0 {
+		return
+	}
+
+	switch {
+	case strings.HasPrefix(text, tokensPrefix):
+		p.openAssistant()
+		usage, cost := parseTokenReport(text)
+		p.usage.InputTokens += usage.InputTokens
+		p.usage.OutputTokens += usage.OutputTokens
+		p.usage.CacheRead += usage.CacheRead
+		p.usage.CacheWrite += usage.CacheWrite
+		p.sess.Cost += cost
+
+	case strings.HasPrefix(text, appliedEdit):
+		p.openAssistant()
+		p.addTool("edit", strings.TrimSpace(strings.TrimPrefix(text, appliedEdit)), text)
+
+	case strings.HasPrefix(text, addedPrefix) && strings.HasSuffix(text, addedToChat):
+		p.openAssistant()
+		path := strings.TrimSuffix(strings.TrimPrefix(text, addedPrefix), addedToChat)
+		p.addTool("add", strings.TrimSpace(path), text)
+
+	default:
+		p.openAssistant()
+		p.text = append(p.text, "> "+text)
+	}
+}
+
+// addTool records a file operation Aider performed as a tool call + result,
+// so file-touch analytics see Aider edits like any other agent's.
+func (p *historyParser) addTool(name, path, output string) {
+	p.toolSeq++
+	id := fmt.Sprintf("%s-tool-%d", p.sess.ID, p.toolSeq)
+	input := fmt.Sprintf(`{"file_path":%s}`, strconv.Quote(path))
+	p.blocks = append(p.blocks,
+		adapter.ContentBlock{Type: "tool_use", ToolUseID: id, ToolName: name, ToolInput: input},
+		adapter.ContentBlock{Type: "tool_result", ToolUseID: id, ToolOutput: output},
+	)
+}
+
+func (p *historyParser) openAssistant() {
+	if p.role == "assistant" {
+		return
+	}
+	p.flushMessage()
+	p.role = "assistant"
+}
+
+func (p *historyParser) startSession(stamp string) {
+	p.flushSession()
+	started, err := time.ParseInLocation(headerLayout, stamp, time.Local)
+	if err != nil {
+		started = time.Time{}
+	}
+	id := "aider-" + strings.NewReplacer("-", "", ":", "", " ", "-").Replace(stamp)
+	// Two runs started in the same second would otherwise collide
+	for n, base := 2, id; p.hasSession(id); n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	p.sess = &chatSession{ID: id, StartedAt: started}
+	p.model = ""
+	p.toolSeq = 0
+}
+
+func (p *historyParser) hasSession(id string) bool {
+	for i := range p.sessions {
+		if p.sessions[i].ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *historyParser) flushSession() {
+	if p.sess == nil {
+		return
+	}
+	p.flushMessage()
+	p.sessions = append(p.sessions, *p.sess)
+	p.sess = nil
+}
+
+func (p *historyParser) flushMessage() {
+	defer func() {
+		p.role = ""
+		p.userClosed = false
+		p.text = nil
+		p.blocks = nil
+		p.usage = adapter.TokenUsage{}
+	}()
+	if p.role == "" {
+		return
+	}
+	content := strings.TrimSpace(strings.Join(p.text, "\n"))
+	if content == "" && len(p.blocks) == 0 {
+		return
+	}
+
+	msg := adapter.Message{
+		ID:         fmt.Sprintf("%s-%d", p.sess.ID, len(p.sess.Messages)),
+		Role:       p.role,
+		Content:    content,
+		TokenUsage: p.usage,
+	}
+	if p.role == "assistant" {
+		msg.Model = p.model
+	}
+	if len(p.blocks) > 0 {
+		if content != "" {
+			msg.ContentBlocks = append(msg.ContentBlocks, adapter.ContentBlock{Type: "text", Text: content})
+		}
+		msg.ContentBlocks = append(msg.ContentBlocks, p.blocks...)
+	}
+	p.sess.Messages = append(p.sess.Messages, msg)
+}
+
+// parseTokenReport parses Aider's per-message token report, e.g.
+// "Tokens: 12k sent, 3.2k cache write, 1.1k cache hit, 200 received. Cost: ...".
+// Aider's "sent" count includes cache writes but not cache hits, so cache
+// writes are subtracted to avoid counting them twice.
+func parseTokenReport(text string) (adapter.TokenUsage, float64) {
+	var usage adapter.TokenUsage
+	report := strings.TrimPrefix(text, tokensPrefix)
+	if end := strings.Index(report, " received"); end >= 0 {
+		report = report[:end+len(" received")]
+	}
+	var sent int
+	for _, part := range strings.Split(report, ", ") {
+		num, label, _ := strings.Cut(strings.TrimSpace(part), " ")
+		n := parseTokenCount(num)
+		switch label {
+		case "sent":
+			sent = n
+		case "received":
+			usage.OutputTokens = n
+		case "cache write":
+			usage.CacheWrite = n
+		case "cache hit":
+			usage.CacheRead = n
+		}
+	}
+	usage.InputTokens = sent - usage.CacheWrite
+	if usage.InputTokens < 0 {
+		usage.InputTokens = 0
+	}
+
+	var cost float64
+	if m := costRe.FindStringSubmatch(text); m != nil {
+		cost, _ = strconv.ParseFloat(strings.TrimSuffix(m[1], "."), 64)
+	}
+	return usage, cost
+}
+
+// parseTokenCount parses Aider's abbreviated counts: "200", "1,234", "2.5k", "1.2M".
+func parseTokenCount(s string) int {
+	s = strings.ReplaceAll(s, ",", "")
+	mult := 1.0
+	switch {
+	case strings.HasSuffix(s, "k"):
+		mult, s = 1e3, strings.TrimSuffix(s, "k")
+	case strings.HasSuffix(s, "M"):
+		mult, s = 1e6, strings.TrimSuffix(s, "M")
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return int(f*mult + 0.5)
+}
+
+// parseInputHistory reads .aider.input.history, which stores each prompt as
+// a "# <timestamp>" line followed by "+"-prefixed text lines.
+func parseInputHistory(path string) ([]inputEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []inputEntry
+	var cur *inputEntry
+	var lines []string
+	flush := func() {
+		if cur != nil {
+			cur.Text = strings.TrimSpace(strings.Join(lines, "\n"))
+			entries = append(entries, *cur)
+		}
+		cur, lines = nil, nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case strings.HasPrefix(line, "# "):
+			flush()
+			ts, err := time.ParseInLocation(inputLayout, strings.TrimPrefix(line, "# "), time.Local)
+			if err != nil {
+				continue
+			}
+			cur = &inputEntry{Timestamp: ts}
+		case strings.HasPrefix(line, "+") && cur != nil:
+			lines = append(lines, strings.TrimPrefix(line, "+"))
+		}
+	}
+	flush()
+	return entries, scanner.Err()
+}
+
+// assignTimestamps dates user prompts from the input history and carries
+// each timestamp forward to the replies that follow it. The chat history
+// itself only records when each session started.
+func assignTimestamps(sessions []chatSession, entries []inputEntry) {
+	next := 0
+	for si := range sessions {
+		s := &sessions[si]
+		for mi := range s.Messages {
+			msg := &s.Messages[mi]
+			if msg.Role != "user" {
+				continue
+			}
+			for k := next; k < len(entries); k++ {
+				if entries[k].Timestamp.Before(s.StartedAt) {
+					continue
+				}
+				if entries[k].Text == msg.Content {
+					msg.Timestamp = entries[k].Timestamp
+					next = k + 1
+					break
+				}
+			}
+		}
+
+		running := s.StartedAt
+		for mi := range s.Messages {
+			msg := &s.Messages[mi]
+			if msg.Timestamp.IsZero() || msg.Timestamp.Before(running) {
+				msg.Timestamp = running
+			}
+			running = msg.Timestamp
+		}
+		s.UpdatedAt = running
+	}
+}
+
+// lastSessionID returns the ID of the final session in a chat history file
+// without parsing message bodies.
+func lastSessionID(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+
+	var stamps []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, headerPrefix) {
+			stamps = append(stamps, strings.TrimSpace(strings.TrimPrefix(line, headerPrefix)))
+		}
+	}
+	if len(stamps) == 0 {
+		return ""
+	}
+	// Replay the headers so same-second suffixes match parseChatHistory
+	p := &historyParser{}
+	for _, stamp := range stamps {
+		p.startSession(stamp)
+	}
+	return p.sess.ID
+}
@@ -0,0 +1,9 @@
+package aider
+
+import "github.com/toddwbucy/hermes/internal/adapter"
+
+func init() {
+	adapter.RegisterFactory(func() adapter.Adapter {
+		return New()
+	})
+}
@@ -0,0 +1,30 @@
+package aider
+
+import (
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+// chatSession is one "# aider chat started at" section of the chat history.
+type chatSession struct {
+	ID        string
+	StartedAt time.Time
+	UpdatedAt time.Time
+	Messages  []adapter.Message
+	Cost      float64 // sum of per-message costs from Aider's cost lines
+}
+
+// inputEntry is one timestamped prompt from .aider.input.history.
+type inputEntry struct {
+	Timestamp time.Time
+	Text      string
+}
+
+// parsedHistory caches the parsed chat history of one project.
+type parsedHistory struct {
+	modTime  time.Time
+	size     int64
+	inputMod time.Time
+	sessions []chatSession
+}
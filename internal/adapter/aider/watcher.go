@@ -0,0 +1,103 @@
+package aider
+
+import (
+	"io"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+// NewWatcher watches a project root for Aider history writes. Events carry
+// the ID of the file's last session, since Aider only appends.
+func NewWatcher(projectRoot string) (<-chan adapter.Event, io.Closer, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Watch the directory rather than the file so a history created after
+	// startup is still seen.
+	if err := watcher.Add(projectRoot); err != nil {
+		_ = watcher.Close()
+		return nil, nil, err
+	}
+
+	historyPath := filepath.Join(projectRoot, chatHistoryFile)
+	knownLast := lastSessionID(historyPath)
+	events := make(chan adapter.Event, 32)
+
+	go func() {
+		var debounceTimer *time.Timer
+		debounceDelay := 200 * time.Millisecond
+
+		var closed bool
+		var mu sync.Mutex
+
+		defer func() {
+			mu.Lock()
+			closed = true
+			if debounceTimer != nil {
+				debounceTimer.Stop()
+			}
+			mu.Unlock()
+			close(events)
+		}()
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				base := filepath.Base(event.Name)
+				if base != chatHistoryFile && base != inputHistoryFile {
+					continue
+				}
+				if event.Op&fsnotify.Remove != 0 {
+					continue
+				}
+
+				mu.Lock()
+				if debounceTimer != nil {
+					debounceTimer.Stop()
+				}
+				debounceTimer = time.AfterFunc(debounceDelay, func() {
+					mu.Lock()
+					defer mu.Unlock()
+
+					if closed {
+						return
+					}
+
+					sessionID := lastSessionID(historyPath)
+					eventType := adapter.EventSessionUpdated
+					if sessionID != knownLast {
+						eventType = adapter.EventSessionCreated
+						knownLast = sessionID
+					}
+
+					select {
+					case events <- adapter.Event{
+						Type:      eventType,
+						SessionID: sessionID,
+					}:
+					default:
+						// Channel full, drop event
+					}
+				})
+				mu.Unlock()
+
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return events, watcher, nil
+}
@@ -0,0 +1,176 @@
+package archive
+
+import (
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+const (
+	adapterID   = "archive"
+	adapterName = "Archive"
+	adapterIcon = "▤" // ▤ — mnemonic for "filed away"
+)
+
+// Adapter implements adapter.Adapter for archived sessions. It is read-only:
+// archives are created by the conversations plugin's storage view, and the
+// adapter never watches for changes.
+type Adapter struct {
+	store *Store
+}
+
+// New creates an archive adapter over ~/.config/hermes/archive.
+func New() *Adapter {
+	return NewWithStore(NewStore(DefaultDir("")))
+}
+
+// NewWithStore creates an archive adapter over store.
+func NewWithStore(store *Store) *Adapter {
+	return &Adapter{store: store}
+}
+
+func (a *Adapter) ID() string   { return adapterID }
+func (a *Adapter) Name() string { return adapterName }
+func (a *Adapter) Icon() string { return adapterIcon }
+
+// Capabilities returns the supported features.
+func (a *Adapter) Capabilities() adapter.CapabilitySet {
+	return adapter.CapabilitySet{
+		adapter.CapSessions: true,
+		adapter.CapMessages: true,
+		adapter.CapUsage:    true,
+	}
+}
+
+// Detect reports whether any session from projectRoot has been archived.
+func (a *Adapter) Detect(projectRoot string) (bool, error) {
+	entries, err := a.entries(projectRoot)
+	return len(entries) > 0, err
+}
+
+// Sessions returns the sessions archived from projectRoot, most recently
+// updated first.
+func (a *Adapter) Sessions(projectRoot string) ([]adapter.Session, error) {
+	entries, err := a.entries(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]adapter.Session, 0, len(entries))
+	for i := range entries {
+		sessions = append(sessions, archivedSession(&entries[i]))
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].UpdatedAt.After(sessions[j].UpdatedAt)
+	})
+	return sessions, nil
+}
+
+// SessionByID returns the archived session with the given ID.
+// Implements adapter.TargetedRefresher.
+func (a *Adapter) SessionByID(sessionID string) (*adapter.Session, error) {
+	e, err := a.store.Find(sessionID)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	s := archivedSession(e)
+	return &s, nil
+}
+
+// Messages returns the messages stored in the archive. An unknown session
+// returns nil, nil.
+func (a *Adapter) Messages(sessionID string) ([]adapter.Message, error) {
+	e, err := a.store.Find(sessionID)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	return ReadMessages(e.Path)
+}
+
+// Usage returns the usage recorded at archive time, or totals computed from
+// the archived messages when none was recorded.
+func (a *Adapter) Usage(sessionID string) (*adapter.UsageStats, error) {
+	e, err := a.store.Find(sessionID)
+	if err != nil || e == nil {
+		return nil, err
+	}
+	if e.Manifest.Usage != nil {
+		u := *e.Manifest.Usage
+		return &u, nil
+	}
+	msgs, err := ReadMessages(e.Path)
+	if err != nil {
+		return nil, err
+	}
+	stats := &adapter.UsageStats{}
+	for _, m := range msgs {
+		stats.TotalInputTokens += m.InputTokens
+		stats.TotalOutputTokens += m.OutputTokens
+		stats.TotalCacheRead += m.CacheRead
+		stats.TotalCacheWrite += m.CacheWrite
+		stats.MessageCount++
+	}
+	return stats, nil
+}
+
+// Watch is a no-op: archives only change through the storage view, which
+// reloads sessions itself.
+func (a *Adapter) Watch(projectRoot string) (<-chan adapter.Event, io.Closer, error) {
+	return nil, nil, nil
+}
+
+// WatchScope returns Global because the archive directory is shared by
+// all projects.
+func (a *Adapter) WatchScope() adapter.WatchScope {
+	return adapter.WatchScopeGlobal
+}
+
+// SearchMessages searches the archived messages.
+// Implements adapter.MessageSearcher interface.
+func (a *Adapter) SearchMessages(sessionID, query string, opts adapter.SearchOptions) ([]adapter.MessageMatch, error) {
+	msgs, err := a.Messages(sessionID)
+	if err != nil || msgs == nil {
+		return nil, err
+	}
+	return adapter.SearchMessagesSlice(msgs, query, opts)
+}
+
+// entries returns the archives whose project root is projectRoot.
+func (a *Adapter) entries(projectRoot string) ([]Entry, error) {
+	if projectRoot == "" {
+		return nil, nil
+	}
+	all, err := a.store.List()
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.Clean(projectRoot)
+	var out []Entry
+	for _, e := range all {
+		if e.Manifest.ProjectRoot != "" && filepath.Clean(e.Manifest.ProjectRoot) == root {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// archivedSession converts an archive entry to the session shown in the
+// list. Path is left empty so the plugin doesn't watch the tarball, and
+// worktree fields are cleared for the plugin to fill in on load.
+func archivedSession(e *Entry) adapter.Session {
+	s := e.Manifest.Session
+	s.ID = e.Manifest.ID()
+	s.AdapterID = adapterID
+	s.AdapterName = adapterName
+	s.AdapterIcon = adapterIcon
+	s.IsActive = false
+	s.FileSize = e.Size
+	s.Path = ""
+	s.WorktreeName = ""
+	s.WorktreePath = ""
+	if s.Name == "" {
+		s.Name = e.Manifest.Session.ID
+	}
+	return s
+}
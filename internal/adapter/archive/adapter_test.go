@@ -0,0 +1,97 @@
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+func writeSessionFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestWriteAndBrowse(t *testing.T) {
+	src := t.TempDir()
+	store := NewStore(filepath.Join(t.TempDir(), "archive"))
+	a := NewWithStore(store)
+
+	updated := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	session := adapter.Session{
+		ID:        "abc/123", // IDs with separators must not escape the store
+		Name:      "Fix the cache",
+		AdapterID: "claude-code",
+		UpdatedAt: updated,
+		IsActive:  true,
+		Path:      writeSessionFile(t, src, "abc.jsonl", `{"raw":true}`+"\n"),
+	}
+	messages := []adapter.Message{
+		{ID: "m1", Role: "user", Content: "the cache is stale"},
+		{ID: "m2", Role: "assistant", Content: "fixed", TokenUsage: adapter.TokenUsage{InputTokens: 10, OutputTokens: 5}},
+	}
+
+	path, err := store.Write(session, "/work/proj", messages, nil)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if filepath.Dir(filepath.Dir(path)) != store.Dir() {
+		t.Errorf("archive written outside the store: %s", path)
+	}
+	if _, err := os.Stat(session.Path); err != nil {
+		t.Errorf("Write should leave the source file in place: %v", err)
+	}
+
+	if found, _ := a.Detect("/work/other"); found {
+		t.Error("Detect matched another project")
+	}
+	sessions, err := a.Sessions("/work/proj/")
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("Sessions = %d, %v; want 1", len(sessions), err)
+	}
+	s := sessions[0]
+	if s.ID != "claude-code:abc/123" || s.AdapterID != adapterID || s.Name != "Fix the cache" {
+		t.Errorf("session = %+v", s)
+	}
+	if s.IsActive || s.Path != "" || s.FileSize <= 0 || !s.UpdatedAt.Equal(updated) {
+		t.Errorf("session state: active=%v path=%q size=%d updated=%v", s.IsActive, s.Path, s.FileSize, s.UpdatedAt)
+	}
+
+	msgs, err := a.Messages(s.ID)
+	if err != nil || len(msgs) != 2 || msgs[1].Content != "fixed" {
+		t.Fatalf("Messages = %+v, %v", msgs, err)
+	}
+	usage, err := a.Usage(s.ID)
+	if err != nil || usage.TotalInputTokens != 10 || usage.MessageCount != 2 {
+		t.Errorf("Usage = %+v, %v", usage, err)
+	}
+	results, err := a.SearchMessages(s.ID, "stale", adapter.DefaultSearchOptions())
+	if err != nil || len(results) != 1 || results[0].MessageID != "m1" {
+		t.Errorf("SearchMessages = %+v, %v", results, err)
+	}
+
+	m, err := ReadManifest(path)
+	if err != nil || m.SourceSize != int64(len(`{"raw":true}`+"\n")) || len(m.Files) != 1 {
+		t.Errorf("manifest = %+v, %v", m, err)
+	}
+
+	if err := store.Remove(s.ID); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if msgs, err := a.Messages(s.ID); err != nil || msgs != nil {
+		t.Errorf("Messages after Remove = %v, %v; want nil, nil", msgs, err)
+	}
+}
+
+func TestWriteRequiresSessionFile(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if _, err := store.Write(adapter.Session{ID: "s1", AdapterID: "warp"}, "/p", nil, nil); err != ErrNoSessionFile {
+		t.Errorf("Write without Path: err = %v, want ErrNoSessionFile", err)
+	}
+}
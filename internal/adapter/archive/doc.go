@@ -0,0 +1,12 @@
+// Package archive stores sessions that have been archived out of their
+// adapter's data directory, and exposes them read-only as the "archive"
+// adapter.
+//
+// Each archive is a gzip-compressed tarball under ~/.config/hermes/archive/
+// holding three entries: manifest.json (the original session metadata and
+// the project it belonged to), messages.json (the session as parsed by its
+// adapter at archive time) and the raw session file under files/, so the
+// original can be restored by hand. Browsing reads only the manifest and
+// messages, which means archived sessions render the same way regardless of
+// the format the source adapter used.
+package archive
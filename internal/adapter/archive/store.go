@@ -0,0 +1,370 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+const (
+	manifestVersion = 1
+	archiveExt      = ".tar.gz"
+
+	manifestEntry = "manifest.json"
+	messagesEntry = "messages.json"
+	filesPrefix   = "files/"
+)
+
+// ErrNoSessionFile is returned when a session has no file on disk to
+// archive, e.g. sessions stored in a shared database.
+var ErrNoSessionFile = errors.New("session has no file on disk")
+
+// Manifest describes an archived session.
+type Manifest struct {
+	Version     int                 `json:"version"`
+	ArchivedAt  time.Time           `json:"archivedAt"`
+	ProjectRoot string              `json:"projectRoot"` // Project the session was listed under
+	Session     adapter.Session     `json:"session"`     // Metadata as reported by the source adapter
+	Usage       *adapter.UsageStats `json:"usage,omitempty"`
+	Files       []string            `json:"files,omitempty"` // Raw files stored under files/
+	SourceSize  int64               `json:"sourceSize"`      // Bytes freed from the adapter's directory
+}
+
+// ID returns the archived session's ID, which is unique across adapters.
+func (m *Manifest) ID() string {
+	return m.Session.AdapterID + ":" + m.Session.ID
+}
+
+// Entry is an archive on disk.
+type Entry struct {
+	Path     string
+	Size     int64 // Compressed size
+	ModTime  time.Time
+	Manifest Manifest
+}
+
+// Store manages the archive directory.
+type Store struct {
+	dir string
+
+	mu        sync.Mutex
+	manifests map[string]Entry // path -> cached entry, invalidated by size/mtime
+}
+
+// NewStore returns a store rooted at dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir, manifests: make(map[string]Entry)}
+}
+
+// DefaultDir returns the archive directory under configDir, falling back
+// to ~/.config/hermes.
+func DefaultDir(configDir string) string {
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		configDir = filepath.Join(home, ".config", "hermes")
+	}
+	return filepath.Join(configDir, "archive")
+}
+
+// Dir returns the archive directory.
+func (s *Store) Dir() string { return s.dir }
+
+// pathFor returns the tarball path for a session.
+func (s *Store) pathFor(session *adapter.Session) string {
+	return filepath.Join(s.dir, safeName(session.AdapterID), safeName(session.ID)+archiveExt)
+}
+
+// Write archives session: its parsed messages plus the raw session file at
+// session.Path. The source file is left in place; callers remove it once
+// Write succeeds. Returns the tarball path.
+func (s *Store) Write(session adapter.Session, projectRoot string, messages []adapter.Message, usage *adapter.UsageStats) (string, error) {
+	if session.Path == "" {
+		return "", ErrNoSessionFile
+	}
+	info, err := os.Stat(session.Path)
+	if err != nil {
+		return "", err
+	}
+	return s.write(session, projectRoot, messages, usage, filepath.Dir(session.Path), []string{filepath.Base(session.Path)}, info.Size())
+}
+
+// WriteDir archives a session kept in a directory of its own: its parsed
+// messages plus every regular file under dir. Like Write, it leaves dir in
+// place.
+func (s *Store) WriteDir(session adapter.Session, dir, projectRoot string, messages []adapter.Message, usage *adapter.UsageStats) (string, error) {
+	var files []string
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", ErrNoSessionFile
+	}
+	return s.write(session, projectRoot, messages, usage, dir, files, size)
+}
+
+// write stores the manifest, messages and files (relative to srcDir) as
+// the session's tarball.
+func (s *Store) write(session adapter.Session, projectRoot string, messages []adapter.Message, usage *adapter.UsageStats, srcDir string, files []string, size int64) (string, error) {
+	if s.dir == "" {
+		return "", fmt.Errorf("archive directory unavailable")
+	}
+
+	m := Manifest{
+		Version:     manifestVersion,
+		ArchivedAt:  time.Now(),
+		ProjectRoot: projectRoot,
+		Session:     session,
+		Usage:       usage,
+		Files:       files,
+		SourceSize:  size,
+	}
+	m.Session.IsActive = false
+
+	path := s.pathFor(&session)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+
+	// Atomic write: temp file + rename
+	tmpPath := path + ".tmp"
+	if err := writeTarball(tmpPath, &m, messages, srcDir); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return "", err
+	}
+	return path, nil
+}
+
+// writeTarball writes the manifest, messages and the manifest's files,
+// read from srcDir, to path.
+func writeTarball(path string, m *Manifest, messages []adapter.Message, srcDir string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	// The manifest goes first so listing only reads the head of the file
+	if err := writeJSONEntry(tw, manifestEntry, m, m.ArchivedAt); err != nil {
+		return err
+	}
+	if err := writeJSONEntry(tw, messagesEntry, messages, m.ArchivedAt); err != nil {
+		return err
+	}
+	for _, name := range m.Files {
+		if err := writeFileEntry(tw, filesPrefix+name, filepath.Join(srcDir, filepath.FromSlash(name))); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeJSONEntry(tw *tar.Writer, name string, v any, modTime time.Time) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: modTime}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+func writeFileEntry(tw *tar.Writer, name, srcPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	hdr := &tar.Header{Name: name, Mode: 0644, Size: info.Size(), ModTime: info.ModTime()}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	// Copy exactly the size in the header in case the file grew meanwhile
+	_, err = io.CopyN(tw, src, info.Size())
+	return err
+}
+
+// List returns every archive in the store, most recently archived first.
+// Unreadable archives are skipped.
+func (s *Store) List() ([]Entry, error) {
+	if s.dir == "" {
+		return nil, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*", "*"+archiveExt))
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[string]bool, len(paths))
+	entries := make([]Entry, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		seen[path] = true
+		if e, ok := s.manifests[path]; ok && e.Size == info.Size() && e.ModTime.Equal(info.ModTime()) {
+			entries = append(entries, e)
+			continue
+		}
+		m, err := ReadManifest(path)
+		if err != nil {
+			continue
+		}
+		e := Entry{Path: path, Size: info.Size(), ModTime: info.ModTime(), Manifest: *m}
+		s.manifests[path] = e
+		entries = append(entries, e)
+	}
+	for path := range s.manifests {
+		if !seen[path] {
+			delete(s.manifests, path)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Manifest.ArchivedAt.After(entries[j].Manifest.ArchivedAt)
+	})
+	return entries, nil
+}
+
+// Find returns the archive with the given archived session ID.
+func (s *Store) Find(id string) (*Entry, error) {
+	entries, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if entries[i].Manifest.ID() == id {
+			return &entries[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Remove deletes the archive with the given archived session ID.
+func (s *Store) Remove(id string) error {
+	e, err := s.Find(id)
+	if err != nil || e == nil {
+		return err
+	}
+	return os.Remove(e.Path)
+}
+
+// ReadManifest reads an archive's manifest.
+func ReadManifest(path string) (*Manifest, error) {
+	var m Manifest
+	if err := readEntry(path, manifestEntry, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ReadMessages reads an archive's messages.
+func ReadMessages(path string) ([]adapter.Message, error) {
+	var msgs []adapter.Message
+	if err := readEntry(path, messagesEntry, &msgs); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// readEntry decodes the JSON tar entry name from the archive at path.
+func readEntry(path, name string, v any) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return fmt.Errorf("%s: missing %s", path, name)
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Name == name {
+			return json.NewDecoder(tr).Decode(v)
+		}
+	}
+}
+
+// safeName makes an ID usable as a single path component.
+func safeName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', 0:
+			return '_'
+		}
+		return r
+	}, s)
+	if s == "" || s == "." || s == ".." {
+		return "_"
+	}
+	return s
+}
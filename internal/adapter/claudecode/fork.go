@@ -0,0 +1,151 @@
+package claudecode
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/toddwbucy/hermes/internal/adapter/cache"
+)
+
+// ForkSession implements adapter.Forker. The session's JSONL lines up to
+// throughMessageID are copied under a new session ID into the project
+// directory for workDir, so `claude --resume` finds the copy there. Tool
+// results answering the last copied message are kept; an assistant turn
+// with unanswered tool calls cannot be resumed.
+func (a *Adapter) ForkSession(sessionID, throughMessageID, workDir string) (string, error) {
+	src := a.sessionFilePath(sessionID)
+	if src == "" {
+		return "", fmt.Errorf("session %s not found", sessionID)
+	}
+	lines, err := forkLines(src, throughMessageID)
+	if err != nil {
+		return "", err
+	}
+
+	newID := uuid.NewString()
+	var out bytes.Buffer
+	for _, line := range lines {
+		rewritten, err := rewriteForkLine(line, newID, workDir)
+		if err != nil {
+			return "", err
+		}
+		out.Write(rewritten)
+		out.WriteByte('\n')
+	}
+
+	dir := a.projectDirPath(workDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, newID+".jsonl")
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, out.Bytes(), 0644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return "", err
+	}
+
+	a.mu.Lock()
+	a.sessionIndex[newID] = path
+	a.mu.Unlock()
+	return newID, nil
+}
+
+// forkLines returns the lines of a session file through the message with
+// UUID throughID, followed by the tool-result lines that answer it.
+func forkLines(path, throughID string) ([][]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	buf := cache.GetScannerBuffer()
+	defer cache.PutScannerBuffer(buf)
+	scanner.Buffer(buf, 10*1024*1024)
+
+	var lines [][]byte
+	found := false
+	for scanner.Scan() {
+		line := append([]byte(nil), scanner.Bytes()...)
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var raw RawMessage
+		parsed := json.Unmarshal(line, &raw) == nil
+
+		if !found {
+			lines = append(lines, line)
+			if parsed && raw.UUID == throughID && (raw.Type == "user" || raw.Type == "assistant") {
+				found = true
+			}
+			continue
+		}
+
+		// Past the fork point: keep only the tool results for it
+		if !parsed || (raw.Type != "user" && raw.Type != "assistant") {
+			continue
+		}
+		if raw.Type != "user" || raw.Message == nil || !isToolResultContent(raw.Message.Content) {
+			break
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("message %s not found in session", throughID)
+	}
+	return lines, nil
+}
+
+// isToolResultContent reports whether message content is only tool results.
+func isToolResultContent(content json.RawMessage) bool {
+	var blocks []ContentBlock
+	if err := json.Unmarshal(content, &blocks); err != nil || len(blocks) == 0 {
+		return false
+	}
+	for _, b := range blocks {
+		if b.Type != "tool_result" {
+			return false
+		}
+	}
+	return true
+}
+
+// rewriteForkLine points a JSONL line at the forked session and its new
+// working directory, leaving every other field as it was.
+func rewriteForkLine(line []byte, sessionID, workDir string) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return line, nil // Copy lines we cannot parse verbatim
+	}
+	set := func(key, value string) error {
+		if _, ok := fields[key]; !ok {
+			return nil
+		}
+		v, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		fields[key] = v
+		return nil
+	}
+	if err := set("sessionId", sessionID); err != nil {
+		return nil, err
+	}
+	if workDir != "" {
+		if err := set("cwd", workDir); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
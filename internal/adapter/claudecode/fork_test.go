@@ -0,0 +1,60 @@
+package claudecode
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/toddwbucy/hermes/internal/adapter/cache"
+)
+
+func TestForkSession(t *testing.T) {
+	tmpDir := t.TempDir()
+	a := &Adapter{
+		projectsDir:  tmpDir,
+		sessionIndex: make(map[string]string),
+		metaCache:    make(map[string]sessionMetaCacheEntry),
+		msgCache:     cache.New[messageCacheEntry](msgCacheMaxEntries),
+	}
+	projectDir := a.projectDirPath("/home/user/project")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatalf("failed to create project dir: %v", err)
+	}
+	copyTestdataFile(t, "testdata/tool_linking.jsonl", filepath.Join(projectDir, "tool-linking-session.jsonl"))
+
+	// Forking after msg-002 keeps the tool results that answer it
+	newID, err := a.ForkSession("tool-linking-session", "msg-002", "/tmp/worktree")
+	if err != nil {
+		t.Fatalf("ForkSession: %v", err)
+	}
+	path := filepath.Join(a.projectDirPath("/tmp/worktree"), newID+".jsonl")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("fork not written to the worktree's project dir: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("fork has %d lines, want msg-001..msg-003", len(lines))
+	}
+	if strings.Contains(string(data), "tool-linking-session") || !strings.Contains(string(data), `"cwd":"/tmp/worktree"`) {
+		t.Errorf("session ID or cwd not rewritten:\n%s", data)
+	}
+
+	messages, err := a.Messages(newID)
+	if err != nil {
+		t.Fatalf("Messages(fork): %v", err)
+	}
+	if len(messages) != 3 || messages[1].ID != "msg-002" {
+		t.Fatalf("fork messages = %+v", messages)
+	}
+	for _, tu := range messages[1].ToolUses {
+		if tu.Output == "" {
+			t.Errorf("tool result for %s missing from fork", tu.ID)
+		}
+	}
+
+	if _, err := a.ForkSession("tool-linking-session", "msg-999", "/tmp/worktree"); err == nil {
+		t.Error("unknown message should fail")
+	}
+}
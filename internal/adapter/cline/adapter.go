@@ -0,0 +1,664 @@
+package cline
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/adapter/cache"
+	"github.com/toddwbucy/hermes/internal/adapter/tieredwatcher"
+)
+
+const (
+	apiHistoryFile   = "api_conversation_history.json"
+	uiMessagesFile   = "ui_messages.json"
+	taskMetadataFile = "task_metadata.json"
+	historyItemFile  = "history_item.json"
+	tasksDirName     = "tasks"
+
+	msgCacheMaxEntries = 64
+)
+
+// Extension variants. Both extensions share Cline's storage layout.
+var (
+	clineVariant = variant{
+		id:          "cline",
+		name:        "Cline",
+		icon:        "\u2B21", // ⬡
+		extensionID: "saoudrizwan.claude-dev",
+	}
+	rooCodeVariant = variant{
+		id:          "roo-code",
+		name:        "Roo Code",
+		icon:        "\U0001F998", // 🦘
+		extensionID: "rooveterinaryinc.roo-cline",
+	}
+)
+
+// editorDirs are the VS Code distributions whose globalStorage is searched.
+var editorDirs = []string{"Code", "Code - Insiders", "VSCodium", "Cursor", "Windsurf"}
+
+// variant identifies one VS Code extension.
+type variant struct {
+	id          string
+	name        string
+	icon        string
+	extensionID string
+}
+
+// Adapter implements adapter.Adapter for Cline-style task histories stored
+// under VS Code's globalStorage/<extension>/tasks/<id>/.
+type Adapter struct {
+	variant
+	storageDirs []string // globalStorage/<extension> dirs, existing or not
+
+	mu           sync.RWMutex
+	taskIndex    map[string]string               // task ID -> task dir
+	projectRoots map[string]*resolvedProjectPath // roots seen by Sessions()
+	metaCache    map[string]metaCacheEntry       // task dir -> summary
+	histCache    map[string]historyCacheEntry    // storage dir -> task history
+	msgCache     *cache.Cache[msgCacheEntry]     // api history path -> messages
+}
+
+// historyCacheEntry caches a parsed state/taskHistory.json.
+type historyCacheEntry struct {
+	modTime time.Time
+	items   map[string]historyItem
+}
+
+// NewCline creates an adapter for the Cline extension.
+func NewCline() *Adapter {
+	return newAdapter(clineVariant, globalStorageDirs(clineVariant.extensionID))
+}
+
+// NewRooCode creates an adapter for the Roo Code extension.
+func NewRooCode() *Adapter {
+	return newAdapter(rooCodeVariant, globalStorageDirs(rooCodeVariant.extensionID))
+}
+
+func newAdapter(v variant, storageDirs []string) *Adapter {
+	return &Adapter{
+		variant:      v,
+		storageDirs:  storageDirs,
+		taskIndex:    make(map[string]string),
+		projectRoots: make(map[string]*resolvedProjectPath),
+		metaCache:    make(map[string]metaCacheEntry),
+		histCache:    make(map[string]historyCacheEntry),
+		msgCache:     cache.New[msgCacheEntry](msgCacheMaxEntries),
+	}
+}
+
+// globalStorageDirs returns the extension's globalStorage directory for
+// every known VS Code distribution on this platform.
+func globalStorageDirs(extensionID string) []string {
+	home, _ := os.UserHomeDir()
+	var base string
+	switch runtime.GOOS {
+	case "darwin":
+		base = filepath.Join(home, "Library", "Application Support")
+	case "windows":
+		base = os.Getenv("APPDATA")
+	default:
+		base = os.Getenv("XDG_CONFIG_HOME")
+		if base == "" {
+			base = filepath.Join(home, ".config")
+		}
+	}
+	if base == "" {
+		return nil
+	}
+	dirs := make([]string, 0, len(editorDirs))
+	for _, editor := range editorDirs {
+		dirs = append(dirs, filepath.Join(base, editor, "User", "globalStorage", extensionID))
+	}
+	return dirs
+}
+
+// ID returns the adapter identifier.
+func (a *Adapter) ID() string { return a.id }
+
+// Name returns the human-readable adapter name.
+func (a *Adapter) Name() string { return a.name }
+
+// Icon returns the adapter icon for badge display.
+func (a *Adapter) Icon() string { return a.icon }
+
+// Detect checks if any task belongs to the given project.
+func (a *Adapter) Detect(projectRoot string) (bool, error) {
+	sessions, err := a.Sessions(projectRoot)
+	if err != nil {
+		return false, err
+	}
+	return len(sessions) > 0, nil
+}
+
+// Capabilities returns the supported features.
+func (a *Adapter) Capabilities() adapter.CapabilitySet {
+	return adapter.CapabilitySet{
+		adapter.CapSessions: true,
+		adapter.CapMessages: true,
+		adapter.CapUsage:    true,
+		adapter.CapWatch:    true,
+	}
+}
+
+// WatchScope returns Global because tasks live in the editor's global storage.
+func (a *Adapter) WatchScope() adapter.WatchScope {
+	return adapter.WatchScopeGlobal
+}
+
+// Sessions returns the tasks whose workspace is inside projectRoot, sorted
+// by update time.
+func (a *Adapter) Sessions(projectRoot string) ([]adapter.Session, error) {
+	resolved := newResolvedProjectPath(projectRoot)
+	if resolved == nil {
+		return nil, nil
+	}
+	a.mu.Lock()
+	a.projectRoots[resolved.abs] = resolved
+	a.mu.Unlock()
+
+	var sessions []adapter.Session
+	for _, storageDir := range a.storageDirs {
+		history := a.taskHistory(storageDir)
+		entries, err := os.ReadDir(filepath.Join(storageDir, tasksDirName))
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			taskDir := filepath.Join(storageDir, tasksDirName, e.Name())
+			s, cwd, ok := a.taskSession(e.Name(), taskDir, history)
+			if !ok || !resolved.matchesCWD(cwd) {
+				continue
+			}
+			sessions = append(sessions, s)
+			a.mu.Lock()
+			a.taskIndex[s.ID] = taskDir
+			a.mu.Unlock()
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].UpdatedAt.After(sessions[j].UpdatedAt)
+	})
+	return sessions, nil
+}
+
+// SessionByID returns a single task by ID, including tasks created since the
+// last Sessions() call. Implements adapter.TargetedRefresher.
+func (a *Adapter) SessionByID(sessionID string) (*adapter.Session, error) {
+	taskDir := a.taskDir(sessionID)
+	if taskDir == "" {
+		return nil, fmt.Errorf("task %s not found", sessionID)
+	}
+	storageDir := filepath.Dir(filepath.Dir(taskDir))
+	s, cwd, ok := a.taskSession(sessionID, taskDir, a.taskHistory(storageDir))
+	if !ok {
+		return nil, fmt.Errorf("task %s has no conversation", sessionID)
+	}
+	if !a.inKnownProject(cwd) {
+		return nil, fmt.Errorf("task %s is not in this project", sessionID)
+	}
+	return &s, nil
+}
+
+// Messages returns all messages for the given task.
+func (a *Adapter) Messages(sessionID string) ([]adapter.Message, error) {
+	taskDir := a.taskDir(sessionID)
+	if taskDir == "" {
+		return nil, nil
+	}
+	apiPath := filepath.Join(taskDir, apiHistoryFile)
+	info, err := os.Stat(apiPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	uiPath := filepath.Join(taskDir, uiMessagesFile)
+	uiMod := modTime(uiPath)
+
+	if entry, ok := a.msgCache.Get(apiPath, info.Size(), info.ModTime()); ok && entry.uiMod.Equal(uiMod) {
+		return append([]adapter.Message(nil), entry.messages...), nil
+	}
+
+	var api []apiMessage
+	if err := readJSONFile(apiPath, &api); err != nil {
+		return nil, err
+	}
+	// The UI log and task metadata are optional enrichment
+	var ui []uiMessage
+	_ = readJSONFile(uiPath, &ui)
+	md := readTaskMetadata(filepath.Join(taskDir, taskMetadataFile))
+
+	messages := buildMessages(sessionID, api, ui, md)
+	a.msgCache.Set(apiPath, msgCacheEntry{messages: messages, uiMod: uiMod}, info.Size(), info.ModTime(), 0)
+	return append([]adapter.Message(nil), messages...), nil
+}
+
+// Usage returns aggregate usage stats for the given task.
+func (a *Adapter) Usage(sessionID string) (*adapter.UsageStats, error) {
+	messages, err := a.Messages(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	stats := &adapter.UsageStats{MessageCount: len(messages)}
+	for _, m := range messages {
+		stats.TotalInputTokens += m.InputTokens
+		stats.TotalOutputTokens += m.OutputTokens
+		stats.TotalCacheRead += m.CacheRead
+		stats.TotalCacheWrite += m.CacheWrite
+	}
+	return stats, nil
+}
+
+// Watch returns a tiered watcher over the project's tasks: recently active
+// tasks are watched with fsnotify, the rest are polled.
+func (a *Adapter) Watch(projectRoot string) (<-chan adapter.Event, io.Closer, error) {
+	sessions, err := a.Sessions(projectRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	tw, ch, err := tieredwatcher.New(tieredwatcher.Config{
+		RootDirs:  a.SessionRootDirs(),
+		ExtractID: a.SessionIDFromPath,
+		Filter:    func(path string) bool { return a.SessionIDFromPath(path) != "" },
+		ScanDir:   a.ScanSessionDir,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	infos := make([]tieredwatcher.SessionInfo, 0, len(sessions))
+	active := 0
+	for _, s := range sessions {
+		info := tieredwatcher.SessionInfo{ID: s.ID, Path: s.Path, ModTime: s.UpdatedAt, FileSize: s.FileSize}
+		if s.IsActive {
+			info.LastHot = s.UpdatedAt
+			active++
+		}
+		infos = append(infos, info)
+	}
+	tw.RegisterSessions(infos)
+	tw.SetHotTarget(max(active, 1))
+	return ch, tw.NewCloser(), nil
+}
+
+// SessionDir returns a task's directory, so archiving and deleting take its
+// UI log and metadata along with the API history. Implements
+// adapter.SessionDirProvider.
+func (a *Adapter) SessionDir(sessionID string) string {
+	return a.taskDir(sessionID)
+}
+
+// SessionIDFromPath maps a task directory, or a history file inside one, to
+// its task ID. Implements tieredwatcher.Layout.
+func (a *Adapter) SessionIDFromPath(path string) string {
+	for _, storageDir := range a.storageDirs {
+		rel, err := filepath.Rel(filepath.Join(storageDir, tasksDirName), path)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		parts := strings.Split(rel, string(filepath.Separator))
+		switch {
+		case len(parts) == 1:
+			return parts[0]
+		case len(parts) == 2 && (parts[1] == apiHistoryFile || parts[1] == uiMessagesFile):
+			return parts[0]
+		}
+	}
+	return ""
+}
+
+// ScanSessionDir lists tasks in a tasks directory, or the task itself for a
+// task directory. Tasks known to belong to other workspaces are skipped.
+// Implements tieredwatcher.Layout.
+func (a *Adapter) ScanSessionDir(dir string) ([]tieredwatcher.SessionInfo, error) {
+	if !a.isTasksDir(dir) {
+		if !a.isTasksDir(filepath.Dir(dir)) {
+			return nil, nil
+		}
+		if info, ok := a.scanTask(dir); ok {
+			return []tieredwatcher.SessionInfo{info}, nil
+		}
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var result []tieredwatcher.SessionInfo
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if info, ok := a.scanTask(filepath.Join(dir, e.Name())); ok {
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
+
+// SessionRootDirs returns the existing tasks directories, where new tasks
+// appear. Implements tieredwatcher.Layout.
+func (a *Adapter) SessionRootDirs() []string {
+	var dirs []string
+	for _, storageDir := range a.storageDirs {
+		dir := filepath.Join(storageDir, tasksDirName)
+		if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// scanTask returns watch info for a task directory.
+func (a *Adapter) scanTask(taskDir string) (tieredwatcher.SessionInfo, bool) {
+	id := filepath.Base(taskDir)
+	info := tieredwatcher.SessionInfo{ID: id, Path: filepath.Join(taskDir, apiHistoryFile)}
+	if fi, err := os.Stat(info.Path); err == nil {
+		info.ModTime = fi.ModTime()
+		info.FileSize = fi.Size()
+		// New tasks have no workspace recorded yet; keep them until they do
+		history := a.taskHistory(filepath.Dir(filepath.Dir(taskDir)))
+		if cwd := a.taskCWD(id, taskDir, history); cwd != "" && !a.inKnownProject(cwd) {
+			return info, false
+		}
+	} else if fi, err := os.Stat(taskDir); err == nil {
+		info.ModTime = fi.ModTime()
+	} else {
+		return info, false
+	}
+	return info, true
+}
+
+// taskSession builds a Session for a task directory, returning its
+// workspace for project filtering.
+func (a *Adapter) taskSession(id, taskDir string, history map[string]historyItem) (adapter.Session, string, bool) {
+	apiPath := filepath.Join(taskDir, apiHistoryFile)
+	info, err := os.Stat(apiPath)
+	if err != nil {
+		return adapter.Session{}, "", false
+	}
+	meta, err := a.taskMeta(taskDir, info)
+	if err != nil {
+		return adapter.Session{}, "", false
+	}
+
+	item, hasItem := a.historyItem(id, taskDir, history)
+	cwd := meta.CWD
+	if hasItem && item.CWD != "" {
+		cwd = item.CWD
+	} else if hasItem && item.Workspace != "" {
+		cwd = item.Workspace
+	}
+
+	// Task history holds the extension's own running totals; fall back to
+	// summing the UI log's API requests for tasks not in the history yet.
+	usage, cost := meta.Usage, meta.Cost
+	if hasItem {
+		usage = adapter.TokenUsage{
+			InputTokens:  item.TokensIn,
+			OutputTokens: item.TokensOut,
+			CacheRead:    item.CacheReads,
+			CacheWrite:   item.CacheWrites,
+		}
+		cost = item.TotalCost
+	}
+
+	task := meta.Task
+	if hasItem && item.Task != "" {
+		task = item.Task
+	}
+	name := truncateTitle(task, 50)
+	if name == "" {
+		name = shortID(id)
+	}
+
+	created, updated := meta.FirstTs, meta.LastTs
+	if hasItem && msToTime(item.Ts).After(updated) {
+		updated = msToTime(item.Ts)
+	}
+	if updated.IsZero() {
+		updated = info.ModTime()
+	}
+	if created.IsZero() {
+		created = updated
+	}
+
+	return adapter.Session{
+		ID:              id,
+		Name:            name,
+		AdapterID:       a.id,
+		AdapterName:     a.name,
+		AdapterIcon:     a.icon,
+		CreatedAt:       created,
+		UpdatedAt:       updated,
+		Duration:        updated.Sub(created),
+		IsActive:        time.Since(updated) < 5*time.Minute,
+		TotalTokens:     usage.InputTokens + usage.OutputTokens + usage.CacheRead + usage.CacheWrite,
+		EstCost:         cost,
+		MessageCount:    meta.MessageCount,
+		FileSize:        info.Size(),
+		Path:            apiPath, // Tiered watching tracks the task by its API history file
+		SessionCategory: adapter.SessionCategoryInteractive,
+		CWD:             cwd,
+	}, cwd, true
+}
+
+// taskMeta returns the cached task summary, reparsing when either history
+// file changed.
+func (a *Adapter) taskMeta(taskDir string, apiInfo os.FileInfo) (taskMeta, error) {
+	uiMod := modTime(filepath.Join(taskDir, uiMessagesFile))
+
+	a.mu.RLock()
+	entry, ok := a.metaCache[taskDir]
+	a.mu.RUnlock()
+	if ok && entry.apiMod.Equal(apiInfo.ModTime()) && entry.apiSize == apiInfo.Size() && entry.uiMod.Equal(uiMod) {
+		return entry.meta, nil
+	}
+
+	var api []apiMessage
+	if err := readJSONFile(filepath.Join(taskDir, apiHistoryFile), &api); err != nil {
+		return taskMeta{}, err
+	}
+	var ui []uiMessage
+	_ = readJSONFile(filepath.Join(taskDir, uiMessagesFile), &ui)
+	meta := computeMeta(api, ui)
+
+	a.mu.Lock()
+	a.metaCache[taskDir] = metaCacheEntry{meta: meta, apiMod: apiInfo.ModTime(), apiSize: apiInfo.Size(), uiMod: uiMod}
+	a.mu.Unlock()
+	return meta, nil
+}
+
+// taskCWD returns a task's workspace from its history item or summary.
+func (a *Adapter) taskCWD(id, taskDir string, history map[string]historyItem) string {
+	if item, ok := a.historyItem(id, taskDir, history); ok {
+		if item.CWD != "" {
+			return item.CWD
+		}
+		if item.Workspace != "" {
+			return item.Workspace
+		}
+	}
+	info, err := os.Stat(filepath.Join(taskDir, apiHistoryFile))
+	if err != nil {
+		return ""
+	}
+	meta, err := a.taskMeta(taskDir, info)
+	if err != nil {
+		return ""
+	}
+	return meta.CWD
+}
+
+// historyItem looks a task up in the shared task history, then in the
+// per-task history_item.json Roo Code writes.
+func (a *Adapter) historyItem(id, taskDir string, history map[string]historyItem) (historyItem, bool) {
+	if item, ok := history[id]; ok {
+		return item, true
+	}
+	var item historyItem
+	if err := readJSONFile(filepath.Join(taskDir, historyItemFile), &item); err != nil {
+		return historyItem{}, false
+	}
+	return item, true
+}
+
+// taskHistory returns the storage dir's state/taskHistory.json keyed by
+// task ID, cached on mtime.
+func (a *Adapter) taskHistory(storageDir string) map[string]historyItem {
+	path := filepath.Join(storageDir, "state", "taskHistory.json")
+	mod := modTime(path)
+	if mod.IsZero() {
+		return nil
+	}
+
+	a.mu.RLock()
+	entry, ok := a.histCache[storageDir]
+	a.mu.RUnlock()
+	if ok && entry.modTime.Equal(mod) {
+		return entry.items
+	}
+
+	var items []historyItem
+	if err := readJSONFile(path, &items); err != nil {
+		return nil
+	}
+	byID := make(map[string]historyItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	a.mu.Lock()
+	a.histCache[storageDir] = historyCacheEntry{modTime: mod, items: byID}
+	a.mu.Unlock()
+	return byID
+}
+
+// taskDir resolves a task ID to its directory, searching storage dirs for
+// tasks created since the last Sessions() call.
+func (a *Adapter) taskDir(sessionID string) string {
+	a.mu.RLock()
+	dir, ok := a.taskIndex[sessionID]
+	a.mu.RUnlock()
+	if ok {
+		return dir
+	}
+	if sessionID == "" || strings.ContainsAny(sessionID, `/\`) {
+		return ""
+	}
+	for _, storageDir := range a.storageDirs {
+		dir := filepath.Join(storageDir, tasksDirName, sessionID)
+		if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+			a.mu.Lock()
+			a.taskIndex[sessionID] = dir
+			a.mu.Unlock()
+			return dir
+		}
+	}
+	return ""
+}
+
+// isTasksDir reports whether dir is one of the storage dirs' tasks directory.
+func (a *Adapter) isTasksDir(dir string) bool {
+	dir = filepath.Clean(dir)
+	for _, storageDir := range a.storageDirs {
+		if filepath.Join(storageDir, tasksDirName) == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// inKnownProject reports whether cwd is inside a project Sessions() served.
+func (a *Adapter) inKnownProject(cwd string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for _, root := range a.projectRoots {
+		if root.matchesCWD(cwd) {
+			return true
+		}
+	}
+	return false
+}
+
+// modTime returns a file's mtime, or the zero time if it does not exist.
+func modTime(path string) time.Time {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return fi.ModTime()
+}
+
+// resolvedProjectPath holds a pre-resolved project path for efficient matching.
+type resolvedProjectPath struct {
+	abs string
+}
+
+// newResolvedProjectPath creates a resolved path from projectRoot, performing
+// expensive filepath.Abs and filepath.EvalSymlinks calls once.
+func newResolvedProjectPath(projectRoot string) *resolvedProjectPath {
+	if projectRoot == "" {
+		return nil
+	}
+	projectAbs, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return nil
+	}
+	if resolved, err := filepath.EvalSymlinks(projectAbs); err == nil {
+		projectAbs = resolved
+	}
+	return &resolvedProjectPath{abs: filepath.Clean(projectAbs)}
+}
+
+// matchesCWD checks if a task workspace is inside this project path.
+func (r *resolvedProjectPath) matchesCWD(cwd string) bool {
+	if r == nil || cwd == "" {
+		return false
+	}
+	cwdAbs, err := filepath.Abs(cwd)
+	if err != nil {
+		return false
+	}
+	if resolved, err := filepath.EvalSymlinks(cwdAbs); err == nil {
+		cwdAbs = resolved
+	}
+	rel, err := filepath.Rel(r.abs, filepath.Clean(cwdAbs))
+	if err != nil {
+		return false
+	}
+	return rel == "." || !strings.HasPrefix(rel, "..")
+}
+
+func shortID(id string) string {
+	if len(id) >= 8 {
+		return id[:8]
+	}
+	return id
+}
+
+// truncateTitle truncates text to maxLen runes on one line, adding "..."
+// if truncated.
+func truncateTitle(s string, maxLen int) string {
+	s = strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(s, "\r", ""), "\n", " "))
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen-3]) + "..."
+}
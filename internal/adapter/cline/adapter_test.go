@@ -0,0 +1,278 @@
+package cline
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+// base is the fixture's first UI timestamp (Unix ms).
+const base = int64(1_740_000_000_000)
+
+func writeJSON(t *testing.T, path string, v any) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func apiReq(ts int64, in, out, cacheR, cacheW int, cost float64) map[string]any {
+	info, _ := json.Marshal(map[string]any{
+		"request": "...", "tokensIn": in, "tokensOut": out,
+		"cacheReads": cacheR, "cacheWrites": cacheW, "cost": cost,
+	})
+	return map[string]any{"ts": ts, "type": "say", "say": "api_req_started", "text": string(info)}
+}
+
+// writeTask writes a two-request task: a prompt, a tool call, its result
+// and a final answer.
+func writeTask(t *testing.T, storage, id, cwd string) string {
+	t.Helper()
+	dir := filepath.Join(storage, tasksDirName, id)
+	env := "<environment_details>\n# Current Working Directory (" + cwd + ") Files\nmain.go\n</environment_details>"
+	writeJSON(t, filepath.Join(dir, apiHistoryFile), []map[string]any{
+		{"role": "user", "content": []map[string]any{
+			{"type": "text", "text": "<task>\nfix the tests\n</task>"},
+			{"type": "text", "text": env},
+		}},
+		{"role": "assistant", "content": []map[string]any{
+			{"type": "thinking", "thinking": "look at main.go"},
+			{"type": "text", "text": "Reading the file."},
+			{"type": "tool_use", "id": "tu1", "name": "read_file", "input": map[string]any{"path": "main.go"}},
+		}},
+		{"role": "user", "content": []map[string]any{
+			{"type": "tool_result", "tool_use_id": "tu1", "content": []map[string]any{{"type": "text", "text": "package main"}}},
+			{"type": "text", "text": env},
+		}},
+		{"role": "assistant", "content": "Fixed."},
+	})
+	writeJSON(t, filepath.Join(dir, uiMessagesFile), []map[string]any{
+		{"ts": base, "type": "say", "say": "task", "text": "fix the tests"},
+		apiReq(base+1000, 100, 20, 0, 50, 0.01),
+		{"ts": base + 3000, "type": "say", "say": "text", "text": "Reading the file."},
+		apiReq(base+5000, 200, 30, 50, 0, 0.02),
+		{"ts": base + 9000, "type": "say", "say": "completion_result", "text": "Fixed."},
+	})
+	return dir
+}
+
+func TestSessionsFiltersByWorkspace(t *testing.T) {
+	storage := t.TempDir()
+	project := t.TempDir()
+	other := t.TempDir()
+
+	writeTask(t, storage, "1740000000000", project)
+	writeTask(t, storage, "1740000000001", other)
+	// Task history supplies the workspace and totals for the first task
+	writeJSON(t, filepath.Join(storage, "state", "taskHistory.json"), []map[string]any{{
+		"id": "1740000000000", "ts": base + 9000, "task": "fix the tests",
+		"tokensIn": 300, "tokensOut": 50, "cacheReads": 50, "cacheWrites": 50,
+		"totalCost": 0.03, "cwdOnTaskInitialization": project,
+	}})
+
+	a := newAdapter(clineVariant, []string{storage})
+	sessions, err := a.Sessions(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(sessions))
+	}
+	s := sessions[0]
+	if s.ID != "1740000000000" || s.Name != "fix the tests" || s.AdapterID != "cline" {
+		t.Errorf("session = %+v", s)
+	}
+	if s.EstCost != 0.03 || s.TotalTokens != 450 {
+		t.Errorf("cost/tokens = %v/%d, want 0.03/450", s.EstCost, s.TotalTokens)
+	}
+	if s.MessageCount != 4 || !s.CreatedAt.Equal(time.UnixMilli(base)) {
+		t.Errorf("count/created = %d/%v", s.MessageCount, s.CreatedAt)
+	}
+
+	// The other task is found through its environment_details fallback
+	otherSessions, _ := a.Sessions(other)
+	if len(otherSessions) != 1 || otherSessions[0].EstCost != 0.03 {
+		t.Errorf("fallback session = %+v", otherSessions)
+	}
+}
+
+func TestRooCodeHistoryItem(t *testing.T) {
+	storage := t.TempDir()
+	project := t.TempDir()
+	dir := writeTask(t, storage, "0b9f-uuid", t.TempDir())
+	writeJSON(t, filepath.Join(dir, historyItemFile), map[string]any{
+		"id": "0b9f-uuid", "ts": base + 9000, "task": "roo task", "workspace": project, "totalCost": 1.5,
+	})
+
+	a := newAdapter(rooCodeVariant, []string{storage})
+	sessions, err := a.Sessions(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || sessions[0].Name != "roo task" || sessions[0].EstCost != 1.5 || sessions[0].AdapterName != "Roo Code" {
+		t.Fatalf("sessions = %+v", sessions)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	storage := t.TempDir()
+	project := t.TempDir()
+	dir := writeTask(t, storage, "task1", project)
+	writeJSON(t, filepath.Join(dir, taskMetadataFile), map[string]any{
+		"model_usage": []map[string]any{
+			{"ts": base + 6000, "model_id": "claude-sonnet-4"},
+			{"ts": base, "model_id": "claude-3-7-sonnet"},
+		},
+	})
+
+	a := newAdapter(clineVariant, []string{storage})
+	msgs, err := a.Messages("task1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 4 {
+		t.Fatalf("got %d messages, want 4", len(msgs))
+	}
+
+	if msgs[0].Content != "fix the tests" {
+		t.Errorf("prompt = %q, want task tag and environment stripped", msgs[0].Content)
+	}
+
+	reply := msgs[1]
+	if len(reply.ContentBlocks) != 3 || reply.ContentBlocks[0].Type != "thinking" || len(reply.ThinkingBlocks) != 1 {
+		t.Errorf("reply blocks = %+v", reply.ContentBlocks)
+	}
+	tool := reply.ContentBlocks[2]
+	if tool.Type != "tool_use" || tool.ToolName != "read_file" || tool.ToolInput != `{"path":"main.go"}` || tool.ToolOutput != "package main" {
+		t.Errorf("tool block = %+v", tool)
+	}
+	if reply.InputTokens != 100 || reply.CacheWrite != 50 || reply.OutputTokens != 20 {
+		t.Errorf("reply usage = %+v", reply.TokenUsage)
+	}
+	if reply.Model != "claude-3-7-sonnet" {
+		t.Errorf("reply model = %q", reply.Model)
+	}
+	if !reply.Timestamp.Equal(time.UnixMilli(base + 3000)) {
+		t.Errorf("reply timestamp = %v, want end of first request", reply.Timestamp)
+	}
+
+	result := msgs[2]
+	if result.Content != "[1 tool result(s)]" || !result.Timestamp.Equal(time.UnixMilli(base+5000)) {
+		t.Errorf("tool result message = %q at %v", result.Content, result.Timestamp)
+	}
+
+	final := msgs[3]
+	if final.Content != "Fixed." || final.CacheRead != 50 || final.Model != "claude-sonnet-4" {
+		t.Errorf("final = %+v", final)
+	}
+
+	usage, err := a.Usage("task1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.TotalInputTokens != 300 || usage.TotalOutputTokens != 50 || usage.MessageCount != 4 {
+		t.Errorf("usage = %+v", usage)
+	}
+
+	matches, err := a.SearchMessages("task1", "package main", adapter.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) == 0 || matches[0].MessageIdx != 1 {
+		t.Errorf("search = %+v", matches)
+	}
+}
+
+func TestSessionByIDFindsNewTask(t *testing.T) {
+	storage := t.TempDir()
+	project := t.TempDir()
+	a := newAdapter(clineVariant, []string{storage})
+	if _, err := a.Sessions(project); err != nil {
+		t.Fatal(err)
+	}
+
+	writeTask(t, storage, "new", project)
+	writeTask(t, storage, "elsewhere", t.TempDir())
+
+	s, err := a.SessionByID("new")
+	if err != nil || s == nil || s.ID != "new" {
+		t.Fatalf("SessionByID(new) = %+v, %v", s, err)
+	}
+	if _, err := a.SessionByID("elsewhere"); err == nil {
+		t.Error("task from another workspace should not resolve")
+	}
+
+	// Archiving and deleting take the whole task directory
+	if dir := a.SessionDir("new"); dir != filepath.Dir(s.Path) {
+		t.Errorf("SessionDir = %q, want the directory of %q", dir, s.Path)
+	}
+	if dir := a.SessionDir("../new"); dir != "" {
+		t.Errorf("SessionDir(../new) = %q, want none", dir)
+	}
+}
+
+func TestTieredLayout(t *testing.T) {
+	storage := t.TempDir()
+	project := t.TempDir()
+	a := newAdapter(clineVariant, []string{storage})
+	tasks := filepath.Join(storage, tasksDirName)
+
+	writeTask(t, storage, "mine", project)
+	writeTask(t, storage, "theirs", t.TempDir())
+	if _, err := a.Sessions(project); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{
+		filepath.Join(tasks, "mine"):                                "mine",
+		filepath.Join(tasks, "mine", uiMessagesFile):                "mine",
+		filepath.Join(tasks, "mine", apiHistoryFile):                "mine",
+		filepath.Join(tasks, "mine", "checkpoints", "x"):            "",
+		filepath.Join(tasks, "mine", taskMetadataFile):              "",
+		filepath.Join(storage, "state", "taskHistory.json"):         "",
+		filepath.Join(t.TempDir(), "tasks", "mine", apiHistoryFile): "",
+	}
+	for path, want := range cases {
+		if got := a.SessionIDFromPath(path); got != want {
+			t.Errorf("SessionIDFromPath(%s) = %q, want %q", path, got, want)
+		}
+	}
+
+	if roots := a.SessionRootDirs(); len(roots) != 1 || roots[0] != tasks {
+		t.Errorf("SessionRootDirs = %v", roots)
+	}
+
+	// Scanning the tasks root skips tasks from other workspaces but keeps
+	// brand-new tasks whose workspace is not recorded yet.
+	if err := os.MkdirAll(filepath.Join(tasks, "fresh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	infos, err := a.ScanSessionDir(tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, info := range infos {
+		ids = append(ids, info.ID)
+	}
+	if got := strings.Join(ids, ","); got != "fresh,mine" {
+		t.Errorf("scanned tasks = %s, want fresh,mine", got)
+	}
+
+	infos, _ = a.ScanSessionDir(filepath.Join(tasks, "mine"))
+	if len(infos) != 1 || infos[0].Path != filepath.Join(tasks, "mine", apiHistoryFile) {
+		t.Errorf("task dir scan = %+v", infos)
+	}
+}
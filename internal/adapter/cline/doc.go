@@ -0,0 +1,4 @@
+// Package cline provides adapters for the Cline and Roo Code VS Code
+// extensions, which store task histories as JSON under the editor's
+// globalStorage/<extension>/tasks/<id>/ directories.
+package cline
@@ -0,0 +1,357 @@
+package cline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+var (
+	// environmentRe matches the environment_details block both extensions
+	// append to user messages.
+	environmentRe = regexp.MustCompile(`(?s)<environment_details>.*?</environment_details>`)
+	// workingDirRe extracts the workspace from environment_details:
+	// "# Current Working Directory (/path) Files" (Cline) or
+	// "# Current Workspace Directory (/path) Files" (Roo Code).
+	workingDirRe = regexp.MustCompile(`# Current (?:Working|Workspace) Directory \((.+?)\) Files`)
+	// taskTagRe unwraps the <task> tag around the initial prompt.
+	taskTagRe = regexp.MustCompile(`(?s)^\s*<task>\s*(.*?)\s*</task>`)
+)
+
+// readJSONFile decodes a JSON file into v.
+func readJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// readTaskMetadata reads task_metadata.json, returning nil when absent.
+func readTaskMetadata(path string) *taskMetadata {
+	var md taskMetadata
+	if err := readJSONFile(path, &md); err != nil {
+		return nil
+	}
+	sort.SliceStable(md.ModelUsage, func(i, j int) bool {
+		return md.ModelUsage[i].Ts < md.ModelUsage[j].Ts
+	})
+	return &md
+}
+
+// apiRequest is an "api_req_started" UI message with its decoded usage.
+type apiRequest struct {
+	Index int // index in the UI message list
+	Ts    time.Time
+	Info  apiRequestInfo
+}
+
+// apiRequests returns the UI messages that mark an API request, in order.
+func apiRequests(ui []uiMessage) []apiRequest {
+	var reqs []apiRequest
+	for i, m := range ui {
+		if m.Type != "say" || m.Say != "api_req_started" {
+			continue
+		}
+		req := apiRequest{Index: i, Ts: msToTime(m.Ts)}
+		_ = json.Unmarshal([]byte(m.Text), &req.Info)
+		reqs = append(reqs, req)
+	}
+	return reqs
+}
+
+// computeMeta summarizes a task for the session list.
+func computeMeta(api []apiMessage, ui []uiMessage) taskMeta {
+	var meta taskMeta
+	for _, m := range ui {
+		ts := msToTime(m.Ts)
+		if ts.IsZero() {
+			continue
+		}
+		if meta.FirstTs.IsZero() || ts.Before(meta.FirstTs) {
+			meta.FirstTs = ts
+		}
+		if ts.After(meta.LastTs) {
+			meta.LastTs = ts
+		}
+		if meta.Task == "" && m.Type == "say" && m.Say == "task" {
+			meta.Task = m.Text
+		}
+	}
+	for _, req := range apiRequests(ui) {
+		meta.Usage.InputTokens += req.Info.TokensIn
+		meta.Usage.OutputTokens += req.Info.TokensOut
+		meta.Usage.CacheRead += req.Info.CacheReads
+		meta.Usage.CacheWrite += req.Info.CacheWrites
+		if req.Info.Cost != nil {
+			meta.Cost += *req.Info.Cost
+		}
+	}
+
+	meta.MessageCount = len(api)
+	for _, m := range api {
+		if ts := msToTime(m.Ts); !ts.IsZero() {
+			if meta.FirstTs.IsZero() || ts.Before(meta.FirstTs) {
+				meta.FirstTs = ts
+			}
+			if ts.After(meta.LastTs) {
+				meta.LastTs = ts
+			}
+		}
+		if meta.CWD == "" && m.Role == "user" {
+			text := rawText(m.Content)
+			if match := workingDirRe.FindStringSubmatch(text); match != nil {
+				meta.CWD = strings.TrimSpace(match[1])
+			}
+		}
+	}
+	if meta.Task == "" {
+		for _, m := range api {
+			if m.Role == "user" {
+				text := rawText(m.Content)
+				meta.Task = cleanUserText(text)
+				break
+			}
+		}
+	}
+	return meta
+}
+
+// buildMessages converts the API conversation into adapter messages. Usage
+// and timestamps come from the UI log's api_req_started entries, matched to
+// assistant messages in order, unless the API entry records its own.
+func buildMessages(taskID string, api []apiMessage, ui []uiMessage, md *taskMetadata) []adapter.Message {
+	results := collectToolResults(api)
+	reqs := apiRequests(ui)
+
+	var start time.Time
+	if len(ui) > 0 {
+		start = msToTime(ui[0].Ts)
+	}
+	// endOf returns when the k-th API request's response finished: the last
+	// UI message before the next request starts.
+	endOf := func(k int) time.Time {
+		end := len(ui) - 1
+		if k+1 < len(reqs) {
+			end = reqs[k+1].Index - 1
+		}
+		if end < 0 || end >= len(ui) {
+			return time.Time{}
+		}
+		return msToTime(ui[end].Ts)
+	}
+
+	messages := make([]adapter.Message, 0, len(api))
+	assistantIdx := 0
+	for i, m := range api {
+		if m.Role != "user" && m.Role != "assistant" {
+			continue
+		}
+		msg := adapter.Message{
+			ID:        fmt.Sprintf("%s-%d", taskID, i),
+			Role:      m.Role,
+			Timestamp: msToTime(m.Ts),
+		}
+		msg.Content, msg.ToolUses, msg.ThinkingBlocks, msg.ContentBlocks = parseContent(m.Content, m.Role, results)
+
+		if m.Role == "assistant" {
+			if assistantIdx < len(reqs) {
+				info := reqs[assistantIdx].Info
+				msg.TokenUsage = adapter.TokenUsage{
+					InputTokens:  info.TokensIn,
+					OutputTokens: info.TokensOut,
+					CacheRead:    info.CacheReads,
+					CacheWrite:   info.CacheWrites,
+				}
+				if msg.Timestamp.IsZero() {
+					msg.Timestamp = endOf(assistantIdx)
+				}
+			}
+			if m.ModelInfo != nil {
+				msg.Model = m.ModelInfo.ModelID
+			}
+			assistantIdx++
+		} else if msg.Timestamp.IsZero() {
+			// A user turn is sent as the next API request
+			if assistantIdx < len(reqs) {
+				msg.Timestamp = reqs[assistantIdx].Ts
+			} else if assistantIdx == 0 {
+				msg.Timestamp = start
+			}
+		}
+		messages = append(messages, msg)
+	}
+
+	// Keep timestamps monotonic; entries without one inherit the previous
+	running := start
+	for i := range messages {
+		if messages[i].Timestamp.IsZero() || messages[i].Timestamp.Before(running) {
+			messages[i].Timestamp = running
+		}
+		running = messages[i].Timestamp
+		if messages[i].Role == "assistant" && messages[i].Model == "" {
+			messages[i].Model = modelAt(md, running)
+		}
+	}
+	return messages
+}
+
+// modelAt returns the model in use at ts according to task_metadata.json,
+// whose model_usage entries are sorted by readTaskMetadata.
+func modelAt(md *taskMetadata, ts time.Time) string {
+	if md == nil || len(md.ModelUsage) == 0 {
+		return ""
+	}
+	model := md.ModelUsage[0].ModelID
+	for _, u := range md.ModelUsage {
+		if msToTime(u.Ts).After(ts) {
+			break
+		}
+		model = u.ModelID
+	}
+	return model
+}
+
+// toolResult is a tool_result block keyed by its tool_use ID.
+type toolResult struct {
+	content string
+	isError bool
+}
+
+// collectToolResults gathers tool results so tool_use blocks can show their
+// output inline.
+func collectToolResults(api []apiMessage) map[string]toolResult {
+	results := make(map[string]toolResult)
+	for _, m := range api {
+		if m.Role != "user" {
+			continue
+		}
+		var blocks []contentBlock
+		if err := json.Unmarshal(m.Content, &blocks); err != nil {
+			continue
+		}
+		for _, b := range blocks {
+			if b.Type == "tool_result" && b.ToolUseID != "" {
+				text := rawText(b.Content)
+				results[b.ToolUseID] = toolResult{content: text, isError: b.IsError}
+			}
+		}
+	}
+	return results
+}
+
+// parseContent maps API content onto adapter content blocks.
+func parseContent(raw json.RawMessage, role string, results map[string]toolResult) (string, []adapter.ToolUse, []adapter.ThinkingBlock, []adapter.ContentBlock) {
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		if role == "user" {
+			str = cleanUserText(str)
+		}
+		return str, nil, nil, []adapter.ContentBlock{{Type: "text", Text: str}}
+	}
+
+	var blocks []contentBlock
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return "", nil, nil, nil
+	}
+
+	var texts []string
+	var toolUses []adapter.ToolUse
+	var thinking []adapter.ThinkingBlock
+	var out []adapter.ContentBlock
+	toolResultCount := 0
+	for _, b := range blocks {
+		switch b.Type {
+		case "text":
+			text := b.Text
+			if role == "user" {
+				text = cleanUserText(text)
+			}
+			if text == "" {
+				continue
+			}
+			texts = append(texts, text)
+			out = append(out, adapter.ContentBlock{Type: "text", Text: text})
+		case "image":
+			texts = append(texts, "[image]")
+			out = append(out, adapter.ContentBlock{Type: "text", Text: "[image]"})
+		case "thinking":
+			tokenCount := len(b.Thinking) / 4
+			thinking = append(thinking, adapter.ThinkingBlock{Content: b.Thinking, TokenCount: tokenCount})
+			out = append(out, adapter.ContentBlock{Type: "thinking", Text: b.Thinking, TokenCount: tokenCount})
+		case "tool_use":
+			input := string(b.Input)
+			result := results[b.ID]
+			toolUses = append(toolUses, adapter.ToolUse{ID: b.ID, Name: b.Name, Input: input, Output: result.content})
+			out = append(out, adapter.ContentBlock{
+				Type:       "tool_use",
+				ToolUseID:  b.ID,
+				ToolName:   b.Name,
+				ToolInput:  input,
+				ToolOutput: result.content,
+				IsError:    result.isError,
+			})
+		case "tool_result":
+			toolResultCount++
+			text := rawText(b.Content)
+			out = append(out, adapter.ContentBlock{
+				Type:       "tool_result",
+				ToolUseID:  b.ToolUseID,
+				ToolOutput: text,
+				IsError:    b.IsError,
+			})
+		}
+	}
+
+	content := strings.Join(texts, "\n")
+	if content == "" && toolResultCount > 0 {
+		content = fmt.Sprintf("[%d tool result(s)]", toolResultCount)
+	}
+	return content, toolUses, thinking, out
+}
+
+// rawText flattens string or text-block content into plain text.
+func rawText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str
+	}
+	var blocks []contentBlock
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return ""
+	}
+	var texts []string
+	for _, b := range blocks {
+		if b.Type == "text" && b.Text != "" {
+			texts = append(texts, b.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// cleanUserText strips the environment_details block and unwraps the
+// <task> tag the extensions add around user input.
+func cleanUserText(text string) string {
+	text = environmentRe.ReplaceAllString(text, "")
+	if m := taskTagRe.FindStringSubmatch(text); m != nil {
+		text = m[1] + text[len(m[0]):]
+	}
+	return strings.TrimSpace(text)
+}
+
+// msToTime converts a Unix millisecond timestamp, treating 0 as unset.
+func msToTime(ms int64) time.Time {
+	if ms <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms)
+}
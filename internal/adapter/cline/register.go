@@ -0,0 +1,12 @@
+package cline
+
+import "github.com/toddwbucy/hermes/internal/adapter"
+
+func init() {
+	adapter.RegisterFactory(func() adapter.Adapter {
+		return NewCline()
+	})
+	adapter.RegisterFactory(func() adapter.Adapter {
+		return NewRooCode()
+	})
+}
@@ -0,0 +1,99 @@
+package cline
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+// apiMessage is one entry of api_conversation_history.json: an Anthropic
+// MessageParam, plus the timestamp and model newer versions record.
+type apiMessage struct {
+	Role      string          `json:"role"`
+	Content   json.RawMessage `json:"content"`
+	Ts        int64           `json:"ts,omitempty"`
+	ModelInfo *struct {
+		ModelID string `json:"modelId"`
+	} `json:"modelInfo,omitempty"`
+}
+
+// contentBlock is one block of an API message's content array.
+type contentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	Thinking  string          `json:"thinking,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   json.RawMessage `json:"content,omitempty"`
+	IsError   bool            `json:"is_error,omitempty"`
+}
+
+// uiMessage is one entry of ui_messages.json, the chat the extension renders.
+type uiMessage struct {
+	Ts   int64  `json:"ts"`
+	Type string `json:"type"` // "ask" or "say"
+	Say  string `json:"say,omitempty"`
+	Ask  string `json:"ask,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+// apiRequestInfo is the JSON payload of an "api_req_started" UI message,
+// filled in with usage once the request completes.
+type apiRequestInfo struct {
+	TokensIn    int      `json:"tokensIn"`
+	TokensOut   int      `json:"tokensOut"`
+	CacheWrites int      `json:"cacheWrites"`
+	CacheReads  int      `json:"cacheReads"`
+	Cost        *float64 `json:"cost"`
+}
+
+// historyItem is a task's entry in the extension's task history
+// (state/taskHistory.json for Cline, tasks/<id>/history_item.json for Roo Code).
+type historyItem struct {
+	ID          string  `json:"id"`
+	Ts          int64   `json:"ts"`
+	Task        string  `json:"task"`
+	TokensIn    int     `json:"tokensIn"`
+	TokensOut   int     `json:"tokensOut"`
+	CacheWrites int     `json:"cacheWrites"`
+	CacheReads  int     `json:"cacheReads"`
+	TotalCost   float64 `json:"totalCost"`
+	CWD         string  `json:"cwdOnTaskInitialization,omitempty"` // Cline
+	Workspace   string  `json:"workspace,omitempty"`               // Roo Code
+}
+
+// taskMetadata is tasks/<id>/task_metadata.json (Cline only).
+type taskMetadata struct {
+	ModelUsage []struct {
+		Ts      int64  `json:"ts"`
+		ModelID string `json:"model_id"`
+	} `json:"model_usage"`
+}
+
+// taskMeta is the per-task summary used to build a Session.
+type taskMeta struct {
+	Task         string
+	CWD          string
+	FirstTs      time.Time
+	LastTs       time.Time
+	MessageCount int
+	Usage        adapter.TokenUsage
+	Cost         float64
+}
+
+// metaCacheEntry caches taskMeta keyed on both history files' mtimes.
+type metaCacheEntry struct {
+	meta    taskMeta
+	apiMod  time.Time
+	apiSize int64
+	uiMod   time.Time
+}
+
+// msgCacheEntry caches parsed messages for a task.
+type msgCacheEntry struct {
+	messages []adapter.Message
+	uiMod    time.Time
+}
@@ -0,0 +1,146 @@
+package codex
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/toddwbucy/hermes/internal/adapter/cache"
+)
+
+// ForkSession implements adapter.Forker. The rollout's records up to the
+// message throughMessageID are written to a new rollout file under today's
+// sessions directory, with the session_meta record given a new ID and
+// workDir as its cwd, so `codex resume` picks it up.
+func (a *Adapter) ForkSession(sessionID, throughMessageID, workDir string) (string, error) {
+	src := a.sessionFilePath(sessionID)
+	if src == "" {
+		return "", fmt.Errorf("session %s not found", sessionID)
+	}
+	lines, err := a.forkLines(src, sessionID, throughMessageID)
+	if err != nil {
+		return "", err
+	}
+
+	newID := uuid.NewString()
+	var out bytes.Buffer
+	for _, line := range lines {
+		rewritten, err := rewriteSessionMeta(line, newID, workDir)
+		if err != nil {
+			return "", err
+		}
+		out.Write(rewritten)
+		out.WriteByte('\n')
+	}
+
+	now := time.Now()
+	dir := filepath.Join(a.sessionsDir, now.Format("2006"), now.Format("01"), now.Format("02"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("rollout-%s-%s.jsonl", now.Format("2006-01-02T15-04-05"), newID))
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, out.Bytes(), 0644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return "", err
+	}
+
+	a.mu.Lock()
+	a.sessionIndex[newID] = path
+	a.mu.Unlock()
+	a.dirCacheMu.Lock()
+	a.dirCache = nil
+	a.dirCacheMu.Unlock()
+	return newID, nil
+}
+
+// forkLines returns the records of a rollout through the one that produced
+// message throughID. Message IDs are positional, so records are replayed
+// through the same parser Messages uses.
+func (a *Adapter) forkLines(path, sessionID, throughID string) ([][]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	buf := cache.GetScannerBuffer()
+	defer cache.PutScannerBuffer(buf)
+	scanner.Buffer(buf, 10*1024*1024)
+
+	state := newParseState(sessionID)
+	var lines [][]byte
+	for scanner.Scan() {
+		line := append([]byte(nil), scanner.Bytes()...)
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		n := len(state.messages)
+		a.processMessageRecord(line, state)
+		for _, m := range state.messages[n:] {
+			if m.ID != throughID {
+				continue
+			}
+			// Synthetic tool-call messages are flushed by the record that
+			// starts the next turn, which stays out of the fork
+			if strings.HasPrefix(m.ID, "synthetic-") {
+				return lines, nil
+			}
+			return append(lines, line), nil
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Trailing tool calls become a synthetic message at the end of the file
+	state.flushPending()
+	if n := len(state.messages); n > 0 && state.messages[n-1].ID == throughID {
+		return lines, nil
+	}
+	return nil, fmt.Errorf("message %s not found in session", throughID)
+}
+
+// rewriteSessionMeta gives a session_meta record a new session ID and
+// working directory. Other records are returned unchanged.
+func rewriteSessionMeta(line []byte, sessionID, workDir string) ([]byte, error) {
+	var record map[string]json.RawMessage
+	if err := json.Unmarshal(line, &record); err != nil {
+		return line, nil
+	}
+	var recordType string
+	if err := json.Unmarshal(record["type"], &recordType); err != nil || recordType != "session_meta" {
+		return line, nil
+	}
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(record["payload"], &payload); err != nil {
+		return line, nil
+	}
+
+	id, err := json.Marshal(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	payload["id"] = id
+	if workDir != "" {
+		cwd, err := json.Marshal(workDir)
+		if err != nil {
+			return nil, err
+		}
+		payload["cwd"] = cwd
+	}
+	if record["payload"], err = json.Marshal(payload); err != nil {
+		return nil, err
+	}
+	return json.Marshal(record)
+}
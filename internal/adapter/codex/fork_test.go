@@ -0,0 +1,75 @@
+package codex
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestForkSession(t *testing.T) {
+	root := t.TempDir()
+	sessionsDir := filepath.Join(root, "sessions")
+	path := filepath.Join(sessionsDir, "2025", "11", "20")
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		t.Fatalf("mkdir sessions: %v", err)
+	}
+	lines := []string{
+		`{"timestamp":"2025-11-21T04:13:55.791Z","type":"session_meta","payload":{"id":"id-1","timestamp":"2025-11-21T04:13:55.777Z","cwd":"/repo"}}`,
+		`{"timestamp":"2025-11-21T04:14:00.000Z","type":"response_item","payload":{"type":"message","role":"user","content":[{"type":"input_text","text":"hello"}]}}`,
+		`{"timestamp":"2025-11-21T04:14:05.000Z","type":"response_item","payload":{"type":"message","role":"assistant","content":[{"type":"output_text","text":"done"}]}}`,
+		`{"timestamp":"2025-11-21T04:14:06.000Z","type":"response_item","payload":{"type":"custom_tool_call","name":"apply_patch","input":"patch","call_id":"call-2"}}`,
+		`{"timestamp":"2025-11-21T04:14:07.000Z","type":"response_item","payload":{"type":"custom_tool_call_output","call_id":"call-2","output":"applied"}}`,
+		`{"timestamp":"2025-11-21T04:14:08.000Z","type":"response_item","payload":{"type":"message","role":"user","content":[{"type":"input_text","text":"next"}]}}`,
+	}
+	if err := writeSessionFile(filepath.Join(path, "rollout-1.jsonl"), lines); err != nil {
+		t.Fatalf("write session file: %v", err)
+	}
+
+	a := New()
+	a.sessionsDir = sessionsDir
+	original, err := a.Messages("id-1")
+	if err != nil || len(original) != 4 {
+		t.Fatalf("Messages = %d, %v", len(original), err)
+	}
+
+	tests := []struct {
+		name    string
+		through int // index into original
+		want    []string
+	}{
+		{"assistant reply", 1, []string{"hello", "done"}},
+		{"trailing tool calls", 2, []string{"hello", "done", "tool calls"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newID, err := a.ForkSession("id-1", original[tt.through].ID, "/worktree")
+			if err != nil {
+				t.Fatalf("ForkSession: %v", err)
+			}
+			forked, err := a.Messages(newID)
+			if err != nil {
+				t.Fatalf("Messages(fork): %v", err)
+			}
+			var got []string
+			for _, m := range forked {
+				got = append(got, m.Content)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("fork messages = %q, want %q", got, tt.want)
+			}
+
+			data, err := os.ReadFile(a.sessionFilePath(newID))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(data), `"id":"`+newID+`"`) || !strings.Contains(string(data), `"cwd":"/worktree"`) {
+				t.Errorf("session_meta not rewritten:\n%s", data)
+			}
+		})
+	}
+
+	if _, err := a.ForkSession("id-1", "missing", "/worktree"); err == nil {
+		t.Error("unknown message should fail")
+	}
+}
@@ -0,0 +1,367 @@
+package otlp
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/adapter/tieredwatcher"
+	"github.com/toddwbucy/hermes/internal/adapter/weaver"
+)
+
+const (
+	adapterID   = "otlp"
+	adapterName = "OpenInference"
+	adapterIcon = "\u25CE" // ◎ — mnemonic for "receiver dish"
+
+	storeDirName = "otlp"
+
+	// DefaultPort is the standard OTLP/HTTP port.
+	DefaultPort = 4318
+)
+
+// Options configures the receiver adapter.
+type Options struct {
+	Enabled  bool
+	Port     int    // Loopback port; 0 picks a free one
+	StateDir string // Directory for the per-session JSONL files
+}
+
+// Adapter implements adapter.Adapter for spans received over OTLP/HTTP.
+//
+// The receiver starts on the first Detect call with the adapter enabled and
+// runs for the life of the process. Received spans are written to the
+// store before the export request is acknowledged.
+type Adapter struct {
+	opts  Options
+	store *Store
+
+	startOnce sync.Once
+	receiver  *Receiver
+	startErr  error
+
+	mu          sync.Mutex
+	subscribers map[chan adapter.Event]struct{}
+}
+
+// New constructs an OTLP adapter with the receiver disabled, on the default
+// port and state directory. Configure applies the user's settings.
+func New() *Adapter {
+	return newAdapter(Options{Port: DefaultPort, StateDir: DefaultDir("")})
+}
+
+// DefaultDir returns the span store directory under configDir, or under
+// ~/.config/hermes when configDir is empty.
+func DefaultDir(configDir string) string {
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		configDir = filepath.Join(home, ".config", "hermes")
+	}
+	return filepath.Join(configDir, storeDirName)
+}
+
+func newAdapter(opts Options) *Adapter {
+	return &Adapter{
+		opts:        opts,
+		store:       NewStore(opts.StateDir),
+		subscribers: make(map[chan adapter.Event]struct{}),
+	}
+}
+
+// Configure replaces the receiver options. It must be called before Detect
+// starts the receiver; once running, the receiver keeps its options. An
+// empty StateDir falls back to DefaultDir.
+func (a *Adapter) Configure(opts Options) {
+	if a.receiver != nil {
+		return
+	}
+	if opts.StateDir == "" {
+		opts.StateDir = DefaultDir("")
+	}
+	a.opts = opts
+	a.store = NewStore(opts.StateDir)
+}
+
+func (a *Adapter) ID() string   { return adapterID }
+func (a *Adapter) Name() string { return adapterName }
+func (a *Adapter) Icon() string { return adapterIcon }
+
+// Detect starts the receiver when the adapter is enabled. Sessions received
+// earlier stay browsable even if the port is taken, so a bind failure is
+// logged rather than disabling the adapter.
+func (a *Adapter) Detect(projectRoot string) (bool, error) {
+	if !a.opts.Enabled || a.opts.StateDir == "" {
+		return false, nil
+	}
+	if err := a.start(); err != nil {
+		slog.Warn("otlp receiver unavailable", "port", a.opts.Port, "err", err)
+	}
+	return true, nil
+}
+
+// start launches the receiver once.
+func (a *Adapter) start() error {
+	a.startOnce.Do(func() {
+		r := NewReceiver(a.ingest)
+		if err := r.Start(a.opts.Port); err != nil {
+			a.startErr = err
+			return
+		}
+		a.receiver = r
+	})
+	return a.startErr
+}
+
+// Addr returns the receiver's listening address, or "" when not running.
+func (a *Adapter) Addr() string {
+	if a.receiver == nil {
+		return ""
+	}
+	return a.receiver.Addr()
+}
+
+// Close stops the receiver.
+func (a *Adapter) Close() error {
+	if a.receiver == nil {
+		return nil
+	}
+	return a.receiver.Close()
+}
+
+// Capabilities returns the supported features.
+func (a *Adapter) Capabilities() adapter.CapabilitySet {
+	return adapter.CapabilitySet{
+		adapter.CapSessions: true,
+		adapter.CapMessages: true,
+		adapter.CapUsage:    true,
+		adapter.CapWatch:    true,
+	}
+}
+
+// WatchScope returns Global scope — received spans are not tied to a project.
+func (a *Adapter) WatchScope() adapter.WatchScope {
+	return adapter.WatchScopeGlobal
+}
+
+// Sessions returns every session in the store, newest first. Received
+// spans carry no working directory, so sessions are not project-filtered.
+func (a *Adapter) Sessions(projectRoot string) ([]adapter.Session, error) {
+	ids, err := a.store.SessionIDs()
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]adapter.Session, 0, len(ids))
+	for _, id := range ids {
+		s, err := a.SessionByID(id)
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, *s)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].UpdatedAt.After(sessions[j].UpdatedAt)
+	})
+	return sessions, nil
+}
+
+// SessionByID returns a single session. Implements adapter.TargetedRefresher.
+func (a *Adapter) SessionByID(sessionID string) (*adapter.Session, error) {
+	path := a.store.Path(sessionID)
+	info, err := os.Stat(path)
+	if err != nil || !validSessionID(sessionID) {
+		return nil, fmt.Errorf("otlp session %s not found", sessionID)
+	}
+	spans, err := a.store.Load(sessionID)
+	if err != nil && len(spans) == 0 {
+		return nil, err
+	}
+	s := buildSession(sessionID, path, spans, info)
+	return &s, nil
+}
+
+// Messages returns the message stream built from the session's LLM and
+// TOOL spans with weaver's span-to-message mapping.
+func (a *Adapter) Messages(sessionID string) ([]adapter.Message, error) {
+	spans, err := a.store.Load(sessionID)
+	if err != nil && len(spans) == 0 {
+		return nil, err
+	}
+	return weaver.BuildMessages(spans), nil
+}
+
+// Usage aggregates token counts across the session's LLM spans.
+func (a *Adapter) Usage(sessionID string) (*adapter.UsageStats, error) {
+	spans, err := a.store.Load(sessionID)
+	if err != nil && len(spans) == 0 {
+		return nil, err
+	}
+	sum := weaver.Summarize(spans, time.Time{})
+	return &adapter.UsageStats{
+		TotalInputTokens:  sum.InputTokens,
+		TotalOutputTokens: sum.OutputTokens,
+		MessageCount:      sum.LLMCalls,
+	}, nil
+}
+
+// TraceSpans returns the session's spans for the trace waterfall.
+// Implements adapter.TraceProvider.
+func (a *Adapter) TraceSpans(sessionID string) ([]adapter.TraceSpan, error) {
+	spans, err := a.store.Load(sessionID)
+	if err != nil && len(spans) == 0 {
+		return nil, err
+	}
+	out := make([]adapter.TraceSpan, len(spans))
+	for i := range spans {
+		out[i] = weaver.ToTraceSpan(&spans[i])
+	}
+	return out, nil
+}
+
+// Watch streams an event per session touched by each export request.
+// Events are emitted on ingest rather than from file notifications, so
+// sessions created before the first watcher starts are still reported.
+func (a *Adapter) Watch(projectRoot string) (<-chan adapter.Event, io.Closer, error) {
+	ch := make(chan adapter.Event, 32)
+	a.mu.Lock()
+	a.subscribers[ch] = struct{}{}
+	a.mu.Unlock()
+	return ch, &subscription{a: a, ch: ch}, nil
+}
+
+// subscription unregisters a Watch channel on Close.
+type subscription struct {
+	a    *Adapter
+	ch   chan adapter.Event
+	once sync.Once
+}
+
+func (s *subscription) Close() error {
+	s.once.Do(func() {
+		s.a.mu.Lock()
+		delete(s.a.subscribers, s.ch)
+		s.a.mu.Unlock()
+		close(s.ch)
+	})
+	return nil
+}
+
+// ingest stores received spans and notifies watchers.
+func (a *Adapter) ingest(spans []weaver.Span) error {
+	written, created, err := a.store.Append(spans)
+	isNew := make(map[string]bool, len(created))
+	for _, id := range created {
+		isNew[id] = true
+	}
+
+	ids := make([]string, 0, len(written))
+	for id := range written {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, id := range ids {
+		evt := adapter.Event{Type: adapter.EventMessageAdded, SessionID: id}
+		if isNew[id] {
+			evt.Type = adapter.EventSessionCreated
+		}
+		for ch := range a.subscribers {
+			select {
+			case ch <- evt:
+			default:
+				// Channel full, drop event
+			}
+		}
+	}
+	return err
+}
+
+// SessionIDFromPath maps a store file to its session ID. Implements
+// tieredwatcher.Layout.
+func (a *Adapter) SessionIDFromPath(path string) string {
+	if filepath.Dir(path) != filepath.Clean(a.store.Dir()) || filepath.Ext(path) != storeExt {
+		return ""
+	}
+	return strings.TrimSuffix(filepath.Base(path), storeExt)
+}
+
+// ScanSessionDir lists the session files in dir. Implements
+// tieredwatcher.Layout.
+func (a *Adapter) ScanSessionDir(dir string) ([]tieredwatcher.SessionInfo, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+storeExt))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]tieredwatcher.SessionInfo, 0, len(matches))
+	for _, path := range matches {
+		id := a.SessionIDFromPath(path)
+		info, err := os.Stat(path)
+		if id == "" || err != nil {
+			continue
+		}
+		result = append(result, tieredwatcher.SessionInfo{
+			ID:       id,
+			Path:     path,
+			ModTime:  info.ModTime(),
+			FileSize: info.Size(),
+		})
+	}
+	return result, nil
+}
+
+// SessionRootDirs returns the store directory. Implements
+// tieredwatcher.Layout.
+func (a *Adapter) SessionRootDirs() []string {
+	return []string{a.store.Dir()}
+}
+
+// buildSession summarizes a session file. Sessions are named after the
+// emitting service, falling back to the session ID.
+func buildSession(id, path string, spans []weaver.Span, info os.FileInfo) adapter.Session {
+	sum := weaver.Summarize(spans, info.ModTime().UTC())
+
+	name := id
+	for i := range spans {
+		if spans[i].Resource.ServiceName != "" {
+			name = spans[i].Resource.ServiceName
+			break
+		}
+	}
+
+	return adapter.Session{
+		ID:              id,
+		Name:            name,
+		Slug:            shortID(id),
+		AdapterID:       adapterID,
+		AdapterName:     adapterName,
+		AdapterIcon:     adapterIcon,
+		CreatedAt:       sum.First,
+		UpdatedAt:       sum.Last,
+		Duration:        sum.Last.Sub(sum.First),
+		IsActive:        time.Since(sum.Last) < 5*time.Minute,
+		TotalTokens:     sum.InputTokens + sum.OutputTokens,
+		MessageCount:    sum.LLMCalls,
+		FileSize:        info.Size(),
+		Path:            path,
+		SessionCategory: adapter.SessionCategoryInteractive,
+	}
+}
+
+// shortID truncates long session IDs (hex trace IDs, UUIDs) for display.
+func shortID(id string) string {
+	if len(id) <= 12 {
+		return id
+	}
+	return id[:12]
+}
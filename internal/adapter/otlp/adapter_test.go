@@ -0,0 +1,374 @@
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+// jsonFixture is an OpenInference trace as exported over OTLP/JSON: an
+// agent span, an LLM call and a failed tool call, keyed by session.id.
+const jsonFixture = `{
+  "resourceSpans": [{
+    "resource": {"attributes": [
+      {"key": "service.name", "value": {"stringValue": "support-bot"}},
+      {"key": "host.name", "value": {"stringValue": "olympus"}}
+    ]},
+    "scopeSpans": [{
+      "scope": {"name": "openinference.instrumentation.openai", "version": "0.1.2"},
+      "spans": [
+        {
+          "traceId": "5B8EFFF798038103D269B633813FC60C", "spanId": "EEE19B7EC3C1B174",
+          "name": "agent.run", "startTimeUnixNano": "1700000000000000000", "endTimeUnixNano": "1700000005000000000",
+          "attributes": [
+            {"key": "openinference.span.kind", "value": {"stringValue": "AGENT"}},
+            {"key": "session.id", "value": {"stringValue": "chat/42"}}
+          ],
+          "status": {}
+        },
+        {
+          "traceId": "5B8EFFF798038103D269B633813FC60C", "spanId": "EEE19B7EC3C1B175", "parentSpanId": "EEE19B7EC3C1B174",
+          "name": "ChatCompletion", "startTimeUnixNano": 1700000001000000000, "endTimeUnixNano": "1700000003000000000",
+          "attributes": [
+            {"key": "openinference.span.kind", "value": {"stringValue": "LLM"}},
+            {"key": "llm.model_name", "value": {"stringValue": "gpt-4o"}},
+            {"key": "llm.token_count.prompt", "value": {"intValue": "120"}},
+            {"key": "llm.token_count.completion", "value": {"intValue": 30}}
+          ],
+          "status": {"code": 1}
+        },
+        {
+          "traceId": "5B8EFFF798038103D269B633813FC60C", "spanId": "EEE19B7EC3C1B176", "parentSpanId": "EEE19B7EC3C1B175",
+          "name": "lookup_order", "startTimeUnixNano": "1700000002000000000", "endTimeUnixNano": "1700000002500000000",
+          "attributes": [
+            {"key": "openinference.span.kind", "value": {"stringValue": "TOOL"}},
+            {"key": "tool.name", "value": {"stringValue": "lookup_order"}},
+            {"key": "tool.parameters", "value": {"stringValue": "{\"order\":7}"}},
+            {"key": "output.value", "value": {"stringValue": "not found"}},
+            {"key": "retry", "value": {"kvlistValue": {"values": [
+              {"key": "attempts", "value": {"intValue": "2"}},
+              {"key": "backoff", "value": {"arrayValue": {"values": [{"doubleValue": 0.5}, {"boolValue": true}]}}}
+            ]}}}
+          ],
+          "status": {"code": 2, "message": "order missing"}
+        }
+      ]
+    }]
+  }]
+}`
+
+func startAdapter(t *testing.T) *Adapter {
+	t.Helper()
+	a := newAdapter(Options{Enabled: true, StateDir: t.TempDir()})
+	ok, err := a.Detect("")
+	if err != nil || !ok {
+		t.Fatalf("Detect = %v, %v", ok, err)
+	}
+	if a.Addr() == "" {
+		t.Fatal("receiver not listening")
+	}
+	t.Cleanup(func() { _ = a.Close() })
+	return a
+}
+
+func post(t *testing.T, a *Adapter, contentType string, body []byte, headers ...string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, "http://"+a.Addr()+tracesPath, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestAdapterMetadata(t *testing.T) {
+	a := newAdapter(Options{StateDir: t.TempDir()})
+	if a.ID() != "otlp" || a.Name() != "OpenInference" || a.Icon() == "" {
+		t.Errorf("metadata = %q %q %q", a.ID(), a.Name(), a.Icon())
+	}
+	if a.WatchScope() != adapter.WatchScopeGlobal {
+		t.Error("WatchScope should be global")
+	}
+	// Disabled adapters neither detect nor listen
+	if ok, _ := a.Detect(""); ok || a.Addr() != "" {
+		t.Error("disabled adapter should not detect or start the receiver")
+	}
+}
+
+func TestReceiveJSON(t *testing.T) {
+	a := startAdapter(t)
+
+	resp := post(t, a, "application/json", []byte(jsonFixture))
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != "{}" {
+		t.Fatalf("response = %d %q", resp.StatusCode, body)
+	}
+
+	sessions, err := a.Sessions("/any/project")
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("Sessions = %d, %v", len(sessions), err)
+	}
+	s := sessions[0]
+	if s.ID != "chat_42" || s.Name != "support-bot" || s.AdapterID != "otlp" {
+		t.Errorf("session = %q %q %q", s.ID, s.Name, s.AdapterID)
+	}
+	if s.TotalTokens != 150 || s.MessageCount != 1 || s.Duration != 5*time.Second {
+		t.Errorf("session totals = %d tokens, %d msgs, %v", s.TotalTokens, s.MessageCount, s.Duration)
+	}
+	if s.Path != filepath.Join(a.opts.StateDir, "chat_42.jsonl") {
+		t.Errorf("Path = %q", s.Path)
+	}
+
+	msgs, err := a.Messages(s.ID)
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("Messages = %d, %v", len(msgs), err)
+	}
+	if msgs[0].Model != "gpt-4o" || msgs[0].InputTokens != 120 || msgs[0].OutputTokens != 30 {
+		t.Errorf("message = %+v", msgs[0])
+	}
+	if len(msgs[0].ToolUses) != 1 || msgs[0].ToolUses[0].Name != "lookup_order" || msgs[0].ToolUses[0].Output != "not found" {
+		t.Errorf("tool uses = %+v", msgs[0].ToolUses)
+	}
+
+	spans, err := a.TraceSpans(s.ID)
+	if err != nil || len(spans) != 3 {
+		t.Fatalf("TraceSpans = %d, %v", len(spans), err)
+	}
+	tool := spans[2]
+	if tool.TraceID != "5b8efff798038103d269b633813fc60c" || tool.ParentID != "eee19b7ec3c1b175" {
+		t.Errorf("IDs not normalized: %q %q", tool.TraceID, tool.ParentID)
+	}
+	if !tool.IsError() || tool.StatusMessage != "order missing" || spans[0].StatusCode != adapter.SpanStatusUnset {
+		t.Errorf("status = %q %q", tool.StatusCode, tool.StatusMessage)
+	}
+	if got := tool.Attributes["retry"]; got != `{"attempts":2,"backoff":[0.5,true]}` {
+		t.Errorf("nested attribute = %s", got)
+	}
+
+	// Spans survive a restart: a fresh adapter reads them from the state dir
+	b := newAdapter(a.opts)
+	if usage, err := b.Usage("chat_42"); err != nil || usage.TotalInputTokens != 120 {
+		t.Errorf("Usage after reload = %+v, %v", usage, err)
+	}
+}
+
+func TestReceiveProtobufGzip(t *testing.T) {
+	a := startAdapter(t)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write(protoFixture())
+	_ = gz.Close()
+
+	resp := post(t, a, "application/x-protobuf", buf.Bytes(), "Content-Encoding", "gzip")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/x-protobuf" {
+		t.Fatalf("response = %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	// Without a session.id the weaver run ID keys the session
+	spans, err := a.TraceSpans("run_01")
+	if err != nil || len(spans) != 2 {
+		t.Fatalf("TraceSpans = %d, %v", len(spans), err)
+	}
+	llm := spans[1]
+	if llm.SpanID != "0000000000000002" || llm.ParentID != "0000000000000001" || llm.Kind != "LLM" {
+		t.Errorf("span = %+v", llm)
+	}
+	if llm.Duration() != 1500*time.Millisecond || llm.StatusCode != adapter.SpanStatusOK {
+		t.Errorf("timing/status = %v %s", llm.Duration(), llm.StatusCode)
+	}
+	if llm.Attributes["llm.token_count.prompt"] != "42" || llm.Attributes["temperature"] != "0.25" || llm.Attributes["stream"] != "true" {
+		t.Errorf("attributes = %v", llm.Attributes)
+	}
+	sessions, _ := a.Sessions("")
+	if len(sessions) != 1 || sessions[0].Name != "bench" {
+		t.Errorf("sessions = %+v", sessions)
+	}
+}
+
+func TestTraceSpansFollowTheirSession(t *testing.T) {
+	a := startAdapter(t)
+	post(t, a, "application/json", []byte(jsonFixture))
+
+	// A late span of the same trace, from a resource without a session key
+	late := `{"resourceSpans":[{"resource":{},"scopeSpans":[{"spans":[
+		{"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b177","parentSpanId":"eee19b7ec3c1b174",
+		 "name":"summarize","startTimeUnixNano":"1700000006000000000","endTimeUnixNano":"1700000007000000000"}]}]}]}`
+	post(t, a, "application/json", []byte(late))
+
+	if spans, _ := a.TraceSpans("chat_42"); len(spans) != 4 {
+		t.Errorf("late span not grouped with its trace: %d spans", len(spans))
+	}
+	if ids, _ := a.store.SessionIDs(); len(ids) != 1 {
+		t.Errorf("sessions = %v, want one", ids)
+	}
+}
+
+func TestReceiveRejectsBadRequests(t *testing.T) {
+	a := startAdapter(t)
+
+	resp, err := http.Get("http://" + a.Addr() + tracesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET = %d, want 405", resp.StatusCode)
+	}
+
+	if resp := post(t, a, "text/plain", []byte("hi")); resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("text/plain = %d, want 415", resp.StatusCode)
+	}
+
+	resp = post(t, a, "application/json", []byte(`{"resourceSpans": [`))
+	var st struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&st); err != nil || resp.StatusCode != http.StatusBadRequest || st.Code != codeInvalidArgument {
+		t.Errorf("bad JSON = %d %+v %v", resp.StatusCode, st, err)
+	}
+
+	// A length prefix running past the end of the body
+	resp = post(t, a, "application/x-protobuf", []byte{0x0a, 0x10, 0x01})
+	if resp.StatusCode != http.StatusBadRequest || resp.Header.Get("Content-Type") != "application/x-protobuf" {
+		t.Errorf("bad protobuf = %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	if ids, _ := a.store.SessionIDs(); len(ids) != 0 {
+		t.Errorf("rejected requests stored sessions: %v", ids)
+	}
+}
+
+func TestWatchEmitsOnIngest(t *testing.T) {
+	a := startAdapter(t)
+	events, closer, err := a.Watch("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	post(t, a, "application/json", []byte(jsonFixture))
+	post(t, a, "application/json", []byte(jsonFixture))
+	for _, want := range []adapter.EventType{adapter.EventSessionCreated, adapter.EventMessageAdded} {
+		select {
+		case evt := <-events:
+			if evt.Type != want || evt.SessionID != "chat_42" {
+				t.Errorf("event = %+v, want %s for chat_42", evt, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("no %s event", want)
+		}
+	}
+
+	_ = closer.Close()
+	if _, ok := <-events; ok {
+		t.Error("channel should close with the watcher")
+	}
+}
+
+func TestLayout(t *testing.T) {
+	a := newAdapter(Options{StateDir: t.TempDir()})
+	dir := a.store.Dir()
+	if got := a.SessionIDFromPath(filepath.Join(dir, "chat_42.jsonl")); got != "chat_42" {
+		t.Errorf("SessionIDFromPath = %q", got)
+	}
+	if got := a.SessionIDFromPath(filepath.Join(dir, "sub", "x.jsonl")); got != "" {
+		t.Errorf("nested path resolved to %q", got)
+	}
+	if _, err := a.SessionByID("../escape"); err == nil {
+		t.Error("SessionByID should reject IDs outside the store")
+	}
+}
+
+func TestSanitizeSessionID(t *testing.T) {
+	cases := map[string]string{
+		"chat/42":                   "chat_42",
+		"../../etc/passwd":          "_.._etc_passwd",
+		"":                          "unknown",
+		"550e8400-e29b-41d4-a716-4": "550e8400-e29b-41d4-a716-4",
+		strings.Repeat("a", 300):    strings.Repeat("a", maxSessionIDLen),
+	}
+	for in, want := range cases {
+		if got := sanitizeSessionID(in); got != want {
+			t.Errorf("sanitizeSessionID(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// protoFixture encodes a two-span ExportTraceServiceRequest keyed by a
+// weaver run ID.
+func protoFixture() []byte {
+	str := func(key, v string) []byte {
+		return protoKV(key, appendProtoBytes(nil, 1, []byte(v)))
+	}
+	traceID, _ := hex.DecodeString("00000000000000000000000000000001")
+
+	res := appendProtoBytes(nil, 1, str("service.name", "bench"))
+	res = appendProtoBytes(res, 1, str("weaver.run_id", "run_01"))
+
+	root := appendProtoBytes(nil, 1, traceID)
+	root = appendProtoBytes(root, 2, mustHex("0000000000000001"))
+	root = appendProtoBytes(root, 5, []byte("benchmark.run"))
+	root = appendProtoFixed64(root, 7, 1700000000000000000)
+	root = appendProtoFixed64(root, 8, 1700000002000000000)
+	root = appendProtoBytes(root, 9, str("openinference.span.kind", "AGENT"))
+
+	llm := appendProtoBytes(nil, 1, traceID)
+	llm = appendProtoBytes(llm, 2, mustHex("0000000000000002"))
+	llm = appendProtoBytes(llm, 4, mustHex("0000000000000001"))
+	llm = appendProtoBytes(llm, 5, []byte("llm.stream"))
+	llm = appendProtoVarint(llm, 6, 3) // SPAN_KIND_CLIENT, ignored
+	llm = appendProtoFixed64(llm, 7, 1700000000500000000)
+	llm = appendProtoFixed64(llm, 8, 1700000002000000000)
+	llm = appendProtoBytes(llm, 9, str("openinference.span.kind", "LLM"))
+	llm = appendProtoBytes(llm, 9, protoKV("llm.token_count.prompt", appendProtoVarint(nil, 3, 42)))
+	llm = appendProtoBytes(llm, 9, protoKV("temperature", appendProtoFixed64(nil, 4, math.Float64bits(0.25))))
+	llm = appendProtoBytes(llm, 9, protoKV("stream", appendProtoVarint(nil, 2, 1)))
+	llm = appendProtoBytes(llm, 15, appendProtoVarint(nil, 3, 1))
+
+	sc := appendProtoBytes(nil, 1, appendProtoBytes(nil, 1, []byte("weaver-trace")))
+	sc = appendProtoBytes(sc, 2, root)
+	sc = appendProtoBytes(sc, 2, llm)
+
+	rs := appendProtoBytes(nil, 1, res)
+	rs = appendProtoBytes(rs, 2, sc)
+	rs = appendProtoBytes(rs, 3, []byte("https://opentelemetry.io/schemas/1.26.0"))
+	return appendProtoBytes(nil, 1, rs)
+}
+
+func protoKV(key string, value []byte) []byte {
+	kv := appendProtoBytes(nil, 1, []byte(key))
+	return appendProtoBytes(kv, 2, value)
+}
+
+func appendProtoFixed64(b []byte, field int, v uint64) []byte {
+	b = appendProtoTag(b, field, wireFixed64)
+	return binary.LittleEndian.AppendUint64(b, v)
+}
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
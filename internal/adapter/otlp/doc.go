@@ -0,0 +1,17 @@
+// Package otlp implements an adapter that runs a local OTLP/HTTP trace
+// receiver, so any OpenInference-instrumented app can be pointed at Hermes
+// with OTEL_EXPORTER_OTLP_ENDPOINT=http://127.0.0.1:4318.
+//
+// The receiver accepts ExportTraceServiceRequest payloads on /v1/traces in
+// both OTLP/JSON and binary protobuf encodings. Received spans are converted
+// to the weaver-trace JSONL shape and appended to one file per session under
+// ~/.config/hermes/otlp/, so they survive restarts and render through the
+// same span-to-message mapping as Weaver trace files.
+//
+// Spans are grouped into sessions by the OpenInference `session.id`
+// attribute (on the span or its resource), then `weaver.run_id`, then the
+// trace ID. A trace stays in the session its first keyed span chose.
+//
+// The receiver is off by default; enable it with
+// plugins.conversations.otlpReceiver.enabled in config.json.
+package otlp
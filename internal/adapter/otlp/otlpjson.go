@@ -0,0 +1,216 @@
+package otlp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/toddwbucy/hermes/internal/adapter/weaver"
+)
+
+// exportRequest mirrors opentelemetry.proto.collector.trace.v1
+// ExportTraceServiceRequest. Field names follow the OTLP/JSON mapping; the
+// protobuf decoder fills the same structs.
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// otlpSpan is an OTLP span. IDs are hex strings in OTLP/JSON; the protobuf
+// decoder hex-encodes the raw bytes to match.
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId"`
+	Name              string     `json:"name"`
+	StartTimeUnixNano flexUint64 `json:"startTimeUnixNano"`
+	EndTimeUnixNano   flexUint64 `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes"`
+	Status            status     `json:"status"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+// anyValue is the OTLP AnyValue oneof; exactly one field is set.
+type anyValue struct {
+	StringValue *string      `json:"stringValue,omitempty"`
+	BoolValue   *bool        `json:"boolValue,omitempty"`
+	IntValue    *flexInt64   `json:"intValue,omitempty"`
+	DoubleValue *float64     `json:"doubleValue,omitempty"`
+	ArrayValue  *arrayValue  `json:"arrayValue,omitempty"`
+	KvlistValue *kvlistValue `json:"kvlistValue,omitempty"`
+	BytesValue  []byte       `json:"bytesValue,omitempty"` // base64 in JSON
+}
+
+type arrayValue struct {
+	Values []anyValue `json:"values"`
+}
+
+type kvlistValue struct {
+	Values []keyValue `json:"values"`
+}
+
+// flexUint64 accepts 64-bit integers as JSON numbers or strings; OTLP/JSON
+// encodes them as strings to survive JavaScript number precision.
+type flexUint64 uint64
+
+func (f *flexUint64) UnmarshalJSON(data []byte) error {
+	v, err := strconv.ParseUint(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid uint64 %s", data)
+	}
+	*f = flexUint64(v)
+	return nil
+}
+
+// flexInt64 is the signed counterpart of flexUint64.
+type flexInt64 int64
+
+func (f *flexInt64) UnmarshalJSON(data []byte) error {
+	v, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid int64 %s", data)
+	}
+	*f = flexInt64(v)
+	return nil
+}
+
+// decodeJSONRequest parses an OTLP/JSON export request.
+func decodeJSONRequest(data []byte) (*exportRequest, error) {
+	var req exportRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// value converts an AnyValue to its plain Go form for JSON encoding.
+// Bytes are base64-encoded, matching the OTLP/JSON mapping.
+func (v anyValue) value() any {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.IntValue != nil:
+		return int64(*v.IntValue)
+	case v.DoubleValue != nil:
+		return *v.DoubleValue
+	case v.ArrayValue != nil:
+		out := make([]any, len(v.ArrayValue.Values))
+		for i, item := range v.ArrayValue.Values {
+			out[i] = item.value()
+		}
+		return out
+	case v.KvlistValue != nil:
+		out := make(map[string]any, len(v.KvlistValue.Values))
+		for _, kv := range v.KvlistValue.Values {
+			out[kv.Key] = kv.Value.value()
+		}
+		return out
+	case v.BytesValue != nil:
+		return base64.StdEncoding.EncodeToString(v.BytesValue)
+	default:
+		return nil
+	}
+}
+
+// rawAttributes converts OTLP key/value attributes into weaver's raw JSON
+// attribute map.
+func rawAttributes(kvs []keyValue) map[string]json.RawMessage {
+	out := make(map[string]json.RawMessage, len(kvs))
+	for _, kv := range kvs {
+		raw, err := json.Marshal(kv.Value.value())
+		if err != nil {
+			continue
+		}
+		out[kv.Key] = raw
+	}
+	return out
+}
+
+// toWeaverResource maps OTLP resource attributes onto weaver's Resource,
+// keeping the ones it has no field for as flattened extras.
+func toWeaverResource(r resource) weaver.Resource {
+	attrs := rawAttributes(r.Attributes)
+	var res weaver.Resource
+	take := func(key string, dst *string) {
+		if raw, ok := attrs[key]; ok {
+			_ = json.Unmarshal(raw, dst)
+			delete(attrs, key)
+		}
+	}
+	take("service.name", &res.ServiceName)
+	take("weaver.run_id", &res.RunID)
+	take("openinference.spec_version", &res.OpenInferenceVersion)
+	if len(attrs) > 0 {
+		res.AdditionalAttributes, _ = json.Marshal(attrs)
+	}
+	return res
+}
+
+// statusCodes maps OTLP StatusCode values to weaver's status strings.
+var statusCodes = map[int]string{
+	0: "UNSET",
+	1: "OK",
+	2: "ERROR",
+}
+
+// toWeaverSpans flattens an export request into weaver spans, copying the
+// resource and scope onto every span as weaver-trace does.
+func toWeaverSpans(req *exportRequest) []weaver.Span {
+	var spans []weaver.Span
+	for _, rs := range req.ResourceSpans {
+		res := toWeaverResource(rs.Resource)
+		for _, ss := range rs.ScopeSpans {
+			sc := weaver.Scope{Name: ss.Scope.Name, Version: ss.Scope.Version}
+			for _, s := range ss.Spans {
+				code, ok := statusCodes[s.Status.Code]
+				if !ok {
+					code = "UNSET"
+				}
+				spans = append(spans, weaver.Span{
+					TraceID:           strings.ToLower(s.TraceID),
+					SpanID:            strings.ToLower(s.SpanID),
+					ParentSpanID:      strings.ToLower(s.ParentSpanID),
+					Name:              s.Name,
+					StartTimeUnixNano: uint64(s.StartTimeUnixNano),
+					EndTimeUnixNano:   uint64(s.EndTimeUnixNano),
+					Attributes:        rawAttributes(s.Attributes),
+					Status:            weaver.SpanStatus{Code: code, Message: s.Status.Message},
+					Resource:          res,
+					Scope:             sc,
+				})
+			}
+		}
+	}
+	return spans
+}
@@ -0,0 +1,378 @@
+package otlp
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// This file decodes the binary protobuf encoding of ExportTraceServiceRequest
+// with a minimal wire-format reader. Only the fields the adapter maps are
+// read; everything else (events, links, dropped counts, schema URLs) is
+// skipped by wire type.
+
+// Protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("truncated protobuf message")
+
+// protoReader iterates over the fields of one protobuf message.
+type protoReader struct {
+	buf []byte
+}
+
+// next returns the next field number and wire type.
+func (r *protoReader) next() (field, wireType int, err error) {
+	key, err := r.varint()
+	if err != nil {
+		return 0, 0, err
+	}
+	field, wireType = int(key>>3), int(key&7)
+	if field == 0 {
+		return 0, 0, errors.New("invalid protobuf field number 0")
+	}
+	return field, wireType, nil
+}
+
+func (r *protoReader) done() bool { return len(r.buf) == 0 }
+
+func (r *protoReader) varint() (uint64, error) {
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		return 0, errTruncated
+	}
+	r.buf = r.buf[n:]
+	return v, nil
+}
+
+func (r *protoReader) fixed64() (uint64, error) {
+	if len(r.buf) < 8 {
+		return 0, errTruncated
+	}
+	v := binary.LittleEndian.Uint64(r.buf)
+	r.buf = r.buf[8:]
+	return v, nil
+}
+
+func (r *protoReader) bytes() ([]byte, error) {
+	n, err := r.varint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(r.buf)) {
+		return nil, errTruncated
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b, nil
+}
+
+// skip discards a field's value.
+func (r *protoReader) skip(wireType int) error {
+	switch wireType {
+	case wireVarint:
+		_, err := r.varint()
+		return err
+	case wireFixed64:
+		_, err := r.fixed64()
+		return err
+	case wireBytes:
+		_, err := r.bytes()
+		return err
+	case wireFixed32:
+		if len(r.buf) < 4 {
+			return errTruncated
+		}
+		r.buf = r.buf[4:]
+		return nil
+	default:
+		return fmt.Errorf("unsupported protobuf wire type %d", wireType)
+	}
+}
+
+// fields walks a message, calling fn for each field. fn reads the value
+// and returns handled=true, or returns false to have it skipped.
+func fields(data []byte, fn func(r *protoReader, field, wireType int) (bool, error)) error {
+	r := &protoReader{buf: data}
+	for !r.done() {
+		field, wireType, err := r.next()
+		if err != nil {
+			return err
+		}
+		handled, err := fn(r, field, wireType)
+		if err != nil {
+			return err
+		}
+		if !handled {
+			if err := r.skip(wireType); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decodeProtoRequest parses a binary ExportTraceServiceRequest.
+func decodeProtoRequest(data []byte) (*exportRequest, error) {
+	var req exportRequest
+	err := fields(data, func(r *protoReader, field, wt int) (bool, error) {
+		if field != 1 || wt != wireBytes {
+			return false, nil
+		}
+		b, err := r.bytes()
+		if err != nil {
+			return true, err
+		}
+		rs, err := decodeResourceSpans(b)
+		req.ResourceSpans = append(req.ResourceSpans, rs)
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func decodeResourceSpans(data []byte) (resourceSpans, error) {
+	var rs resourceSpans
+	err := fields(data, func(r *protoReader, field, wt int) (bool, error) {
+		if wt != wireBytes || (field != 1 && field != 2) {
+			return false, nil
+		}
+		b, err := r.bytes()
+		if err != nil {
+			return true, err
+		}
+		if field == 1 {
+			rs.Resource.Attributes, err = decodeAttributes(b, 1)
+			return true, err
+		}
+		ss, err := decodeScopeSpans(b)
+		rs.ScopeSpans = append(rs.ScopeSpans, ss)
+		return true, err
+	})
+	return rs, err
+}
+
+func decodeScopeSpans(data []byte) (scopeSpans, error) {
+	var ss scopeSpans
+	err := fields(data, func(r *protoReader, field, wt int) (bool, error) {
+		if wt != wireBytes || (field != 1 && field != 2) {
+			return false, nil
+		}
+		b, err := r.bytes()
+		if err != nil {
+			return true, err
+		}
+		if field == 1 {
+			ss.Scope, err = decodeScope(b)
+			return true, err
+		}
+		s, err := decodeSpan(b)
+		ss.Spans = append(ss.Spans, s)
+		return true, err
+	})
+	return ss, err
+}
+
+func decodeScope(data []byte) (scope, error) {
+	var sc scope
+	err := fields(data, func(r *protoReader, field, wt int) (bool, error) {
+		if wt != wireBytes || (field != 1 && field != 2) {
+			return false, nil
+		}
+		b, err := r.bytes()
+		if field == 1 {
+			sc.Name = string(b)
+		} else {
+			sc.Version = string(b)
+		}
+		return true, err
+	})
+	return sc, err
+}
+
+func decodeSpan(data []byte) (otlpSpan, error) {
+	var s otlpSpan
+	err := fields(data, func(r *protoReader, field, wt int) (bool, error) {
+		switch {
+		case wt == wireFixed64 && (field == 7 || field == 8):
+			v, err := r.fixed64()
+			if field == 7 {
+				s.StartTimeUnixNano = flexUint64(v)
+			} else {
+				s.EndTimeUnixNano = flexUint64(v)
+			}
+			return true, err
+		case wt != wireBytes:
+			return false, nil
+		}
+		b, err := r.bytes()
+		if err != nil {
+			return true, err
+		}
+		switch field {
+		case 1:
+			s.TraceID = hex.EncodeToString(b)
+		case 2:
+			s.SpanID = hex.EncodeToString(b)
+		case 4:
+			s.ParentSpanID = hex.EncodeToString(b)
+		case 5:
+			s.Name = string(b)
+		case 9:
+			kv, err := decodeKeyValue(b)
+			s.Attributes = append(s.Attributes, kv)
+			return true, err
+		case 15:
+			s.Status, err = decodeStatus(b)
+		}
+		return true, err
+	})
+	return s, err
+}
+
+func decodeStatus(data []byte) (status, error) {
+	var st status
+	err := fields(data, func(r *protoReader, field, wt int) (bool, error) {
+		switch {
+		case field == 2 && wt == wireBytes:
+			b, err := r.bytes()
+			st.Message = string(b)
+			return true, err
+		case field == 3 && wt == wireVarint:
+			v, err := r.varint()
+			st.Code = int(v)
+			return true, err
+		}
+		return false, nil
+	})
+	return st, err
+}
+
+// decodeAttributes reads the repeated KeyValue field numbered field.
+func decodeAttributes(data []byte, field int) ([]keyValue, error) {
+	var kvs []keyValue
+	err := fields(data, func(r *protoReader, f, wt int) (bool, error) {
+		if f != field || wt != wireBytes {
+			return false, nil
+		}
+		b, err := r.bytes()
+		if err != nil {
+			return true, err
+		}
+		kv, err := decodeKeyValue(b)
+		kvs = append(kvs, kv)
+		return true, err
+	})
+	return kvs, err
+}
+
+func decodeKeyValue(data []byte) (keyValue, error) {
+	var kv keyValue
+	err := fields(data, func(r *protoReader, field, wt int) (bool, error) {
+		if wt != wireBytes || (field != 1 && field != 2) {
+			return false, nil
+		}
+		b, err := r.bytes()
+		if err != nil {
+			return true, err
+		}
+		if field == 1 {
+			kv.Key = string(b)
+			return true, nil
+		}
+		kv.Value, err = decodeAnyValue(b)
+		return true, err
+	})
+	return kv, err
+}
+
+func decodeAnyValue(data []byte) (anyValue, error) {
+	var v anyValue
+	err := fields(data, func(r *protoReader, field, wt int) (bool, error) {
+		switch {
+		case field == 2 && wt == wireVarint:
+			n, err := r.varint()
+			b := n != 0
+			v.BoolValue = &b
+			return true, err
+		case field == 3 && wt == wireVarint:
+			n, err := r.varint()
+			i := flexInt64(int64(n))
+			v.IntValue = &i
+			return true, err
+		case field == 4 && wt == wireFixed64:
+			n, err := r.fixed64()
+			f := math.Float64frombits(n)
+			v.DoubleValue = &f
+			return true, err
+		case wt != wireBytes:
+			return false, nil
+		}
+		b, err := r.bytes()
+		if err != nil {
+			return true, err
+		}
+		switch field {
+		case 1:
+			s := string(b)
+			v.StringValue = &s
+		case 5:
+			var arr arrayValue
+			err = fields(b, func(r *protoReader, f, wt int) (bool, error) {
+				if f != 1 || wt != wireBytes {
+					return false, nil
+				}
+				item, err := r.bytes()
+				if err != nil {
+					return true, err
+				}
+				av, err := decodeAnyValue(item)
+				arr.Values = append(arr.Values, av)
+				return true, err
+			})
+			v.ArrayValue = &arr
+		case 6:
+			kvs, kerr := decodeAttributes(b, 1)
+			v.KvlistValue = &kvlistValue{Values: kvs}
+			err = kerr
+		case 7:
+			v.BytesValue = append([]byte{}, b...)
+		}
+		return true, err
+	})
+	return v, err
+}
+
+// appendProtoTag appends a field key.
+func appendProtoTag(b []byte, field, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(field)<<3|uint64(wireType))
+}
+
+// appendProtoBytes appends a length-delimited field.
+func appendProtoBytes(b []byte, field int, v []byte) []byte {
+	b = appendProtoTag(b, field, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// appendProtoVarint appends a varint field.
+func appendProtoVarint(b []byte, field int, v uint64) []byte {
+	b = appendProtoTag(b, field, wireVarint)
+	return binary.AppendUvarint(b, v)
+}
+
+// encodeProtoStatus encodes a google.rpc.Status, the OTLP error body.
+func encodeProtoStatus(code int, message string) []byte {
+	var b []byte
+	b = appendProtoVarint(b, 1, uint64(code))
+	return appendProtoBytes(b, 2, []byte(message))
+}
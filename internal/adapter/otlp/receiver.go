@@ -0,0 +1,174 @@
+package otlp
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter/weaver"
+)
+
+const (
+	tracesPath = "/v1/traces"
+
+	contentTypeJSON     = "application/json"
+	contentTypeProtobuf = "application/x-protobuf"
+
+	// maxRequestBytes caps a decompressed export request.
+	maxRequestBytes = 32 << 20
+)
+
+// gRPC status codes used in OTLP error responses.
+const (
+	codeInvalidArgument = 3
+	codeInternal        = 13
+)
+
+// Receiver serves the OTLP/HTTP trace endpoint and hands decoded spans to
+// its ingest function.
+type Receiver struct {
+	ingest func([]weaver.Span) error
+
+	listener net.Listener
+	server   *http.Server
+}
+
+// NewReceiver returns a receiver passing each export request's spans to
+// ingest. A failing ingest is reported to the client as a server error so
+// exporters retry.
+func NewReceiver(ingest func([]weaver.Span) error) *Receiver {
+	return &Receiver{ingest: ingest}
+}
+
+// Start listens on 127.0.0.1:port and serves in the background. Port 0
+// picks a free port; Addr reports the one bound.
+func (r *Receiver) Start(port int) error {
+	ln, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(tracesPath, r)
+	r.listener = ln
+	r.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := r.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Warn("otlp receiver stopped", "err", err)
+		}
+	}()
+	return nil
+}
+
+// Addr returns the listening address, or "" before Start.
+func (r *Receiver) Addr() string {
+	if r.listener == nil {
+		return ""
+	}
+	return r.listener.Addr().String()
+}
+
+// Close stops the server.
+func (r *Receiver) Close() error {
+	if r.server == nil {
+		return nil
+	}
+	return r.server.Close()
+}
+
+// ServeHTTP handles POST /v1/traces in OTLP/JSON or protobuf encoding,
+// optionally gzip-compressed. Responses use the request's encoding.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeStatus(w, mediaType, http.StatusMethodNotAllowed, codeInvalidArgument, "method not allowed")
+		return
+	}
+	if mediaType != contentTypeJSON && mediaType != contentTypeProtobuf {
+		writeStatus(w, contentTypeJSON, http.StatusUnsupportedMediaType, codeInvalidArgument,
+			fmt.Sprintf("unsupported content type %q", mediaType))
+		return
+	}
+
+	body, err := readBody(req)
+	if err != nil {
+		writeStatus(w, mediaType, http.StatusBadRequest, codeInvalidArgument, err.Error())
+		return
+	}
+
+	var export *exportRequest
+	if mediaType == contentTypeJSON {
+		export, err = decodeJSONRequest(body)
+	} else {
+		export, err = decodeProtoRequest(body)
+	}
+	if err != nil {
+		writeStatus(w, mediaType, http.StatusBadRequest, codeInvalidArgument, "decode: "+err.Error())
+		return
+	}
+
+	if spans := toWeaverSpans(export); len(spans) > 0 {
+		if err := r.ingest(spans); err != nil {
+			writeStatus(w, mediaType, http.StatusInternalServerError, codeInternal, err.Error())
+			return
+		}
+	}
+
+	// An empty ExportTraceServiceResponse: full success.
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(http.StatusOK)
+	if mediaType == contentTypeJSON {
+		_, _ = w.Write([]byte("{}"))
+	}
+}
+
+// readBody reads the request body, decompressing gzip and enforcing the
+// size cap on the decompressed bytes.
+func readBody(req *http.Request) ([]byte, error) {
+	var body io.Reader = req.Body
+	switch req.Header.Get("Content-Encoding") {
+	case "", "identity":
+	case "gzip":
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = gz.Close() }()
+		body = gz
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", req.Header.Get("Content-Encoding"))
+	}
+	data, err := io.ReadAll(io.LimitReader(body, maxRequestBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxRequestBytes {
+		return nil, errors.New("request too large")
+	}
+	return data, nil
+}
+
+// writeStatus writes an OTLP error response: a google.rpc.Status in the
+// request's encoding.
+func writeStatus(w http.ResponseWriter, mediaType string, httpCode, code int, message string) {
+	if mediaType == contentTypeProtobuf {
+		w.Header().Set("Content-Type", contentTypeProtobuf)
+		w.WriteHeader(httpCode)
+		_, _ = w.Write(encodeProtoStatus(code, message))
+		return
+	}
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(httpCode)
+	_ = json.NewEncoder(w).Encode(map[string]any{"code": code, "message": message})
+}
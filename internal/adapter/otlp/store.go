@@ -0,0 +1,217 @@
+package otlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/toddwbucy/hermes/internal/adapter/weaver"
+)
+
+const (
+	storeExt = ".jsonl"
+
+	// spanCacheMaxEntries bounds the number of session files whose parsed
+	// spans are kept in memory.
+	spanCacheMaxEntries = 64
+
+	// maxTrackedTraces bounds the trace -> session map. Traces finish
+	// quickly, so forgetting old ones only matters for spans that arrive
+	// long after their trace's first batch.
+	maxTrackedTraces = 4096
+
+	// maxSessionIDLen keeps session file names well under filesystem limits.
+	maxSessionIDLen = 128
+)
+
+// Store persists received spans as weaver-format JSONL, one file per
+// session, and reads them back through weaver's incremental span cache.
+type Store struct {
+	dir   string
+	spans *weaver.SpanCache
+
+	// mu serializes appends. traceSessions remembers which session each
+	// trace was assigned to, so spans of a trace that arrive in later
+	// batches without a session key follow the trace's first spans.
+	mu            sync.Mutex
+	traceSessions map[string]string
+}
+
+// NewStore returns a store writing to dir. The directory is created on the
+// first append.
+func NewStore(dir string) *Store {
+	return &Store{
+		dir:           dir,
+		spans:         weaver.NewSpanCache(spanCacheMaxEntries),
+		traceSessions: make(map[string]string),
+	}
+}
+
+// Dir returns the directory holding the session files.
+func (s *Store) Dir() string { return s.dir }
+
+// Path returns the JSONL file for a session ID.
+func (s *Store) Path(sessionID string) string {
+	return filepath.Join(s.dir, sessionID+storeExt)
+}
+
+// Append groups spans into sessions and appends them to the sessions'
+// files. It returns the number of spans written per session ID and the IDs
+// whose files were created by this call.
+func (s *Store) Append(spans []weaver.Span) (written map[string]int, created []string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, nil, err
+	}
+	if len(s.traceSessions) > maxTrackedTraces {
+		s.traceSessions = make(map[string]string)
+	}
+
+	// Keys found anywhere in the batch apply to the whole trace, so a
+	// child span exported before its keyed root lands in the same session.
+	for i := range spans {
+		if key := explicitSessionKey(&spans[i]); key != "" {
+			if _, ok := s.traceSessions[spans[i].TraceID]; !ok {
+				s.traceSessions[spans[i].TraceID] = sanitizeSessionID(key)
+			}
+		}
+	}
+
+	batches := make(map[string]*bytes.Buffer)
+	written = make(map[string]int)
+	var order []string
+	for i := range spans {
+		id := s.sessionFor(&spans[i])
+		line, err := json.Marshal(&spans[i])
+		if err != nil {
+			continue
+		}
+		buf, ok := batches[id]
+		if !ok {
+			buf = &bytes.Buffer{}
+			batches[id] = buf
+			order = append(order, id)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+		written[id]++
+	}
+
+	for _, id := range order {
+		path := s.Path(id)
+		if _, statErr := os.Stat(path); os.IsNotExist(statErr) {
+			created = append(created, id)
+		}
+		if werr := appendFile(path, batches[id].Bytes()); werr != nil {
+			err = werr
+			delete(written, id)
+		}
+	}
+	return written, created, err
+}
+
+// sessionFor resolves the session a span belongs to, recording the choice
+// for the span's trace. Callers hold s.mu.
+func (s *Store) sessionFor(span *weaver.Span) string {
+	if id, ok := s.traceSessions[span.TraceID]; ok {
+		return id
+	}
+	key := span.Resource.RunID
+	if key == "" {
+		key = span.TraceID
+	}
+	if key == "" {
+		key = "unknown"
+	}
+	id := sanitizeSessionID(key)
+	s.traceSessions[span.TraceID] = id
+	return id
+}
+
+// Load returns a session's spans. A session without a file returns nil.
+func (s *Store) Load(sessionID string) ([]weaver.Span, error) {
+	if !validSessionID(sessionID) {
+		return nil, nil
+	}
+	spans, err := s.spans.Load(s.Path(sessionID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return spans, err
+}
+
+// SessionIDs lists the sessions with a file in the store, sorted.
+func (s *Store) SessionIDs() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(s.dir, "*"+storeExt))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(matches))
+	for _, m := range matches {
+		ids = append(ids, strings.TrimSuffix(filepath.Base(m), storeExt))
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// explicitSessionKey returns the OpenInference session.id on the span or
+// its resource.
+func explicitSessionKey(span *weaver.Span) string {
+	if id := span.AttrString("session.id"); id != "" {
+		return id
+	}
+	if len(span.Resource.AdditionalAttributes) == 0 {
+		return ""
+	}
+	var extras map[string]any
+	if err := json.Unmarshal(span.Resource.AdditionalAttributes, &extras); err != nil {
+		return ""
+	}
+	id, _ := extras["session.id"].(string)
+	return id
+}
+
+// sanitizeSessionID makes a session key safe to use as a file name.
+func sanitizeSessionID(key string) string {
+	var sb strings.Builder
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('_')
+		}
+	}
+	id := strings.TrimLeft(sb.String(), ".")
+	if len(id) > maxSessionIDLen {
+		id = id[:maxSessionIDLen]
+	}
+	if id == "" {
+		return "unknown"
+	}
+	return id
+}
+
+// validSessionID reports whether id could have been produced by
+// sanitizeSessionID, which also keeps lookups inside the store directory.
+func validSessionID(id string) bool {
+	return id != "" && sanitizeSessionID(id) == id
+}
+
+func appendFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -843,47 +843,11 @@ func (a *Adapter) processMessageLine(line []byte, messages *[]adapter.Message, t
 
 	switch raw.Message.Role {
 	case "user":
-		content, _, _, contentBlocks := parseContent(raw.Message.Content)
-		sourceLabel := extractSourceLabel(content)
-		content = stripMessagePrefix(content)
-		// Also strip prefixes from text content blocks
-		for i := range contentBlocks {
-			if contentBlocks[i].Type == "text" {
-				contentBlocks[i].Text = stripMessagePrefix(contentBlocks[i].Text)
-			}
-		}
-		msg := adapter.Message{
-			ID:            raw.ID,
-			Role:          "user",
-			Content:       content,
-			Timestamp:     raw.Timestamp,
-			ContentBlocks: contentBlocks,
-			SourceLabel:   sourceLabel,
-		}
+		msg, _ := messageFromRaw(&raw)
 		*messages = append(*messages, msg)
 
 	case "assistant":
-		content, toolUses, thinkingBlocks, contentBlocks := parseContent(raw.Message.Content)
-		msg := adapter.Message{
-			ID:             raw.ID,
-			Role:           "assistant",
-			Content:        content,
-			Timestamp:      raw.Timestamp,
-			Model:          raw.Message.Model,
-			ToolUses:       toolUses,
-			ThinkingBlocks: thinkingBlocks,
-			ContentBlocks:  contentBlocks,
-		}
-
-		if raw.Message.Usage != nil {
-			msg.TokenUsage = adapter.TokenUsage{
-				InputTokens:  raw.Message.Usage.Input,
-				OutputTokens: raw.Message.Usage.Output,
-				CacheRead:    raw.Message.Usage.CacheRead,
-				CacheWrite:   raw.Message.Usage.CacheWrite,
-			}
-		}
-
+		msg, _ := messageFromRaw(&raw)
 		msgIdx := len(*messages)
 		*messages = append(*messages, msg)
 
@@ -948,6 +912,54 @@ func (a *Adapter) processMessageLine(line []byte, messages *[]adapter.Message, t
 	}
 }
 
+// messageFromRaw builds the message for a user or assistant line. Tool
+// results are linked separately since they arrive on later lines.
+func messageFromRaw(raw *RawLine) (adapter.Message, bool) {
+	switch raw.Message.Role {
+	case "user":
+		content, _, _, contentBlocks := parseContent(raw.Message.Content)
+		sourceLabel := extractSourceLabel(content)
+		content = stripMessagePrefix(content)
+		// Also strip prefixes from text content blocks
+		for i := range contentBlocks {
+			if contentBlocks[i].Type == "text" {
+				contentBlocks[i].Text = stripMessagePrefix(contentBlocks[i].Text)
+			}
+		}
+		return adapter.Message{
+			ID:            raw.ID,
+			Role:          "user",
+			Content:       content,
+			Timestamp:     raw.Timestamp,
+			ContentBlocks: contentBlocks,
+			SourceLabel:   sourceLabel,
+		}, true
+
+	case "assistant":
+		content, toolUses, thinkingBlocks, contentBlocks := parseContent(raw.Message.Content)
+		msg := adapter.Message{
+			ID:             raw.ID,
+			Role:           "assistant",
+			Content:        content,
+			Timestamp:      raw.Timestamp,
+			Model:          raw.Message.Model,
+			ToolUses:       toolUses,
+			ThinkingBlocks: thinkingBlocks,
+			ContentBlocks:  contentBlocks,
+		}
+		if raw.Message.Usage != nil {
+			msg.TokenUsage = adapter.TokenUsage{
+				InputTokens:  raw.Message.Usage.Input,
+				OutputTokens: raw.Message.Usage.Output,
+				CacheRead:    raw.Message.Usage.CacheRead,
+				CacheWrite:   raw.Message.Usage.CacheWrite,
+			}
+		}
+		return msg, true
+	}
+	return adapter.Message{}, false
+}
+
 // parseContent extracts text, tool uses, thinking blocks, and content blocks
 // from a Pi message content array.
 func parseContent(rawContent json.RawMessage) (string, []adapter.ToolUse, []adapter.ThinkingBlock, []adapter.ContentBlock) {
@@ -0,0 +1,84 @@
+package pi
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/adapter/cache"
+)
+
+// SearchMessages searches message content within a session by streaming its
+// JSONL file, so a query never holds the whole session in memory and stops
+// reading once opts.MaxResults is reached. Tool results are matched against
+// the assistant message that made the call, as in Messages.
+// Implements adapter.MessageSearcher interface.
+func (a *Adapter) SearchMessages(sessionID, query string, opts adapter.SearchOptions) ([]adapter.MessageMatch, error) {
+	stream, err := adapter.NewSearchStream(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	path := a.sessionFilePath(sessionID)
+	if path == "" {
+		return nil, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	buf := cache.GetScannerBuffer()
+	defer cache.PutScannerBuffer(buf)
+	scanner.Buffer(buf, 10*1024*1024)
+
+	// Tool call ID -> the assistant message that made the call
+	callers := make(map[string]adapter.MessageMatch)
+	msgIdx := 0
+
+	for !stream.Full() && scanner.Scan() {
+		var raw RawLine
+		if err := json.Unmarshal(scanner.Bytes(), &raw); err != nil {
+			continue
+		}
+		if raw.Type != "message" || raw.Message == nil {
+			continue
+		}
+
+		if raw.Message.Role == "toolResult" {
+			if caller, ok := callers[raw.Message.ToolCallID]; ok {
+				stream.AddContent(caller, "tool_result", extractTextContent(raw.Message.Content))
+			}
+			continue
+		}
+
+		msg, ok := messageFromRaw(&raw)
+		if !ok {
+			continue
+		}
+		stream.AddMessage(&msg, msgIdx)
+		for _, tu := range msg.ToolUses {
+			if tu.ID != "" {
+				callers[tu.ID] = adapter.MessageMatch{
+					MessageID:  msg.ID,
+					MessageIdx: msgIdx,
+					Role:       msg.Role,
+					Timestamp:  msg.Timestamp,
+					Model:      msg.Model,
+				}
+			}
+		}
+		msgIdx++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return stream.Results(), nil
+}
@@ -0,0 +1,83 @@
+package pi
+
+import (
+	"testing"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+func TestSearchMessages_InterfaceCompliance(t *testing.T) {
+	var _ adapter.MessageSearcher = New()
+}
+
+func TestSearchMessages_NonExistentSession(t *testing.T) {
+	a := newTestAdapter(t, "tool-session.jsonl")
+	results, err := a.SearchMessages("nonexistent-session-xyz", "test", adapter.DefaultSearchOptions())
+	if err != nil {
+		t.Fatalf("expected no error for nonexistent session, got %v", err)
+	}
+	if results != nil {
+		t.Errorf("expected nil results, got %v", results)
+	}
+}
+
+func TestSearchMessages_BlockTypes(t *testing.T) {
+	a := newTestAdapter(t, "tool-session.jsonl")
+	populateIndex(t, a, "/test/project")
+
+	tests := []struct {
+		query     string
+		opts      adapter.SearchOptions
+		blockType string
+		msgIdx    int
+	}{
+		{"check", adapter.SearchOptions{}, "thinking", 1},
+		{`"command":"ls"`, adapter.SearchOptions{}, "tool_use", 1},
+		// Tool results live on their own line but belong to the caller
+		{`file\d\.go$`, adapter.SearchOptions{UseRegex: true}, "tool_result", 1},
+	}
+	for _, tt := range tests {
+		results, err := a.SearchMessages("test-tool", tt.query, tt.opts)
+		if err != nil {
+			t.Fatalf("SearchMessages(%q): %v", tt.query, err)
+		}
+		if len(results) != 1 || results[0].MessageIdx != tt.msgIdx || results[0].MessageID != "m2" {
+			t.Fatalf("SearchMessages(%q) = %+v, want one match in m2", tt.query, results)
+		}
+		for _, m := range results[0].Matches {
+			if m.BlockType != tt.blockType {
+				t.Errorf("SearchMessages(%q) block = %q, want %q", tt.query, m.BlockType, tt.blockType)
+			}
+		}
+	}
+}
+
+func TestSearchMessages_CaseAndLimit(t *testing.T) {
+	a := newTestAdapter(t, "tool-session.jsonl")
+	populateIndex(t, a, "/test/project")
+
+	results, err := a.SearchMessages("test-tool", "FILE1", adapter.SearchOptions{CaseSensitive: true})
+	if err != nil {
+		t.Fatalf("SearchMessages: %v", err)
+	}
+	if results != nil {
+		t.Errorf("case-sensitive search matched: %+v", results)
+	}
+
+	// file1 appears in the tool result (m2) and the final reply (m4)
+	results, err = a.SearchMessages("test-tool", "file1", adapter.DefaultSearchOptions())
+	if err != nil {
+		t.Fatalf("SearchMessages: %v", err)
+	}
+	if len(results) != 2 || results[0].MessageID != "m2" || results[1].MessageID != "m4" {
+		t.Fatalf("results = %+v, want m2 then m4", results)
+	}
+
+	results, err = a.SearchMessages("test-tool", "file1", adapter.SearchOptions{MaxResults: 1})
+	if err != nil {
+		t.Fatalf("SearchMessages: %v", err)
+	}
+	if adapter.TotalMatches(results) != 1 {
+		t.Errorf("MaxResults 1 returned %d matches", adapter.TotalMatches(results))
+	}
+}
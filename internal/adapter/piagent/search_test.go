@@ -0,0 +1,78 @@
+package piagent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+func TestSearchMessages_InterfaceCompliance(t *testing.T) {
+	var _ adapter.MessageSearcher = New()
+}
+
+func TestSearchMessages_NonExistentSession(t *testing.T) {
+	a := New()
+	a.sessionsDir = t.TempDir()
+	results, err := a.SearchMessages("nonexistent-session-xyz", "test", adapter.DefaultSearchOptions())
+	if err != nil {
+		t.Fatalf("expected no error for nonexistent session, got %v", err)
+	}
+	if results != nil {
+		t.Errorf("expected nil results, got %v", results)
+	}
+}
+
+func TestSearchMessages_WithTestData(t *testing.T) {
+	sessionsDir := t.TempDir()
+	projectDir := filepath.Join(sessionsDir, "--test-project--")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile("testdata/session1.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	destPath := filepath.Join(projectDir, "2026-02-01T00-00-00Z_test-session-1.jsonl")
+	if err := os.WriteFile(destPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := New()
+	a.sessionsDir = sessionsDir
+	if _, err := a.Sessions("/test/project"); err != nil {
+		t.Fatalf("Sessions() error = %v", err)
+	}
+
+	tests := []struct {
+		query     string
+		opts      adapter.SearchOptions
+		blockType string
+	}{
+		{"check the files", adapter.SearchOptions{}, "thinking"},
+		{`"command":"ls"`, adapter.SearchOptions{}, "tool_use"},
+		{`^README\.md$`, adapter.SearchOptions{UseRegex: true}, "tool_result"},
+	}
+	for _, tt := range tests {
+		results, err := a.SearchMessages("test-session-1", tt.query, tt.opts)
+		if err != nil {
+			t.Fatalf("SearchMessages(%q): %v", tt.query, err)
+		}
+		// Everything above belongs to the assistant message with the tool call
+		if len(results) != 1 || results[0].MessageID != "m2" || results[0].MessageIdx != 1 {
+			t.Fatalf("SearchMessages(%q) = %+v, want one match in m2", tt.query, results)
+		}
+		if got := results[0].Matches[0].BlockType; got != tt.blockType {
+			t.Errorf("SearchMessages(%q) block = %q, want %q", tt.query, got, tt.blockType)
+		}
+	}
+
+	results, err := a.SearchMessages("test-session-1", "readme", adapter.SearchOptions{CaseSensitive: true})
+	if err != nil {
+		t.Fatalf("SearchMessages: %v", err)
+	}
+	if results != nil {
+		t.Errorf("case-sensitive search matched: %+v", results)
+	}
+}
@@ -42,6 +42,37 @@ func ModelCost(model string, usage Usage) float64 {
 	return inputCost + cacheReadCost + cacheWriteCost + outputCost
 }
 
+// Context window sizes in tokens.
+const (
+	contextClaude   = 200_000
+	contextLong     = 1_000_000 // Claude 1M-context variants, Gemini, GPT-4.1
+	contextGPT5     = 400_000
+	contextGPT4o    = 128_000
+	contextFallback = 200_000
+)
+
+// ContextWindow returns the context window size in tokens for a model ID.
+// Unknown models get the Claude default.
+func ContextWindow(model string) int {
+	lower := strings.ToLower(model)
+
+	switch {
+	case strings.Contains(lower, "[1m]") || strings.HasSuffix(lower, "-1m"):
+		return contextLong
+	case strings.Contains(lower, "claude"), strings.Contains(lower, "opus"),
+		strings.Contains(lower, "sonnet"), strings.Contains(lower, "haiku"):
+		return contextClaude
+	case strings.Contains(lower, "gemini"), strings.Contains(lower, "gpt-4.1"):
+		return contextLong
+	case strings.Contains(lower, "gpt-5"):
+		return contextGPT5
+	case strings.Contains(lower, "gpt-4o"):
+		return contextGPT4o
+	default:
+		return contextFallback
+	}
+}
+
 // classifyModel determines the pricing tier for a model ID string.
 func classifyModel(model string) modelTier {
 	lower := strings.ToLower(model)
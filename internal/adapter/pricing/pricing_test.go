@@ -200,3 +200,24 @@ func assertCost(t *testing.T, expected, actual float64) {
 		t.Errorf("expected cost %.2f, got %.4f", expected, actual)
 	}
 }
+
+func TestContextWindow(t *testing.T) {
+	tests := []struct {
+		model string
+		want  int
+	}{
+		{"claude-opus-4-6-20260101", 200_000},
+		{"claude-sonnet-4-5[1m]", 1_000_000},
+		{"claude-3-5-haiku-20241022", 200_000},
+		{"gpt-5-codex", 400_000},
+		{"gpt-4.1-mini", 1_000_000},
+		{"gemini-2.5-pro", 1_000_000},
+		{"gpt-4o", 128_000},
+		{"", 200_000},
+	}
+	for _, tt := range tests {
+		if got := ContextWindow(tt.model); got != tt.want {
+			t.Errorf("ContextWindow(%q) = %d, want %d", tt.model, got, tt.want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ package adapter
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -150,30 +151,97 @@ func deduplicateMatches(matches []ContentMatch) []ContentMatch {
 // SearchMessagesSlice is a helper that searches a slice of messages.
 // Returns MessageMatch results up to opts.MaxResults.
 func SearchMessagesSlice(messages []Message, query string, opts SearchOptions) ([]MessageMatch, error) {
-	maxResults := opts.MaxResults
-	if maxResults <= 0 {
-		maxResults = DefaultMaxResults
+	stream, err := NewSearchStream(query, opts)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range messages {
+		if stream.Full() {
+			break
+		}
+		stream.AddMessage(&messages[idx], idx)
 	}
+	return stream.Results(), nil
+}
+
+// SearchStream accumulates matches from messages fed in session order, so an
+// adapter can search while reading a session file instead of building the
+// whole message list first. Content that is written after its message, such
+// as tool results on later lines, is attributed with AddContent.
+type SearchStream struct {
+	re      *regexp.Regexp
+	max     int
+	total   int
+	results []MessageMatch
+	byIdx   map[int]int // message index -> position in results
+}
 
+// NewSearchStream compiles the query for a streaming search. A zero
+// opts.MaxResults uses DefaultMaxResults.
+func NewSearchStream(query string, opts SearchOptions) (*SearchStream, error) {
 	re, err := CompileSearchPattern(query, opts)
 	if err != nil {
 		return nil, err
 	}
+	maxResults := opts.MaxResults
+	if maxResults <= 0 {
+		maxResults = DefaultMaxResults
+	}
+	return &SearchStream{re: re, max: maxResults, byIdx: make(map[int]int)}, nil
+}
 
-	var results []MessageMatch
-	totalMatches := 0
+// AddMessage searches msg, the msgIdx-th message of the session.
+func (s *SearchStream) AddMessage(msg *Message, msgIdx int) {
+	if s.Full() {
+		return
+	}
+	if m := SearchMessage(msg, msgIdx, s.re, s.max, s.total); m != nil {
+		s.merge(*m)
+	}
+}
 
-	for idx, msg := range messages {
-		if maxResults > 0 && totalMatches >= maxResults {
-			break
-		}
+// AddContent searches content belonging to the message described by header
+// (ID, index, role, timestamp and model; header.Matches is ignored).
+func (s *SearchStream) AddContent(header MessageMatch, blockType, content string) {
+	if s.Full() {
+		return
+	}
+	matches := SearchContent(content, blockType, s.re)
+	if len(matches) == 0 {
+		return
+	}
+	if remaining := s.max - s.total; len(matches) > remaining {
+		matches = matches[:remaining]
+	}
+	header.Matches = matches
+	s.merge(header)
+}
 
-		match := SearchMessage(&msg, idx, re, maxResults, totalMatches)
-		if match != nil {
-			results = append(results, *match)
-			totalMatches += len(match.Matches)
-		}
+// Full reports whether MaxResults has been reached, so callers can stop
+// reading early.
+func (s *SearchStream) Full() bool {
+	return s.total >= s.max
+}
+
+// Results returns the matches ordered by message index.
+func (s *SearchStream) Results() []MessageMatch {
+	sort.SliceStable(s.results, func(i, j int) bool {
+		return s.results[i].MessageIdx < s.results[j].MessageIdx
+	})
+	for i := range s.results {
+		s.byIdx[s.results[i].MessageIdx] = i
 	}
+	return s.results
+}
 
-	return results, nil
+// merge adds m to the results, appending to an existing entry for the same
+// message.
+func (s *SearchStream) merge(m MessageMatch) {
+	s.total += len(m.Matches)
+	if i, ok := s.byIdx[m.MessageIdx]; ok {
+		s.results[i].Matches = append(s.results[i].Matches, m.Matches...)
+		return
+	}
+	s.byIdx[m.MessageIdx] = len(s.results)
+	s.results = append(s.results, m)
 }
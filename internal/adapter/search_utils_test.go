@@ -282,3 +282,26 @@ func TestDeduplicateMatches(t *testing.T) {
 		t.Errorf("expected 2 unique matches, got %d", len(result))
 	}
 }
+
+func TestSearchStream_AddContentMergesIntoMessage(t *testing.T) {
+	s, err := NewSearchStream("result", SearchOptions{MaxResults: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.AddMessage(&Message{ID: "m1", Content: "first result"}, 0)
+	s.AddMessage(&Message{ID: "m2", Content: "no match"}, 1)
+	s.AddMessage(&Message{ID: "m3", Content: "last result"}, 2)
+	// A tool result read after m3 still lands on m1
+	s.AddContent(MessageMatch{MessageID: "m1", MessageIdx: 0}, "tool_result", "result one\nresult two")
+
+	if !s.Full() {
+		t.Error("stream should be full after 3 matches")
+	}
+	results := s.Results()
+	if len(results) != 2 || results[0].MessageID != "m1" || results[1].MessageID != "m3" {
+		t.Fatalf("results = %+v, want m1 then m3", results)
+	}
+	if len(results[0].Matches) != 2 || results[0].Matches[1].BlockType != "tool_result" {
+		t.Errorf("m1 matches = %+v, want text and one capped tool_result", results[0].Matches)
+	}
+}
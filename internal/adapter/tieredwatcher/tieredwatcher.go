@@ -76,6 +76,21 @@ type Config struct {
 	ScanDir func(dir string) ([]SessionInfo, error)
 	// Filter optionally filters watched paths (overrides FilePattern if set)
 	Filter func(path string) bool
+	// RootDirs are additional root directories to keep watched (optional)
+	RootDirs []string
+}
+
+// Layout is an optional interface for file-based adapters whose sessions
+// are not flat "<id>.<ext>" files, e.g. one directory per session. Callers
+// use it in place of filename-based ID extraction and directory scans.
+type Layout interface {
+	// SessionIDFromPath returns the session ID a changed path belongs to,
+	// or "" when the path is not part of a session.
+	SessionIDFromPath(path string) string
+	// ScanSessionDir lists the sessions found in dir for new-session discovery.
+	ScanSessionDir(dir string) ([]SessionInfo, error)
+	// SessionRootDirs returns directories in which new sessions are created.
+	SessionRootDirs() []string
 }
 
 // New creates a new TieredWatcher.
@@ -102,15 +117,18 @@ func New(cfg Config) (*TieredWatcher, <-chan adapter.Event, error) {
 		filter:      cfg.Filter,
 	}
 
-	// Watch the root directory if provided
-	if cfg.RootDir != "" {
-		if err := watcher.Add(cfg.RootDir); err != nil {
+	// Watch the root directories if provided
+	for _, dir := range append([]string{cfg.RootDir}, cfg.RootDirs...) {
+		if dir == "" || tw.watchDirs[dir] {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
 			_ = watcher.Close()
 			return nil, nil, err
 		}
-		tw.watchDirs[cfg.RootDir] = true
-		tw.rootDirs[cfg.RootDir] = true
-		tw.knownDirs[cfg.RootDir] = true
+		tw.watchDirs[dir] = true
+		tw.rootDirs[dir] = true
+		tw.knownDirs[dir] = true
 	}
 
 	// Start background goroutines
@@ -371,6 +389,7 @@ func (tw *TieredWatcher) watchLoop() {
 					sessionID = tw.extractID(lastPath)
 				}
 				info := tw.sessions[sessionID]
+				inRoot := tw.rootDirs[filepath.Dir(lastPath)]
 
 				// Update mod time if this is a known session
 				if info != nil {
@@ -382,6 +401,17 @@ func (tw *TieredWatcher) watchLoop() {
 				}
 				tw.mu.Unlock()
 
+				// A path created in a root with no registered session may be
+				// a new session directory; scan that root so its files get
+				// watched. The scan reports the new session itself.
+				if info == nil && inRoot && capturedEvent.Op&fsnotify.Create != 0 {
+					for _, id := range tw.scanNewSessionsIn(filepath.Dir(lastPath)) {
+						if id == sessionID {
+							return
+						}
+					}
+				}
+
 				var eventType adapter.EventType
 				switch {
 				case capturedEvent.Op&fsnotify.Create != 0:
@@ -539,49 +569,59 @@ func (tw *TieredWatcher) scanForNewSessions() {
 	tw.mu.Unlock()
 
 	for _, dir := range dirs {
-		sessions, err := tw.scanDir(dir)
-		if err != nil {
+		tw.scanNewSessionsIn(dir)
+	}
+}
+
+// scanNewSessionsIn registers sessions in dir that aren't tracked yet and
+// emits a created event for each. Returns their IDs.
+func (tw *TieredWatcher) scanNewSessionsIn(dir string) []string {
+	if tw.scanDir == nil {
+		return nil
+	}
+	sessions, err := tw.scanDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var newIDs []string
+	needsRebuild := false
+	tw.mu.Lock()
+	for _, s := range sessions {
+		if tw.sessions[s.ID] != nil {
 			continue
 		}
-
-		var newIDs []string
-		needsRebuild := false
-		tw.mu.Lock()
-		for _, s := range sessions {
-			if tw.sessions[s.ID] != nil {
-				continue
-			}
-			info := &SessionInfo{
-				ID:       s.ID,
-				Path:     s.Path,
-				ModTime:  s.ModTime,
-				LastHot:  time.Now(),
-				FileSize: s.FileSize,
-			}
-			if time.Since(s.ModTime) > FrozenThreshold {
-				info.Frozen = true
-			}
-			tw.sessions[s.ID] = info
-			tw.pathIndex[s.Path] = s.ID
-			tw.knownDirs[filepath.Dir(s.Path)] = true
-			newIDs = append(newIDs, s.ID)
-			needsRebuild = true
+		info := &SessionInfo{
+			ID:       s.ID,
+			Path:     s.Path,
+			ModTime:  s.ModTime,
+			LastHot:  time.Now(),
+			FileSize: s.FileSize,
 		}
-		if needsRebuild {
-			tw.rebuildHotSetLocked()
+		if time.Since(s.ModTime) > FrozenThreshold {
+			info.Frozen = true
 		}
-		tw.mu.Unlock()
+		tw.sessions[s.ID] = info
+		tw.pathIndex[s.Path] = s.ID
+		tw.knownDirs[filepath.Dir(s.Path)] = true
+		newIDs = append(newIDs, s.ID)
+		needsRebuild = true
+	}
+	if needsRebuild {
+		tw.rebuildHotSetLocked()
+	}
+	tw.mu.Unlock()
 
-		for _, id := range newIDs {
-			select {
-			case tw.events <- adapter.Event{
-				Type:      adapter.EventSessionCreated,
-				SessionID: id,
-			}:
-			default:
-			}
+	for _, id := range newIDs {
+		select {
+		case tw.events <- adapter.Event{
+			Type:      adapter.EventSessionCreated,
+			SessionID: id,
+		}:
+		default:
 		}
 	}
+	return newIDs
 }
 
 // demotionLoop periodically demotes inactive HOT sessions to COLD.
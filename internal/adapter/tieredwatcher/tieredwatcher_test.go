@@ -4,8 +4,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
 )
 
 func TestNew(t *testing.T) {
@@ -640,3 +643,95 @@ done:
 		t.Error("expected update event for session-b after modification")
 	}
 }
+
+func TestRootDirsDiscoverSessionDirectories(t *testing.T) {
+	// One directory per session, as in Cline's tasks/<id>/ layout
+	rootA := filepath.Join(t.TempDir(), "tasks")
+	rootB := filepath.Join(t.TempDir(), "tasks")
+	for _, dir := range []string{rootA, rootB} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("MkdirAll error: %v", err)
+		}
+	}
+	idFromPath := func(path string) string {
+		for _, root := range []string{rootA, rootB} {
+			if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") && rel != "." {
+				return strings.Split(rel, string(filepath.Separator))[0]
+			}
+		}
+		return ""
+	}
+	var scanMu sync.Mutex
+	var scanned []string
+	scanDir := func(dir string) ([]SessionInfo, error) {
+		scanMu.Lock()
+		scanned = append(scanned, dir)
+		scanMu.Unlock()
+		if dir != rootA && dir != rootB {
+			return nil, nil
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		var result []SessionInfo
+		for _, e := range entries {
+			result = append(result, SessionInfo{
+				ID:      e.Name(),
+				Path:    filepath.Join(dir, e.Name(), "history.json"),
+				ModTime: time.Now(),
+			})
+		}
+		return result, nil
+	}
+
+	tw, ch, err := New(Config{
+		RootDirs:  []string{rootA, rootB},
+		ExtractID: idFromPath,
+		Filter:    func(path string) bool { return idFromPath(path) != "" },
+		ScanDir:   scanDir,
+	})
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer func() { _ = tw.Close() }()
+
+	if err := os.Mkdir(filepath.Join(rootB, "task-1"), 0755); err != nil {
+		t.Fatalf("Mkdir error: %v", err)
+	}
+
+	created := 0
+	timeout := time.After(2 * time.Second)
+	settle := time.After(500 * time.Millisecond)
+wait:
+	for {
+		select {
+		case evt := <-ch:
+			if evt.SessionID == "task-1" && evt.Type == adapter.EventSessionCreated {
+				created++
+			}
+		case <-settle:
+			if created > 0 {
+				break wait
+			}
+		case <-timeout:
+			t.Fatal("no event for new session directory")
+		}
+	}
+
+	tw.mu.Lock()
+	info := tw.sessions["task-1"]
+	tw.mu.Unlock()
+	if info == nil {
+		t.Fatal("new session directory should be registered")
+	}
+	if created != 1 {
+		t.Errorf("got %d created events for task-1, want 1", created)
+	}
+	// Only the root the directory was created in is rescanned
+	scanMu.Lock()
+	defer scanMu.Unlock()
+	if len(scanned) != 1 || scanned[0] != rootB {
+		t.Errorf("scanned %v, want only %s", scanned, rootB)
+	}
+}
@@ -0,0 +1,5 @@
+// Package toolstats aggregates tool calls from adapter messages into
+// per-session and per-project usage statistics, and maintains a shared
+// index of the files each session read or edited so other plugins can
+// find the sessions that touched a given file.
+package toolstats
@@ -0,0 +1,184 @@
+package toolstats
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+// SessionRef describes a session that touched a file.
+type SessionRef struct {
+	SessionID   string
+	Name        string
+	AdapterID   string
+	AdapterIcon string
+	UpdatedAt   time.Time
+	Op          string // OpEdit if any touch edited the file, else OpRead
+	Touches     int    // Number of tool calls that touched the file
+	MessageID   string // First message that touched the file
+}
+
+// indexKey keys a session by adapter and ID, since session IDs are only
+// unique within an adapter.
+func indexKey(adapterID, sessionID string) string {
+	return adapterID + "\x00" + sessionID
+}
+
+// indexedSession is the stored state for one session.
+type indexedSession struct {
+	updatedAt time.Time
+	stats     SessionStats
+}
+
+// Index is a thread-safe, in-memory map of sessions to their tool stats
+// and of files to the sessions that touched them.
+type Index struct {
+	mu       sync.RWMutex
+	sessions map[string]indexedSession
+	files    map[string]map[string]*SessionRef // path -> index key -> ref
+}
+
+// NewIndex creates an empty index.
+func NewIndex() *Index {
+	return &Index{
+		sessions: make(map[string]indexedSession),
+		files:    make(map[string]map[string]*SessionRef),
+	}
+}
+
+// shared is the process-wide index populated by the conversations plugin
+// and queried by the file browser and git status plugins.
+var shared = NewIndex()
+
+// Shared returns the process-wide index.
+func Shared() *Index { return shared }
+
+// Put stores stats for a session, replacing any previous entry.
+func (ix *Index) Put(session adapter.Session, stats SessionStats) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	key := indexKey(session.AdapterID, session.ID)
+	ix.removeLocked(key)
+	ix.sessions[key] = indexedSession{updatedAt: session.UpdatedAt, stats: stats}
+
+	for _, touch := range stats.Files {
+		refs := ix.files[touch.Path]
+		if refs == nil {
+			refs = make(map[string]*SessionRef)
+			ix.files[touch.Path] = refs
+		}
+		ref := refs[key]
+		if ref == nil {
+			ref = &SessionRef{
+				SessionID:   session.ID,
+				Name:        session.Name,
+				AdapterID:   session.AdapterID,
+				AdapterIcon: session.AdapterIcon,
+				UpdatedAt:   session.UpdatedAt,
+				Op:          touch.Op,
+				MessageID:   touch.MessageID,
+			}
+			refs[key] = ref
+		}
+		ref.Touches++
+		if touch.Op == OpEdit {
+			ref.Op = OpEdit
+		}
+	}
+}
+
+// removeLocked drops a session's file references. Caller holds ix.mu.
+func (ix *Index) removeLocked(key string) {
+	old, ok := ix.sessions[key]
+	if !ok {
+		return
+	}
+	for _, touch := range old.stats.Files {
+		if refs := ix.files[touch.Path]; refs != nil {
+			delete(refs, key)
+			if len(refs) == 0 {
+				delete(ix.files, touch.Path)
+			}
+		}
+	}
+	delete(ix.sessions, key)
+}
+
+// Get returns the stored stats for a session.
+func (ix *Index) Get(adapterID, sessionID string) (SessionStats, bool) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	entry, ok := ix.sessions[indexKey(adapterID, sessionID)]
+	return entry.stats, ok
+}
+
+// NeedsIndex reports whether a session is missing or older than its
+// current UpdatedAt.
+func (ix *Index) NeedsIndex(session adapter.Session) bool {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	entry, ok := ix.sessions[indexKey(session.AdapterID, session.ID)]
+	return !ok || entry.updatedAt.Before(session.UpdatedAt)
+}
+
+// Stats returns the stored stats for the given sessions, skipping any that
+// have not been indexed.
+func (ix *Index) Stats(sessions []adapter.Session) []SessionStats {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	out := make([]SessionStats, 0, len(sessions))
+	for _, s := range sessions {
+		if entry, ok := ix.sessions[indexKey(s.AdapterID, s.ID)]; ok {
+			out = append(out, entry.stats)
+		}
+	}
+	return out
+}
+
+// SessionsForFile returns the sessions that touched path, most recently
+// updated first. path should be absolute.
+func (ix *Index) SessionsForFile(path string) []SessionRef {
+	path = filepath.Clean(path)
+
+	ix.mu.RLock()
+	refs := ix.files[path]
+	out := make([]SessionRef, 0, len(refs))
+	for _, ref := range refs {
+		out = append(out, *ref)
+	}
+	ix.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].UpdatedAt.Equal(out[j].UpdatedAt) {
+			return out[i].UpdatedAt.After(out[j].UpdatedAt)
+		}
+		if out[i].SessionID != out[j].SessionID {
+			return out[i].SessionID < out[j].SessionID
+		}
+		return out[i].AdapterID < out[j].AdapterID
+	})
+	return out
+}
+
+// Reset clears the index.
+func (ix *Index) Reset() {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.sessions = make(map[string]indexedSession)
+	ix.files = make(map[string]map[string]*SessionRef)
+}
+
+// Summary returns a short description of how the session touched the file,
+// e.g. "edited 3×" or "read 1×".
+func (r SessionRef) Summary() string {
+	verb := "read"
+	if r.Op == OpEdit {
+		verb = "edited"
+	}
+	return fmt.Sprintf("%s %d×", verb, r.Touches)
+}
@@ -0,0 +1,290 @@
+package toolstats
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+// File touch operations.
+const (
+	OpRead = "read"
+	OpEdit = "edit"
+)
+
+// ToolStat holds aggregate statistics for a single tool.
+type ToolStat struct {
+	Name          string
+	Calls         int
+	Errors        int           // Calls whose tool_result had IsError set
+	Timed         int           // Calls with a measurable duration
+	TotalDuration time.Duration // Sum of measured durations
+	MaxDuration   time.Duration // Slowest measured call
+}
+
+// ErrorRate returns the fraction of calls that errored (0..1).
+func (t ToolStat) ErrorRate() float64 {
+	if t.Calls == 0 {
+		return 0
+	}
+	return float64(t.Errors) / float64(t.Calls)
+}
+
+// AvgDuration returns the mean duration of timed calls.
+func (t ToolStat) AvgDuration() time.Duration {
+	if t.Timed == 0 {
+		return 0
+	}
+	return t.TotalDuration / time.Duration(t.Timed)
+}
+
+// FileTouch records a single tool call that read or edited a file.
+type FileTouch struct {
+	Path      string // Absolute, cleaned path when resolvable
+	Op        string // OpRead or OpEdit
+	ToolName  string
+	MessageID string // Message containing the tool call (for transcript jumps)
+	Timestamp time.Time
+}
+
+// SessionStats holds tool analytics for one session.
+type SessionStats struct {
+	SessionID string
+	Tools     map[string]*ToolStat
+	Files     []FileTouch
+}
+
+// TotalCalls returns the number of tool calls across all tools.
+func (s *SessionStats) TotalCalls() int {
+	n := 0
+	for _, t := range s.Tools {
+		n += t.Calls
+	}
+	return n
+}
+
+// TotalErrors returns the number of errored tool calls across all tools.
+func (s *SessionStats) TotalErrors() int {
+	n := 0
+	for _, t := range s.Tools {
+		n += t.Errors
+	}
+	return n
+}
+
+// Sorted returns tool stats ordered by call count descending, then name.
+func (s *SessionStats) Sorted() []ToolStat {
+	return sortStats(s.Tools)
+}
+
+// pendingCall tracks a tool_use until its matching tool_result arrives.
+type pendingCall struct {
+	name    string
+	started time.Time
+}
+
+// Compute builds tool analytics for a session's messages. Relative file
+// paths in tool inputs are resolved against cwd when it is non-empty.
+//
+// Calls are collected from tool_use content blocks, falling back to
+// Message.ToolUses for adapters that don't emit blocks. Errors and
+// durations come from tool_result blocks linked by ToolUseID; durations use
+// the timestamps of the messages carrying the call and its result, so they
+// are only available when the adapter records per-message timestamps.
+func Compute(sessionID, cwd string, messages []adapter.Message) SessionStats {
+	stats := SessionStats{
+		SessionID: sessionID,
+		Tools:     make(map[string]*ToolStat),
+	}
+	pending := make(map[string]pendingCall)
+	seen := make(map[string]bool)
+
+	record := func(msg *adapter.Message, id, name, input string) {
+		if id != "" {
+			if seen[id] {
+				return
+			}
+			seen[id] = true
+		}
+		if name == "" {
+			name = "unknown"
+		}
+		stat := stats.Tools[name]
+		if stat == nil {
+			stat = &ToolStat{Name: name}
+			stats.Tools[name] = stat
+		}
+		stat.Calls++
+		if id != "" {
+			pending[id] = pendingCall{name: name, started: msg.Timestamp}
+		}
+		op := classifyOp(name)
+		if op == "" {
+			return
+		}
+		if fp := ExtractFilePath(input); fp != "" {
+			stats.Files = append(stats.Files, FileTouch{
+				Path:      resolvePath(fp, cwd),
+				Op:        op,
+				ToolName:  name,
+				MessageID: msg.ID,
+				Timestamp: msg.Timestamp,
+			})
+		}
+	}
+
+	for i := range messages {
+		msg := &messages[i]
+		if !hasToolUseBlocks(msg) {
+			for _, tu := range msg.ToolUses {
+				record(msg, tu.ID, tu.Name, tu.Input)
+			}
+		}
+		for _, block := range msg.ContentBlocks {
+			switch block.Type {
+			case "tool_use":
+				record(msg, block.ToolUseID, block.ToolName, block.ToolInput)
+			case "tool_result":
+				call, ok := pending[block.ToolUseID]
+				if !ok {
+					continue
+				}
+				delete(pending, block.ToolUseID)
+				stat := stats.Tools[call.name]
+				if block.IsError {
+					stat.Errors++
+				}
+				if call.started.IsZero() || msg.Timestamp.IsZero() {
+					continue
+				}
+				if d := msg.Timestamp.Sub(call.started); d >= 0 {
+					stat.Timed++
+					stat.TotalDuration += d
+					if d > stat.MaxDuration {
+						stat.MaxDuration = d
+					}
+				}
+			}
+		}
+	}
+
+	return stats
+}
+
+// hasToolUseBlocks reports whether msg carries tool calls as content blocks.
+func hasToolUseBlocks(msg *adapter.Message) bool {
+	for _, block := range msg.ContentBlocks {
+		if block.Type == "tool_use" {
+			return true
+		}
+	}
+	return false
+}
+
+// Aggregate merges per-session stats into project-wide tool stats, ordered
+// by call count descending.
+func Aggregate(sessions []SessionStats) []ToolStat {
+	merged := make(map[string]*ToolStat)
+	for _, s := range sessions {
+		for name, t := range s.Tools {
+			m := merged[name]
+			if m == nil {
+				m = &ToolStat{Name: name}
+				merged[name] = m
+			}
+			m.Calls += t.Calls
+			m.Errors += t.Errors
+			m.Timed += t.Timed
+			m.TotalDuration += t.TotalDuration
+			if t.MaxDuration > m.MaxDuration {
+				m.MaxDuration = t.MaxDuration
+			}
+		}
+	}
+	return sortStats(merged)
+}
+
+// Slowest returns up to n tools with timing data, ordered by average
+// duration descending.
+func Slowest(stats []ToolStat, n int) []ToolStat {
+	var timed []ToolStat
+	for _, t := range stats {
+		if t.Timed > 0 {
+			timed = append(timed, t)
+		}
+	}
+	sort.Slice(timed, func(i, j int) bool {
+		if timed[i].AvgDuration() != timed[j].AvgDuration() {
+			return timed[i].AvgDuration() > timed[j].AvgDuration()
+		}
+		return timed[i].Name < timed[j].Name
+	})
+	if n > 0 && len(timed) > n {
+		timed = timed[:n]
+	}
+	return timed
+}
+
+func sortStats(m map[string]*ToolStat) []ToolStat {
+	out := make([]ToolStat, 0, len(m))
+	for _, t := range m {
+		out = append(out, *t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Calls != out[j].Calls {
+			return out[i].Calls > out[j].Calls
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// filePathKeys are the tool input fields that carry a file path, across
+// the tool schemas used by the supported agents.
+var filePathKeys = []string{"file_path", "filePath", "path", "notebook_path", "target_file"}
+
+// ExtractFilePath returns the file path from a tool's JSON input, or "".
+func ExtractFilePath(input string) string {
+	if input == "" || input[0] != '{' {
+		return ""
+	}
+	var data map[string]any
+	if err := json.Unmarshal([]byte(input), &data); err != nil {
+		return ""
+	}
+	for _, key := range filePathKeys {
+		if fp, ok := data[key].(string); ok && fp != "" {
+			return fp
+		}
+	}
+	return ""
+}
+
+// classifyOp maps a tool name to OpEdit or OpRead. Search tools return ""
+// since their path argument is a directory scope, not a file they touched.
+func classifyOp(toolName string) string {
+	lower := strings.ToLower(toolName)
+	for _, marker := range []string{"grep", "glob", "search", "find", "list", "ls"} {
+		if lower == marker || strings.HasPrefix(lower, marker+"_") {
+			return ""
+		}
+	}
+	for _, marker := range []string{"edit", "write", "patch", "replace", "create", "delete"} {
+		if strings.Contains(lower, marker) {
+			return OpEdit
+		}
+	}
+	return OpRead
+}
+
+// resolvePath makes a tool file path absolute using the session cwd.
+func resolvePath(path, cwd string) string {
+	if !filepath.IsAbs(path) && cwd != "" {
+		path = filepath.Join(cwd, path)
+	}
+	return filepath.Clean(path)
+}
@@ -0,0 +1,155 @@
+package toolstats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+func sampleMessages(base time.Time) []adapter.Message {
+	return []adapter.Message{
+		{
+			ID:        "m1",
+			Role:      "assistant",
+			Timestamp: base,
+			ContentBlocks: []adapter.ContentBlock{
+				{Type: "tool_use", ToolUseID: "t1", ToolName: "Read", ToolInput: `{"file_path":"/repo/a.go"}`},
+				{Type: "tool_use", ToolUseID: "t2", ToolName: "Edit", ToolInput: `{"file_path":"b.go"}`},
+			},
+			// Same calls mirrored in ToolUses must not be double counted
+			ToolUses: []adapter.ToolUse{
+				{ID: "t1", Name: "Read", Input: `{"file_path":"/repo/a.go"}`},
+				{ID: "t2", Name: "Edit", Input: `{"file_path":"b.go"}`},
+			},
+		},
+		{
+			ID:        "m2",
+			Role:      "user",
+			Timestamp: base.Add(2 * time.Second),
+			ContentBlocks: []adapter.ContentBlock{
+				{Type: "tool_result", ToolUseID: "t1"},
+				{Type: "tool_result", ToolUseID: "t2", IsError: true},
+			},
+		},
+		{
+			ID:        "m3",
+			Role:      "assistant",
+			Timestamp: base.Add(3 * time.Second),
+			ToolUses: []adapter.ToolUse{
+				{ID: "t3", Name: "Grep", Input: `{"pattern":"x","path":"/repo"}`},
+			},
+		},
+	}
+}
+
+func TestCompute(t *testing.T) {
+	stats := Compute("s1", "/repo", sampleMessages(time.Unix(1000, 0)))
+
+	if got := stats.TotalCalls(); got != 3 {
+		t.Fatalf("TotalCalls = %d, want 3", got)
+	}
+	if got := stats.TotalErrors(); got != 1 {
+		t.Errorf("TotalErrors = %d, want 1", got)
+	}
+
+	edit := stats.Tools["Edit"]
+	if edit == nil || edit.ErrorRate() != 1 {
+		t.Fatalf("Edit stat = %+v, want error rate 1", edit)
+	}
+	if edit.AvgDuration() != 2*time.Second {
+		t.Errorf("Edit avg = %v, want 2s", edit.AvgDuration())
+	}
+	if grep := stats.Tools["Grep"]; grep == nil || grep.Timed != 0 {
+		t.Errorf("Grep stat = %+v, want untimed call", grep)
+	}
+
+	if len(stats.Files) != 2 {
+		t.Fatalf("Files = %+v, want 2 touches (Grep path skipped)", stats.Files)
+	}
+	if stats.Files[0].Op != OpRead || stats.Files[0].Path != "/repo/a.go" {
+		t.Errorf("Files[0] = %+v", stats.Files[0])
+	}
+	if stats.Files[1].Op != OpEdit || stats.Files[1].Path != "/repo/b.go" {
+		t.Errorf("Files[1] = %+v, want relative path resolved against cwd", stats.Files[1])
+	}
+}
+
+func TestAggregateAndSlowest(t *testing.T) {
+	a := Compute("s1", "/repo", sampleMessages(time.Unix(1000, 0)))
+	b := Compute("s2", "/repo", sampleMessages(time.Unix(2000, 0)))
+
+	merged := Aggregate([]SessionStats{a, b})
+	if len(merged) != 3 {
+		t.Fatalf("Aggregate returned %d tools, want 3", len(merged))
+	}
+	for _, m := range merged {
+		if m.Calls != 2 {
+			t.Errorf("%s calls = %d, want 2", m.Name, m.Calls)
+		}
+	}
+
+	slow := Slowest(merged, 1)
+	if len(slow) != 1 || slow[0].Name != "Edit" {
+		t.Errorf("Slowest = %+v, want Edit first (ties broken by name)", slow)
+	}
+}
+
+func TestIndexSessionsForFile(t *testing.T) {
+	ix := NewIndex()
+	base := time.Unix(1000, 0)
+	older := adapter.Session{ID: "s1", Name: "older", UpdatedAt: base}
+	newer := adapter.Session{ID: "s2", Name: "newer", UpdatedAt: base.Add(time.Hour)}
+
+	ix.Put(older, Compute(older.ID, "/repo", sampleMessages(base)))
+	ix.Put(newer, Compute(newer.ID, "/repo", sampleMessages(base)[:1]))
+
+	refs := ix.SessionsForFile("/repo/b.go")
+	if len(refs) != 2 {
+		t.Fatalf("SessionsForFile = %+v, want 2 sessions", refs)
+	}
+	if refs[0].SessionID != "s2" || refs[0].Op != OpEdit || refs[0].MessageID != "m1" {
+		t.Errorf("refs[0] = %+v, want newest session first", refs[0])
+	}
+
+	if ix.NeedsIndex(older) {
+		t.Error("NeedsIndex should be false for unchanged session")
+	}
+	older.UpdatedAt = base.Add(2 * time.Hour)
+	if !ix.NeedsIndex(older) {
+		t.Error("NeedsIndex should be true after session update")
+	}
+
+	// Re-indexing replaces old file references
+	ix.Put(older, SessionStats{SessionID: "s1"})
+	if refs := ix.SessionsForFile("/repo/b.go"); len(refs) != 1 {
+		t.Errorf("after re-index got %d refs, want 1", len(refs))
+	}
+}
+
+func TestIndexKeysByAdapter(t *testing.T) {
+	ix := NewIndex()
+	base := time.Unix(1000, 0)
+	claude := adapter.Session{ID: "s1", AdapterID: "claude-code", UpdatedAt: base}
+	codex := adapter.Session{ID: "s1", AdapterID: "codex", UpdatedAt: base}
+
+	// The same session ID from two adapters is two sessions
+	ix.Put(claude, Compute(claude.ID, "/repo", sampleMessages(base)))
+	ix.Put(codex, Compute(codex.ID, "/repo", sampleMessages(base)[:1]))
+	if refs := ix.SessionsForFile("/repo/b.go"); len(refs) != 2 {
+		t.Fatalf("SessionsForFile = %+v, want one ref per adapter", refs)
+	}
+	if got := len(ix.Stats([]adapter.Session{claude, codex})); got != 2 {
+		t.Errorf("Stats returned %d entries, want 2", got)
+	}
+
+	// Re-indexing one adapter's session leaves the other alone
+	ix.Put(codex, SessionStats{SessionID: "s1"})
+	refs := ix.SessionsForFile("/repo/b.go")
+	if len(refs) != 1 || refs[0].AdapterID != "claude-code" {
+		t.Errorf("after re-index refs = %+v, want only claude-code", refs)
+	}
+	if stats, ok := ix.Get("claude-code", "s1"); !ok || len(stats.Files) == 0 {
+		t.Errorf("Get(claude-code, s1) = %+v, %v", stats, ok)
+	}
+}
@@ -0,0 +1,53 @@
+// Package adapter provides interfaces and types for AI session data sources.
+// This file defines the optional TraceProvider interface for adapters whose
+// sessions are backed by span traces rather than a chat transcript.
+
+package adapter
+
+import "time"
+
+// TraceProvider is an optional interface for adapters that can expose the
+// raw spans behind a session. The conversations plugin uses it to render a
+// span waterfall alongside the flattened message view.
+type TraceProvider interface {
+	// TraceSpans returns every span recorded for the session, in file order.
+	// An unknown session returns nil without error.
+	TraceSpans(sessionID string) ([]TraceSpan, error)
+}
+
+// Span status codes, following OpenTelemetry.
+const (
+	SpanStatusUnset = "UNSET"
+	SpanStatusOK    = "OK"
+	SpanStatusError = "ERROR"
+)
+
+// TraceSpan is an adapter-neutral view of one span in a trace.
+type TraceSpan struct {
+	TraceID  string
+	SpanID   string
+	ParentID string // Empty for root spans
+	Name     string
+	Kind     string // OpenInference span kind ("AGENT", "LLM", "TOOL", ...)
+	Start    time.Time
+	End      time.Time
+
+	StatusCode    string // SpanStatusUnset, SpanStatusOK or SpanStatusError
+	StatusMessage string
+
+	// Attributes holds span attributes rendered as display strings.
+	Attributes map[string]string
+}
+
+// Duration returns the span's wall time, or zero for spans still open.
+func (s *TraceSpan) Duration() time.Duration {
+	if s.End.Before(s.Start) {
+		return 0
+	}
+	return s.End.Sub(s.Start)
+}
+
+// IsError reports whether the span recorded an error status.
+func (s *TraceSpan) IsError() bool {
+	return s.StatusCode == SpanStatusError
+}
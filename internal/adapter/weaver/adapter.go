@@ -5,6 +5,7 @@
 package weaver
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 	"time"
 
 	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/adapter/tieredwatcher"
 )
 
 const (
@@ -22,24 +24,35 @@ const (
 	adapterIcon = "\u25A3" // ▣ — mnemonic for "spans in a trace"
 	traceGlob   = "trace-*.jsonl"
 	logsDirName = "logs"
+
+	// spanCacheMaxEntries bounds the number of trace files whose parsed
+	// spans are kept in memory.
+	spanCacheMaxEntries = 64
 )
 
 // Adapter implements adapter.Adapter for Weaver trace files.
 //
-// Stateless by design for the first pass — Detect/Sessions/Messages each
-// rescan the logs directory. Trace files are small (one line per span,
-// order of tens of KB per benchmark run) so a cache is not yet needed.
+// Parsed spans are cached per file with the byte offset they were read up
+// to. Benchmark runs append spans while they execute, so a re-read only
+// parses the lines written since the last one.
 type Adapter struct {
 	// sessionIndex maps run_id -> trace file path. Populated by Sessions()
 	// and reused by Messages() to resolve the file without reading every
-	// trace line again.
+	// trace line again. pathIndex is the reverse mapping.
 	mu           sync.RWMutex
 	sessionIndex map[string]string
+	pathIndex    map[string]string
+
+	spanCache *SpanCache
 }
 
 // New constructs a Weaver adapter.
 func New() *Adapter {
-	return &Adapter{sessionIndex: make(map[string]string)}
+	return &Adapter{
+		sessionIndex: make(map[string]string),
+		pathIndex:    make(map[string]string),
+		spanCache:    NewSpanCache(spanCacheMaxEntries),
+	}
 }
 
 func (a *Adapter) ID() string   { return adapterID }
@@ -56,16 +69,21 @@ func (a *Adapter) Detect(projectRoot string) (bool, error) {
 	return len(paths) > 0, nil
 }
 
-// Capabilities advertises read-only access — Watch is deferred.
+// Capabilities returns the supported features.
 func (a *Adapter) Capabilities() adapter.CapabilitySet {
 	return adapter.CapabilitySet{
 		adapter.CapSessions: true,
 		adapter.CapMessages: true,
 		adapter.CapUsage:    true,
-		adapter.CapWatch:    false,
+		adapter.CapWatch:    true,
 	}
 }
 
+// WatchScope returns Project scope — traces live under each project's logs/.
+func (a *Adapter) WatchScope() adapter.WatchScope {
+	return adapter.WatchScopeProject
+}
+
 // Sessions returns one Session per trace file under `<projectRoot>/logs/`.
 // The session's ID is the trace's run_id (read from the first span's
 // resource.run_id); if no spans are parseable, the filename stem is used
@@ -83,8 +101,8 @@ func (a *Adapter) Sessions(projectRoot string) ([]adapter.Session, error) {
 		if err != nil {
 			continue
 		}
-		spans, err := readSpans(p)
-		// A scanner error after some good lines still returns those lines —
+		spans, err := a.loadSpans(p)
+		// A read error after some good lines still returns those lines —
 		// keep the partial trace rather than dropping the whole file. Only
 		// skip when nothing parsed.
 		if err != nil && len(spans) == 0 {
@@ -97,38 +115,19 @@ func (a *Adapter) Sessions(projectRoot string) ([]adapter.Session, error) {
 		// stem. Without this, the second file silently shadows the first
 		// in sessionIndex and Messages/Usage resolve the wrong path.
 		if prev, exists := newIndex[runID]; exists && prev != p {
-			runID = runID + "::" + strings.TrimSuffix(filepath.Base(p), ".jsonl")
+			runID = disambiguate(runID, p)
 		}
 		newIndex[runID] = p
-
-		// File mtime is the right fallback for spanless or malformed traces.
-		// Using time.Now() would make broken sessions look freshly active and
-		// sort to the top of the list.
-		first, last := spanTimeRange(spans, info.ModTime().UTC())
-		inputTok, outputTok := aggregateTokens(spans)
-		msgCount := countKind(spans, "LLM")
-
-		sessions = append(sessions, adapter.Session{
-			ID:              runID,
-			Name:            runID,
-			AdapterID:       adapterID,
-			AdapterName:     adapterName,
-			AdapterIcon:     adapterIcon,
-			CreatedAt:       first,
-			UpdatedAt:       last,
-			Duration:        last.Sub(first),
-			IsActive:        time.Since(last) < 5*time.Minute,
-			TotalTokens:     inputTok + outputTok,
-			MessageCount:    msgCount,
-			FileSize:        info.Size(),
-			Path:            p,
-			SessionCategory: adapter.SessionCategoryInteractive,
-			CWD:             projectRoot,
-		})
+		sessions = append(sessions, buildSession(runID, projectRoot, p, spans, info))
 	}
 
+	newPaths := make(map[string]string, len(newIndex))
+	for id, p := range newIndex {
+		newPaths[p] = id
+	}
 	a.mu.Lock()
 	a.sessionIndex = newIndex
+	a.pathIndex = newPaths
 	a.mu.Unlock()
 
 	sort.Slice(sessions, func(i, j int) bool {
@@ -145,14 +144,14 @@ func (a *Adapter) Messages(sessionID string) ([]adapter.Message, error) {
 	if path == "" {
 		return nil, nil
 	}
-	spans, err := readSpans(path)
-	// Mirror Sessions(): keep partial traces. A scanner error after some
+	spans, err := a.loadSpans(path)
+	// Mirror Sessions(): keep partial traces. A read error after some
 	// good lines still yields usable spans — surface them rather than
 	// failing the whole call.
 	if err != nil && len(spans) == 0 {
 		return nil, err
 	}
-	return buildMessages(spans), nil
+	return BuildMessages(spans), nil
 }
 
 // Usage aggregates token counts across every LLM span in the session.
@@ -161,7 +160,7 @@ func (a *Adapter) Usage(sessionID string) (*adapter.UsageStats, error) {
 	if path == "" {
 		return &adapter.UsageStats{}, nil
 	}
-	spans, err := readSpans(path)
+	spans, err := a.loadSpans(path)
 	if err != nil && len(spans) == 0 {
 		return nil, err
 	}
@@ -173,17 +172,135 @@ func (a *Adapter) Usage(sessionID string) (*adapter.UsageStats, error) {
 	}, nil
 }
 
-// Watch is not yet implemented — returns a closed channel. The fsnotify
-// wiring is straightforward but out of scope for the initial adapter.
+// SessionByID returns a single session by run ID, including traces created
+// since the last Sessions() call that were resolved via SessionIDFromPath.
+// Implements adapter.TargetedRefresher.
+func (a *Adapter) SessionByID(sessionID string) (*adapter.Session, error) {
+	path := a.sessionPath(sessionID)
+	if path == "" {
+		return nil, fmt.Errorf("trace %s not found", sessionID)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	spans, err := a.loadSpans(path)
+	if err != nil && len(spans) == 0 {
+		return nil, err
+	}
+	projectRoot := filepath.Dir(filepath.Dir(path))
+	s := buildSession(sessionID, projectRoot, path, spans, info)
+	return &s, nil
+}
+
+// TraceSpans returns the session's spans for the trace waterfall.
+// Implements adapter.TraceProvider.
+func (a *Adapter) TraceSpans(sessionID string) ([]adapter.TraceSpan, error) {
+	path := a.sessionPath(sessionID)
+	if path == "" {
+		return nil, nil
+	}
+	spans, err := a.loadSpans(path)
+	if err != nil && len(spans) == 0 {
+		return nil, err
+	}
+	out := make([]adapter.TraceSpan, len(spans))
+	for i := range spans {
+		out[i] = ToTraceSpan(&spans[i])
+	}
+	return out, nil
+}
+
+// Watch tails the project's trace files. Events carry the run ID of the
+// file that changed and the spans appended to it since the last event.
 func (a *Adapter) Watch(projectRoot string) (<-chan adapter.Event, io.Closer, error) {
-	ch := make(chan adapter.Event)
-	close(ch)
-	return ch, nopCloser{}, nil
+	return NewWatcher(a, projectRoot)
 }
 
-type nopCloser struct{}
+// SessionIDFromPath maps a trace file to its run ID, resolving and indexing
+// traces that appeared after the last Sessions() call. Returns "" for paths
+// that are not trace files. Implements tieredwatcher.Layout.
+func (a *Adapter) SessionIDFromPath(path string) string {
+	if !isTraceFile(path) {
+		return ""
+	}
+	a.mu.RLock()
+	id, ok := a.pathIndex[path]
+	a.mu.RUnlock()
+	if ok {
+		return id
+	}
 
-func (nopCloser) Close() error { return nil }
+	// A trace still being created may have no spans yet; wait for its
+	// first line so the run ID is stable.
+	spans, _ := a.loadSpans(path)
+	if len(spans) == 0 {
+		return ""
+	}
+	id = sessionIDFromSpans(spans, path)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if existing, ok := a.pathIndex[path]; ok {
+		return existing
+	}
+	if prev, exists := a.sessionIndex[id]; exists && prev != path {
+		id = disambiguate(id, path)
+	}
+	a.sessionIndex[id] = path
+	a.pathIndex[path] = id
+	return id
+}
+
+// ScanSessionDir lists the trace files in a logs directory.
+// Implements tieredwatcher.Layout.
+func (a *Adapter) ScanSessionDir(dir string) ([]tieredwatcher.SessionInfo, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, traceGlob))
+	if err != nil {
+		return nil, err
+	}
+	var result []tieredwatcher.SessionInfo
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		id := a.SessionIDFromPath(path)
+		if id == "" {
+			continue
+		}
+		result = append(result, tieredwatcher.SessionInfo{
+			ID:       id,
+			Path:     path,
+			ModTime:  info.ModTime(),
+			FileSize: info.Size(),
+		})
+	}
+	return result, nil
+}
+
+// SessionRootDirs returns the logs directories of the indexed traces so
+// new trace files are discovered. Implements tieredwatcher.Layout.
+func (a *Adapter) SessionRootDirs() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	seen := make(map[string]bool)
+	var dirs []string
+	for path := range a.pathIndex {
+		dir := filepath.Dir(path)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// loadSpans returns a trace file's spans from the incremental cache.
+func (a *Adapter) loadSpans(path string) ([]Span, error) {
+	return a.spanCache.Load(path)
+}
 
 // traceFiles lists all trace-*.jsonl files under `<projectRoot>/logs/`.
 func (a *Adapter) traceFiles(projectRoot string) ([]string, error) {
@@ -214,6 +331,46 @@ func (a *Adapter) sessionPath(sessionID string) string {
 	return ""
 }
 
+// buildSession summarizes a trace file as a Session.
+func buildSession(id, projectRoot, path string, spans []Span, info os.FileInfo) adapter.Session {
+	// File mtime is the right fallback for spanless or malformed traces.
+	// Using time.Now() would make broken sessions look freshly active and
+	// sort to the top of the list.
+	sum := Summarize(spans, info.ModTime().UTC())
+
+	return adapter.Session{
+		ID:              id,
+		Name:            id,
+		AdapterID:       adapterID,
+		AdapterName:     adapterName,
+		AdapterIcon:     adapterIcon,
+		CreatedAt:       sum.First,
+		UpdatedAt:       sum.Last,
+		Duration:        sum.Last.Sub(sum.First),
+		IsActive:        time.Since(sum.Last) < 5*time.Minute,
+		TotalTokens:     sum.InputTokens + sum.OutputTokens,
+		MessageCount:    sum.LLMCalls,
+		FileSize:        info.Size(),
+		Path:            path,
+		SessionCategory: adapter.SessionCategoryInteractive,
+		CWD:             projectRoot,
+	}
+}
+
+// isTraceFile reports whether path is a logs/trace-*.jsonl file.
+func isTraceFile(path string) bool {
+	if filepath.Base(filepath.Dir(path)) != logsDirName {
+		return false
+	}
+	ok, _ := filepath.Match(traceGlob, filepath.Base(path))
+	return ok
+}
+
+// disambiguate suffixes a duplicate run_id with the trace file's stem.
+func disambiguate(runID, path string) string {
+	return runID + "::" + strings.TrimSuffix(filepath.Base(path), ".jsonl")
+}
+
 func sessionIDFromSpans(spans []Span, path string) string {
 	for i := range spans {
 		if spans[i].Resource.RunID != "" {
@@ -225,6 +382,24 @@ func sessionIDFromSpans(spans []Span, path string) string {
 	return strings.TrimPrefix(base, "trace-")
 }
 
+// Summary holds the session-level totals of a span list.
+type Summary struct {
+	First, Last  time.Time
+	InputTokens  int
+	OutputTokens int
+	LLMCalls     int
+}
+
+// Summarize computes a trace's time range and token totals. fallback is
+// used as the time range when there are no spans.
+func Summarize(spans []Span, fallback time.Time) Summary {
+	var sum Summary
+	sum.First, sum.Last = spanTimeRange(spans, fallback)
+	sum.InputTokens, sum.OutputTokens = aggregateTokens(spans)
+	sum.LLMCalls = countKind(spans, "LLM")
+	return sum
+}
+
 func spanTimeRange(spans []Span, fallback time.Time) (time.Time, time.Time) {
 	if len(spans) == 0 {
 		return fallback, fallback
@@ -1,9 +1,10 @@
 package weaver
 
 import (
-	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -59,8 +60,8 @@ func TestAdapterMetadata(t *testing.T) {
 			t.Errorf("capability %s should be true", c)
 		}
 	}
-	if caps[adapter.CapWatch] {
-		t.Errorf("CapWatch is deferred and should be false")
+	if !caps[adapter.CapWatch] {
+		t.Errorf("CapWatch should be true")
 	}
 }
 
@@ -330,33 +331,148 @@ func TestMessagesAndUsagePreservePartialTraces(t *testing.T) {
 	}
 }
 
-func TestWatchReturnsClosedChannel(t *testing.T) {
+// spanLine renders one fixture-shaped span line for run "run_live".
+func spanLine(id, parent, kind, status string, start, end int) string {
+	parentField := ""
+	if parent != "" {
+		parentField = `"parentSpanId":"` + parent + `",`
+	}
+	return `{"traceId":"00000000000000000000000000000009","spanId":"` + id + `",` + parentField +
+		`"name":"` + strings.ToLower(kind) + `","startTimeUnixNano":` + strconv.Itoa(start) + `,"endTimeUnixNano":` + strconv.Itoa(end) +
+		`,"attributes":{"openinference.span.kind":"` + kind + `"},"status":{"code":"` + status + `"},` +
+		`"resource":{"service.name":"x","weaver.run_id":"run_live","openinference.spec_version":"1.0"},"scope":{"name":"weaver-trace","version":"0.1.0"}}` + "\n"
+}
+
+func TestLoadSpansTailsAppendedLines(t *testing.T) {
 	a := New()
-	ch, closer, err := a.Watch(t.TempDir())
+	root := t.TempDir()
+	path := filepath.Join(root, "logs", "trace-live.jsonl")
+	mustWrite(t, path, spanLine("a1", "", "AGENT", "UNSET", 1, 0))
+	if _, err := a.Sessions(root); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := a.loadSpans(path)
+	if err != nil || len(first) != 1 {
+		t.Fatalf("initial spans = %d, %v", len(first), err)
+	}
+
+	// A span half-flushed by the writer is not consumed until complete
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
 	if err != nil {
-		t.Fatalf("Watch: %v", err)
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	line := spanLine("b2", "a1", "LLM", "ERROR", 2, 3)
+	_, _ = f.WriteString(line[:40])
+	if spans, _ := a.loadSpans(path); len(spans) != 1 {
+		t.Fatalf("partial line parsed: got %d spans", len(spans))
+	}
+	_, _ = f.WriteString(line[40:])
+	spans, err := a.loadSpans(path)
+	if err != nil || len(spans) != 2 || spans[1].SpanID != "b2" {
+		t.Fatalf("after append = %+v, %v", spans, err)
+	}
+	if len(first) != 1 {
+		t.Error("earlier result was modified by the append")
+	}
+
+	trace, err := a.TraceSpans("run_live")
+	if err != nil || len(trace) != 2 {
+		t.Fatalf("TraceSpans = %d, %v", len(trace), err)
+	}
+	if trace[1].ParentID != "a1" || trace[1].Kind != "LLM" || !trace[1].IsError() {
+		t.Errorf("trace span = %+v", trace[1])
+	}
+	if !trace[0].End.IsZero() {
+		t.Errorf("open span end = %v, want zero", trace[0].End)
+	}
+}
+
+func TestSessionIDFromPathIndexesNewTraces(t *testing.T) {
+	a := New()
+	root := projectWithFixture(t)
+	if _, err := a.Sessions(root); err != nil {
+		t.Fatal(err)
+	}
+
+	newPath := filepath.Join(root, "logs", "trace-later.jsonl")
+	mustWrite(t, newPath, spanLine("a1", "", "AGENT", "OK", 1, 2))
+
+	cases := map[string]string{
+		filepath.Join(root, "logs", "trace-run_01HZTEST.jsonl"): "run_01HZTEST",
+		newPath: "run_live",
+		filepath.Join(root, "logs", "other.jsonl"):      "",
+		filepath.Join(root, "trace-outside-logs.jsonl"): "",
+	}
+	for path, want := range cases {
+		if got := a.SessionIDFromPath(path); got != want {
+			t.Errorf("SessionIDFromPath(%s) = %q, want %q", path, got, want)
+		}
+	}
+
+	s, err := a.SessionByID("run_live")
+	if err != nil || s.Path != newPath || s.CWD != root {
+		t.Fatalf("SessionByID = %+v, %v", s, err)
+	}
+	if dirs := a.SessionRootDirs(); len(dirs) != 1 || dirs[0] != filepath.Join(root, "logs") {
+		t.Errorf("SessionRootDirs = %v", dirs)
+	}
+}
+
+func TestWatchTailsAppendedSpans(t *testing.T) {
+	a := New()
+	root := t.TempDir()
+	path := filepath.Join(root, "logs", "trace-live.jsonl")
+	mustWrite(t, path, spanLine("a1", "", "AGENT", "UNSET", 1, 0))
+	if _, err := a.Sessions(root); err != nil {
+		t.Fatal(err)
 	}
-	if closer == nil {
-		t.Fatal("Watch closer should be non-nil")
+
+	events, closer, err := a.Watch(root)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
 	}
 	defer func() { _ = closer.Close() }()
 
-	// Channel should be closed (no events) — Watch is deferred.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(spanLine("b2", "a1", "LLM", "OK", 2, 3) + spanLine("c3", "a1", "TOOL", "ERROR", 3, 4))
+	_ = f.Close()
+
 	select {
-	case _, ok := <-ch:
-		if ok {
-			t.Errorf("Watch channel should be closed, got an event")
+	case evt := <-events:
+		if evt.Type != adapter.EventMessageAdded || evt.SessionID != "run_live" {
+			t.Errorf("event = %+v", evt)
 		}
-	default:
-		t.Errorf("Watch channel should be closed (recv immediately), got blocked")
+		spans, ok := evt.Data.([]adapter.TraceSpan)
+		if !ok || len(spans) != 2 || spans[0].SpanID != "b2" || !spans[1].IsError() {
+			t.Errorf("appended spans = %+v", evt.Data)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("no watch event")
 	}
+}
 
-	// Closing should not error.
-	if err := closer.Close(); err != nil {
-		t.Errorf("closer.Close: %v", err)
+func TestWatchPicksUpLogsDirCreatedLater(t *testing.T) {
+	a := New()
+	root := t.TempDir()
+	events, closer, err := a.Watch(root)
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
 	}
+	defer func() { _ = closer.Close() }()
+
+	mustWrite(t, filepath.Join(root, "logs", "trace-first.jsonl"), spanLine("a1", "", "AGENT", "OK", 1, 2))
 
-	// Quiet io.EOF reference for compilers that flag the import as unused
-	// when the file shrinks.
-	_ = io.EOF
+	select {
+	case evt := <-events:
+		if evt.Type != adapter.EventSessionCreated || evt.SessionID != "run_live" {
+			t.Errorf("event = %+v", evt)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("no watch event for a trace in a new logs directory")
+	}
 }
@@ -2,6 +2,7 @@ package weaver
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"io"
@@ -16,31 +17,50 @@ import (
 // lines are skipped rather than aborting the parse — a partial trace is more
 // useful than no trace.
 func readSpans(path string) ([]Span, error) {
+	spans, _, err := tailSpans(path, 0)
+	return spans, err
+}
+
+// tailSpans reads the spans appended to a trace file after offset and
+// returns them with the offset to resume from. Complete lines are always
+// consumed; an unterminated final line is consumed only once it parses, so
+// a span the writer is still flushing is picked up by the next read rather
+// than skipped as malformed.
+func tailSpans(path string, offset int64) ([]Span, int64, error) {
 	f, err := os.Open(path)
 	if err != nil {
-		return nil, err
+		return nil, offset, err
 	}
 	defer func() { _ = f.Close() }()
 
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	if offset > 0 {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return nil, offset, err
+		}
+	}
 
+	reader := bufio.NewReaderSize(f, 64*1024)
 	var spans []Span
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return spans, offset, err
 		}
-		var s Span
-		if err := json.Unmarshal(line, &s); err != nil {
-			continue
+		complete := err == nil
+		if len(line) > 0 {
+			var s Span
+			parsed := json.Unmarshal(bytes.TrimSpace(line), &s) == nil
+			if parsed {
+				spans = append(spans, s)
+			}
+			if complete || parsed {
+				offset += int64(len(line))
+			}
+		}
+		if !complete {
+			return spans, offset, nil
 		}
-		spans = append(spans, s)
-	}
-	if err := scanner.Err(); err != nil && !errors.Is(err, io.EOF) {
-		return spans, err
 	}
-	return spans, nil
 }
 
 // unixNanoToTime converts a u64 nanosecond timestamp to time.Time in UTC.
@@ -48,7 +68,7 @@ func unixNanoToTime(n uint64) time.Time {
 	return time.Unix(0, int64(n)).UTC()
 }
 
-// buildMessages reshapes a flat span list into a Hermes message stream.
+// BuildMessages reshapes a flat span list into a Hermes message stream.
 //
 // Design:
 //   - Each LLM span becomes an assistant Message. Prompt/completion token
@@ -60,7 +80,7 @@ func unixNanoToTime(n uint64) time.Time {
 //     visible in the UI.
 //   - Messages are sorted by start time so the conversation reads in
 //     chronological order.
-func buildMessages(spans []Span) []adapter.Message {
+func BuildMessages(spans []Span) []adapter.Message {
 	// Index spans by span_id for parent lookup.
 	byID := make(map[string]*Span, len(spans))
 	for i := range spans {
@@ -201,3 +221,32 @@ func toolOnlyMessage(t *Span) adapter.Message {
 		}},
 	}
 }
+
+// ToTraceSpan converts a weaver span into the adapter-neutral form used by
+// the trace waterfall. Attribute values are rendered as display strings:
+// JSON strings are unquoted, everything else keeps its JSON encoding.
+func ToTraceSpan(s *Span) adapter.TraceSpan {
+	attrs := make(map[string]string, len(s.Attributes))
+	for k := range s.Attributes {
+		attrs[k] = s.AttrString(k)
+	}
+	status := s.Status.Code
+	if status == "" {
+		status = adapter.SpanStatusUnset
+	}
+	ts := adapter.TraceSpan{
+		TraceID:       s.TraceID,
+		SpanID:        s.SpanID,
+		ParentID:      s.ParentSpanID,
+		Name:          s.Name,
+		Kind:          s.SpanKind(),
+		Start:         unixNanoToTime(s.StartTimeUnixNano),
+		StatusCode:    status,
+		StatusMessage: s.Status.Message,
+		Attributes:    attrs,
+	}
+	if s.EndTimeUnixNano > 0 {
+		ts.End = unixNanoToTime(s.EndTimeUnixNano)
+	}
+	return ts
+}
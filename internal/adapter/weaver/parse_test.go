@@ -1,6 +1,7 @@
 package weaver
 
 import (
+	"encoding/json"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -33,6 +34,27 @@ func TestReadSpansFixture(t *testing.T) {
 	}
 }
 
+func TestResourceRoundTrip(t *testing.T) {
+	spans, err := readSpans(fixturePath)
+	if err != nil {
+		t.Fatalf("readSpans: %v", err)
+	}
+	data, err := json.Marshal(spans[0])
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var got Span
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got.Resource.RunID != "run_01HZTEST" || got.Resource.ServiceName != "weaver-herobench" {
+		t.Errorf("resource fields lost: %+v", got.Resource)
+	}
+	if !strings.Contains(string(got.Resource.AdditionalAttributes), `"host.name":"olympus"`) {
+		t.Errorf("extras not flattened back: %s", data)
+	}
+}
+
 func TestSpanKindAndAttrs(t *testing.T) {
 	spans, err := readSpans(fixturePath)
 	if err != nil {
@@ -91,7 +113,7 @@ func TestBuildMessagesAttachesToolToLLMParent(t *testing.T) {
 	if err != nil {
 		t.Fatalf("readSpans: %v", err)
 	}
-	msgs := buildMessages(spans)
+	msgs := BuildMessages(spans)
 
 	// 2 LLM spans + 1 orphan TOOL (parent is CHAIN, not LLM) = 3 messages.
 	if len(msgs) != 3 {
@@ -147,7 +169,7 @@ func TestBuildMessagesSortsByTimestamp(t *testing.T) {
 	if err != nil {
 		t.Fatalf("readSpans: %v", err)
 	}
-	msgs := buildMessages(spans)
+	msgs := BuildMessages(spans)
 	for i := 1; i < len(msgs); i++ {
 		if msgs[i].Timestamp.Before(msgs[i-1].Timestamp) {
 			t.Errorf("messages out of order: msg[%d].Timestamp %v < msg[%d].Timestamp %v",
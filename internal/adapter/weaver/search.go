@@ -0,0 +1,23 @@
+package weaver
+
+import (
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+// SearchMessages searches message content within a session. TOOL spans are
+// linked to their enclosing LLM span by parent ID, so a trace has to be read
+// whole before messages exist; spans come from the incremental span cache,
+// so repeated searches don't re-read the file. Tool inputs and outputs are
+// searched as tool_use and tool_result blocks.
+// Implements adapter.MessageSearcher interface.
+func (a *Adapter) SearchMessages(sessionID, query string, opts adapter.SearchOptions) ([]adapter.MessageMatch, error) {
+	path := a.sessionPath(sessionID)
+	if path == "" {
+		return nil, nil
+	}
+	spans, err := a.loadSpans(path)
+	if err != nil && len(spans) == 0 {
+		return nil, err
+	}
+	return adapter.SearchMessagesSlice(BuildMessages(spans), query, opts)
+}
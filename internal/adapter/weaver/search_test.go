@@ -0,0 +1,44 @@
+package weaver
+
+import (
+	"testing"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+func TestSearchMessages_InterfaceCompliance(t *testing.T) {
+	var _ adapter.MessageSearcher = New()
+}
+
+func TestSearchMessages_ToolSpans(t *testing.T) {
+	a := New()
+	root := projectWithFixture(t)
+	if _, err := a.Sessions(root); err != nil {
+		t.Fatalf("Sessions: %v", err)
+	}
+
+	// Tool output is attributed to the LLM span that called the tool
+	results, err := a.SearchMessages("run_01HZTEST", `"hp":\d+`, adapter.SearchOptions{UseRegex: true})
+	if err != nil {
+		t.Fatalf("SearchMessages: %v", err)
+	}
+	if len(results) != 1 || results[0].MessageIdx != 0 {
+		t.Fatalf("results = %+v, want one match in the first message", results)
+	}
+	if got := results[0].Matches[0].BlockType; got != "tool_result" {
+		t.Errorf("BlockType = %q, want tool_result", got)
+	}
+
+	results, err = a.SearchMessages("run_01HZTEST", "TASK_KEYWORDS", adapter.SearchOptions{CaseSensitive: true})
+	if err != nil {
+		t.Fatalf("SearchMessages: %v", err)
+	}
+	if results != nil {
+		t.Errorf("case-sensitive search matched: %+v", results)
+	}
+
+	results, err = a.SearchMessages("nonexistent", "hp", adapter.DefaultSearchOptions())
+	if err != nil || results != nil {
+		t.Errorf("SearchMessages(unknown) = %v, %v; want nil, nil", results, err)
+	}
+}
@@ -0,0 +1,47 @@
+package weaver
+
+import (
+	"os"
+
+	"github.com/toddwbucy/hermes/internal/adapter/cache"
+)
+
+// SpanCache caches parsed spans per JSONL file together with the byte
+// offset they were read up to. Span files are append-only while a run is
+// in progress, so a re-read only parses the lines written since the last.
+type SpanCache struct {
+	c *cache.Cache[[]Span]
+}
+
+// NewSpanCache returns a cache holding at most maxEntries files.
+func NewSpanCache(maxEntries int) *SpanCache {
+	return &SpanCache{c: cache.New[[]Span](maxEntries)}
+}
+
+// Load returns a span file's spans, parsing only the lines appended since
+// the cached read. A file that shrank or was rewritten in place is parsed
+// from the start. The returned slice must not be modified.
+func (sc *SpanCache) Load(path string) ([]Span, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, offset, size, modTime, ok := sc.c.GetWithOffset(path)
+	if ok && info.Size() == size && info.ModTime().Equal(modTime) {
+		return cached, nil
+	}
+	if !ok || info.Size() < offset || (info.Size() == size && !info.ModTime().Equal(modTime)) {
+		cached, offset = nil, 0
+	}
+
+	appended, newOffset, err := tailSpans(path, offset)
+	if err != nil && len(appended) == 0 {
+		return cached, err
+	}
+	// Cap capacity so the append never writes into a slice a previous
+	// caller still holds.
+	spans := append(cached[:len(cached):len(cached)], appended...)
+	sc.c.Set(path, spans, info.Size(), info.ModTime(), newOffset)
+	return spans, err
+}
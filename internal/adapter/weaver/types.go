@@ -65,6 +65,27 @@ func (r *Resource) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON writes the extra attributes back flattened next to the
+// named fields, so a marshalled span round-trips through UnmarshalJSON.
+func (r Resource) MarshalJSON() ([]byte, error) {
+	out := make(map[string]json.RawMessage)
+	if len(r.AdditionalAttributes) > 0 {
+		if err := json.Unmarshal(r.AdditionalAttributes, &out); err != nil {
+			return nil, err
+		}
+	}
+	for key, value := range map[string]string{
+		"service.name":               r.ServiceName,
+		"weaver.run_id":              r.RunID,
+		"openinference.spec_version": r.OpenInferenceVersion,
+	} {
+		if value != "" {
+			out[key], _ = json.Marshal(value)
+		}
+	}
+	return json.Marshal(out)
+}
+
 // Scope identifies the instrumentation library (weaver-trace + version).
 type Scope struct {
 	Name    string `json:"name"`
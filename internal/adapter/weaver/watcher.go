@@ -0,0 +1,163 @@
+package weaver
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+// NewWatcher tails the trace files under `<projectRoot>/logs/`. Each event
+// names the run that changed and carries the spans appended since the
+// previous event as []adapter.TraceSpan in Data.
+func NewWatcher(a *Adapter, projectRoot string) (<-chan adapter.Event, io.Closer, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Benchmarks create logs/ on their first run; watch the project root
+	// until it exists.
+	logsDir := filepath.Join(projectRoot, logsDirName)
+	watchingLogs := true
+	if err := watcher.Add(logsDir); err != nil {
+		watchingLogs = false
+		if err := watcher.Add(projectRoot); err != nil {
+			_ = watcher.Close()
+			return nil, nil, err
+		}
+	}
+
+	// seen tracks how many spans of each file have already been reported.
+	seen := make(map[string]int)
+	if paths, err := a.traceFiles(projectRoot); err == nil {
+		for _, p := range paths {
+			spans, _ := a.loadSpans(p)
+			seen[p] = len(spans)
+		}
+	}
+
+	events := make(chan adapter.Event, 32)
+
+	go func() {
+		var debounceTimer *time.Timer
+		debounceDelay := 150 * time.Millisecond
+		pending := make(map[string]bool)
+
+		var closed bool
+		var mu sync.Mutex
+
+		defer func() {
+			mu.Lock()
+			closed = true
+			if debounceTimer != nil {
+				debounceTimer.Stop()
+			}
+			mu.Unlock()
+			close(events)
+		}()
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if !watchingLogs && event.Name == logsDir && event.Op&fsnotify.Create != 0 {
+					if err := watcher.Add(logsDir); err == nil {
+						watchingLogs = true
+						// Traces written before the watch was added
+						if paths, err := a.traceFiles(projectRoot); err == nil {
+							mu.Lock()
+							for _, p := range paths {
+								pending[p] = true
+							}
+							mu.Unlock()
+						}
+					}
+				} else if !isTraceFile(event.Name) || event.Op&fsnotify.Remove != 0 {
+					continue
+				} else {
+					mu.Lock()
+					pending[event.Name] = true
+					mu.Unlock()
+				}
+
+				mu.Lock()
+				if debounceTimer != nil {
+					debounceTimer.Stop()
+				}
+				debounceTimer = time.AfterFunc(debounceDelay, func() {
+					mu.Lock()
+					defer mu.Unlock()
+
+					if closed {
+						return
+					}
+
+					for path := range pending {
+						delete(pending, path)
+						if evt, ok := tailEvent(a, path, seen); ok {
+							select {
+							case events <- evt:
+							default:
+								// Channel full, drop event
+							}
+						}
+					}
+				})
+				mu.Unlock()
+
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return events, watcher, nil
+}
+
+// tailEvent reads the spans appended to path since the last event and
+// builds the event announcing them. The first event for a file is a
+// session creation.
+func tailEvent(a *Adapter, path string, seen map[string]int) (adapter.Event, bool) {
+	if _, err := os.Stat(path); err != nil {
+		return adapter.Event{}, false
+	}
+	sessionID := a.SessionIDFromPath(path)
+	if sessionID == "" {
+		return adapter.Event{}, false
+	}
+	spans, _ := a.loadSpans(path)
+
+	prev, known := seen[path]
+	if prev > len(spans) {
+		prev = 0 // rewritten from scratch
+	}
+	if known && prev == len(spans) {
+		return adapter.Event{}, false
+	}
+	seen[path] = len(spans)
+
+	appended := make([]adapter.TraceSpan, 0, len(spans)-prev)
+	for i := prev; i < len(spans); i++ {
+		appended = append(appended, ToTraceSpan(&spans[i]))
+	}
+
+	eventType := adapter.EventMessageAdded
+	if !known {
+		eventType = adapter.EventSessionCreated
+	}
+	return adapter.Event{
+		Type:      eventType,
+		SessionID: sessionID,
+		Data:      appended,
+	}, true
+}
@@ -14,6 +14,12 @@ type ToastMsg = msg.ToastMsg
 // ShowToast is re-exported from msg package for backward compatibility.
 var ShowToast = msg.ShowToast
 
+// HeaderBadgeMsg is re-exported from msg package.
+type HeaderBadgeMsg = msg.HeaderBadgeMsg
+
+// RunCommandMsg is re-exported from msg package.
+type RunCommandMsg = msg.RunCommandMsg
+
 // Message types for tea.Cmd
 type (
 	// TickMsg is sent on each clock tick.
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/toddwbucy/hermes/internal/state"
 	"github.com/toddwbucy/hermes/internal/styles"
 	"github.com/toddwbucy/hermes/internal/theme"
+	"github.com/toddwbucy/hermes/internal/tty"
 	"github.com/toddwbucy/hermes/internal/version"
 )
 
@@ -203,6 +205,9 @@ type Model struct {
 	statusExpiry  time.Time
 	statusIsError bool
 
+	// Persistent header badges set by plugins, sorted by ID
+	headerBadges []HeaderBadgeMsg
+
 	// Error handling
 	lastError error
 
@@ -260,6 +265,9 @@ func New(reg *plugin.Registry, km *keymap.Registry, cfg *config.Config, currentV
 	ui.WorkDir = workDir
 	ui.ProjectRoot = projectRoot
 
+	// Picked once: sessions started on one backend aren't visible to another
+	tty.Configure(cfg.Terminal.Backend)
+
 	// Determine initial active plugin index
 	activeIdx := 0
 	if initialPluginID != "" {
@@ -393,6 +401,27 @@ func (m *Model) ShowToast(msg string, duration time.Duration) {
 	m.statusExpiry = time.Now().Add(duration)
 }
 
+// SetHeaderBadge adds, replaces or (with empty text) removes a header badge.
+func (m *Model) SetHeaderBadge(b HeaderBadgeMsg) {
+	for i := range m.headerBadges {
+		if m.headerBadges[i].ID == b.ID {
+			if b.Text == "" {
+				m.headerBadges = append(m.headerBadges[:i], m.headerBadges[i+1:]...)
+			} else {
+				m.headerBadges[i] = b
+			}
+			return
+		}
+	}
+	if b.Text == "" {
+		return
+	}
+	m.headerBadges = append(m.headerBadges, b)
+	sort.Slice(m.headerBadges, func(i, j int) bool {
+		return m.headerBadges[i].ID < m.headerBadges[j].ID
+	})
+}
+
 // ClearToast clears any expired toast message.
 func (m *Model) ClearToast() {
 	if m.statusMsg != "" && time.Now().After(m.statusExpiry) {
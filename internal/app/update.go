@@ -201,6 +201,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusIsError = msg.IsError
 		return m, nil
 
+	case HeaderBadgeMsg:
+		m.SetHeaderBadge(msg)
+		return m, nil
+
 	case RefreshMsg:
 		m.ui.MarkRefresh()
 		// Refresh active plugin
@@ -363,6 +367,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if cmd, ok := m.keymap.GetCommand(msg.CommandID); ok && cmd.Handler != nil {
 			return m, cmd.Handler()
 		}
+		// Otherwise let the active plugin run it
+		if p := m.ActivePlugin(); p != nil {
+			newPlugin, cmd := p.Update(RunCommandMsg{CommandID: msg.CommandID, Context: msg.Context})
+			plugins := m.registry.Plugins()
+			if m.activePlugin < len(plugins) {
+				plugins[m.activePlugin] = newPlugin
+			}
+			m.updateContext()
+			return m, cmd
+		}
 		return m, nil
 
 	case version.UpdateAvailableMsg:
@@ -509,11 +509,12 @@ func (m Model) renderHeader() string {
 	}
 	tabBar := strings.Join(tabs, " ")
 
-	// Clock (conditional on config)
+	// Clock (conditional on config), preceded by any plugin badges
 	clock := ""
 	if m.showClock {
 		clock = styles.BarText.Render(m.ui.Clock.Format("15:04"))
 	}
+	clock = m.renderHeaderBadges() + clock
 
 	// Calculate spacing (always use finalTitleWidth so tabs don't shift)
 	tabWidth := lipgloss.Width(tabBar)
@@ -530,6 +531,20 @@ func (m Model) renderHeader() string {
 	return styles.Header.Width(m.width).Render(header)
 }
 
+// renderHeaderBadges renders plugin badges for the right side of the header.
+func (m Model) renderHeaderBadges() string {
+	var sb strings.Builder
+	for _, b := range m.headerBadges {
+		style := styles.StatusModified
+		if b.IsError {
+			style = styles.StatusDeleted
+		}
+		sb.WriteString(style.Render(b.Text))
+		sb.WriteString(" ")
+	}
+	return sb.String()
+}
+
 // getTabBounds calculates the X position bounds for each tab in the header.
 // Used for mouse click detection on tabs.
 func (m Model) getTabBounds() []TabBounds {
@@ -564,9 +579,9 @@ func (m Model) getTabBounds() []TabBounds {
 		totalTabWidth += len(plugins) - 1
 	}
 
-	// Clock width
+	// Clock width, including header badges
 	clock := styles.BarText.Render(m.ui.Clock.Format("15:04"))
-	clockWidth := lipgloss.Width(clock)
+	clockWidth := lipgloss.Width(m.renderHeaderBadges() + clock)
 
 	// Calculate spacing
 	spacing := m.width - titleWidth - totalTabWidth - clockWidth
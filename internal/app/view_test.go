@@ -239,6 +239,37 @@ func TestRenderHeader_ShowClockConfig(t *testing.T) {
 	})
 }
 
+func TestRenderHeader_Badges(t *testing.T) {
+	m := Model{
+		ui:       &UIState{Clock: time.Now()},
+		registry: plugin.NewRegistry(nil),
+		width:    120,
+		intro:    IntroModel{Done: true},
+	}
+
+	m.SetHeaderBadge(HeaderBadgeMsg{ID: "budget", Text: "$ 85%"})
+	m.SetHeaderBadge(HeaderBadgeMsg{ID: "alpha", Text: "A"})
+	if len(m.headerBadges) != 2 || m.headerBadges[0].ID != "alpha" {
+		t.Fatalf("badges = %+v, want sorted by ID", m.headerBadges)
+	}
+	if header := m.renderHeader(); !strings.Contains(header, "$ 85%") {
+		t.Error("header should contain budget badge")
+	}
+
+	m.SetHeaderBadge(HeaderBadgeMsg{ID: "budget", Text: "$ over", IsError: true})
+	if header := m.renderHeader(); !strings.Contains(header, "$ over") || strings.Contains(header, "$ 85%") {
+		t.Error("badge should be replaced in place")
+	}
+
+	m.SetHeaderBadge(HeaderBadgeMsg{ID: "budget"})
+	if header := m.renderHeader(); strings.Contains(header, "$ over") {
+		t.Error("empty text should remove the badge")
+	}
+	if len(m.headerBadges) != 1 {
+		t.Errorf("badges = %+v, want only alpha", m.headerBadges)
+	}
+}
+
 func TestIntroActive_SetFalseAfterCompletion(t *testing.T) {
 	m := Model{
 		intro: IntroModel{
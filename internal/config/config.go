@@ -1,6 +1,9 @@
 package config
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Config is the root configuration structure.
 type Config struct {
@@ -9,6 +12,21 @@ type Config struct {
 	Keymap   KeymapConfig   `json:"keymap"`
 	UI       UIConfig       `json:"ui"`
 	Features FeaturesConfig `json:"features"`
+	Terminal TerminalConfig `json:"terminal"`
+}
+
+// Terminal backends.
+const (
+	TerminalBackendAuto = "auto" // tmux when installed, else pty
+	TerminalBackendTmux = "tmux" // Sessions in the user's tmux server
+	TerminalBackendPTY  = "pty"  // In-process PTYs, no tmux needed
+)
+
+// TerminalConfig configures where agent, shell, and inline editor sessions run.
+type TerminalConfig struct {
+	// Backend is "auto" (default), "tmux", or "pty". PTY sessions end when
+	// Hermes exits and can't be attached to outside of interactive mode.
+	Backend string `json:"backend,omitempty"`
 }
 
 // FeaturesConfig holds feature flag settings.
@@ -60,6 +78,117 @@ type ConversationsPluginConfig struct {
 	// Example: ["interactive"] hides cron/system sessions by default.
 	// Empty or omitted means show all sessions (no filter).
 	DefaultCategoryFilter []string `json:"defaultCategoryFilter,omitempty"`
+	// OTLPReceiver configures the local OTLP/HTTP span receiver.
+	OTLPReceiver OTLPReceiverConfig `json:"otlpReceiver"`
+	// Insights configures insight extraction and task deduplication.
+	Insights InsightsConfig `json:"insights"`
+	// Retention sets when sessions are flagged for archiving or deletion.
+	Retention RetentionConfig `json:"retention"`
+	// Budgets are spend and token caps checked as sessions update.
+	Budgets []BudgetConfig `json:"budgets,omitempty"`
+	// SavedFilters are named session filters, selectable from the filter
+	// menu and the command palette.
+	SavedFilters []SavedFilterConfig `json:"savedFilters,omitempty"`
+	// DefaultFilter names the saved filter applied on startup.
+	DefaultFilter string `json:"defaultFilter,omitempty"`
+}
+
+// SavedFilterConfig is a named combination of session filters. Within a
+// list, a session matches any entry; across fields, it must match all.
+type SavedFilterConfig struct {
+	Name string `json:"name"`
+	// Tags are user tags set on sessions.
+	Tags []string `json:"tags,omitempty"`
+	// Adapters are adapter IDs (e.g. "claude-code").
+	Adapters []string `json:"adapters,omitempty"`
+	// Models are model families: "opus", "sonnet" or "haiku".
+	Models []string `json:"models,omitempty"`
+	// Categories are session categories: "interactive", "cron" or "system".
+	Categories []string `json:"categories,omitempty"`
+	// Date is a date preset: "today", "yesterday", "week" or "month".
+	Date       string `json:"date,omitempty"`
+	ActiveOnly bool   `json:"activeOnly,omitempty"`
+	MinTokens  int    `json:"minTokens,omitempty"`
+	MaxTokens  int    `json:"maxTokens,omitempty"`
+}
+
+// Budget periods. Periods follow the local calendar: days start at
+// midnight, weeks on Monday and months on the 1st.
+const (
+	BudgetDaily   = "daily"
+	BudgetWeekly  = "weekly"
+	BudgetMonthly = "monthly"
+)
+
+// DefaultBudgetWarnAt is the fraction of a budget at which it warns.
+const DefaultBudgetWarnAt = 0.8
+
+// BudgetConfig caps estimated cost and/or tokens over a calendar period.
+type BudgetConfig struct {
+	// Name labels the budget in alerts. Defaults to a description of its scope.
+	Name string `json:"name,omitempty"`
+	// Period is "daily", "weekly" or "monthly".
+	Period string `json:"period"`
+	// Project limits the budget to one project root (supports ~ expansion).
+	// Empty applies it to whichever project is open.
+	Project string `json:"project,omitempty"`
+	// Adapter limits the budget to one adapter ID (e.g. "claude-code").
+	Adapter string `json:"adapter,omitempty"`
+	// MaxCost is the cap in dollars. 0 means no cost cap.
+	MaxCost float64 `json:"maxCost,omitempty"`
+	// MaxTokens is the token cap. 0 means no token cap.
+	MaxTokens int `json:"maxTokens,omitempty"`
+	// WarnAt is the fraction (0-1) of a cap that triggers a warning.
+	// 0 uses the default (0.8).
+	WarnAt float64 `json:"warnAt,omitempty"`
+}
+
+// RetentionConfig sets the limits past which the storage view flags
+// sessions as candidates for archiving or deletion. Nothing is removed
+// automatically. Zero disables a limit.
+type RetentionConfig struct {
+	// MaxAgeDays flags sessions not updated in this many days.
+	MaxAgeDays int `json:"maxAgeDays,omitempty"`
+	// MaxSizeMB flags sessions whose file is larger than this.
+	MaxSizeMB int `json:"maxSizeMB,omitempty"`
+}
+
+// InsightsConfig configures how insights are extracted from conversations
+// and turned into Persephone tasks.
+type InsightsConfig struct {
+	// DisableBuiltin turns off the built-in extractors (★ Insight blocks,
+	// key phrases, blockquote notes, section bullets, signal phrases).
+	DisableBuiltin bool `json:"disableBuiltin,omitempty"`
+	// Rules are user-defined extractors, applied after the built-in ones.
+	Rules []InsightRuleConfig `json:"rules,omitempty"`
+	// DuplicateThreshold is the similarity (0-1) at which an insight counts
+	// as a duplicate of an existing task. 0 uses the default (0.75).
+	DuplicateThreshold float64 `json:"duplicateThreshold,omitempty"`
+}
+
+// InsightRuleConfig is a user-defined insight extractor. Exactly one of
+// Pattern or Heading should be set.
+type InsightRuleConfig struct {
+	// Name identifies the rule in task descriptions and warnings.
+	Name string `json:"name"`
+	// Badge is the short label shown next to matches (e.g. "TODO").
+	// Defaults to the first three letters of Name.
+	Badge string `json:"badge,omitempty"`
+	// Pattern is a regex matched against each line. The first capture
+	// group, if any, becomes the insight text; otherwise the whole line.
+	Pattern string `json:"pattern,omitempty"`
+	// Heading is a regex matched against markdown heading text. Bullet
+	// points under a matching heading become insights.
+	Heading string `json:"heading,omitempty"`
+}
+
+// OTLPReceiverConfig configures the local OTLP/HTTP receiver that surfaces
+// OpenInference-instrumented apps as sessions.
+type OTLPReceiverConfig struct {
+	// Enabled starts the receiver. Default: false.
+	Enabled bool `json:"enabled"`
+	// Port is the loopback port to listen on. Default: 4318 (OTLP/HTTP).
+	Port int `json:"port"`
 }
 
 // WorkspacePluginConfig configures the workspace plugin.
@@ -79,6 +208,69 @@ type WorkspacePluginConfig struct {
 	InteractiveCopyKey string `json:"interactiveCopyKey,omitempty"`
 	// InteractivePasteKey is the keybinding to paste clipboard in interactive mode. Default: "alt+v".
 	InteractivePasteKey string `json:"interactivePasteKey,omitempty"`
+	// Notifications configures alerts when a background agent needs attention.
+	Notifications NotificationsConfig `json:"notifications"`
+	// MergeQueue configures how queued worktrees are verified and landed.
+	MergeQueue MergeQueueConfig `json:"mergeQueue"`
+	// Recording configures agent pane output history.
+	Recording RecordingConfig `json:"recording"`
+}
+
+// NotificationsConfig configures the alerts sent when an agent in a
+// background worktree starts waiting, finishes, or fails. Events are listed
+// in the workspace notification center either way.
+type NotificationsConfig struct {
+	// Disabled turns off terminal, desktop, and bell alerts.
+	Disabled bool `json:"disabled,omitempty"`
+	// Statuses lists the statuses that alert: "waiting", "done", "error".
+	// Empty means all three.
+	Statuses []string `json:"statuses,omitempty"`
+	// Methods lists the channels to use: "terminal" (OSC 9/777), "desktop"
+	// (notify-send, falling back to tmux display-message), "tmux", "bell".
+	// Empty means terminal, desktop, and bell.
+	Methods []string `json:"methods,omitempty"`
+	// Terminal picks the escape sequence: "osc9" or "osc777". Empty picks
+	// one from the terminal's environment.
+	Terminal string `json:"terminal,omitempty"`
+	// MinIntervalSeconds is the least time between alerts for the same
+	// worktree. 0 uses the default (30).
+	MinIntervalSeconds int `json:"minIntervalSeconds,omitempty"`
+}
+
+// Merge queue landing methods.
+const (
+	MergeQueueMethodMerge = "merge" // Merge into the base branch and push
+	MergeQueueMethodPR    = "pr"    // Push and open a pull request
+)
+
+// DefaultMergeQueueVerifyTimeoutSeconds bounds each verification run.
+const DefaultMergeQueueVerifyTimeoutSeconds = 1800
+
+// MergeQueueConfig configures the workspace merge queue, which rebases each
+// queued worktree onto its updated base, verifies it, and lands it in turn.
+type MergeQueueConfig struct {
+	// VerifyCommand runs in the worktree after the rebase, through sh -c
+	// (e.g. "make test"). Empty skips verification.
+	VerifyCommand string `json:"verifyCommand,omitempty"`
+	// VerifyTimeoutSeconds stops a verification run that takes longer.
+	// 0 uses the default (30 minutes).
+	VerifyTimeoutSeconds int `json:"verifyTimeoutSeconds,omitempty"`
+	// Method lands each item: "merge" (default) or "pr".
+	Method string `json:"method,omitempty"`
+}
+
+// RecordingConfig configures recording of agent pane output into the
+// worktree's .hermes/output directory. Agents can also be recorded one at a
+// time from the workspace list.
+type RecordingConfig struct {
+	// Enabled records every agent from the moment it starts.
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxFileMB rotates the recording once it grows past this size.
+	// 0 uses the default (10).
+	MaxFileMB int `json:"maxFileMB,omitempty"`
+	// MaxFiles is how many rotated recordings are kept per worktree.
+	// 0 uses the default (5).
+	MaxFiles int `json:"maxFiles,omitempty"`
 }
 
 // NotesPluginConfig configures the notes plugin.
@@ -108,6 +300,9 @@ type ThemeConfig struct {
 	Overrides map[string]interface{} `json:"overrides,omitempty"` // user customizations on top
 }
 
+// DefaultOTLPPort is the standard OTLP/HTTP port.
+const DefaultOTLPPort = 4318
+
 // Default returns the default configuration.
 func Default() *Config {
 	return &Config{
@@ -128,10 +323,17 @@ func Default() *Config {
 			Conversations: ConversationsPluginConfig{
 				Enabled:       true,
 				ClaudeDataDir: "~/.claude",
+				OTLPReceiver: OTLPReceiverConfig{
+					Port: DefaultOTLPPort,
+				},
 			},
 			Workspace: WorkspacePluginConfig{
 				DirPrefix:           true,
 				TmuxCaptureMaxBytes: 2 * 1024 * 1024,
+				MergeQueue: MergeQueueConfig{
+					Method:               MergeQueueMethodMerge,
+					VerifyTimeoutSeconds: DefaultMergeQueueVerifyTimeoutSeconds,
+				},
 			},
 		},
 		Keymap: KeymapConfig{
@@ -147,6 +349,9 @@ func Default() *Config {
 		Features: FeaturesConfig{
 			Flags: make(map[string]bool),
 		},
+		Terminal: TerminalConfig{
+			Backend: TerminalBackendAuto,
+		},
 	}
 }
 
@@ -158,8 +363,72 @@ func (c *Config) Validate() error {
 	if c.Plugins.TDMonitor.RefreshInterval < 0 {
 		c.Plugins.TDMonitor.RefreshInterval = 2 * time.Second
 	}
+	if p := c.Plugins.Conversations.OTLPReceiver.Port; p <= 0 || p > 65535 {
+		c.Plugins.Conversations.OTLPReceiver.Port = DefaultOTLPPort
+	}
+	if t := c.Plugins.Conversations.Insights.DuplicateThreshold; t < 0 || t > 1 {
+		c.Plugins.Conversations.Insights.DuplicateThreshold = 0
+	}
+	if c.Plugins.Conversations.Retention.MaxAgeDays < 0 {
+		c.Plugins.Conversations.Retention.MaxAgeDays = 0
+	}
+	if c.Plugins.Conversations.Retention.MaxSizeMB < 0 {
+		c.Plugins.Conversations.Retention.MaxSizeMB = 0
+	}
+	n := &c.Plugins.Workspace.Notifications
+	for i := range n.Statuses {
+		n.Statuses[i] = strings.ToLower(strings.TrimSpace(n.Statuses[i]))
+	}
+	for i := range n.Methods {
+		n.Methods[i] = strings.ToLower(strings.TrimSpace(n.Methods[i]))
+	}
+	n.Terminal = strings.ToLower(strings.TrimSpace(n.Terminal))
+	if n.Terminal != "osc9" && n.Terminal != "osc777" {
+		n.Terminal = ""
+	}
+	if n.MinIntervalSeconds < 0 {
+		n.MinIntervalSeconds = 0
+	}
+	mq := &c.Plugins.Workspace.MergeQueue
+	mq.VerifyCommand = strings.TrimSpace(mq.VerifyCommand)
+	mq.Method = strings.ToLower(strings.TrimSpace(mq.Method))
+	if mq.Method != MergeQueueMethodPR {
+		mq.Method = MergeQueueMethodMerge
+	}
+	if mq.VerifyTimeoutSeconds <= 0 {
+		mq.VerifyTimeoutSeconds = DefaultMergeQueueVerifyTimeoutSeconds
+	}
+	rec := &c.Plugins.Workspace.Recording
+	if rec.MaxFileMB < 0 {
+		rec.MaxFileMB = 0
+	}
+	if rec.MaxFiles < 0 {
+		rec.MaxFiles = 0
+	}
+	for i := range c.Plugins.Conversations.Budgets {
+		b := &c.Plugins.Conversations.Budgets[i]
+		b.Period = strings.ToLower(strings.TrimSpace(b.Period))
+		if b.WarnAt <= 0 || b.WarnAt > 1 {
+			b.WarnAt = DefaultBudgetWarnAt
+		}
+	}
+	saved := c.Plugins.Conversations.SavedFilters[:0]
+	for _, f := range c.Plugins.Conversations.SavedFilters {
+		f.Name = strings.TrimSpace(f.Name)
+		f.Date = strings.ToLower(strings.TrimSpace(f.Date))
+		if f.Name != "" {
+			saved = append(saved, f)
+		}
+	}
+	c.Plugins.Conversations.SavedFilters = saved
+	c.Plugins.Conversations.DefaultFilter = strings.TrimSpace(c.Plugins.Conversations.DefaultFilter)
 	if c.Plugins.Workspace.TmuxCaptureMaxBytes <= 0 {
 		c.Plugins.Workspace.TmuxCaptureMaxBytes = 2 * 1024 * 1024
 	}
+	t := &c.Terminal
+	t.Backend = strings.ToLower(strings.TrimSpace(t.Backend))
+	if t.Backend != TerminalBackendTmux && t.Backend != TerminalBackendPTY {
+		t.Backend = TerminalBackendAuto
+	}
 	return nil
 }
@@ -35,6 +35,7 @@ type rawConfig struct {
 	Keymap   KeymapConfig      `json:"keymap"`
 	UI       rawUIConfig       `json:"ui"`
 	Features FeaturesConfig    `json:"features"`
+	Terminal TerminalConfig    `json:"terminal"`
 }
 
 type rawUIConfig struct {
@@ -63,12 +64,15 @@ type rawPluginsConfig struct {
 }
 
 type rawWorkspaceConfig struct {
-	DirPrefix            *bool  `json:"dirPrefix"`
-	TmuxCaptureMaxBytes  *int   `json:"tmuxCaptureMaxBytes"`
-	InteractiveExitKey   string `json:"interactiveExitKey"`
-	InteractiveAttachKey string `json:"interactiveAttachKey"`
-	InteractiveCopyKey   string `json:"interactiveCopyKey"`
-	InteractivePasteKey  string `json:"interactivePasteKey"`
+	DirPrefix            *bool                `json:"dirPrefix"`
+	TmuxCaptureMaxBytes  *int                 `json:"tmuxCaptureMaxBytes"`
+	InteractiveExitKey   string               `json:"interactiveExitKey"`
+	InteractiveAttachKey string               `json:"interactiveAttachKey"`
+	InteractiveCopyKey   string               `json:"interactiveCopyKey"`
+	InteractivePasteKey  string               `json:"interactivePasteKey"`
+	Notifications        *NotificationsConfig `json:"notifications"`
+	MergeQueue           *MergeQueueConfig    `json:"mergeQueue"`
+	Recording            *RecordingConfig     `json:"recording"`
 }
 
 type rawGitStatusConfig struct {
@@ -83,8 +87,19 @@ type rawTDMonitorConfig struct {
 }
 
 type rawConversationsConfig struct {
-	Enabled       *bool  `json:"enabled"`
-	ClaudeDataDir string `json:"claudeDataDir"`
+	Enabled       *bool                 `json:"enabled"`
+	ClaudeDataDir string                `json:"claudeDataDir"`
+	OTLPReceiver  rawOTLPReceiverConfig `json:"otlpReceiver"`
+	Insights      *InsightsConfig       `json:"insights"`
+	Retention     *RetentionConfig      `json:"retention"`
+	Budgets       []BudgetConfig        `json:"budgets"`
+	SavedFilters  []SavedFilterConfig   `json:"savedFilters"`
+	DefaultFilter *string               `json:"defaultFilter"`
+}
+
+type rawOTLPReceiverConfig struct {
+	Enabled *bool `json:"enabled"`
+	Port    *int  `json:"port"`
 }
 
 // Load loads configuration from the default location.
@@ -122,6 +137,11 @@ func LoadFrom(path string) (*Config, error) {
 
 	// Expand paths
 	cfg.Plugins.Conversations.ClaudeDataDir = ExpandPath(cfg.Plugins.Conversations.ClaudeDataDir)
+	for i := range cfg.Plugins.Conversations.Budgets {
+		if p := cfg.Plugins.Conversations.Budgets[i].Project; p != "" {
+			cfg.Plugins.Conversations.Budgets[i].Project = ExpandPath(p)
+		}
+	}
 
 	// Expand paths in project list and warn if path doesn't exist
 	for i := range cfg.Projects.List {
@@ -185,6 +205,27 @@ func mergeConfig(cfg *Config, raw *rawConfig) {
 	if raw.Plugins.Conversations.ClaudeDataDir != "" {
 		cfg.Plugins.Conversations.ClaudeDataDir = raw.Plugins.Conversations.ClaudeDataDir
 	}
+	if raw.Plugins.Conversations.OTLPReceiver.Enabled != nil {
+		cfg.Plugins.Conversations.OTLPReceiver.Enabled = *raw.Plugins.Conversations.OTLPReceiver.Enabled
+	}
+	if raw.Plugins.Conversations.OTLPReceiver.Port != nil {
+		cfg.Plugins.Conversations.OTLPReceiver.Port = *raw.Plugins.Conversations.OTLPReceiver.Port
+	}
+	if raw.Plugins.Conversations.Insights != nil {
+		cfg.Plugins.Conversations.Insights = *raw.Plugins.Conversations.Insights
+	}
+	if raw.Plugins.Conversations.Retention != nil {
+		cfg.Plugins.Conversations.Retention = *raw.Plugins.Conversations.Retention
+	}
+	if raw.Plugins.Conversations.Budgets != nil {
+		cfg.Plugins.Conversations.Budgets = raw.Plugins.Conversations.Budgets
+	}
+	if raw.Plugins.Conversations.SavedFilters != nil {
+		cfg.Plugins.Conversations.SavedFilters = raw.Plugins.Conversations.SavedFilters
+	}
+	if raw.Plugins.Conversations.DefaultFilter != nil {
+		cfg.Plugins.Conversations.DefaultFilter = *raw.Plugins.Conversations.DefaultFilter
+	}
 
 	// Workspace
 	if raw.Plugins.Workspace.DirPrefix != nil {
@@ -205,6 +246,15 @@ func mergeConfig(cfg *Config, raw *rawConfig) {
 	if raw.Plugins.Workspace.InteractivePasteKey != "" {
 		cfg.Plugins.Workspace.InteractivePasteKey = raw.Plugins.Workspace.InteractivePasteKey
 	}
+	if raw.Plugins.Workspace.Notifications != nil {
+		cfg.Plugins.Workspace.Notifications = *raw.Plugins.Workspace.Notifications
+	}
+	if raw.Plugins.Workspace.MergeQueue != nil {
+		cfg.Plugins.Workspace.MergeQueue = *raw.Plugins.Workspace.MergeQueue
+	}
+	if raw.Plugins.Workspace.Recording != nil {
+		cfg.Plugins.Workspace.Recording = *raw.Plugins.Workspace.Recording
+	}
 
 	// Keymap
 	if raw.Keymap.Overrides != nil {
@@ -248,6 +298,11 @@ func mergeConfig(cfg *Config, raw *rawConfig) {
 			cfg.Features.Flags[k] = v
 		}
 	}
+
+	// Terminal
+	if raw.Terminal.Backend != "" {
+		cfg.Terminal.Backend = raw.Terminal.Backend
+	}
 }
 
 // ExpandPath expands ~ to home directory.
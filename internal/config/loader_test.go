@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -65,6 +66,269 @@ func TestLoadFrom_ValidJSON(t *testing.T) {
 	}
 }
 
+func TestLoadFrom_OTLPReceiver(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	content := []byte(`{
+		"plugins": {
+			"conversations": {
+				"otlpReceiver": {"enabled": true, "port": 99999}
+			}
+		}
+	}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	rc := cfg.Plugins.Conversations.OTLPReceiver
+	if !rc.Enabled {
+		t.Error("otlp receiver should be enabled")
+	}
+	// Out-of-range ports fall back to the OTLP/HTTP default
+	if rc.Port != DefaultOTLPPort {
+		t.Errorf("got port %d, want %d", rc.Port, DefaultOTLPPort)
+	}
+	if !cfg.Plugins.Conversations.Enabled {
+		t.Error("conversations should still be enabled (default)")
+	}
+}
+
+func TestLoadFrom_InsightRules(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	content := []byte(`{
+		"plugins": {
+			"conversations": {
+				"insights": {
+					"disableBuiltin": true,
+					"duplicateThreshold": 1.5,
+					"rules": [
+						{"name": "todo", "badge": "TODO", "pattern": "TODO:\\s*(.+)"},
+						{"name": "risks", "heading": "(?i)^risks$"}
+					]
+				}
+			}
+		}
+	}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	ins := cfg.Plugins.Conversations.Insights
+	if !ins.DisableBuiltin || len(ins.Rules) != 2 {
+		t.Fatalf("insights = %+v", ins)
+	}
+	if ins.Rules[0].Pattern != `TODO:\s*(.+)` || ins.Rules[1].Heading != "(?i)^risks$" {
+		t.Errorf("rules = %+v", ins.Rules)
+	}
+	// Out-of-range thresholds fall back to the default
+	if ins.DuplicateThreshold != 0 {
+		t.Errorf("threshold = %v, want 0 (default)", ins.DuplicateThreshold)
+	}
+}
+
+func TestLoadFrom_Retention(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	content := []byte(`{"plugins": {"conversations": {"retention": {"maxAgeDays": 90, "maxSizeMB": -5}}}}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	r := cfg.Plugins.Conversations.Retention
+	// Negative limits are treated as unset
+	if r.MaxAgeDays != 90 || r.MaxSizeMB != 0 {
+		t.Errorf("retention = %+v, want 90 days and no size limit", r)
+	}
+}
+
+func TestLoadFrom_Notifications(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	content := []byte(`{"plugins": {"workspace": {"notifications": {
+		"statuses": [" Waiting", "ERROR"], "methods": ["Bell"], "terminal": "osc99", "minIntervalSeconds": -1
+	}}}}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	n := cfg.Plugins.Workspace.Notifications
+	if len(n.Statuses) != 2 || n.Statuses[0] != "waiting" || n.Statuses[1] != "error" {
+		t.Errorf("statuses = %v", n.Statuses)
+	}
+	if len(n.Methods) != 1 || n.Methods[0] != "bell" {
+		t.Errorf("methods = %v", n.Methods)
+	}
+	// Unknown sequences and negative intervals fall back to defaults
+	if n.Terminal != "" || n.MinIntervalSeconds != 0 {
+		t.Errorf("terminal = %q, interval = %d", n.Terminal, n.MinIntervalSeconds)
+	}
+}
+
+func TestLoadFrom_MergeQueue(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	content := []byte(`{"plugins": {"workspace": {"mergeQueue": {"verifyCommand": " make test ", "method": "PR"}}}}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	mq := cfg.Plugins.Workspace.MergeQueue
+	if mq.VerifyCommand != "make test" || mq.Method != MergeQueueMethodPR {
+		t.Errorf("merge queue = %+v", mq)
+	}
+	if mq.VerifyTimeoutSeconds != DefaultMergeQueueVerifyTimeoutSeconds {
+		t.Errorf("timeout = %d, want default", mq.VerifyTimeoutSeconds)
+	}
+
+	// Defaults apply without a section, and unknown methods merge
+	cfg = Default()
+	cfg.Plugins.Workspace.MergeQueue.Method = "rebase"
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Plugins.Workspace.MergeQueue.Method != MergeQueueMethodMerge {
+		t.Errorf("method = %q, want merge", cfg.Plugins.Workspace.MergeQueue.Method)
+	}
+}
+
+func TestLoadFrom_Recording(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	content := []byte(`{"plugins": {"workspace": {"recording": {"enabled": true, "maxFileMB": 2, "maxFiles": -1}}}}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	rec := cfg.Plugins.Workspace.Recording
+	if !rec.Enabled || rec.MaxFileMB != 2 || rec.MaxFiles != 0 {
+		t.Errorf("recording = %+v", rec)
+	}
+	if toSaveRecording(RecordingConfig{}) != nil {
+		t.Error("default recording config should not be saved")
+	}
+}
+
+func TestLoadFrom_Terminal(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{`{}`, TerminalBackendAuto},
+		{`{"terminal": {"backend": " PTY "}}`, TerminalBackendPTY},
+		{`{"terminal": {"backend": "tmux"}}`, TerminalBackendTmux},
+		{`{"terminal": {"backend": "screen"}}`, TerminalBackendAuto},
+	}
+	for i, tt := range tests {
+		path := filepath.Join(dir, fmt.Sprintf("config%d.json", i))
+		if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := LoadFrom(path)
+		if err != nil {
+			t.Fatalf("LoadFrom(%s) failed: %v", tt.content, err)
+		}
+		if cfg.Terminal.Backend != tt.want {
+			t.Errorf("LoadFrom(%s) backend = %q, want %q", tt.content, cfg.Terminal.Backend, tt.want)
+		}
+	}
+	if toSaveTerminal(Default().Terminal) != nil {
+		t.Error("default terminal config should not be saved")
+	}
+}
+
+func TestLoadFrom_Budgets(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	content := []byte(`{"plugins": {"conversations": {"budgets": [
+		{"name": "team", "period": "Monthly", "project": "~/src/app", "maxCost": 200},
+		{"period": "daily", "adapter": "codex", "maxTokens": 5000000, "warnAt": 0.5}
+	]}}}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	budgets := cfg.Plugins.Conversations.Budgets
+	if len(budgets) != 2 {
+		t.Fatalf("budgets = %+v", budgets)
+	}
+	home, _ := os.UserHomeDir()
+	if budgets[0].Period != BudgetMonthly || budgets[0].Project != filepath.Join(home, "src/app") {
+		t.Errorf("budget[0] = %+v", budgets[0])
+	}
+	if budgets[0].WarnAt != DefaultBudgetWarnAt || budgets[1].WarnAt != 0.5 {
+		t.Errorf("warnAt = %v, %v", budgets[0].WarnAt, budgets[1].WarnAt)
+	}
+}
+
+func TestLoadFrom_SavedFilters(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+
+	content := []byte(`{"plugins": {"conversations": {
+		"savedFilters": [
+			{"name": " review ", "tags": ["review"], "adapters": ["claude-code"], "date": "Week"},
+			{"name": "", "tags": ["dropped"]}
+		],
+		"defaultFilter": "review"
+	}}}`)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom failed: %v", err)
+	}
+	conv := cfg.Plugins.Conversations
+	if len(conv.SavedFilters) != 1 {
+		t.Fatalf("saved filters = %+v, want nameless filter dropped", conv.SavedFilters)
+	}
+	f := conv.SavedFilters[0]
+	if f.Name != "review" || f.Date != "week" || len(f.Tags) != 1 || f.Adapters[0] != "claude-code" {
+		t.Errorf("saved filter = %+v", f)
+	}
+	if conv.DefaultFilter != "review" {
+		t.Errorf("default filter = %q", conv.DefaultFilter)
+	}
+}
+
 func TestLoadFrom_InvalidJSON(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "config.json")
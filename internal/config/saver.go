@@ -15,6 +15,7 @@ type saveConfig struct {
 	Keymap   KeymapConfig       `json:"keymap"`
 	UI       UIConfig           `json:"ui"`
 	Features FeaturesConfig     `json:"features,omitempty"`
+	Terminal *TerminalConfig    `json:"terminal,omitempty"`
 }
 
 type saveProjectsConfig struct {
@@ -42,17 +43,31 @@ type saveTDMonitorConfig struct {
 }
 
 type saveConversationsConfig struct {
-	Enabled       *bool  `json:"enabled,omitempty"`
-	ClaudeDataDir string `json:"claudeDataDir,omitempty"`
+	Enabled       *bool                   `json:"enabled,omitempty"`
+	ClaudeDataDir string                  `json:"claudeDataDir,omitempty"`
+	OTLPReceiver  *saveOTLPReceiverConfig `json:"otlpReceiver,omitempty"`
+	Insights      *InsightsConfig         `json:"insights,omitempty"`
+	Retention     *RetentionConfig        `json:"retention,omitempty"`
+	Budgets       []BudgetConfig          `json:"budgets,omitempty"`
+	SavedFilters  []SavedFilterConfig     `json:"savedFilters,omitempty"`
+	DefaultFilter string                  `json:"defaultFilter,omitempty"`
+}
+
+type saveOTLPReceiverConfig struct {
+	Enabled bool `json:"enabled"`
+	Port    int  `json:"port,omitempty"`
 }
 
 type saveWorkspaceConfig struct {
-	DirPrefix            *bool  `json:"dirPrefix,omitempty"`
-	TmuxCaptureMaxBytes  *int   `json:"tmuxCaptureMaxBytes,omitempty"`
-	InteractiveExitKey   string `json:"interactiveExitKey,omitempty"`
-	InteractiveAttachKey string `json:"interactiveAttachKey,omitempty"`
-	InteractiveCopyKey   string `json:"interactiveCopyKey,omitempty"`
-	InteractivePasteKey  string `json:"interactivePasteKey,omitempty"`
+	DirPrefix            *bool                `json:"dirPrefix,omitempty"`
+	TmuxCaptureMaxBytes  *int                 `json:"tmuxCaptureMaxBytes,omitempty"`
+	InteractiveExitKey   string               `json:"interactiveExitKey,omitempty"`
+	InteractiveAttachKey string               `json:"interactiveAttachKey,omitempty"`
+	InteractiveCopyKey   string               `json:"interactiveCopyKey,omitempty"`
+	InteractivePasteKey  string               `json:"interactivePasteKey,omitempty"`
+	Notifications        *NotificationsConfig `json:"notifications,omitempty"`
+	MergeQueue           *MergeQueueConfig    `json:"mergeQueue,omitempty"`
+	Recording            *RecordingConfig     `json:"recording,omitempty"`
 }
 
 // toSaveConfig converts Config to the JSON-serializable format.
@@ -76,6 +91,12 @@ func toSaveConfig(cfg *Config) saveConfig {
 			Conversations: saveConversationsConfig{
 				Enabled:       &cfg.Plugins.Conversations.Enabled,
 				ClaudeDataDir: cfg.Plugins.Conversations.ClaudeDataDir,
+				OTLPReceiver:  toSaveOTLPReceiver(cfg.Plugins.Conversations.OTLPReceiver),
+				Insights:      toSaveInsights(cfg.Plugins.Conversations.Insights),
+				Retention:     toSaveRetention(cfg.Plugins.Conversations.Retention),
+				Budgets:       cfg.Plugins.Conversations.Budgets,
+				SavedFilters:  cfg.Plugins.Conversations.SavedFilters,
+				DefaultFilter: cfg.Plugins.Conversations.DefaultFilter,
 			},
 			Workspace: saveWorkspaceConfig{
 				DirPrefix:            &cfg.Plugins.Workspace.DirPrefix,
@@ -84,12 +105,76 @@ func toSaveConfig(cfg *Config) saveConfig {
 				InteractiveAttachKey: cfg.Plugins.Workspace.InteractiveAttachKey,
 				InteractiveCopyKey:   cfg.Plugins.Workspace.InteractiveCopyKey,
 				InteractivePasteKey:  cfg.Plugins.Workspace.InteractivePasteKey,
+				Notifications:        toSaveNotifications(cfg.Plugins.Workspace.Notifications),
+				MergeQueue:           toSaveMergeQueue(cfg.Plugins.Workspace.MergeQueue),
+				Recording:            toSaveRecording(cfg.Plugins.Workspace.Recording),
 			},
 		},
 		Keymap:   cfg.Keymap,
 		UI:       cfg.UI,
 		Features: cfg.Features,
+		Terminal: toSaveTerminal(cfg.Terminal),
+	}
+}
+
+// toSaveOTLPReceiver omits the receiver section while it is left at its
+// defaults, so saving other settings doesn't pin the port.
+func toSaveOTLPReceiver(c OTLPReceiverConfig) *saveOTLPReceiverConfig {
+	if !c.Enabled && c.Port == DefaultOTLPPort {
+		return nil
+	}
+	return &saveOTLPReceiverConfig{Enabled: c.Enabled, Port: c.Port}
+}
+
+// toSaveInsights omits the insights section when nothing is customized.
+func toSaveInsights(c InsightsConfig) *InsightsConfig {
+	if !c.DisableBuiltin && len(c.Rules) == 0 && c.DuplicateThreshold == 0 {
+		return nil
+	}
+	return &c
+}
+
+// toSaveRetention omits the retention section when no limit is set.
+func toSaveRetention(c RetentionConfig) *RetentionConfig {
+	if c.MaxAgeDays == 0 && c.MaxSizeMB == 0 {
+		return nil
+	}
+	return &c
+}
+
+// toSaveNotifications omits the notifications section when nothing is
+// customized.
+func toSaveNotifications(c NotificationsConfig) *NotificationsConfig {
+	if !c.Disabled && len(c.Statuses) == 0 && len(c.Methods) == 0 && c.Terminal == "" && c.MinIntervalSeconds == 0 {
+		return nil
+	}
+	return &c
+}
+
+// toSaveMergeQueue omits the merge queue section while it is left at its
+// defaults.
+func toSaveMergeQueue(c MergeQueueConfig) *MergeQueueConfig {
+	if c.VerifyCommand == "" && c.Method == MergeQueueMethodMerge && c.VerifyTimeoutSeconds == DefaultMergeQueueVerifyTimeoutSeconds {
+		return nil
+	}
+	return &c
+}
+
+// toSaveRecording omits the recording section when nothing is customized.
+func toSaveRecording(c RecordingConfig) *RecordingConfig {
+	if c == (RecordingConfig{}) {
+		return nil
+	}
+	return &c
+}
+
+// toSaveTerminal omits the terminal section while the backend is picked
+// automatically.
+func toSaveTerminal(c TerminalConfig) *TerminalConfig {
+	if c.Backend == "" || c.Backend == TerminalBackendAuto {
+		return nil
 	}
+	return &c
 }
 
 // Save writes the config to ~/.config/hermes/config.json, preserving
@@ -127,6 +212,9 @@ func Save(cfg *Config) error {
 	if len(sc.Features.Flags) > 0 {
 		fields["features"] = sc.Features
 	}
+	if sc.Terminal != nil {
+		fields["terminal"] = sc.Terminal
+	}
 	for key, val := range fields {
 		b, err := json.Marshal(val)
 		if err != nil {
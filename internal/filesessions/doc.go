@@ -0,0 +1,4 @@
+// Package filesessions provides the modal, shared by the file browser and
+// git status plugins, that lists the agent sessions which read or edited a
+// file and jumps to the selected one in the conversations plugin.
+package filesessions
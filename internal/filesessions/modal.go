@@ -0,0 +1,135 @@
+package filesessions
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter/toolstats"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/modal"
+	"github.com/toddwbucy/hermes/internal/mouse"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+	"github.com/toddwbucy/hermes/internal/styles"
+	"github.com/toddwbucy/hermes/internal/ui"
+)
+
+const listID = "file-sessions-list"
+
+// Modal lists the indexed sessions that touched one file.
+type Modal struct {
+	path string
+	refs []toolstats.SessionRef
+	idx  int
+
+	modal       *modal.Modal
+	modalWidth  int
+	screenWidth int // Width of the last render
+}
+
+// Open looks up the sessions that touched path, which should be absolute.
+// When none did, it returns a nil Modal and a toast saying so.
+func Open(path string) (*Modal, tea.Cmd) {
+	refs := toolstats.Shared().SessionsForFile(path)
+	if len(refs) == 0 {
+		return nil, appmsg.ShowToast("No indexed sessions touched "+filepath.Base(path), 2*time.Second)
+	}
+	return &Modal{path: path, refs: refs}, nil
+}
+
+// ensureModal builds or rebuilds the modal for the given screen width.
+func (m *Modal) ensureModal(screenWidth int) {
+	modalW := ui.ModalWidthLarge
+	if modalW > screenWidth-4 {
+		modalW = screenWidth - 4
+	}
+	if modalW < 30 {
+		modalW = 30
+	}
+	if m.modal != nil && m.modalWidth == modalW {
+		return
+	}
+	m.modalWidth = modalW
+
+	items := make([]modal.ListItem, len(m.refs))
+	for i, ref := range m.refs {
+		name := ref.Name
+		if name == "" {
+			name = ref.SessionID
+		}
+		items[i] = modal.ListItem{
+			ID:    itemID(i),
+			Label: fmt.Sprintf("%s %s  %s · %s", ref.AdapterIcon, name, ref.Summary(), ref.UpdatedAt.Local().Format("Jan 02 15:04")),
+		}
+	}
+
+	m.modal = modal.New("Sessions: "+filepath.Base(m.path),
+		modal.WithWidth(modalW),
+		modal.WithHints(false),
+	).
+		AddSection(modal.Text(styles.Muted.Render("Enter opens the transcript at the first touch"))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.List(listID, items, &m.idx, modal.WithMaxVisible(10)))
+}
+
+func itemID(i int) string {
+	return fmt.Sprintf("session-%d", i)
+}
+
+// Render renders the modal for a screen of the given size.
+func (m *Modal) Render(width, height int, handler *mouse.Handler) string {
+	m.screenWidth = width
+	m.ensureModal(width)
+	return m.modal.Render(width, height, handler)
+}
+
+// HandleKey handles a key press. done reports that the modal should be
+// closed; cmd opens the selected session when enter was pressed.
+func (m *Modal) HandleKey(msg tea.KeyMsg) (cmd tea.Cmd, done bool) {
+	m.ensureModal(m.screenWidth)
+	switch msg.String() {
+	case "q", "T":
+		return nil, true
+	case "enter":
+		return m.openSelected(), true
+	}
+
+	action, cmd := m.modal.HandleKey(msg)
+	return cmd, action == "cancel"
+}
+
+// HandleMouse handles a mouse event. done reports that the modal should be
+// closed; cmd opens a clicked session.
+func (m *Modal) HandleMouse(msg tea.MouseMsg, handler *mouse.Handler) (cmd tea.Cmd, done bool) {
+	m.ensureModal(m.screenWidth)
+	action := m.modal.HandleMouse(msg, handler)
+	switch action {
+	case "cancel":
+		return nil, true
+	case "":
+	default:
+		for i := range m.refs {
+			if action == itemID(i) {
+				m.idx = i
+				return m.openSelected(), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// openSelected jumps to the conversations plugin at the selected session's
+// first touch of the file.
+func (m *Modal) openSelected() tea.Cmd {
+	if m.idx < 0 || m.idx >= len(m.refs) {
+		return nil
+	}
+	ref := m.refs[m.idx]
+	return tea.Batch(
+		app.FocusPlugin("conversations"),
+		func() tea.Msg {
+			return appmsg.OpenConversationMsg{AdapterID: ref.AdapterID, SessionID: ref.SessionID, MessageID: ref.MessageID}
+		},
+	)
+}
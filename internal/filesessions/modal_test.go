@@ -0,0 +1,68 @@
+package filesessions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/adapter/toolstats"
+	"github.com/toddwbucy/hermes/internal/app"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+)
+
+func TestModalOpensSelectedSession(t *testing.T) {
+	index := toolstats.Shared()
+	index.Reset()
+	defer index.Reset()
+
+	if m, cmd := Open("/repo/none.go"); m != nil || cmd == nil {
+		t.Fatal("Open should toast when no session touched the file")
+	}
+
+	base := time.Unix(1000, 0)
+	for _, s := range []adapter.Session{
+		{ID: "s1", Name: "older", AdapterID: "claude-code", UpdatedAt: base},
+		{ID: "s2", Name: "newer", AdapterID: "codex", UpdatedAt: base.Add(time.Hour)},
+	} {
+		index.Put(s, toolstats.SessionStats{
+			SessionID: s.ID,
+			Files:     []toolstats.FileTouch{{Path: "/repo/a.go", Op: toolstats.OpEdit, MessageID: "m-" + s.ID}},
+		})
+	}
+
+	m, _ := Open("/repo/a.go")
+	if m == nil {
+		t.Fatal("Open returned no modal")
+	}
+	if out := m.Render(100, 40, nil); !strings.Contains(out, "Sessions: a.go") || !strings.Contains(out, "newer") {
+		t.Errorf("render missing title or session:\n%s", out)
+	}
+
+	// Down selects the older session; enter jumps to its first touch
+	if _, done := m.HandleKey(tea.KeyMsg{Type: tea.KeyDown}); done {
+		t.Fatal("navigation should keep the modal open")
+	}
+	cmd, done := m.HandleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if !done || cmd == nil {
+		t.Fatalf("enter: done = %v, cmd nil = %v", done, cmd == nil)
+	}
+	var open appmsg.OpenConversationMsg
+	var focus bool
+	for _, c := range cmd().(tea.BatchMsg) {
+		switch msg := c().(type) {
+		case appmsg.OpenConversationMsg:
+			open = msg
+		case app.FocusPluginByIDMsg:
+			focus = msg.PluginID == "conversations"
+		}
+	}
+	if !focus || open.AdapterID != "claude-code" || open.SessionID != "s1" || open.MessageID != "m-s1" {
+		t.Errorf("jump = %+v, focus conversations = %v", open, focus)
+	}
+
+	if _, done := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); !done {
+		t.Error("q should close the modal")
+	}
+}
@@ -68,6 +68,7 @@ func DefaultBindings() []Binding {
 		{Key: "Z", Command: "stash-pop", Context: "git-status"},
 		{Key: "ctrl+z", Command: "stash-apply", Context: "git-status"},
 		{Key: "O", Command: "open-in-file-browser", Context: "git-status"},
+		{Key: "T", Command: "file-sessions", Context: "git-status"},
 		{Key: "o", Command: "open-in-github", Context: "git-status"},
 		{Key: "y", Command: "yank-file", Context: "git-status"},
 		{Key: "Y", Command: "yank-path", Context: "git-status"},
@@ -178,6 +179,10 @@ func DefaultBindings() []Binding {
 		{Key: "y", Command: "yank-error", Context: "git-error"},
 		{Key: "esc", Command: "dismiss", Context: "git-error"},
 
+		// Git sessions modal context
+		{Key: "enter", Command: "open-session", Context: "git-sessions"},
+		{Key: "esc", Command: "dismiss", Context: "git-sessions"},
+
 		// Git pull conflict context
 		{Key: "a", Command: "abort-pull", Context: "git-pull-conflict"},
 		{Key: "esc", Command: "dismiss", Context: "git-pull-conflict"},
@@ -222,7 +227,12 @@ func DefaultBindings() []Binding {
 		{Key: "C", Command: "toggle-category", Context: "conversations-sidebar"},
 		{Key: "W", Command: "toggle-workspace", Context: "conversations-sidebar"},
 		{Key: "R", Command: "resume-in-workspace", Context: "conversations-sidebar"},
+		{Key: "X", Command: "compare", Context: "conversations-sidebar"},
 		{Key: "I", Command: "extract-insights", Context: "conversations-sidebar"},
+		{Key: "B", Command: "bookmarks", Context: "conversations-sidebar"},
+		{Key: "S", Command: "storage", Context: "conversations-sidebar"},
+		{Key: "$", Command: "budgets", Context: "conversations-sidebar"},
+		{Key: "T", Command: "tag", Context: "conversations-sidebar"},
 
 		// Conversations main context (two-pane mode, right pane focused)
 		{Key: "tab", Command: "switch-pane", Context: "conversations-main"},
@@ -235,13 +245,48 @@ func DefaultBindings() []Binding {
 		{Key: "h", Command: "focus-left", Context: "conversations-main"},
 		{Key: "left", Command: "focus-left", Context: "conversations-main"},
 		{Key: "v", Command: "toggle-view", Context: "conversations-main"},
+		{Key: "t", Command: "toggle-tools", Context: "conversations-main"},
+		{Key: "P", Command: "replay", Context: "conversations-main"},
+		{Key: "T", Command: "trace", Context: "conversations-main"},
+		{Key: "f", Command: "follow", Context: "conversations-main"},
 		{Key: "e", Command: "expand", Context: "conversations-main"},
 		{Key: "enter", Command: "detail", Context: "conversations-main"},
 		{Key: "\\", Command: "toggle-sidebar", Context: "conversations-main"},
 		{Key: "y", Command: "yank-details", Context: "conversations-main"},
 		{Key: "Y", Command: "yank-resume", Context: "conversations-main"},
 		{Key: "R", Command: "resume-in-workspace", Context: "conversations-main"},
+		{Key: "K", Command: "fork", Context: "conversations-main"},
 		{Key: "I", Command: "extract-insights", Context: "conversations-main"},
+		{Key: "b", Command: "bookmark", Context: "conversations-main"},
+		{Key: "a", Command: "annotate", Context: "conversations-main"},
+		{Key: "B", Command: "bookmarks", Context: "conversations-main"},
+
+		// Conversations compare context (side-by-side session comparison)
+		{Key: "s", Command: "swap", Context: "conversations-compare"},
+		{Key: "esc", Command: "back", Context: "conversations-compare"},
+		{Key: "q", Command: "back", Context: "conversations-compare"},
+
+		// Conversations replay context (right pane replaying a session)
+		{Key: " ", Command: "replay-play", Context: "conversations-replay"},
+		{Key: "l", Command: "replay-step", Context: "conversations-replay"},
+		{Key: "h", Command: "replay-step", Context: "conversations-replay"},
+		{Key: "right", Command: "replay-step", Context: "conversations-replay"},
+		{Key: "left", Command: "replay-step", Context: "conversations-replay"},
+		{Key: "+", Command: "replay-speed", Context: "conversations-replay"},
+		{Key: "-", Command: "replay-speed", Context: "conversations-replay"},
+		{Key: "g", Command: "replay-seek", Context: "conversations-replay"},
+		{Key: "G", Command: "replay-seek", Context: "conversations-replay"},
+		{Key: "esc", Command: "back", Context: "conversations-replay"},
+		{Key: "q", Command: "back", Context: "conversations-replay"},
+
+		// Conversations trace context (right pane showing a span waterfall)
+		{Key: "enter", Command: "trace-collapse", Context: "conversations-trace"},
+		{Key: "n", Command: "trace-error", Context: "conversations-trace"},
+		{Key: "N", Command: "trace-error", Context: "conversations-trace"},
+		{Key: "J", Command: "trace-detail", Context: "conversations-trace"},
+		{Key: "K", Command: "trace-detail", Context: "conversations-trace"},
+		{Key: "esc", Command: "back", Context: "conversations-trace"},
+		{Key: "q", Command: "back", Context: "conversations-trace"},
 
 		// Conversations insights modal context
 		{Key: "esc", Command: "close", Context: "conversations-insights"},
@@ -254,6 +299,35 @@ func DefaultBindings() []Binding {
 		{Key: "a", Command: "toggle-all", Context: "conversations-insights"},
 		{Key: "enter", Command: "create", Context: "conversations-insights"},
 
+		// Conversations bookmarks browser context
+		{Key: "esc", Command: "close", Context: "conversations-bookmarks"},
+		{Key: "q", Command: "close", Context: "conversations-bookmarks"},
+		{Key: "j", Command: "navigate", Context: "conversations-bookmarks"},
+		{Key: "k", Command: "navigate", Context: "conversations-bookmarks"},
+		{Key: "enter", Command: "open", Context: "conversations-bookmarks"},
+		{Key: "a", Command: "annotate", Context: "conversations-bookmarks"},
+		{Key: "d", Command: "delete", Context: "conversations-bookmarks"},
+
+		// Conversations annotation editor context
+		{Key: "enter", Command: "save", Context: "conversations-annotate"},
+		{Key: "esc", Command: "cancel", Context: "conversations-annotate"},
+
+		// Conversations storage view context
+		{Key: "esc", Command: "close", Context: "conversations-storage"},
+		{Key: "q", Command: "close", Context: "conversations-storage"},
+		{Key: " ", Command: "select", Context: "conversations-storage"},
+		{Key: "r", Command: "select-flagged", Context: "conversations-storage"},
+		{Key: "a", Command: "archive", Context: "conversations-storage"},
+		{Key: "D", Command: "delete", Context: "conversations-storage"},
+
+		// Conversations text prompt context (tags, saved filter names)
+		{Key: "enter", Command: "save", Context: "conversations-prompt"},
+		{Key: "esc", Command: "cancel", Context: "conversations-prompt"},
+
+		// Conversations budget panel context
+		{Key: "esc", Command: "close", Context: "conversations-budget"},
+		{Key: "q", Command: "close", Context: "conversations-budget"},
+
 		// File browser tree context
 		{Key: "tab", Command: "switch-pane", Context: "file-browser-tree"},
 		{Key: "shift+tab", Command: "switch-pane", Context: "file-browser-tree"},
@@ -276,6 +350,7 @@ func DefaultBindings() []Binding {
 		{Key: "R", Command: "rename", Context: "file-browser-tree"},
 		{Key: "ctrl+r", Command: "reveal", Context: "file-browser-tree"},
 		{Key: "I", Command: "info", Context: "file-browser-tree"},
+		{Key: "T", Command: "sessions", Context: "file-browser-tree"},
 		{Key: "e", Command: "edit", Context: "file-browser-tree"},
 		{Key: "E", Command: "edit-external", Context: "file-browser-tree"},
 		{Key: "B", Command: "blame", Context: "file-browser-tree"},
@@ -295,6 +370,7 @@ func DefaultBindings() []Binding {
 		{Key: "R", Command: "rename", Context: "file-browser-preview"},
 		{Key: "ctrl+r", Command: "reveal", Context: "file-browser-preview"},
 		{Key: "I", Command: "info", Context: "file-browser-preview"},
+		{Key: "T", Command: "sessions", Context: "file-browser-preview"},
 		{Key: "e", Command: "edit", Context: "file-browser-preview"},
 		{Key: "E", Command: "edit-external", Context: "file-browser-preview"},
 		{Key: "B", Command: "blame", Context: "file-browser-preview"},
@@ -378,6 +454,33 @@ func DefaultBindings() []Binding {
 		{Key: "[", Command: "prev-tab", Context: "workspace-list"},
 		{Key: "]", Command: "next-tab", Context: "workspace-list"},
 		{Key: "F", Command: "fetch-pr", Context: "workspace-list"},
+		{Key: "a", Command: "notifications", Context: "workspace-list"},
+		{Key: "M", Command: "merge-queue-add", Context: "workspace-list"},
+		{Key: "Q", Command: "merge-queue", Context: "workspace-list"},
+		{Key: "w", Command: "toggle-recording", Context: "workspace-list"},
+		{Key: "H", Command: "output-history", Context: "workspace-list"},
+
+		// Workspace notification center context
+		{Key: "esc", Command: "cancel", Context: "workspace-notifications"},
+		{Key: "enter", Command: "select", Context: "workspace-notifications"},
+		{Key: "c", Command: "clear", Context: "workspace-notifications"},
+
+		// Workspace fan-out launcher context
+		{Key: "esc", Command: "cancel", Context: "workspace-fan-out"},
+		{Key: "enter", Command: "launch", Context: "workspace-fan-out"},
+		{Key: " ", Command: "toggle-prompt", Context: "workspace-fan-out"},
+
+		// Workspace merge queue context
+		{Key: "esc", Command: "cancel", Context: "workspace-merge-queue"},
+		{Key: "s", Command: "start", Context: "workspace-merge-queue"},
+		{Key: "p", Command: "pause", Context: "workspace-merge-queue"},
+		{Key: "x", Command: "remove", Context: "workspace-merge-queue"},
+
+		// Workspace output history context
+		{Key: "esc", Command: "cancel", Context: "workspace-history"},
+		{Key: "/", Command: "search", Context: "workspace-history"},
+		{Key: "n", Command: "next-match", Context: "workspace-history"},
+		{Key: "e", Command: "export", Context: "workspace-history"},
 
 		// Workspace fetch PR context
 		{Key: "esc", Command: "cancel", Context: "workspace-fetch-pr"},
@@ -429,6 +532,8 @@ func DefaultBindings() []Binding {
 		{Key: "o", Command: "sort", Context: "persephone"},
 		{Key: "ctrl+s", Command: "save", Context: "persephone"},
 		{Key: "tab", Command: "select", Context: "persephone"},
+		{Key: " ", Command: "mark", Context: "persephone"},
+		{Key: "L", Command: "launch", Context: "persephone"},
 
 		// Notes list context
 		{Key: "j", Command: "cursor-down", Context: "notes-list"},
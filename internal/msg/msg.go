@@ -27,6 +27,7 @@ func ShowToast(message string, duration time.Duration) tea.Cmd {
 type InsightTask struct {
 	Title       string // First ~80 chars of insight text
 	Description string // Full insight text + source reference
+	Text        string // Raw insight text, compared against existing tasks
 }
 
 // CreateInsightTasksMsg is emitted by the conversations plugin to request
@@ -34,7 +35,10 @@ type InsightTask struct {
 type CreateInsightTasksMsg struct {
 	Tasks       []InsightTask
 	SessionName string
-	Epoch       uint64
+	// DuplicateThreshold is the similarity at which an insight is skipped
+	// as already covered by an existing task. 0 uses the default.
+	DuplicateThreshold float64
+	Epoch              uint64
 }
 
 // GetEpoch implements plugin.EpochMessage for staleness detection.
@@ -43,10 +47,59 @@ func (m CreateInsightTasksMsg) GetEpoch() uint64 { return m.Epoch }
 // InsightTasksCreatedMsg is emitted by the Persephone plugin after processing
 // a CreateInsightTasksMsg. Broadcast back to conversations plugin.
 type InsightTasksCreatedMsg struct {
-	Count int
-	Err   error
-	Epoch uint64
+	Count   int
+	Skipped []string // Titles of insights skipped as duplicates
+	Err     error
+	Epoch   uint64
 }
 
 // GetEpoch implements plugin.EpochMessage for staleness detection.
 func (m InsightTasksCreatedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// OpenConversationMsg asks the conversations plugin to select a session and
+// scroll to a message. Broadcast to all plugins; senders pair it with a
+// focus request for the conversations plugin.
+type OpenConversationMsg struct {
+	AdapterID string // Session IDs are only unique within an adapter
+	SessionID string
+	MessageID string // Optional message to scroll to ("" = top of session)
+}
+
+// HeaderBadgeMsg sets a persistent badge in the app header, e.g. a budget
+// alert. Badges are keyed by ID; an empty Text removes the badge.
+type HeaderBadgeMsg struct {
+	ID      string
+	Text    string
+	IsError bool // true renders the badge as an error (red), false as a warning
+}
+
+// RunCommandMsg asks the active plugin to run a command selected from the
+// command palette. It is sent for commands without a registered handler,
+// so plugins can serve commands they bind at runtime.
+type RunCommandMsg struct {
+	CommandID string
+	Context   string
+}
+
+// LaunchTask is a Persephone task to start an agent on.
+type LaunchTask struct {
+	Key         string
+	Title       string
+	Description string
+	Acceptance  string
+	Handoff     string // Latest handoff, formatted as text; empty if none
+}
+
+// LaunchTasksMsg asks the workspace plugin to open its fan-out launcher
+// for tasks selected on the Persephone board. Broadcast to all plugins;
+// senders pair it with a focus request for the workspace plugin.
+type LaunchTasksMsg struct {
+	Tasks []LaunchTask
+}
+
+// TasksStartedMsg is emitted by the workspace plugin after it starts an
+// agent on tasks, so the Persephone plugin can move open ones to
+// in_progress. Broadcast to all plugins.
+type TasksStartedMsg struct {
+	Keys []string
+}
@@ -0,0 +1,4 @@
+// Package notify delivers short alerts outside the TUI: OSC 9/777 terminal
+// notifications, notify-send desktop notifications, tmux display-message,
+// and the terminal bell, rate-limited per key.
+package notify
@@ -0,0 +1,222 @@
+package notify
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/config"
+)
+
+// Delivery channels, as named in config.
+const (
+	MethodTerminal = "terminal" // OSC 9 or OSC 777 escape sequence
+	MethodDesktop  = "desktop"  // notify-send, falling back to tmux
+	MethodTmux     = "tmux"     // tmux display-message
+	MethodBell     = "bell"     // BEL character
+)
+
+// Terminal escape sequences.
+const (
+	TerminalOSC9   = "osc9"   // iTerm2, WezTerm, kitty, Windows Terminal
+	TerminalOSC777 = "osc777" // urxvt, foot, VTE-based terminals
+)
+
+// DefaultMinInterval is the least time between alerts for one key.
+const DefaultMinInterval = 30 * time.Second
+
+// defaultMethods are used when config lists none.
+var defaultMethods = []string{MethodTerminal, MethodDesktop, MethodBell}
+
+// Note is one alert.
+type Note struct {
+	Title string
+	Body  string
+}
+
+// Notifier sends alerts over the configured channels. Safe for concurrent
+// use; Send is blocking and meant to run inside a tea.Cmd.
+type Notifier struct {
+	disabled    bool
+	statuses    map[string]bool // nil means all
+	methods     []string
+	terminal    string
+	minInterval time.Duration
+
+	mu   sync.Mutex
+	last map[string]time.Time
+
+	// Replaced in tests
+	now      func() time.Time
+	writeTTY func([]byte) error
+	run      func(name string, args ...string) error
+	lookPath func(file string) (string, error)
+	getenv   func(key string) string
+}
+
+// New creates a notifier from config.
+func New(cfg config.NotificationsConfig) *Notifier {
+	n := &Notifier{
+		disabled:    cfg.Disabled,
+		methods:     cfg.Methods,
+		terminal:    cfg.Terminal,
+		minInterval: time.Duration(cfg.MinIntervalSeconds) * time.Second,
+		last:        make(map[string]time.Time),
+		now:         time.Now,
+		writeTTY:    writeTTY,
+		run:         func(name string, args ...string) error { return exec.Command(name, args...).Run() },
+		lookPath:    exec.LookPath,
+		getenv:      os.Getenv,
+	}
+	if len(n.methods) == 0 {
+		n.methods = defaultMethods
+	}
+	if n.minInterval <= 0 {
+		n.minInterval = DefaultMinInterval
+	}
+	if len(cfg.Statuses) > 0 {
+		n.statuses = make(map[string]bool, len(cfg.Statuses))
+		for _, s := range cfg.Statuses {
+			n.statuses[s] = true
+		}
+	}
+	return n
+}
+
+// Wants reports whether a status (e.g. "waiting") should alert.
+func (n *Notifier) Wants(status string) bool {
+	if n == nil || n.disabled {
+		return false
+	}
+	return n.statuses == nil || n.statuses[status]
+}
+
+// Allow reports whether key may alert now and, if so, starts its quiet
+// period.
+func (n *Notifier) Allow(key string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	now := n.now()
+	if last, ok := n.last[key]; ok && now.Sub(last) < n.minInterval {
+		return false
+	}
+	n.last[key] = now
+	return true
+}
+
+// Send delivers a note over every configured channel, unless key alerted
+// within the minimum interval. It returns the channels that delivered.
+func (n *Notifier) Send(key string, note Note) []string {
+	if n == nil || n.disabled || !n.Allow(key) {
+		return nil
+	}
+	var sent []string
+	tmuxSent := false
+	for _, method := range n.methods {
+		var ok bool
+		switch method {
+		case MethodTerminal:
+			ok = n.writeTTY(n.terminalSequence(note)) == nil
+		case MethodDesktop:
+			if _, err := n.lookPath("notify-send"); err == nil {
+				ok = n.run("notify-send", "--app-name=Hermes", note.Title, note.Body) == nil
+			} else if !tmuxSent && n.inTmux() {
+				ok = n.tmuxMessage(note)
+				tmuxSent = ok
+			}
+		case MethodTmux:
+			if !tmuxSent && n.inTmux() {
+				ok = n.tmuxMessage(note)
+				tmuxSent = ok
+			}
+		case MethodBell:
+			ok = n.writeTTY([]byte("\a")) == nil
+		}
+		if ok {
+			sent = append(sent, method)
+		}
+	}
+	return sent
+}
+
+// inTmux reports whether Hermes runs inside tmux.
+func (n *Notifier) inTmux() bool {
+	return n.getenv("TMUX") != ""
+}
+
+// tmuxMessage shows the note in the tmux status line.
+func (n *Notifier) tmuxMessage(note Note) bool {
+	msg := note.Title
+	if note.Body != "" {
+		msg += ": " + note.Body
+	}
+	// display-message expands #{...} formats; ## is a literal #
+	msg = strings.ReplaceAll(sanitize(msg), "#", "##")
+	return n.run("tmux", "display-message", msg) == nil
+}
+
+// terminalSequence builds the OSC notification for the note, wrapped for
+// tmux passthrough when running inside tmux.
+func (n *Notifier) terminalSequence(note Note) []byte {
+	title, body := sanitize(note.Title), sanitize(note.Body)
+	var seq string
+	if n.terminalKind() == TerminalOSC777 {
+		// Fields are ;-separated, so the title can't contain one
+		seq = "\x1b]777;notify;" + strings.ReplaceAll(title, ";", ",") + ";" + body + "\x1b\\"
+	} else {
+		text := title
+		if body != "" {
+			text += ": " + body
+		}
+		seq = "\x1b]9;" + text + "\x1b\\"
+	}
+	if n.inTmux() {
+		// DCS passthrough; needs `set -g allow-passthrough on` in tmux 3.3+
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return []byte(seq)
+}
+
+// terminalKind returns the configured sequence, or guesses one from the
+// environment.
+func (n *Notifier) terminalKind() string {
+	if n.terminal != "" {
+		return n.terminal
+	}
+	if n.getenv("VTE_VERSION") != "" {
+		return TerminalOSC777
+	}
+	term := n.getenv("TERM")
+	if strings.Contains(term, "rxvt") || strings.HasPrefix(term, "foot") {
+		return TerminalOSC777
+	}
+	return TerminalOSC9
+}
+
+// sanitize drops control characters, which would end or corrupt an escape
+// sequence.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return ' '
+		}
+		if r < 0x20 || r == 0x7f || (r >= 0x80 && r < 0xa0) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// writeTTY writes directly to the controlling terminal, bypassing the
+// Bubble Tea renderer's stdout.
+func writeTTY(b []byte) error {
+	f, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = f.Write(b)
+	return err
+}
@@ -0,0 +1,177 @@
+package notify
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/config"
+)
+
+// fakeNotifier records terminal writes and commands instead of running them.
+type fakeNotifier struct {
+	*Notifier
+	tty  []string
+	cmds []string
+	env  map[string]string
+	now  time.Time
+}
+
+func newFake(cfg config.NotificationsConfig, hasNotifySend bool) *fakeNotifier {
+	f := &fakeNotifier{Notifier: New(cfg), env: map[string]string{}, now: time.Unix(1000, 0)}
+	f.Notifier.now = func() time.Time { return f.now }
+	f.writeTTY = func(b []byte) error {
+		f.tty = append(f.tty, string(b))
+		return nil
+	}
+	f.run = func(name string, args ...string) error {
+		f.cmds = append(f.cmds, name+" "+strings.Join(args, " "))
+		return nil
+	}
+	f.lookPath = func(file string) (string, error) {
+		if hasNotifySend {
+			return "/usr/bin/" + file, nil
+		}
+		return "", errors.New("not found")
+	}
+	f.getenv = func(key string) string { return f.env[key] }
+	return f
+}
+
+func TestSend_DefaultMethods(t *testing.T) {
+	f := newFake(config.NotificationsConfig{}, true)
+	sent := f.Send("wt", Note{Title: "Hermes: auth", Body: "Agent finished"})
+
+	if want := []string{MethodTerminal, MethodDesktop, MethodBell}; !reflect.DeepEqual(sent, want) {
+		t.Errorf("sent = %v, want %v", sent, want)
+	}
+	if want := []string{"\x1b]9;Hermes: auth: Agent finished\x1b\\", "\a"}; !reflect.DeepEqual(f.tty, want) {
+		t.Errorf("tty = %q, want %q", f.tty, want)
+	}
+	if len(f.cmds) != 1 || f.cmds[0] != "notify-send --app-name=Hermes Hermes: auth Agent finished" {
+		t.Errorf("cmds = %q", f.cmds)
+	}
+}
+
+func TestSend_DesktopFallsBackToTmux(t *testing.T) {
+	f := newFake(config.NotificationsConfig{Methods: []string{MethodDesktop, MethodTmux}}, false)
+	f.env["TMUX"] = "/tmp/tmux-1000/default,1,0"
+
+	sent := f.Send("wt", Note{Title: "auth", Body: "50% #1"})
+	// The explicit tmux method doesn't repeat the fallback message
+	if want := []string{MethodDesktop}; !reflect.DeepEqual(sent, want) {
+		t.Errorf("sent = %v, want %v", sent, want)
+	}
+	if len(f.cmds) != 1 || f.cmds[0] != "tmux display-message auth: 50% ##1" {
+		t.Errorf("cmds = %q", f.cmds)
+	}
+}
+
+func TestSend_NoDesktopOutsideTmux(t *testing.T) {
+	f := newFake(config.NotificationsConfig{Methods: []string{MethodDesktop}}, false)
+	if sent := f.Send("wt", Note{Title: "auth"}); len(sent) != 0 || len(f.cmds) != 0 {
+		t.Errorf("sent = %v, cmds = %q, want nothing", sent, f.cmds)
+	}
+}
+
+func TestSend_RateLimitedPerKey(t *testing.T) {
+	f := newFake(config.NotificationsConfig{Methods: []string{MethodBell}, MinIntervalSeconds: 10}, false)
+
+	if f.Send("a", Note{}) == nil {
+		t.Fatal("first alert suppressed")
+	}
+	if f.Send("a", Note{}) != nil {
+		t.Error("repeat alert within interval not suppressed")
+	}
+	if f.Send("b", Note{}) == nil {
+		t.Error("other key suppressed")
+	}
+	f.now = f.now.Add(10 * time.Second)
+	if f.Send("a", Note{}) == nil {
+		t.Error("alert after interval suppressed")
+	}
+}
+
+func TestSend_Disabled(t *testing.T) {
+	f := newFake(config.NotificationsConfig{Disabled: true}, true)
+	if sent := f.Send("wt", Note{Title: "x"}); sent != nil || len(f.tty) != 0 {
+		t.Errorf("disabled notifier sent %v", sent)
+	}
+	if f.Wants("waiting") {
+		t.Error("disabled notifier wants alerts")
+	}
+}
+
+func TestWants(t *testing.T) {
+	all := New(config.NotificationsConfig{})
+	some := New(config.NotificationsConfig{Statuses: []string{"error"}})
+	if !all.Wants("waiting") || !all.Wants("done") {
+		t.Error("empty statuses should want all")
+	}
+	if some.Wants("waiting") || !some.Wants("error") {
+		t.Error("statuses filter not applied")
+	}
+	var none *Notifier
+	if none.Wants("error") {
+		t.Error("nil notifier wants alerts")
+	}
+}
+
+func TestTerminalSequence(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.NotificationsConfig
+		env  map[string]string
+		note Note
+		want string
+	}{
+		{
+			name: "osc9 default",
+			note: Note{Title: "T", Body: "B"},
+			want: "\x1b]9;T: B\x1b\\",
+		},
+		{
+			name: "osc777 for VTE",
+			env:  map[string]string{"VTE_VERSION": "7600"},
+			note: Note{Title: "a;b", Body: "c;d"},
+			want: "\x1b]777;notify;a,b;c;d\x1b\\",
+		},
+		{
+			name: "osc777 for foot",
+			env:  map[string]string{"TERM": "foot-extra"},
+			note: Note{Title: "T"},
+			want: "\x1b]777;notify;T;\x1b\\",
+		},
+		{
+			name: "config overrides environment",
+			cfg:  config.NotificationsConfig{Terminal: TerminalOSC9},
+			env:  map[string]string{"TERM": "rxvt-unicode"},
+			note: Note{Title: "T"},
+			want: "\x1b]9;T\x1b\\",
+		},
+		{
+			name: "control characters stripped",
+			note: Note{Title: "T\x1b]0;x\x07", Body: "line1\nline2"},
+			want: "\x1b]9;T]0;x: line1 line2\x1b\\",
+		},
+		{
+			name: "tmux passthrough",
+			env:  map[string]string{"TMUX": "/tmp/tmux"},
+			note: Note{Title: "T"},
+			want: "\x1bPtmux;\x1b\x1b]9;T\x1b\x1b\\\x1b\\",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFake(tt.cfg, false)
+			for k, v := range tt.env {
+				f.env[k] = v
+			}
+			if got := string(f.terminalSequence(tt.note)); got != tt.want {
+				t.Errorf("sequence = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
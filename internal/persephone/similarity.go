@@ -0,0 +1,97 @@
+package persephone
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultDuplicateThreshold is the similarity at which FindSimilarTask
+// treats text as already covered by a task.
+const DefaultDuplicateThreshold = 0.75
+
+// minTitleTokens keeps short generic titles ("Fix tests") from matching
+// every insight that happens to mention the same words.
+const minTitleTokens = 3
+
+// stopwords are dropped before comparing, so overlap reflects content.
+var stopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "that": true, "this": true,
+	"with": true, "from": true, "are": true, "was": true, "were": true,
+	"but": true, "not": true, "you": true, "its": true, "into": true,
+	"than": true, "then": true, "when": true, "which": true, "will": true,
+	"should": true, "would": true, "could": true, "have": true, "has": true,
+	"been": true, "can": true, "our": true, "all": true, "also": true,
+	"discuss": true,
+}
+
+// similarityTokens lowercases text and splits it into content words.
+func similarityTokens(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := make(map[string]bool, len(words))
+	for _, w := range words {
+		if len(w) < 3 || stopwords[w] {
+			continue
+		}
+		// Fold simple plurals so "tests" matches "test"
+		if len(w) > 4 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = w[:len(w)-1]
+		}
+		tokens[w] = true
+	}
+	return tokens
+}
+
+// containment returns the fraction of a's tokens that also appear in b.
+func containment(a, b map[string]bool) float64 {
+	if len(a) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range a {
+		if b[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a))
+}
+
+// TextSimilarity scores how well text is covered by a task, from 0 to 1.
+// Descriptions are scored by how much of text they contain, since insight
+// tasks wrap the insight in a longer template. Titles are scored the other
+// way round — a title is usually a truncated summary of the text — and
+// only when they carry enough words to be specific.
+func TextSimilarity(text string, task Task) float64 {
+	tokens := similarityTokens(text)
+	if len(tokens) == 0 {
+		return 0
+	}
+	score := containment(tokens, similarityTokens(task.Description))
+
+	title := similarityTokens(task.Title)
+	if len(title) >= minTitleTokens {
+		// Weight by relative size so a short title can't fully match a
+		// long insight on its own
+		t := containment(title, tokens) * min(1, float64(len(title))/float64(len(tokens))*2)
+		score = max(score, t)
+	}
+	return score
+}
+
+// FindSimilarTask returns the task most similar to text and its score, or
+// nil when no task reaches threshold. A threshold <= 0 uses
+// DefaultDuplicateThreshold.
+func FindSimilarTask(text string, tasks []Task, threshold float64) (*Task, float64) {
+	if threshold <= 0 {
+		threshold = DefaultDuplicateThreshold
+	}
+	var best *Task
+	bestScore := 0.0
+	for i := range tasks {
+		if s := TextSimilarity(text, tasks[i]); s >= threshold && s > bestScore {
+			best, bestScore = &tasks[i], s
+		}
+	}
+	return best, bestScore
+}
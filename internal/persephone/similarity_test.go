@@ -0,0 +1,51 @@
+package persephone
+
+import "testing"
+
+func TestFindSimilarTask(t *testing.T) {
+	tasks := []Task{
+		{Key: "t1", Title: "Fix tests"},
+		{
+			Key:   "t2",
+			Title: "Discuss: The cache key ignores file mtime, so rewritten...",
+			Description: "## Discussion Point\n\nThe cache key ignores file mtime, so rewritten " +
+				"traces serve stale spans.\n\n## Context\n\n- **Type**: OBS",
+		},
+		{Key: "t3", Title: "Migrate session store to ArangoDB", Description: "Move JSON session files into the graph."},
+	}
+
+	cases := []struct {
+		text string
+		want string
+	}{
+		// Same insight from another session, reworded slightly
+		{"The cache keys ignore the file mtime, so rewritten traces serve stale spans", "t2"},
+		// Matches on the title alone
+		{"We should migrate the session store to ArangoDB before the release", "t3"},
+		// A short generic title does not swallow unrelated insights
+		{"Fix flaky tests in the watcher by waiting for the debounce", ""},
+		{"Tool calls need a timeout", ""},
+		{"", ""},
+	}
+	for _, c := range cases {
+		got, score := FindSimilarTask(c.text, tasks, 0)
+		key := ""
+		if got != nil {
+			key = got.Key
+		}
+		if key != c.want {
+			t.Errorf("FindSimilarTask(%q) = %q (%.2f), want %q", c.text, key, score, c.want)
+		}
+	}
+}
+
+func TestFindSimilarTaskThreshold(t *testing.T) {
+	tasks := []Task{{Key: "t1", Description: "retry the upload when the token expires"}}
+	text := "retry the upload when the network drops"
+	if got, _ := FindSimilarTask(text, tasks, 0); got != nil {
+		t.Errorf("default threshold matched a partial overlap")
+	}
+	if got, _ := FindSimilarTask(text, tasks, 0.4); got == nil {
+		t.Errorf("lower threshold should match")
+	}
+}
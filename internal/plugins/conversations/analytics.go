@@ -32,6 +32,8 @@ func (p *Plugin) renderAnalytics() string {
 		lines = append(lines, styles.Title.Render(" Usage Analytics"))
 		lines = append(lines, styles.Muted.Render(strings.Repeat("━", p.separatorWidth())))
 		lines = append(lines, styles.StatusDeleted.Render(" Unable to load stats: "+err.Error()))
+		lines = append(lines, "")
+		lines = append(lines, p.projectToolUsageLines()...)
 		p.analyticsLines = lines
 		return strings.Join(lines, "\n")
 	}
@@ -148,6 +150,10 @@ func (p *Plugin) renderAnalytics() string {
 	costLabel := styles.Subtitle.Render(" Total Estimated Cost: ")
 	costValue := lipgloss.NewStyle().Foreground(styles.Accent).Bold(true).Render(fmt.Sprintf("~$%.0f", totalCost))
 	lines = append(lines, costLabel+costValue)
+	lines = append(lines, "")
+
+	// Tool usage for sessions in this project
+	lines = append(lines, p.projectToolUsageLines()...)
 
 	// Store lines for scroll calculation
 	p.analyticsLines = lines
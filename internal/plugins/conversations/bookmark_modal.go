@@ -0,0 +1,484 @@
+package conversations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/modal"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+	"github.com/toddwbucy/hermes/internal/plugin"
+	"github.com/toddwbucy/hermes/internal/styles"
+	"github.com/toddwbucy/hermes/internal/ui"
+)
+
+// Annotation modal field IDs
+const (
+	annotationInputID  = "annotation-input"
+	annotationSaveID   = "annotation-save"
+	annotationCancelID = "annotation-cancel"
+)
+
+// bookmarksModalState holds the bookmarks browser state.
+type bookmarksModalState struct {
+	bookmarks []Bookmark
+	cursor    int
+}
+
+// bookmarkTarget returns the session and message that bookmark keys act on:
+// the selected message in conversation view, or the first message of the
+// selected turn in turn view.
+func (p *Plugin) bookmarkTarget() (*adapter.Session, *adapter.Message) {
+	session := p.findSelectedSession()
+	if session == nil {
+		return nil, nil
+	}
+	if p.turnViewMode {
+		if p.turnCursor < 0 || p.turnCursor >= len(p.turns) {
+			return session, nil
+		}
+		turn := &p.turns[p.turnCursor]
+		for i := range turn.Messages {
+			if turn.Messages[i].ID != "" {
+				return session, &turn.Messages[i]
+			}
+		}
+		return session, nil
+	}
+	return session, p.getSelectedMessage()
+}
+
+// messageBookmark returns the bookmark for a message in the selected session.
+func (p *Plugin) messageBookmark(msgID string) (Bookmark, bool) {
+	if msgID == "" {
+		return Bookmark{}, false
+	}
+	session := p.findSelectedSession()
+	if session == nil {
+		return Bookmark{}, false
+	}
+	return p.bookmarks.Get(session.AdapterID, session.ID, msgID)
+}
+
+// turnBookmarked reports whether any message in the turn is bookmarked.
+func (p *Plugin) turnBookmarked(turn Turn) bool {
+	for _, m := range turn.Messages {
+		if _, ok := p.messageBookmark(m.ID); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sessionBookmarks returns the selected session's bookmarks keyed by message ID.
+func (p *Plugin) sessionBookmarks() map[string]Bookmark {
+	session := p.findSelectedSession()
+	if session == nil {
+		return nil
+	}
+	return p.bookmarks.ForSession(session.AdapterID, session.ID)
+}
+
+// toggleBookmark adds or removes a bookmark on the selected message.
+func (p *Plugin) toggleBookmark() tea.Cmd {
+	session, msg := p.bookmarkTarget()
+	if session == nil || msg == nil {
+		return appmsg.ShowToast("No message selected", 2*time.Second)
+	}
+	if msg.ID == "" {
+		return appmsg.ShowToast("Message has no ID to bookmark", 2*time.Second)
+	}
+
+	if _, ok := p.bookmarks.Get(session.AdapterID, session.ID, msg.ID); ok {
+		if err := p.bookmarks.Remove(session.AdapterID, session.ID, msg.ID); err != nil {
+			return bookmarkErrorToast(err)
+		}
+		return appmsg.ShowToast("Bookmark removed", 2*time.Second)
+	}
+	if err := p.bookmarks.Put(newBookmark(session, msg)); err != nil {
+		return bookmarkErrorToast(err)
+	}
+	return appmsg.ShowToast("Bookmarked (a to annotate, B to browse)", 2*time.Second)
+}
+
+// annotateSelectedMessage opens the annotation editor for the selected
+// message. Saving an annotation bookmarks the message.
+func (p *Plugin) annotateSelectedMessage() tea.Cmd {
+	session, msg := p.bookmarkTarget()
+	if session == nil || msg == nil {
+		return appmsg.ShowToast("No message selected", 2*time.Second)
+	}
+	if msg.ID == "" {
+		return appmsg.ShowToast("Message has no ID to annotate", 2*time.Second)
+	}
+
+	b, ok := p.bookmarks.Get(session.AdapterID, session.ID, msg.ID)
+	if !ok {
+		b = newBookmark(session, msg)
+	}
+	return p.openAnnotationModal(b, false)
+}
+
+// openAnnotationModal opens the annotation editor for b.
+func (p *Plugin) openAnnotationModal(b Bookmark, fromBrowser bool) tea.Cmd {
+	p.annotationTarget = &b
+	p.annotationFromBrowser = fromBrowser
+	p.annotationInput = textinput.New()
+	p.annotationInput.Placeholder = "Why this turn matters"
+	p.annotationInput.CharLimit = 500
+	p.annotationInput.SetValue(b.Annotation)
+	p.annotationInput.Focus()
+	p.annotationModal = nil
+	p.showAnnotationModal = true
+	return textinput.Blink
+}
+
+// ensureAnnotationModal builds or caches the annotation modal.
+func (p *Plugin) ensureAnnotationModal() {
+	if p.annotationTarget == nil {
+		return
+	}
+	modalW := 70
+	if maxW := p.width - 4; modalW > maxW {
+		modalW = max(maxW, 30)
+	}
+	if p.annotationModal != nil && p.annotationModalWidth == modalW {
+		return
+	}
+	p.annotationModalWidth = modalW
+
+	b := p.annotationTarget
+	preview := b.Preview
+	if preview == "" {
+		preview = "(no text)"
+	}
+	header := fmt.Sprintf("%s · %s", truncateStr(b.SessionName, 30), b.Role)
+
+	p.annotationModal = modal.New("Annotate Message",
+		modal.WithWidth(modalW),
+		modal.WithPrimaryAction(annotationSaveID),
+		modal.WithHints(false),
+	).
+		AddSection(modal.Text(styles.Muted.Render(header))).
+		AddSection(modal.Text(truncateStr(preview, (modalW-6)*2))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Input(annotationInputID, &p.annotationInput, modal.WithSubmitAction(annotationSaveID))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Save ", annotationSaveID, modal.BtnPrimary()),
+			modal.Btn(" Cancel ", annotationCancelID),
+		))
+}
+
+// handleAnnotationModalKey handles key events when the annotation modal is open.
+func (p *Plugin) handleAnnotationModalKey(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensureAnnotationModal()
+	if p.annotationModal == nil {
+		p.closeAnnotationModal()
+		return p, nil
+	}
+
+	action, cmd := p.annotationModal.HandleKey(msg)
+	switch action {
+	case annotationSaveID:
+		return p, p.saveAnnotation()
+	case annotationCancelID, "cancel":
+		p.closeAnnotationModal()
+		return p, nil
+	}
+	return p, cmd
+}
+
+// handleAnnotationModalMouse handles clicks on the annotation modal buttons.
+func (p *Plugin) handleAnnotationModalMouse(msg tea.MouseMsg) tea.Cmd {
+	p.ensureAnnotationModal()
+	if p.annotationModal == nil {
+		return nil
+	}
+	switch p.annotationModal.HandleMouse(msg, p.mouseHandler) {
+	case annotationSaveID:
+		return p.saveAnnotation()
+	case annotationCancelID, "cancel":
+		p.closeAnnotationModal()
+	}
+	return nil
+}
+
+// saveAnnotation stores the edited annotation and closes the modal.
+func (p *Plugin) saveAnnotation() tea.Cmd {
+	if p.annotationTarget == nil {
+		p.closeAnnotationModal()
+		return nil
+	}
+	b := *p.annotationTarget
+	b.Annotation = strings.TrimSpace(p.annotationInput.Value())
+	err := p.bookmarks.Put(b)
+	p.closeAnnotationModal()
+	if err != nil {
+		return bookmarkErrorToast(err)
+	}
+	if b.Annotation == "" {
+		return appmsg.ShowToast("Annotation cleared", 2*time.Second)
+	}
+	return appmsg.ShowToast("Annotation saved", 2*time.Second)
+}
+
+// closeAnnotationModal closes the annotation editor, returning to the
+// bookmarks browser when it was opened from there.
+func (p *Plugin) closeAnnotationModal() {
+	p.showAnnotationModal = false
+	p.annotationModal = nil
+	p.annotationTarget = nil
+	if p.annotationFromBrowser {
+		p.annotationFromBrowser = false
+		p.refreshBookmarksModal()
+		p.showBookmarksModal = true
+	}
+}
+
+// openBookmarksModal opens the browser over bookmarks from all sessions.
+func (p *Plugin) openBookmarksModal() (plugin.Plugin, tea.Cmd) {
+	p.bookmarksModalState = &bookmarksModalState{}
+	p.refreshBookmarksModal()
+	p.showBookmarksModal = true
+	return p, nil
+}
+
+// refreshBookmarksModal reloads the browser's list, keeping the cursor in range.
+func (p *Plugin) refreshBookmarksModal() {
+	if p.bookmarksModalState == nil {
+		p.bookmarksModalState = &bookmarksModalState{}
+	}
+	state := p.bookmarksModalState
+	state.bookmarks = p.bookmarks.All()
+	if state.cursor >= len(state.bookmarks) {
+		state.cursor = len(state.bookmarks) - 1
+	}
+	if state.cursor < 0 {
+		state.cursor = 0
+	}
+}
+
+// handleBookmarksModalKey handles key events when the bookmarks browser is open.
+func (p *Plugin) handleBookmarksModalKey(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	state := p.bookmarksModalState
+	if state == nil {
+		p.showBookmarksModal = false
+		return p, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q", "B":
+		p.showBookmarksModal = false
+		p.bookmarksModalState = nil
+
+	case "j", "down":
+		if state.cursor < len(state.bookmarks)-1 {
+			state.cursor++
+		}
+
+	case "k", "up":
+		if state.cursor > 0 {
+			state.cursor--
+		}
+
+	case "g":
+		state.cursor = 0
+
+	case "G":
+		if len(state.bookmarks) > 0 {
+			state.cursor = len(state.bookmarks) - 1
+		}
+
+	case "enter":
+		// Jump to the bookmarked message
+		if state.cursor < len(state.bookmarks) {
+			b := state.bookmarks[state.cursor]
+			p.showBookmarksModal = false
+			p.bookmarksModalState = nil
+			p.hitRegionsDirty = true
+			return p, p.openConversation(b.AdapterID, b.SessionID, b.MessageID)
+		}
+
+	case "a":
+		// Edit the annotation, returning to the browser afterwards
+		if state.cursor < len(state.bookmarks) {
+			p.showBookmarksModal = false
+			return p, p.openAnnotationModal(state.bookmarks[state.cursor], true)
+		}
+
+	case "d", "x":
+		if state.cursor < len(state.bookmarks) {
+			b := state.bookmarks[state.cursor]
+			if err := p.bookmarks.Remove(b.AdapterID, b.SessionID, b.MessageID); err != nil {
+				return p, bookmarkErrorToast(err)
+			}
+			p.refreshBookmarksModal()
+		}
+	}
+
+	return p, nil
+}
+
+// renderAnnotationModal renders the annotation editor over the two-pane view.
+func (p *Plugin) renderAnnotationModal(width, height int) string {
+	p.ensureAnnotationModal()
+	background := p.renderTwoPane()
+	if p.annotationModal == nil {
+		return background
+	}
+	rendered := p.annotationModal.Render(width, height, p.mouseHandler)
+	return ui.OverlayModal(background, rendered, width, height)
+}
+
+// renderBookmarksModal renders the bookmarks browser as an overlay string.
+func (p *Plugin) renderBookmarksModal(width, height int) string {
+	state := p.bookmarksModalState
+	if state == nil {
+		return ""
+	}
+
+	modalWidth := min(max(width-8, 50), 100)
+	effectiveHeight := max(height-4, 10)
+
+	title := fmt.Sprintf("Bookmarks (%d)", len(state.bookmarks))
+	if len(state.bookmarks) == 0 {
+		m := modal.New("Bookmarks",
+			modal.WithWidth(modalWidth),
+			modal.WithHints(false),
+		).
+			AddSection(modal.Text("No bookmarks yet.")).
+			AddSection(modal.Spacer()).
+			AddSection(modal.Text(styles.Muted.Render("Press b on a message to bookmark it, or a to annotate it."))).
+			AddSection(modal.Spacer()).
+			AddSection(modal.Buttons(
+				modal.Btn("Close", "cancel"),
+			))
+		return m.Render(width, effectiveHeight, nil)
+	}
+
+	m := modal.New(title,
+		modal.WithWidth(modalWidth),
+		modal.WithHints(false),
+		modal.WithCustomFooter(" enter:open  a:annotate  d:delete  esc:close"),
+	).
+		AddSection(p.bookmarkListSection(state, effectiveHeight-12, modalWidth-6)).
+		AddSection(modal.Spacer()).
+		AddSection(bookmarkDetailSection(state, modalWidth-6))
+
+	return m.Render(width, effectiveHeight, nil)
+}
+
+// bookmarkListSection renders the scrollable bookmark list.
+func (p *Plugin) bookmarkListSection(state *bookmarksModalState, maxHeight, contentWidth int) modal.Section {
+	return modal.Custom(
+		func(cw int, focusID, hoverID string) modal.RenderedSection {
+			visibleCount := min(max(maxHeight, 3), len(state.bookmarks))
+
+			scrollOff := 0
+			if state.cursor >= visibleCount {
+				scrollOff = state.cursor - visibleCount + 1
+			}
+
+			var sb strings.Builder
+			for i := 0; i < visibleCount; i++ {
+				idx := scrollOff + i
+				if idx >= len(state.bookmarks) {
+					break
+				}
+				b := state.bookmarks[idx]
+
+				marker := bookmarkMarker
+				if b.Annotation != "" {
+					marker = annotationMarker
+				}
+				session := truncateStr(b.SessionName, 24)
+				if session == "" {
+					session = shortID(b.SessionID)
+				}
+				text := b.Annotation
+				if text == "" {
+					text = b.Preview
+				}
+				maxTextW := max(contentWidth-lipgloss.Width(session)-8, 10)
+				line := fmt.Sprintf("%s %s  %s", marker, session, truncateStr(firstLine(text), maxTextW))
+
+				if idx == state.cursor {
+					line = styles.ListItemFocused.Render("▸ " + line)
+				} else if !p.hasSession(b.SessionID) {
+					// Sessions outside the current project can't be opened from here
+					line = styles.Muted.Render("  " + line)
+				} else {
+					line = "  " + line
+				}
+
+				if i > 0 {
+					sb.WriteString("\n")
+				}
+				sb.WriteString(line)
+			}
+
+			content := sb.String()
+			if scrollOff > 0 {
+				content = styles.Muted.Render("↑ more above") + "\n" + content
+			}
+			if scrollOff+visibleCount < len(state.bookmarks) {
+				content = content + "\n" + styles.Muted.Render("↓ more below")
+			}
+			return modal.RenderedSection{Content: content}
+		},
+		nil,
+	)
+}
+
+// bookmarkDetailSection shows the highlighted bookmark's message and annotation.
+func bookmarkDetailSection(state *bookmarksModalState, contentWidth int) modal.Section {
+	return modal.Custom(
+		func(cw int, focusID, hoverID string) modal.RenderedSection {
+			if state.cursor >= len(state.bookmarks) {
+				return modal.RenderedSection{}
+			}
+			b := state.bookmarks[state.cursor]
+
+			headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.TextSecondary)
+			header := fmt.Sprintf("%s · %s · %s", b.AdapterID, b.Role, b.MessageTime.Local().Format("2006-01-02 15:04"))
+			lines := []string{headerStyle.Render(header)}
+
+			preview := strings.Split(wrapToWidth(b.Preview, contentWidth), "\n")
+			if len(preview) > 3 {
+				preview = append(preview[:3], "...")
+			}
+			for _, l := range preview {
+				lines = append(lines, styles.Muted.Render(l))
+			}
+			if b.Annotation != "" {
+				lines = append(lines, "")
+				lines = append(lines, strings.Split(wrapToWidth(annotationMarker+" "+b.Annotation, contentWidth), "\n")...)
+			}
+			return modal.RenderedSection{Content: strings.Join(lines, "\n")}
+		},
+		nil,
+	)
+}
+
+// hasSession reports whether sessionID is in the loaded session list.
+func (p *Plugin) hasSession(sessionID string) bool {
+	for i := range p.sessions {
+		if p.sessions[i].ID == sessionID {
+			return true
+		}
+	}
+	return false
+}
+
+// bookmarkErrorToast reports a failed bookmark save.
+func bookmarkErrorToast(err error) tea.Cmd {
+	return func() tea.Msg {
+		return app.ToastMsg{Message: "Bookmark save failed: " + err.Error(), Duration: 2 * time.Second, IsError: true}
+	}
+}
@@ -0,0 +1,302 @@
+package conversations
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/styles"
+)
+
+const (
+	bookmarksFileName = "bookmarks.json"
+	bookmarksVersion  = 1
+
+	bookmarkMarker   = "⚑"
+	annotationMarker = "✎"
+
+	// bookmarkPreviewLen caps the message excerpt stored with a bookmark.
+	bookmarkPreviewLen = 200
+)
+
+// bookmarkStyle renders bookmark markers and annotations in the transcript.
+var bookmarkStyle = lipgloss.NewStyle().Foreground(styles.Warning)
+
+// Bookmark marks a message in a session, with an optional free-text
+// annotation. Bookmarks are keyed by adapter, session and message ID so
+// they survive reloads and work across projects.
+type Bookmark struct {
+	AdapterID   string    `json:"adapterId"`
+	SessionID   string    `json:"sessionId"`
+	MessageID   string    `json:"messageId"`
+	SessionName string    `json:"sessionName,omitempty"`
+	Role        string    `json:"role,omitempty"`
+	MessageTime time.Time `json:"messageTime"`
+	Preview     string    `json:"preview,omitempty"` // Message excerpt at bookmark time
+	Annotation  string    `json:"annotation,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// key returns the store key for the bookmark.
+func (b Bookmark) key() string {
+	return bookmarkKey(b.AdapterID, b.SessionID, b.MessageID)
+}
+
+func bookmarkKey(adapterID, sessionID, messageID string) string {
+	return adapterID + "\x00" + sessionID + "\x00" + messageID
+}
+
+// BookmarkStore persists bookmarks to a JSON file, by default
+// ~/.config/hermes/bookmarks.json.
+type BookmarkStore struct {
+	path string
+
+	mu    sync.RWMutex
+	items map[string]Bookmark
+}
+
+// bookmarkFile is the on-disk format.
+type bookmarkFile struct {
+	Version   int        `json:"version"`
+	Bookmarks []Bookmark `json:"bookmarks"`
+}
+
+// LoadBookmarkStore reads bookmarks from path. A missing or corrupt file
+// yields an empty store rather than an error, so bookmarking never blocks
+// the plugin from starting.
+func LoadBookmarkStore(path string) *BookmarkStore {
+	s := &BookmarkStore{path: path, items: make(map[string]Bookmark)}
+	if path == "" {
+		return s
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Warn("bookmarks: read failed", "err", err)
+		}
+		return s
+	}
+
+	var f bookmarkFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		slog.Warn("bookmarks: parse failed, starting empty", "err", err)
+		return s
+	}
+	for _, b := range f.Bookmarks {
+		if b.SessionID == "" || b.MessageID == "" {
+			continue
+		}
+		s.items[b.key()] = b
+	}
+	return s
+}
+
+// defaultBookmarksPath returns the bookmarks file under configDir, falling
+// back to ~/.config/hermes.
+func defaultBookmarksPath(configDir string) string {
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		configDir = filepath.Join(home, ".config", "hermes")
+	}
+	return filepath.Join(configDir, bookmarksFileName)
+}
+
+// Get returns the bookmark for a message, if any.
+func (s *BookmarkStore) Get(adapterID, sessionID, messageID string) (Bookmark, bool) {
+	if s == nil {
+		return Bookmark{}, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b, ok := s.items[bookmarkKey(adapterID, sessionID, messageID)]
+	return b, ok
+}
+
+// Put adds or replaces a bookmark and saves the store. CreatedAt is kept
+// from any existing entry.
+func (s *BookmarkStore) Put(b Bookmark) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if old, ok := s.items[b.key()]; ok {
+		b.CreatedAt = old.CreatedAt
+	}
+	if b.CreatedAt.IsZero() {
+		b.CreatedAt = now
+	}
+	b.UpdatedAt = now
+	s.items[b.key()] = b
+	return s.saveLocked()
+}
+
+// Remove deletes a bookmark and saves the store.
+func (s *BookmarkStore) Remove(adapterID, sessionID, messageID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := bookmarkKey(adapterID, sessionID, messageID)
+	if _, ok := s.items[key]; !ok {
+		return nil
+	}
+	delete(s.items, key)
+	return s.saveLocked()
+}
+
+// All returns every bookmark, most recently updated first.
+func (s *BookmarkStore) All() []Bookmark {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make([]Bookmark, 0, len(s.items))
+	for _, b := range s.items {
+		out = append(out, b)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].UpdatedAt.Equal(out[j].UpdatedAt) {
+			return out[i].UpdatedAt.After(out[j].UpdatedAt)
+		}
+		return out[i].key() < out[j].key()
+	})
+	return out
+}
+
+// ForSession returns a session's bookmarks keyed by message ID.
+func (s *BookmarkStore) ForSession(adapterID, sessionID string) map[string]Bookmark {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var out map[string]Bookmark
+	for _, b := range s.items {
+		if b.AdapterID != adapterID || b.SessionID != sessionID {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]Bookmark)
+		}
+		out[b.MessageID] = b
+	}
+	return out
+}
+
+// saveLocked writes the store atomically. Caller must hold s.mu.
+func (s *BookmarkStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	f := bookmarkFile{Version: bookmarksVersion, Bookmarks: make([]Bookmark, 0, len(s.items))}
+	for _, b := range s.items {
+		f.Bookmarks = append(f.Bookmarks, b)
+	}
+	sort.Slice(f.Bookmarks, func(i, j int) bool {
+		return f.Bookmarks[i].CreatedAt.Before(f.Bookmarks[j].CreatedAt)
+	})
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Atomic write: temp file + rename
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+// newBookmark builds a bookmark for msg in session.
+func newBookmark(session *adapter.Session, msg *adapter.Message) Bookmark {
+	b := Bookmark{
+		MessageID:   msg.ID,
+		Role:        msg.Role,
+		MessageTime: msg.Timestamp,
+		Preview:     bookmarkPreview(msg),
+	}
+	if session != nil {
+		b.AdapterID = session.AdapterID
+		b.SessionID = session.ID
+		b.SessionName = session.Name
+		if b.SessionName == "" {
+			b.SessionName = session.Slug
+		}
+	}
+	return b
+}
+
+// bookmarkPreview returns a single-line excerpt of the message text.
+func bookmarkPreview(msg *adapter.Message) string {
+	text := msg.Content
+	if text == "" {
+		for _, cb := range msg.ContentBlocks {
+			if cb.Type == "text" && cb.Text != "" {
+				text = cb.Text
+				break
+			}
+		}
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	return truncateStr(text, bookmarkPreviewLen)
+}
+
+// annotationMatches searches bookmark annotations for re and returns one
+// MessageMatch per matching annotation, with BlockType "annotation".
+func annotationMatches(bookmarks []Bookmark, re *regexp.Regexp) []adapter.MessageMatch {
+	var out []adapter.MessageMatch
+	for _, b := range bookmarks {
+		matches := adapter.SearchContent(b.Annotation, "annotation", re)
+		if len(matches) == 0 {
+			continue
+		}
+		out = append(out, adapter.MessageMatch{
+			MessageID:  b.MessageID,
+			MessageIdx: -1, // Position unknown until the session loads
+			Role:       b.Role,
+			Timestamp:  b.MessageTime,
+			Matches:    matches,
+		})
+	}
+	return out
+}
+
+// mergeAnnotationMatches folds annotation matches into adapter matches,
+// appending to the message's entry when the adapter also matched it.
+func mergeAnnotationMatches(matches, notes []adapter.MessageMatch) []adapter.MessageMatch {
+	for _, n := range notes {
+		merged := false
+		for i := range matches {
+			if matches[i].MessageID == n.MessageID {
+				matches[i].Matches = append(matches[i].Matches, n.Matches...)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			matches = append(matches, n)
+		}
+	}
+	return matches
+}
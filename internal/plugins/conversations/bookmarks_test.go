@@ -0,0 +1,212 @@
+package conversations
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+func TestBookmarkStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hermes", bookmarksFileName)
+	s := LoadBookmarkStore(path)
+
+	if err := s.Put(Bookmark{AdapterID: "claude-code", SessionID: "s1", MessageID: "m1", Preview: "first"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(Bookmark{AdapterID: "claude-code", SessionID: "s1", MessageID: "m2"}); err != nil {
+		t.Fatal(err)
+	}
+	// Same message ID in another adapter is a separate bookmark
+	if err := s.Put(Bookmark{AdapterID: "codex", SessionID: "s1", MessageID: "m1"}); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := s.Get("claude-code", "s1", "m1")
+	if err := s.Put(Bookmark{AdapterID: "claude-code", SessionID: "s1", MessageID: "m1", Annotation: "good plan"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := LoadBookmarkStore(path)
+	all := reloaded.All()
+	if len(all) != 3 {
+		t.Fatalf("reloaded %d bookmarks, want 3", len(all))
+	}
+	if all[0].MessageID != "m1" || all[0].AdapterID != "claude-code" || all[0].Annotation != "good plan" {
+		t.Errorf("most recent = %+v", all[0])
+	}
+	if !all[0].CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("update changed CreatedAt")
+	}
+	if got := reloaded.ForSession("claude-code", "s1"); len(got) != 2 {
+		t.Errorf("ForSession = %d bookmarks, want 2", len(got))
+	}
+
+	if err := reloaded.Remove("claude-code", "s1", "m2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := LoadBookmarkStore(path).Get("claude-code", "s1", "m2"); ok {
+		t.Error("removed bookmark still on disk")
+	}
+}
+
+func bookmarkTestPlugin() *Plugin {
+	p := New()
+	p.sessions = []adapter.Session{{ID: "s1", Name: "Refactor", AdapterID: "mock"}}
+	p.selectedSession = "s1"
+	p.messages = []adapter.Message{
+		{ID: "m1", Role: "user", Content: "Please refactor the cache"},
+		{ID: "m2", Role: "assistant", Content: "Done.\nThe key now includes mtime."},
+	}
+	p.messageCursor = 1
+	return p
+}
+
+func TestToggleBookmarkAndAnnotate(t *testing.T) {
+	p := bookmarkTestPlugin()
+
+	_, _ = p.updateMessages(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	b, ok := p.bookmarks.Get("mock", "s1", "m2")
+	if !ok || b.SessionName != "Refactor" || b.Preview != "Done. The key now includes mtime." {
+		t.Fatalf("bookmark = %+v, ok = %v", b, ok)
+	}
+	_, _ = p.updateMessages(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if _, ok := p.bookmarks.Get("mock", "s1", "m2"); ok {
+		t.Fatal("second b should remove the bookmark")
+	}
+
+	// Annotating bookmarks the message
+	p.messageCursor = 0
+	_, _ = p.updateMessages(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if !p.showAnnotationModal || p.FocusContext() != "conversations-annotate" {
+		t.Fatal("annotation modal not open")
+	}
+	p.annotationInput.SetValue("  bad: skipped the tests ")
+	_ = p.View(100, 40) // Rendering registers the input's focus
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.showAnnotationModal {
+		t.Error("enter should close the annotation modal")
+	}
+	b, ok = p.bookmarks.Get("mock", "s1", "m1")
+	if !ok || b.Annotation != "bad: skipped the tests" {
+		t.Errorf("annotated bookmark = %+v, ok = %v", b, ok)
+	}
+
+	lines := p.renderMessageBubble(p.messages[0], 0, 80)
+	joined := strings.Join(lines, "\n")
+	if !strings.Contains(joined, bookmarkMarker) || !strings.Contains(joined, "bad: skipped the tests") {
+		t.Errorf("bubble missing bookmark marker or annotation:\n%s", joined)
+	}
+}
+
+func TestBookmarksBrowser(t *testing.T) {
+	p := bookmarkTestPlugin()
+	_ = p.bookmarks.Put(newBookmark(&p.sessions[0], &p.messages[0]))
+	_ = p.bookmarks.Put(newBookmark(&p.sessions[0], &p.messages[1]))
+	p.selectedSession = ""
+
+	_, _ = p.updateSessions(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("B")})
+	if !p.showBookmarksModal || len(p.bookmarksModalState.bookmarks) != 2 {
+		t.Fatalf("browser state = %+v", p.bookmarksModalState)
+	}
+
+	// Annotating from the browser returns to it
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if !p.showAnnotationModal {
+		t.Fatal("a should open the annotation editor")
+	}
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !p.showBookmarksModal {
+		t.Fatal("closing the editor should return to the browser")
+	}
+
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if len(p.bookmarksModalState.bookmarks) != 1 || len(p.bookmarks.All()) != 1 {
+		t.Fatalf("d should delete: %d left", len(p.bookmarks.All()))
+	}
+
+	// Enter jumps to the bookmarked message
+	target := p.bookmarksModalState.bookmarks[0]
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.showBookmarksModal || p.selectedSession != "s1" || p.pendingScrollMsgID != target.MessageID {
+		t.Errorf("jump: modal=%v session=%q scroll=%q", p.showBookmarksModal, p.selectedSession, p.pendingScrollMsgID)
+	}
+}
+
+func TestAnnotationTakesAppShortcutKeys(t *testing.T) {
+	p := New()
+	m := routedApp(t, p)
+	p.sessions = []adapter.Session{{ID: "s1", Name: "Refactor", AdapterID: "mock"}}
+	p.selectedSession = "s1"
+	p.messages = []adapter.Message{{ID: "m1", Role: "assistant", Content: "Done."}}
+	p.activePane = PaneMessages
+	_ = p.openAnnotationModal(newBookmark(&p.sessions[0], &p.messages[0]), false)
+
+	// The modal finds its focusables on the first render and focuses on the next
+	_ = p.View(100, 40)
+	_ = p.View(100, 40)
+	sendKeys(m, "1", "W", "#", "?", "@", "`", "i")
+	if !p.showAnnotationModal || p.annotationInput.Value() != "1W#?@`i" {
+		t.Errorf("annotation = %q, want the typed keys", p.annotationInput.Value())
+	}
+}
+
+func TestContentSearchMatchesAnnotations(t *testing.T) {
+	sessions := []adapter.Session{
+		{ID: "s1", AdapterID: "mock", MessageCount: 2},
+		{ID: "s2", AdapterID: "plain", MessageCount: 1},
+	}
+	adapters := map[string]adapter.Adapter{
+		"mock": &mockSearchAdapter{id: "mock", results: map[string][]adapter.MessageMatch{
+			"s1": {{MessageID: "m1", Matches: []adapter.ContentMatch{{LineText: "flaky test"}}}},
+		}},
+		// Adapters without MessageSearcher still match on annotations
+		"plain": &mockNonSearchAdapter{id: "plain"},
+	}
+	bookmarks := []Bookmark{
+		{AdapterID: "mock", SessionID: "s1", MessageID: "m1", Annotation: "Flaky retry loop"},
+		{AdapterID: "mock", SessionID: "s1", MessageID: "m9", Annotation: "nothing here"},
+		{AdapterID: "plain", SessionID: "s2", MessageID: "p1", Annotation: "good: wrote a flaky-test guard"},
+	}
+
+	msg := RunContentSearch("flaky", sessions, adapters, bookmarks, adapter.SearchOptions{}, 0)().(ContentSearchResultsMsg)
+	if len(msg.Results) != 2 {
+		t.Fatalf("results = %d sessions, want 2", len(msg.Results))
+	}
+	for _, r := range msg.Results {
+		switch r.Session.ID {
+		case "s1":
+			if len(r.Messages) != 1 || len(r.Messages[0].Matches) != 2 || r.Messages[0].Matches[1].BlockType != "annotation" {
+				t.Errorf("s1 matches = %+v", r.Messages)
+			}
+		case "s2":
+			if len(r.Messages) != 1 || r.Messages[0].MessageID != "p1" {
+				t.Errorf("s2 matches = %+v", r.Messages)
+			}
+		}
+	}
+}
+
+func TestExportIncludesAnnotations(t *testing.T) {
+	messages := []adapter.Message{
+		{ID: "m1", Role: "user", Content: "hi"},
+		{ID: "m2", Role: "assistant", Content: "hello"},
+		{ID: "m3", Role: "assistant", Content: "bye"},
+	}
+	md := ExportSessionAsMarkdown(nil, messages, map[string]Bookmark{
+		"m2": {MessageID: "m2", Annotation: "good tone\nkeep as example"},
+		"m3": {MessageID: "m3"},
+	})
+	for _, want := range []string{
+		"> " + bookmarkMarker + " **Note:** good tone\n> " + bookmarkMarker + " **Note:** keep as example",
+		"> " + bookmarkMarker + " **Bookmarked**",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("export missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Count(md, bookmarkMarker) != 3 {
+		t.Errorf("unbookmarked message was flagged:\n%s", md)
+	}
+}
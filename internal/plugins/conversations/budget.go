@@ -0,0 +1,316 @@
+package conversations
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/config"
+)
+
+// budgetBadgeID identifies the budget badge in the app header.
+const budgetBadgeID = "budget"
+
+// budgetLevel is how close a budget is to its cap.
+type budgetLevel int
+
+const (
+	budgetOK budgetLevel = iota
+	budgetWarn
+	budgetOver
+)
+
+// budgetStatus is a budget's spend in its current period.
+type budgetStatus struct {
+	Budget   config.BudgetConfig
+	Label    string
+	Start    time.Time // Period start
+	End      time.Time // Next period start
+	Cost     float64
+	Tokens   int
+	Sessions int // Sessions active during the period
+
+	Fraction  float64 // Spend over cap, highest of cost and tokens
+	Projected float64 // Fraction projected at the end of the period
+	Level     budgetLevel
+}
+
+// key identifies the budget within its current period, so alerts fire
+// once per period.
+func (s *budgetStatus) key() string {
+	return s.Label + "@" + s.Start.Format(time.RFC3339)
+}
+
+// burnPerDay returns cost and tokens per day so far this period.
+func (s *budgetStatus) burnPerDay(now time.Time) (float64, int) {
+	days := budgetElapsed(s.Start, now).Hours() / 24
+	return s.Cost / days, int(float64(s.Tokens) / days)
+}
+
+// projected returns cost and tokens extrapolated to the end of the period
+// at the current burn rate.
+func (s *budgetStatus) projected(now time.Time) (float64, int) {
+	scale := float64(s.End.Sub(s.Start)) / float64(budgetElapsed(s.Start, now))
+	scale = max(scale, 1)
+	return s.Cost * scale, int(float64(s.Tokens) * scale)
+}
+
+// budgetElapsed returns the time since the period started, at least an
+// hour so projections early in a period aren't wildly inflated.
+func budgetElapsed(start, now time.Time) time.Duration {
+	return max(now.Sub(start), time.Hour)
+}
+
+// budgetPeriod returns the local calendar period containing now.
+func budgetPeriod(period string, now time.Time) (start, end time.Time, ok bool) {
+	y, m, d := now.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	switch period {
+	case config.BudgetDaily:
+		return day, day.AddDate(0, 0, 1), true
+	case config.BudgetWeekly:
+		// Weeks start on Monday
+		offset := (int(day.Weekday()) + 6) % 7
+		start = day.AddDate(0, 0, -offset)
+		return start, start.AddDate(0, 0, 7), true
+	case config.BudgetMonthly:
+		start = time.Date(y, m, 1, 0, 0, 0, 0, now.Location())
+		return start, start.AddDate(0, 1, 0), true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// budgetLabel names a budget: its configured name, or its period and scope.
+func budgetLabel(b config.BudgetConfig) string {
+	if b.Name != "" {
+		return b.Name
+	}
+	parts := []string{b.Period}
+	if b.Project != "" {
+		parts = append(parts, filepath.Base(b.Project))
+	}
+	if b.Adapter != "" {
+		parts = append(parts, b.Adapter)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// budgetError returns why a budget can't be evaluated, or "" if it can.
+func budgetError(b config.BudgetConfig) string {
+	if _, _, ok := budgetPeriod(b.Period, time.Now()); !ok {
+		return fmt.Sprintf("unknown period %q", b.Period)
+	}
+	if b.MaxCost <= 0 && b.MaxTokens <= 0 {
+		return "no maxCost or maxTokens"
+	}
+	return ""
+}
+
+// budgetAppliesTo reports whether a budget covers the project at root.
+// Budgets without a project cover every project.
+func budgetAppliesTo(b config.BudgetConfig, roots ...string) bool {
+	if b.Project == "" {
+		return true
+	}
+	project := filepath.Clean(b.Project)
+	for _, root := range roots {
+		if root == "" {
+			continue
+		}
+		if abs, err := filepath.Abs(root); err == nil {
+			root = abs
+		}
+		if filepath.Clean(root) == project {
+			return true
+		}
+	}
+	return false
+}
+
+// sessionShare returns the fraction of a session's lifetime that falls in
+// [start, end). Spend is assumed to be spread evenly over the session, so a
+// session spanning two periods counts against each in proportion.
+func sessionShare(s *adapter.Session, start, end time.Time) float64 {
+	from, to := s.CreatedAt, s.UpdatedAt
+	if from.IsZero() || from.After(to) {
+		from = to
+	}
+	if !to.After(from) {
+		if !to.Before(start) && to.Before(end) {
+			return 1
+		}
+		return 0
+	}
+	lo, hi := from, to
+	if start.After(lo) {
+		lo = start
+	}
+	if end.Before(hi) {
+		hi = end
+	}
+	if !hi.After(lo) {
+		return 0
+	}
+	return float64(hi.Sub(lo)) / float64(to.Sub(from))
+}
+
+// evaluateBudgets totals each budget's spend over sessions in its current
+// period. Budgets that can't be evaluated are skipped.
+func evaluateBudgets(budgets []config.BudgetConfig, sessions []adapter.Session, now time.Time) []budgetStatus {
+	statuses := make([]budgetStatus, 0, len(budgets))
+	for _, b := range budgets {
+		start, end, ok := budgetPeriod(b.Period, now)
+		if !ok || budgetError(b) != "" {
+			continue
+		}
+		st := budgetStatus{Budget: b, Label: budgetLabel(b), Start: start, End: end}
+		for i := range sessions {
+			s := &sessions[i]
+			if b.Adapter != "" && s.AdapterID != b.Adapter {
+				continue
+			}
+			share := sessionShare(s, start, end)
+			if share == 0 {
+				continue
+			}
+			st.Sessions++
+			st.Cost += s.EstCost * share
+			st.Tokens += int(float64(s.TotalTokens) * share)
+		}
+
+		cost, tokens := st.projected(now)
+		st.Fraction = budgetFraction(b, st.Cost, st.Tokens)
+		st.Projected = budgetFraction(b, cost, tokens)
+		switch {
+		case st.Fraction >= 1:
+			st.Level = budgetOver
+		case st.Fraction >= budgetWarnAt(b):
+			st.Level = budgetWarn
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses
+}
+
+// budgetWarnAt returns the fraction at which a budget warns.
+func budgetWarnAt(b config.BudgetConfig) float64 {
+	if b.WarnAt <= 0 || b.WarnAt > 1 {
+		return config.DefaultBudgetWarnAt
+	}
+	return b.WarnAt
+}
+
+// budgetFraction returns the larger of cost and tokens as a fraction of
+// their caps.
+func budgetFraction(b config.BudgetConfig, cost float64, tokens int) float64 {
+	var f float64
+	if b.MaxCost > 0 {
+		f = cost / b.MaxCost
+	}
+	if b.MaxTokens > 0 {
+		f = max(f, float64(tokens)/float64(b.MaxTokens))
+	}
+	return f
+}
+
+// budgetAlert returns the toast for a budget that just reached its level.
+func budgetAlert(s *budgetStatus) string {
+	b := s.Budget
+	// Report whichever cap is closer to being reached
+	spent := fmt.Sprintf("%s of %s tokens", formatK(s.Tokens), formatK(b.MaxTokens))
+	if b.MaxCost > 0 && budgetFraction(config.BudgetConfig{MaxCost: b.MaxCost}, s.Cost, 0) >= s.Fraction {
+		spent = fmt.Sprintf("%s of $%.2f", formatCost(s.Cost), b.MaxCost)
+	}
+	if s.Level == budgetOver {
+		return fmt.Sprintf("Budget %q exceeded: %s (%s)", s.Label, spent, b.Period)
+	}
+	return fmt.Sprintf("Budget %q at %d%%: %s (%s)", s.Label, int(s.Fraction*100), spent, b.Period)
+}
+
+// budgetBadge returns the header badge for the worst budget, or an empty
+// badge (which clears it) when every budget is within its warning level.
+func budgetBadge(statuses []budgetStatus) app.HeaderBadgeMsg {
+	badge := app.HeaderBadgeMsg{ID: budgetBadgeID}
+	var alerting []*budgetStatus
+	for i := range statuses {
+		if statuses[i].Level != budgetOK {
+			alerting = append(alerting, &statuses[i])
+		}
+	}
+	if len(alerting) == 0 {
+		return badge
+	}
+	sort.SliceStable(alerting, func(i, j int) bool {
+		return alerting[i].Fraction > alerting[j].Fraction
+	})
+	worst := alerting[0]
+	badge.Text = fmt.Sprintf("$ %s %d%%", worst.Label, int(worst.Fraction*100))
+	if len(alerting) > 1 {
+		badge.Text += fmt.Sprintf(" +%d", len(alerting)-1)
+	}
+	badge.IsError = worst.Level == budgetOver
+	return badge
+}
+
+// activeBudgets returns the configured budgets covering the open project.
+func (p *Plugin) activeBudgets() []config.BudgetConfig {
+	if p.ctx == nil {
+		return nil
+	}
+	var out []config.BudgetConfig
+	for _, b := range p.budgets {
+		if budgetAppliesTo(b, p.ctx.ProjectRoot, p.ctx.WorkDir) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// checkBudgets re-evaluates budgets against the loaded sessions. It toasts
+// budgets that crossed into a higher level since the last check and keeps
+// the header badge in sync.
+func (p *Plugin) checkBudgets() tea.Cmd {
+	statuses := evaluateBudgets(p.activeBudgets(), p.sessions, time.Now())
+
+	// Budgets without a project follow whichever project is open, so
+	// alerts are tracked per project
+	root := ""
+	if p.ctx != nil {
+		root = p.ctx.WorkDir
+	}
+
+	var cmds []tea.Cmd
+	var raised []*budgetStatus
+	for i := range statuses {
+		s := &statuses[i]
+		key := root + "|" + s.key()
+		if s.Level > p.budgetAlerted[key] {
+			raised = append(raised, s)
+		}
+		p.budgetAlerted[key] = s.Level
+	}
+	if len(raised) > 0 {
+		sort.SliceStable(raised, func(i, j int) bool {
+			return raised[i].Fraction > raised[j].Fraction
+		})
+		worst := raised[0]
+		text := budgetAlert(worst)
+		if len(raised) > 1 {
+			text += fmt.Sprintf(" (+%d more)", len(raised)-1)
+		}
+		toast := app.ToastMsg{Message: text, Duration: 5 * time.Second, IsError: worst.Level == budgetOver}
+		cmds = append(cmds, func() tea.Msg { return toast })
+	}
+
+	badge := budgetBadge(statuses)
+	if badge != p.budgetBadge {
+		p.budgetBadge = badge
+		cmds = append(cmds, func() tea.Msg { return badge })
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,127 @@
+package conversations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/toddwbucy/hermes/internal/modal"
+	"github.com/toddwbucy/hermes/internal/plugin"
+	"github.com/toddwbucy/hermes/internal/styles"
+)
+
+// openBudgetModal opens the budget panel.
+func (p *Plugin) openBudgetModal() (plugin.Plugin, tea.Cmd) {
+	p.showBudgetModal = true
+	return p, nil
+}
+
+// handleBudgetModalKey handles key events when the budget panel is open.
+func (p *Plugin) handleBudgetModalKey(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "$":
+		p.showBudgetModal = false
+	}
+	return p, nil
+}
+
+// renderBudgetModal renders the budget panel as an overlay string. Spend
+// is recomputed from the loaded sessions on each render.
+func (p *Plugin) renderBudgetModal(width, height int) string {
+	modalWidth := min(max(width-8, 60), 90)
+	contentWidth := modalWidth - 6
+	now := time.Now()
+	statuses := evaluateBudgets(p.activeBudgets(), p.sessions, now)
+
+	m := modal.New("Budgets",
+		modal.WithWidth(modalWidth),
+		modal.WithHints(false),
+		modal.WithCustomFooter(" esc:close"),
+	)
+
+	if len(statuses) == 0 {
+		text := "No budgets cover this project."
+		if len(p.budgets) > 0 {
+			text = fmt.Sprintf("None of the %d configured budget%s cover this project.", len(p.budgets), plural(len(p.budgets)))
+		}
+		m.AddSection(modal.Text(styles.Muted.Render(text + "\nAdd budgets under plugins.conversations.budgets in config.json.")))
+		return m.Render(width, max(height-4, 10), nil)
+	}
+
+	for i := range statuses {
+		if i > 0 {
+			m.AddSection(modal.Spacer())
+		}
+		m.AddSection(modal.Text(renderBudgetStatus(&statuses[i], contentWidth, now)))
+	}
+	return m.Render(width, max(height-4, 10), nil)
+}
+
+// renderBudgetStatus renders one budget: its spend against each cap with a
+// progress bar, the burn rate and the projected total for the period.
+func renderBudgetStatus(s *budgetStatus, width int, now time.Time) string {
+	b := s.Budget
+	var status string
+	switch s.Level {
+	case budgetOver:
+		status = styles.StatusDeleted.Render("OVER")
+	case budgetWarn:
+		status = styles.StatusModified.Render("WARN")
+	default:
+		status = styles.StatusStaged.Render("OK")
+	}
+	title := lipgloss.NewStyle().Bold(true).Render(s.Label)
+	pad := max(width-lipgloss.Width(title)-lipgloss.Width(status), 1)
+	lines := []string{title + strings.Repeat(" ", pad) + status}
+
+	barWidth := max(width-30, 10)
+	burnCost, burnTokens := s.burnPerDay(now)
+	projCost, projTokens := s.projected(now)
+	if b.MaxCost > 0 {
+		f := s.Cost / b.MaxCost
+		lines = append(lines, fmt.Sprintf("  %-22s %s %4d%%",
+			fmt.Sprintf("%s / $%.2f", formatCost(s.Cost), b.MaxCost),
+			renderBudgetBar(f, budgetWarnAt(b), barWidth), int(f*100)))
+	}
+	if b.MaxTokens > 0 {
+		f := float64(s.Tokens) / float64(b.MaxTokens)
+		lines = append(lines, fmt.Sprintf("  %-22s %s %4d%%",
+			fmt.Sprintf("%s / %s tok", formatK(s.Tokens), formatK(b.MaxTokens)),
+			renderBudgetBar(f, budgetWarnAt(b), barWidth), int(f*100)))
+	}
+
+	var burn, proj []string
+	if b.MaxCost > 0 {
+		burn = append(burn, formatCost(burnCost))
+		proj = append(proj, formatCost(projCost))
+	}
+	if b.MaxTokens > 0 {
+		burn = append(burn, formatK(burnTokens)+" tok")
+		proj = append(proj, formatK(projTokens)+" tok")
+	}
+	projection := fmt.Sprintf("projected %s (%d%%)", strings.Join(proj, ", "), int(s.Projected*100))
+	if s.Projected >= 1 {
+		projection = styles.StatusModified.Render(projection)
+	}
+	lines = append(lines, "  "+styles.Muted.Render(fmt.Sprintf("burn %s/day · ", strings.Join(burn, ", ")))+projection)
+	lines = append(lines, "  "+styles.Muted.Render(fmt.Sprintf("%s · %d session%s · resets %s",
+		b.Period, s.Sessions, plural(s.Sessions), s.End.Format("Mon Jan 2 15:04"))))
+	return strings.Join(lines, "\n")
+}
+
+// renderBudgetBar renders a progress bar for a fraction of a cap, colored by
+// whether it is past the warning fraction or the cap.
+func renderBudgetBar(fraction, warnAt float64, width int) string {
+	filled := min(max(int(fraction*float64(width)), 0), width)
+	color := styles.Success
+	switch {
+	case fraction >= 1:
+		color = styles.Error
+	case fraction >= warnAt:
+		color = styles.Warning
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		styles.Muted.Render(strings.Repeat("░", width-filled))
+}
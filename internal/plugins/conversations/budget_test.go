@@ -0,0 +1,187 @@
+package conversations
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/config"
+	"github.com/toddwbucy/hermes/internal/plugin"
+)
+
+func TestBudgetPeriod(t *testing.T) {
+	// Thursday afternoon
+	now := time.Date(2026, 6, 11, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		period     string
+		start, end time.Time
+	}{
+		{config.BudgetDaily, time.Date(2026, 6, 11, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 12, 0, 0, 0, 0, time.UTC)},
+		{config.BudgetWeekly, time.Date(2026, 6, 8, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{config.BudgetMonthly, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		start, end, ok := budgetPeriod(tt.period, now)
+		if !ok || !start.Equal(tt.start) || !end.Equal(tt.end) {
+			t.Errorf("%s: got %v-%v ok=%v, want %v-%v", tt.period, start, end, ok, tt.start, tt.end)
+		}
+	}
+	if _, _, ok := budgetPeriod("yearly", now); ok {
+		t.Error("unknown period should not be ok")
+	}
+}
+
+func TestEvaluateBudgets(t *testing.T) {
+	// Noon on a Wednesday: half of the day, two and a half days of the week
+	now := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	sessions := []adapter.Session{
+		{ID: "today", AdapterID: "claude-code", CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(-time.Hour), EstCost: 3, TotalTokens: 1000},
+		// Half of this session falls before midnight
+		{ID: "overnight", AdapterID: "codex", CreatedAt: now.Add(-14 * time.Hour), UpdatedAt: now.Add(-10 * time.Hour), EstCost: 2, TotalTokens: 4000},
+		{ID: "last-week", AdapterID: "claude-code", CreatedAt: now.AddDate(0, 0, -9), UpdatedAt: now.AddDate(0, 0, -9), EstCost: 50},
+	}
+	budgets := []config.BudgetConfig{
+		{Period: config.BudgetDaily, MaxCost: 5, WarnAt: 0.8},
+		{Period: config.BudgetDaily, Adapter: "codex", MaxTokens: 1000, WarnAt: 0.8},
+		{Period: config.BudgetWeekly, Adapter: "claude-code", MaxCost: 10, WarnAt: 0.8},
+		{Period: "yearly", MaxCost: 1},
+	}
+	got := evaluateBudgets(budgets, sessions, now)
+	if len(got) != 3 {
+		t.Fatalf("got %d statuses, want 3 (unknown period skipped)", len(got))
+	}
+
+	daily := got[0]
+	if math.Abs(daily.Cost-4) > 1e-9 || daily.Tokens != 3000 || daily.Sessions != 2 {
+		t.Errorf("daily = cost %v tokens %d sessions %d, want 4/3000/2", daily.Cost, daily.Tokens, daily.Sessions)
+	}
+	if daily.Level != budgetWarn {
+		t.Errorf("daily level = %v, want warn at 80%%", daily.Level)
+	}
+	// $4 in half a day projects to $8 for the whole day
+	if cost, _ := daily.projected(now); math.Abs(cost-8) > 1e-9 || math.Abs(daily.Projected-1.6) > 1e-9 {
+		t.Errorf("daily projection = $%v (%v)", cost, daily.Projected)
+	}
+	if cost, _ := daily.burnPerDay(now); math.Abs(cost-8) > 1e-9 {
+		t.Errorf("daily burn = $%v/day, want 8", cost)
+	}
+
+	if codex := got[1]; codex.Tokens != 2000 || codex.Level != budgetOver || codex.Label != "daily · codex" {
+		t.Errorf("codex = %+v", codex)
+	}
+	if weekly := got[2]; weekly.Cost != 3 || weekly.Level != budgetOK {
+		t.Errorf("weekly = %+v, want only this week's claude-code spend", weekly)
+	}
+}
+
+func TestBudgetAppliesTo(t *testing.T) {
+	b := config.BudgetConfig{Project: "/work/hermes"}
+	if !budgetAppliesTo(b, "/work/hermes/") || budgetAppliesTo(b, "/work/other") {
+		t.Error("project budget should match only its own root")
+	}
+	if !budgetAppliesTo(config.BudgetConfig{}, "/anywhere") {
+		t.Error("budget without a project should apply everywhere")
+	}
+}
+
+// budgetMsgs runs cmd and returns the toasts and badges it produces.
+func budgetMsgs(cmd tea.Cmd) (toasts []app.ToastMsg, badges []app.HeaderBadgeMsg) {
+	if cmd == nil {
+		return nil, nil
+	}
+	var run func(tea.Msg)
+	run = func(msg tea.Msg) {
+		switch m := msg.(type) {
+		case tea.BatchMsg:
+			for _, c := range m {
+				if c != nil {
+					run(c())
+				}
+			}
+		case app.ToastMsg:
+			toasts = append(toasts, m)
+		case app.HeaderBadgeMsg:
+			badges = append(badges, m)
+		}
+	}
+	run(cmd())
+	return toasts, badges
+}
+
+func TestCheckBudgetsAlertsOncePerLevel(t *testing.T) {
+	p := New()
+	p.ctx = &plugin.Context{WorkDir: "/work/hermes", ProjectRoot: "/work/hermes"}
+	p.budgets = []config.BudgetConfig{
+		{Name: "daily", Period: config.BudgetDaily, MaxCost: 10, WarnAt: 0.8},
+		{Name: "elsewhere", Period: config.BudgetDaily, Project: "/work/other", MaxCost: 0.01},
+	}
+	now := time.Now()
+	session := adapter.Session{ID: "s1", AdapterID: "mock", CreatedAt: now, UpdatedAt: now, EstCost: 2}
+	p.sessions = []adapter.Session{session}
+
+	update := func(cost float64) tea.Cmd {
+		s := session
+		s.EstCost = cost
+		_, cmd := p.Update(SessionsRefreshedMsg{Refreshed: []adapter.Session{s}})
+		return cmd
+	}
+
+	if toasts, badges := budgetMsgs(update(3)); len(toasts) != 0 || len(badges) != 0 {
+		t.Fatalf("under budget: toasts %v badges %v", toasts, badges)
+	}
+
+	toasts, badges := budgetMsgs(update(8.5))
+	if len(toasts) != 1 || toasts[0].IsError || !strings.Contains(toasts[0].Message, "at 85%") {
+		t.Fatalf("warn toasts = %+v", toasts)
+	}
+	if len(badges) != 1 || badges[0].Text != "$ daily 85%" || badges[0].IsError {
+		t.Fatalf("warn badges = %+v", badges)
+	}
+
+	// Still warning: no repeat toast, badge only on change
+	toasts, badges = budgetMsgs(update(9))
+	if len(toasts) != 0 || len(badges) != 1 || badges[0].Text != "$ daily 90%" {
+		t.Fatalf("repeat: toasts %+v badges %+v", toasts, badges)
+	}
+
+	toasts, badges = budgetMsgs(update(12))
+	if len(toasts) != 1 || !toasts[0].IsError || !strings.Contains(toasts[0].Message, "exceeded") {
+		t.Fatalf("over toasts = %+v", toasts)
+	}
+	if len(badges) != 1 || !badges[0].IsError {
+		t.Fatalf("over badges = %+v", badges)
+	}
+
+	// Dropping back under clears the badge
+	toasts, badges = budgetMsgs(update(1))
+	if len(toasts) != 0 || len(badges) != 1 || badges[0].Text != "" {
+		t.Fatalf("cleared: toasts %+v badges %+v", toasts, badges)
+	}
+}
+
+func TestBudgetPanel(t *testing.T) {
+	p := New()
+	p.ctx = &plugin.Context{WorkDir: "/work/hermes", ProjectRoot: "/work/hermes"}
+	p.budgets = []config.BudgetConfig{{Name: "tokens", Period: config.BudgetWeekly, MaxTokens: 1000, WarnAt: 0.8}}
+	now := time.Now()
+	p.sessions = []adapter.Session{{ID: "s1", AdapterID: "mock", CreatedAt: now, UpdatedAt: now, TotalTokens: 500}}
+
+	_, _ = p.updateSessions(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("$")})
+	if !p.showBudgetModal || p.FocusContext() != "conversations-budget" {
+		t.Fatal("$ should open the budget panel")
+	}
+	view := p.View(120, 40)
+	for _, want := range []string{"tokens", "500 / 1.0k tok", "projected", "resets"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("panel missing %q", want)
+		}
+	}
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.showBudgetModal {
+		t.Error("esc should close the budget panel")
+	}
+}
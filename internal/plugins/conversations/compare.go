@@ -0,0 +1,351 @@
+package conversations
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/adapter/toolstats"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+	"github.com/toddwbucy/hermes/internal/plugin"
+)
+
+// compareDiffMaxLines caps the line diff of final outputs; longer outputs
+// are truncated before diffing to bound the LCS table.
+const compareDiffMaxLines = 400
+
+// Exchange is one prompt/response round: a user prompt and everything the
+// agent did until the next prompt.
+type Exchange struct {
+	Prompt    string // user prompt text
+	Reply     string // last assistant text in the round
+	ToolCalls int
+	TokensIn  int
+	TokensOut int
+}
+
+// CompareSide holds the computed view of one session in a comparison.
+type CompareSide struct {
+	Session     adapter.Session
+	Summary     SessionSummary
+	Exchanges   []Exchange
+	FinalOutput string   // last assistant text in the session
+	FilesEdited []string // edited paths, relative to the session's working dir
+	ToolCalls   int
+	ToolErrors  int
+	Duration    time.Duration
+	Cost        float64
+}
+
+// DiffLine is one line of a line-level diff.
+type DiffLine struct {
+	Op   byte // ' ' unchanged, '-' left only, '+' right only
+	Text string
+}
+
+// SessionComparison is the aligned comparison of two sessions.
+type SessionComparison struct {
+	Left, Right CompareSide
+	FilesBoth   []string
+	FilesLeft   []string // edited only in left
+	FilesRight  []string // edited only in right
+	OutputDiff  []DiffLine
+}
+
+// BuildCompareSide computes the comparison data for one session.
+func BuildCompareSide(session adapter.Session, messages []adapter.Message, workDir string) CompareSide {
+	cwd := sessionCWD(session, workDir)
+	side := CompareSide{
+		Session:   session,
+		Summary:   ComputeSessionSummary(messages, session.Duration),
+		Exchanges: groupExchanges(messages),
+	}
+
+	stats := toolstats.Compute(session.ID, cwd, messages)
+	side.ToolCalls = stats.TotalCalls()
+	side.ToolErrors = stats.TotalErrors()
+
+	seen := make(map[string]bool)
+	for _, touch := range stats.Files {
+		if touch.Op != toolstats.OpEdit {
+			continue
+		}
+		path := touch.Path
+		// Relative paths make parallel worktrees comparable
+		if rel, err := filepath.Rel(cwd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+		if !seen[path] {
+			seen[path] = true
+			side.FilesEdited = append(side.FilesEdited, path)
+		}
+	}
+	sort.Strings(side.FilesEdited)
+
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "assistant" {
+			if text := replayText(messages[i]); text != "" {
+				side.FinalOutput = text
+				break
+			}
+		}
+	}
+
+	side.Duration = session.Duration
+	if side.Duration == 0 && len(messages) > 1 {
+		side.Duration = messages[len(messages)-1].Timestamp.Sub(messages[0].Timestamp)
+	}
+	side.Cost = session.EstCost
+	if side.Cost == 0 {
+		side.Cost = side.Summary.TotalCost
+	}
+	return side
+}
+
+// groupExchanges splits messages into prompt/response rounds. User messages
+// that only carry tool results stay in the current round.
+func groupExchanges(messages []adapter.Message) []Exchange {
+	var out []Exchange
+	for _, msg := range messages {
+		text := replayText(msg)
+		if msg.Role == "user" && text != "" {
+			out = append(out, Exchange{Prompt: text})
+		}
+		if len(out) == 0 {
+			// Agent activity before the first prompt (e.g. system setup)
+			out = append(out, Exchange{})
+		}
+		ex := &out[len(out)-1]
+		if msg.Role == "assistant" {
+			if text != "" {
+				ex.Reply = text
+			}
+			ex.ToolCalls += len(replayToolCalls(msg))
+		}
+		ex.TokensIn += msg.InputTokens + msg.CacheRead + msg.CacheWrite
+		ex.TokensOut += msg.OutputTokens
+	}
+	return out
+}
+
+// CompareSessions builds the full comparison from two computed sides.
+func CompareSessions(left, right CompareSide) SessionComparison {
+	c := SessionComparison{Left: left, Right: right}
+
+	inRight := make(map[string]bool, len(right.FilesEdited))
+	for _, f := range right.FilesEdited {
+		inRight[f] = true
+	}
+	inLeft := make(map[string]bool, len(left.FilesEdited))
+	for _, f := range left.FilesEdited {
+		inLeft[f] = true
+		if inRight[f] {
+			c.FilesBoth = append(c.FilesBoth, f)
+		} else {
+			c.FilesLeft = append(c.FilesLeft, f)
+		}
+	}
+	for _, f := range right.FilesEdited {
+		if !inLeft[f] {
+			c.FilesRight = append(c.FilesRight, f)
+		}
+	}
+
+	c.OutputDiff = DiffLines(splitDiffLines(left.FinalOutput), splitDiffLines(right.FinalOutput))
+	return c
+}
+
+// splitDiffLines splits text into lines, capped at compareDiffMaxLines.
+func splitDiffLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	if len(lines) > compareDiffMaxLines {
+		lines = lines[:compareDiffMaxLines]
+	}
+	return lines
+}
+
+// DiffLines returns a line diff of a and b using longest common subsequence.
+func DiffLines(a, b []string) []DiffLine {
+	n, m := len(a), len(b)
+	// lcs[i][j] = LCS length of a[i:] and b[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out []DiffLine
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, DiffLine{Op: ' ', Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, DiffLine{Op: '-', Text: a[i]})
+			i++
+		default:
+			out = append(out, DiffLine{Op: '+', Text: b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		out = append(out, DiffLine{Op: '-', Text: a[i]})
+	}
+	for ; j < m; j++ {
+		out = append(out, DiffLine{Op: '+', Text: b[j]})
+	}
+	return out
+}
+
+// CompareLoadedMsg delivers both sessions' messages for compare mode.
+type CompareLoadedMsg struct {
+	Epoch      uint64
+	Comparison SessionComparison
+	Err        error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m CompareLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// markOrCompare marks the selected session for comparison, or opens compare
+// mode against the previously marked session.
+func (p *Plugin) markOrCompare() (plugin.Plugin, tea.Cmd) {
+	session := p.findSelectedSession()
+	if session == nil {
+		return p, appmsg.ShowToast("No session selected", 2*time.Second)
+	}
+	if p.compareBase == "" {
+		p.compareBase = session.ID
+		return p, appmsg.ShowToast("Marked for compare: select another session and press X", 3*time.Second)
+	}
+	if p.compareBase == session.ID {
+		p.compareBase = ""
+		return p, appmsg.ShowToast("Compare mark cleared", 2*time.Second)
+	}
+
+	var base *adapter.Session
+	for i := range p.sessions {
+		if p.sessions[i].ID == p.compareBase {
+			base = &p.sessions[i]
+			break
+		}
+	}
+	p.compareBase = ""
+	if base == nil {
+		return p, appmsg.ShowToast("Marked session no longer available", 2*time.Second)
+	}
+
+	p.view = ViewCompare
+	p.compare = nil
+	p.compareLoading = true
+	p.compareErr = nil
+	p.compareScrollOff = 0
+	return p, p.loadComparison(*base, *session)
+}
+
+// loadComparison loads both sessions' messages and computes the comparison.
+func (p *Plugin) loadComparison(left, right adapter.Session) tea.Cmd {
+	var epoch uint64
+	workDir := ""
+	if p.ctx != nil {
+		epoch = p.ctx.Epoch
+		workDir = p.ctx.WorkDir
+	}
+	adapters := p.adapters // capture to avoid race in closure
+	return func() tea.Msg {
+		load := func(s adapter.Session) ([]adapter.Message, error) {
+			a := adapters[s.AdapterID]
+			if a == nil {
+				return nil, fmt.Errorf("no adapter for %s", shortID(s.ID))
+			}
+			return a.Messages(s.ID)
+		}
+		lm, err := load(left)
+		if err != nil {
+			return CompareLoadedMsg{Epoch: epoch, Err: err}
+		}
+		rm, err := load(right)
+		if err != nil {
+			return CompareLoadedMsg{Epoch: epoch, Err: err}
+		}
+		return CompareLoadedMsg{
+			Epoch: epoch,
+			Comparison: CompareSessions(
+				BuildCompareSide(left, lm, workDir),
+				BuildCompareSide(right, rm, workDir),
+			),
+		}
+	}
+}
+
+// updateCompare handles key events in compare view.
+func (p *Plugin) updateCompare(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	maxScroll := len(p.compareLines) - (p.height - 2)
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+
+	switch msg.String() {
+	case "esc", "q", "X":
+		p.view = ViewSessions
+		p.compare = nil
+		p.compareLines = nil
+		p.compareLoading = false
+		p.compareScrollOff = 0
+
+	case "s":
+		// Swap sides
+		if p.compare != nil {
+			swapped := CompareSessions(p.compare.Right, p.compare.Left)
+			p.compare = &swapped
+		}
+
+	case "j", "down":
+		if p.compareScrollOff < maxScroll {
+			p.compareScrollOff++
+		}
+
+	case "k", "up":
+		if p.compareScrollOff > 0 {
+			p.compareScrollOff--
+		}
+
+	case "g":
+		p.compareScrollOff = 0
+
+	case "G":
+		p.compareScrollOff = maxScroll
+
+	case "ctrl+d":
+		p.compareScrollOff += 10
+		if p.compareScrollOff > maxScroll {
+			p.compareScrollOff = maxScroll
+		}
+
+	case "ctrl+u":
+		p.compareScrollOff -= 10
+		if p.compareScrollOff < 0 {
+			p.compareScrollOff = 0
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,101 @@
+package conversations
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+func compareMessages(base time.Time, edited, final string, failEdit bool) []adapter.Message {
+	return []adapter.Message{
+		{ID: "u1", Role: "user", Content: "add a flag", Timestamp: base},
+		{
+			ID:         "a1",
+			Role:       "assistant",
+			Timestamp:  base.Add(time.Second),
+			TokenUsage: adapter.TokenUsage{InputTokens: 100, OutputTokens: 20},
+			ContentBlocks: []adapter.ContentBlock{
+				{Type: "tool_use", ToolUseID: "e1", ToolName: "Edit", ToolInput: `{"file_path":"` + edited + `"}`},
+			},
+		},
+		{
+			ID:        "r1",
+			Role:      "user",
+			Timestamp: base.Add(2 * time.Second),
+			ContentBlocks: []adapter.ContentBlock{
+				{Type: "tool_result", ToolUseID: "e1", IsError: failEdit},
+			},
+		},
+		{ID: "a2", Role: "assistant", Content: final, Timestamp: base.Add(3 * time.Second)},
+		{ID: "u2", Role: "user", Content: "thanks", Timestamp: base.Add(4 * time.Second)},
+	}
+}
+
+func TestGroupExchanges(t *testing.T) {
+	ex := groupExchanges(compareMessages(time.Unix(0, 0), "a.go", "done", false))
+	if len(ex) != 2 {
+		t.Fatalf("got %d exchanges, want 2 (tool results stay in round)", len(ex))
+	}
+	if ex[0].Prompt != "add a flag" || ex[0].Reply != "done" || ex[0].ToolCalls != 1 {
+		t.Errorf("exchange[0] = %+v", ex[0])
+	}
+}
+
+func TestCompareSessions(t *testing.T) {
+	base := time.Unix(0, 0)
+	left := BuildCompareSide(
+		adapter.Session{ID: "l", CWD: "/wt/claude"},
+		compareMessages(base, "/wt/claude/cmd/main.go", "Added --verbose\nAll tests pass", false),
+		"/repo",
+	)
+	right := BuildCompareSide(
+		adapter.Session{ID: "r", CWD: "/wt/codex"},
+		compareMessages(base, "/wt/codex/flags.go", "Added --verbose\nTests fail", true),
+		"/repo",
+	)
+
+	if left.FilesEdited[0] != "cmd/main.go" {
+		t.Errorf("left files = %v, want paths relative to session CWD", left.FilesEdited)
+	}
+	if left.ToolErrors != 0 || right.ToolErrors != 1 {
+		t.Errorf("tool errors = %d/%d, want 0/1", left.ToolErrors, right.ToolErrors)
+	}
+
+	c := CompareSessions(left, right)
+	if len(c.FilesLeft) != 1 || len(c.FilesRight) != 1 || len(c.FilesBoth) != 0 {
+		t.Errorf("file sets = both:%v left:%v right:%v", c.FilesBoth, c.FilesLeft, c.FilesRight)
+	}
+
+	var ops strings.Builder
+	for _, d := range c.OutputDiff {
+		ops.WriteByte(d.Op)
+	}
+	if got := ops.String(); got != " -+" {
+		t.Errorf("output diff ops = %q, want \" -+\"", got)
+	}
+
+	p := New()
+	p.width, p.height = 120, 80
+	p.view = ViewCompare
+	p.compare = &c
+	out := p.renderCompare()
+	for _, want := range []string{"Metrics", "tool errors", "flags.go", "Turns  (2 vs 2)", "Final Output Diff"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered compare missing %q", want)
+		}
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	diff := DiffLines([]string{"a", "b", "c"}, []string{"a", "c", "d"})
+	var got []string
+	for _, d := range diff {
+		got = append(got, string(d.Op)+d.Text)
+	}
+	want := []string{" a", "-b", " c", "+d"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("DiffLines = %v, want %v", got, want)
+	}
+}
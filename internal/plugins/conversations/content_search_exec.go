@@ -3,6 +3,7 @@ package conversations
 
 import (
 	"context"
+	"regexp"
 	"runtime"
 	"sort"
 	"sync"
@@ -47,8 +48,11 @@ func searchConcurrency() int {
 //   - Concurrency scales with CPU count
 //   - Sessions sorted by UpdatedAt (recent first for early results)
 //   - Empty sessions skipped
+//
+// Bookmark annotations are searched alongside message content and reported
+// as matches with BlockType "annotation".
 func RunContentSearch(query string, sessions []adapter.Session,
-	adapters map[string]adapter.Adapter, opts adapter.SearchOptions, epoch uint64, version ...int) tea.Cmd {
+	adapters map[string]adapter.Adapter, bookmarks []Bookmark, opts adapter.SearchOptions, epoch uint64, version ...int) tea.Cmd {
 	ver := 0
 	if len(version) > 0 {
 		ver = version[0]
@@ -66,6 +70,19 @@ func RunContentSearch(query string, sessions []adapter.Session,
 			return sortedSessions[i].UpdatedAt.After(sortedSessions[j].UpdatedAt)
 		})
 
+		// Group annotated bookmarks by session for the per-session search
+		annotated := make(map[[2]string][]Bookmark)
+		for _, b := range bookmarks {
+			if b.Annotation != "" {
+				ref := [2]string{b.AdapterID, b.SessionID}
+				annotated[ref] = append(annotated[ref], b)
+			}
+		}
+		var annotationRe *regexp.Regexp
+		if len(annotated) > 0 {
+			annotationRe, _ = adapter.CompileSearchPattern(query, opts)
+		}
+
 		var results []SessionSearchResult
 		var mu sync.Mutex
 		var wg sync.WaitGroup
@@ -118,15 +135,15 @@ func RunContentSearch(query string, sessions []adapter.Session,
 					return
 				}
 
-				// Check if adapter supports search
-				searcher, ok := adp.(adapter.MessageSearcher)
-				if !ok {
-					return
+				// Search messages if the adapter supports it
+				var matches []adapter.MessageMatch
+				if searcher, ok := adp.(adapter.MessageSearcher); ok {
+					matches, _ = searcher.SearchMessages(s.ID, query, opts)
 				}
-
-				// Execute search
-				matches, err := searcher.SearchMessages(s.ID, query, opts)
-				if err != nil || len(matches) == 0 {
+				if notes := annotated[[2]string{s.AdapterID, s.ID}]; annotationRe != nil && len(notes) > 0 {
+					matches = mergeAnnotationMatches(matches, annotationMatches(notes, annotationRe))
+				}
+				if len(matches) == 0 {
 					return
 				}
 
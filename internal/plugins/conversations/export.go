@@ -12,7 +12,8 @@ import (
 )
 
 // ExportSessionAsMarkdown converts a session and its messages to markdown format.
-func ExportSessionAsMarkdown(session *adapter.Session, messages []adapter.Message) string {
+// Bookmarked messages are flagged, with any annotation quoted under the header.
+func ExportSessionAsMarkdown(session *adapter.Session, messages []adapter.Message, bookmarks map[string]Bookmark) string {
 	var sb strings.Builder
 
 	// Header
@@ -49,6 +50,18 @@ func ExportSessionAsMarkdown(session *adapter.Session, messages []adapter.Messag
 		ts := msg.Timestamp.Format("15:04:05")
 		sb.WriteString(fmt.Sprintf("## %s (%s)\n\n", role, ts))
 
+		// Bookmark and annotation
+		if b, ok := bookmarks[msg.ID]; ok {
+			if b.Annotation != "" {
+				for _, line := range strings.Split(b.Annotation, "\n") {
+					sb.WriteString(fmt.Sprintf("> %s **Note:** %s\n", bookmarkMarker, line))
+				}
+			} else {
+				sb.WriteString(fmt.Sprintf("> %s **Bookmarked**\n", bookmarkMarker))
+			}
+			sb.WriteString("\n")
+		}
+
 		// Model info for assistant messages
 		if msg.Role == "assistant" && msg.Model != "" {
 			sb.WriteString(fmt.Sprintf("*Model: %s*\n\n", modelShortName(msg.Model)))
@@ -99,8 +112,8 @@ func CopyToClipboard(content string) error {
 }
 
 // ExportSessionToFile writes a session to a markdown file.
-func ExportSessionToFile(session *adapter.Session, messages []adapter.Message, workDir string) (string, error) {
-	md := ExportSessionAsMarkdown(session, messages)
+func ExportSessionToFile(session *adapter.Session, messages []adapter.Message, bookmarks map[string]Bookmark, workDir string) (string, error) {
+	md := ExportSessionAsMarkdown(session, messages, bookmarks)
 
 	// Generate filename from session name or ID
 	name := "session"
@@ -66,7 +66,7 @@ func TestExportSessionAsMarkdown_NilSession(t *testing.T) {
 	messages := []adapter.Message{
 		{Role: "user", Content: "hello", Timestamp: time.Now()},
 	}
-	result := ExportSessionAsMarkdown(nil, messages)
+	result := ExportSessionAsMarkdown(nil, messages, nil)
 	if !strings.Contains(result, "Unknown Session") {
 		t.Error("nil session should use 'Unknown Session'")
 	}
@@ -106,7 +106,7 @@ func TestExportSessionAsMarkdown_BasicSession(t *testing.T) {
 		},
 	}
 
-	result := ExportSessionAsMarkdown(session, messages)
+	result := ExportSessionAsMarkdown(session, messages, nil)
 
 	checks := []string{
 		"Test Session",
@@ -132,7 +132,7 @@ func TestExportSessionAsMarkdown_EmptyMessages(t *testing.T) {
 		Name:      "Empty",
 		CreatedAt: time.Now(),
 	}
-	result := ExportSessionAsMarkdown(session, nil)
+	result := ExportSessionAsMarkdown(session, nil, nil)
 	if !strings.Contains(result, "Empty") {
 		t.Error("session name should be in header")
 	}
@@ -157,7 +157,7 @@ func TestExportSessionAsMarkdown_WithThinkingBlocks(t *testing.T) {
 		},
 	}
 
-	result := ExportSessionAsMarkdown(session, messages)
+	result := ExportSessionAsMarkdown(session, messages, nil)
 	if !strings.Contains(result, "<details>") {
 		t.Error("thinking blocks should use <details> tag")
 	}
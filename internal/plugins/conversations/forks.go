@@ -0,0 +1,270 @@
+package conversations
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/app"
+)
+
+const (
+	forksFileName = "forks.json"
+	forksVersion  = 1
+)
+
+// ForkLink records that one session was forked from another at a turn.
+type ForkLink struct {
+	AdapterID        string    `json:"adapterId"`
+	SessionID        string    `json:"sessionId"` // The fork
+	OriginID         string    `json:"originId"`
+	ThroughMessageID string    `json:"throughMessageId"`
+	Turn             int       `json:"turn"` // 1-based turn the fork was taken after
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// ForkStore persists fork links to a JSON file, by default
+// ~/.config/hermes/forks.json, so forks and their origins show as related.
+type ForkStore struct {
+	path string
+
+	mu    sync.RWMutex
+	links []ForkLink
+}
+
+// forkFile is the on-disk format.
+type forkFile struct {
+	Version int        `json:"version"`
+	Forks   []ForkLink `json:"forks"`
+}
+
+// LoadForkStore reads fork links from path. A missing or corrupt file
+// yields an empty store, like LoadTagStore.
+func LoadForkStore(path string) *ForkStore {
+	s := &ForkStore{path: path}
+	if path == "" {
+		return s
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Warn("forks: read failed", "err", err)
+		}
+		return s
+	}
+
+	var f forkFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		slog.Warn("forks: parse failed, starting empty", "err", err)
+		return s
+	}
+	for _, l := range f.Forks {
+		if l.SessionID != "" && l.OriginID != "" {
+			s.links = append(s.links, l)
+		}
+	}
+	return s
+}
+
+// defaultForksPath returns the forks file under configDir, falling back to
+// ~/.config/hermes.
+func defaultForksPath(configDir string) string {
+	bookmarks := defaultBookmarksPath(configDir)
+	if bookmarks == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(bookmarks), forksFileName)
+}
+
+// Add records a fork and saves the store.
+func (s *ForkStore) Add(link ForkLink) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.links = append(s.links, link)
+	return s.saveLocked()
+}
+
+// OriginOf returns the link describing where a session was forked from.
+func (s *ForkStore) OriginOf(adapterID, sessionID string) (ForkLink, bool) {
+	if s == nil {
+		return ForkLink{}, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, l := range s.links {
+		if l.AdapterID == adapterID && l.SessionID == sessionID {
+			return l, true
+		}
+	}
+	return ForkLink{}, false
+}
+
+// ForksOf returns the sessions forked from a session, oldest first.
+func (s *ForkStore) ForksOf(adapterID, sessionID string) []ForkLink {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []ForkLink
+	for _, l := range s.links {
+		if l.AdapterID == adapterID && l.OriginID == sessionID {
+			out = append(out, l)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out
+}
+
+// saveLocked writes the store atomically. Caller must hold s.mu.
+func (s *ForkStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(forkFile{Version: forksVersion, Forks: s.links}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Atomic write: temp file + rename
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+// forkPoint returns the turn to fork after: the turn under the cursor in
+// turn view, or the turn holding the selected message in conversation flow.
+// The index is into p.turns.
+func (p *Plugin) forkPoint() (int, bool) {
+	if p.turnViewMode {
+		if p.turnCursor >= 0 && p.turnCursor < len(p.turns) {
+			return p.turnCursor, true
+		}
+		return 0, false
+	}
+	msg := p.getSelectedMessage()
+	if msg == nil {
+		return 0, false
+	}
+	for i := range p.turns {
+		for _, m := range p.turns[i].Messages {
+			if m.ID == msg.ID {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// openForkModal opens the resume modal in fork mode for the turn under the
+// cursor. The fork is materialized by the adapter once the worktree exists.
+func (p *Plugin) openForkModal() tea.Cmd {
+	session := p.getSessionForResume()
+	if session == nil {
+		return func() tea.Msg {
+			return app.ToastMsg{Message: "No session selected", IsError: true}
+		}
+	}
+	if _, ok := p.adapters[session.AdapterID].(adapter.Forker); !ok || resumeCommand(session) == "" {
+		return func() tea.Msg {
+			return app.ToastMsg{Message: "Fork not supported for " + session.AdapterName, IsError: true}
+		}
+	}
+	idx, ok := p.forkPoint()
+	if !ok {
+		return func() tea.Msg {
+			return app.ToastMsg{Message: "No turn selected", IsError: true}
+		}
+	}
+	turn := p.turns[idx]
+	if len(turn.Messages) == 0 {
+		return nil
+	}
+
+	_ = p.openResumeModal()
+	p.resumeType = resumeTypeWorktree
+	p.resumeForkMsgID = turn.Messages[len(turn.Messages)-1].ID
+	p.resumeForkTurn = idx + 1
+	p.resumeForkTurns = len(p.turns)
+	p.resumeNameInput.SetValue("fork-" + sanitizeBranchName(session.Name))
+	return nil
+}
+
+// isResumeForkMode reports whether the resume modal is forking at a turn.
+func (p *Plugin) isResumeForkMode() bool {
+	return p.resumeForkMsgID != ""
+}
+
+// forkPrepare returns the workspace hook that writes the fork into the new
+// worktree, records the link, and returns the command to resume the fork.
+func (p *Plugin) forkPrepare(session adapter.Session, throughMsgID string, turn int) func(string) (string, error) {
+	forker, _ := p.adapters[session.AdapterID].(adapter.Forker)
+	forks := p.forks
+	return func(workDir string) (string, error) {
+		if forker == nil {
+			return "", fmt.Errorf("fork not supported for %s", session.AdapterID)
+		}
+		newID, err := forker.ForkSession(session.ID, throughMsgID, workDir)
+		if err != nil {
+			return "", err
+		}
+		link := ForkLink{
+			AdapterID:        session.AdapterID,
+			SessionID:        newID,
+			OriginID:         session.ID,
+			ThroughMessageID: throughMsgID,
+			Turn:             turn,
+			CreatedAt:        time.Now(),
+		}
+		if err := forks.Add(link); err != nil {
+			slog.Warn("forks: save failed", "err", err)
+		}
+		fork := session
+		fork.ID = newID
+		return resumeCommand(&fork), nil
+	}
+}
+
+// forkLabel describes a session's fork relations for the header, e.g.
+// "⑂ fork of Fix cache @3" or "⑂ 2 forks".
+func (p *Plugin) forkLabel(session *adapter.Session) string {
+	if session == nil {
+		return ""
+	}
+	label := ""
+	if origin, ok := p.forks.OriginOf(session.AdapterID, session.ID); ok {
+		name := shortID(origin.OriginID)
+		for i := range p.sessions {
+			if p.sessions[i].ID == origin.OriginID && p.sessions[i].Name != "" {
+				name = p.sessions[i].Name
+				break
+			}
+		}
+		label = fmt.Sprintf("⑂ fork of %s @%d", name, origin.Turn)
+	}
+	if n := len(p.forks.ForksOf(session.AdapterID, session.ID)); n > 0 {
+		if label != "" {
+			label += " · "
+		}
+		if n == 1 {
+			label += "⑂ 1 fork"
+		} else {
+			label += fmt.Sprintf("⑂ %d forks", n)
+		}
+	}
+	return label
+}
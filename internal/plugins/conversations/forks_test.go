@@ -0,0 +1,122 @@
+package conversations
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/plugins/workspace"
+)
+
+// forkingAdapter records ForkSession calls.
+type forkingAdapter struct {
+	mockAdapter
+	through, workDir string
+}
+
+func (f *forkingAdapter) ForkSession(sessionID, throughMessageID, workDir string) (string, error) {
+	f.through, f.workDir = throughMessageID, workDir
+	return "fork-1", nil
+}
+
+func TestForkStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hermes", forksFileName)
+	s := LoadForkStore(path)
+	now := time.Now()
+	_ = s.Add(ForkLink{AdapterID: "codex", SessionID: "f2", OriginID: "s1", Turn: 4, CreatedAt: now})
+	_ = s.Add(ForkLink{AdapterID: "codex", SessionID: "f1", OriginID: "s1", Turn: 2, CreatedAt: now.Add(-time.Hour)})
+
+	reloaded := LoadForkStore(path)
+	if origin, ok := reloaded.OriginOf("codex", "f1"); !ok || origin.OriginID != "s1" || origin.Turn != 2 {
+		t.Errorf("OriginOf = %+v, %v", origin, ok)
+	}
+	if _, ok := reloaded.OriginOf("claude-code", "f1"); ok {
+		t.Error("links are per adapter")
+	}
+	forks := reloaded.ForksOf("codex", "s1")
+	if len(forks) != 2 || forks[0].SessionID != "f1" {
+		t.Errorf("ForksOf = %+v, want oldest first", forks)
+	}
+}
+
+func TestForkFromTurn(t *testing.T) {
+	forker := &forkingAdapter{}
+	p := New()
+	p.forks = LoadForkStore(filepath.Join(t.TempDir(), forksFileName))
+	p.adapters = map[string]adapter.Adapter{"claude-code": forker}
+	p.width, p.height = 120, 40
+	p.sessions = []adapter.Session{{ID: "s1", Name: "Fix cache", AdapterID: "claude-code", AdapterName: "Claude Code"}}
+	p.selectedSession = "s1"
+	p.activePane = PaneMessages
+	p.messages = []adapter.Message{
+		{ID: "m1", Role: "user", Content: "fix it"},
+		{ID: "m2", Role: "assistant", Content: "fixed"},
+		{ID: "m3", Role: "user", Content: "now test it"},
+		{ID: "m4", Role: "assistant", Content: "tested"},
+	}
+	p.turns = GroupMessagesIntoTurns(p.messages)
+	p.messageCursor = 1
+
+	_, _ = p.updateMessages(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")})
+	if !p.showResumeModal || p.resumeForkMsgID != "m2" || p.resumeType != resumeTypeWorktree {
+		t.Fatalf("K should open the fork modal at m2, got %q", p.resumeForkMsgID)
+	}
+	view := p.View(120, 40)
+	for _, want := range []string{"Fork in Workspace", "turn 2 of 4", "fork-fix-cache"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("modal missing %q", want)
+		}
+	}
+
+	// Submitting sends a worktree resume whose Prepare writes the fork
+	var resume *workspace.ResumeConversationMsg
+	batch, _ := p.executeResume()().(tea.BatchMsg)
+	for _, cmd := range batch {
+		if m, ok := cmd().(workspace.ResumeConversationMsg); ok {
+			resume = &m
+		}
+	}
+	if resume == nil || resume.Type != "worktree" || resume.Prepare == nil {
+		t.Fatalf("resume msg = %+v", resume)
+	}
+	if p.resumeForkMsgID != "" {
+		t.Error("submit should reset fork state")
+	}
+	cmd, err := resume.Prepare("/tmp/wt")
+	if err != nil || cmd != "claude --resume fork-1" {
+		t.Fatalf("Prepare = %q, %v", cmd, err)
+	}
+	if forker.through != "m2" || forker.workDir != "/tmp/wt" {
+		t.Errorf("ForkSession(%q, %q)", forker.through, forker.workDir)
+	}
+
+	// Both sides of the link are labelled
+	if got := p.forkLabel(&p.sessions[0]); got != "⑂ 1 fork" {
+		t.Errorf("origin label = %q", got)
+	}
+	fork := adapter.Session{ID: "fork-1", AdapterID: "claude-code"}
+	if got := p.forkLabel(&fork); got != "⑂ fork of Fix cache @2" {
+		t.Errorf("fork label = %q", got)
+	}
+}
+
+func TestForkUnsupportedAdapter(t *testing.T) {
+	p := New()
+	p.adapters = map[string]adapter.Adapter{"mock": &mockAdapter{}}
+	p.sessions = []adapter.Session{{ID: "s1", AdapterID: "mock", AdapterName: "Mock"}}
+	p.selectedSession = "s1"
+	p.messages = []adapter.Message{{ID: "m1", Role: "user", Content: "hi"}}
+	p.turns = GroupMessagesIntoTurns(p.messages)
+
+	cmd := p.openForkModal()
+	if cmd == nil || p.showResumeModal {
+		t.Fatal("fork should be refused without adapter support")
+	}
+	if toast, ok := cmd().(app.ToastMsg); !ok || !toast.IsError {
+		t.Errorf("expected error toast, got %#v", cmd())
+	}
+}
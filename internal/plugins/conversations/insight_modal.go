@@ -125,7 +125,7 @@ func insightListSection(state *insightModalState, maxHeight, contentWidth int) m
 				}
 
 				// Source badge
-				badge := fmt.Sprintf("[%s]", ins.Source.Badge())
+				badge := fmt.Sprintf("[%s]", ins.Badge())
 
 				// Truncated text preview
 				maxTextW := contentWidth - len(box) - len(badge) - 6 // spacing
@@ -175,7 +175,7 @@ func insightDetailSection(state *insightModalState, contentWidth int) modal.Sect
 				Bold(true).
 				Foreground(styles.TextSecondary)
 
-			header := headerStyle.Render(fmt.Sprintf("[%s] Turn %d", ins.Source.Badge(), ins.TurnIndex+1))
+			header := headerStyle.Render(fmt.Sprintf("[%s] Turn %d", ins.Badge(), ins.TurnIndex+1))
 
 			// Wrap the full text
 			text := ins.Text
@@ -1,8 +1,11 @@
 package conversations
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/toddwbucy/hermes/internal/config"
 )
 
 // InsightSource classifies how an insight was detected.
@@ -13,16 +16,83 @@ const (
 	SourceObservation  InsightSource = "key-observation"
 	SourceDecision     InsightSource = "decision"
 	SourcePattern      InsightSource = "pattern"
+	SourceCustom       InsightSource = "custom" // user-defined rule from config
 )
 
 // Insight represents an extracted insight from a conversation.
 type Insight struct {
 	Text      string        // The extracted insight text
 	Source    InsightSource // How it was detected
+	Rule      *InsightRule  // Matching rule for SourceCustom insights
 	TurnIndex int           // Which turn it came from
 	Selected  bool          // User toggle for task creation
 }
 
+// Badge returns the short label shown for the insight: the rule's badge
+// for user-defined rules, otherwise the source's.
+func (ins Insight) Badge() string {
+	if ins.Rule != nil {
+		return ins.Rule.Badge
+	}
+	return ins.Source.Badge()
+}
+
+// InsightRule is a compiled user-defined extractor. Line rules match each
+// line; heading rules collect the bullets under a matching heading.
+type InsightRule struct {
+	Name    string
+	Badge   string
+	Line    *regexp.Regexp
+	Heading *regexp.Regexp
+}
+
+// compileInsightRules compiles the configured rules. Invalid rules are
+// returned as errors and left out.
+func compileInsightRules(cfgs []config.InsightRuleConfig) ([]*InsightRule, []error) {
+	var rules []*InsightRule
+	var errs []error
+	for i, c := range cfgs {
+		name := c.Name
+		if name == "" {
+			name = fmt.Sprintf("rule %d", i+1)
+		}
+		if (c.Pattern == "") == (c.Heading == "") {
+			errs = append(errs, fmt.Errorf("insight rule %q: set exactly one of pattern or heading", name))
+			continue
+		}
+		rule := &InsightRule{Name: name, Badge: c.Badge}
+		if rule.Badge == "" {
+			rule.Badge = strings.ToUpper(string([]rune(name)[:min(3, len([]rune(name)))]))
+		}
+		var err error
+		if c.Pattern != "" {
+			rule.Line, err = regexp.Compile(c.Pattern)
+		} else {
+			rule.Heading, err = regexp.Compile(c.Heading)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("insight rule %q: %w", name, err))
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, errs
+}
+
+// insightExtractor runs the built-in heuristics and user-defined rules
+// over a conversation.
+type insightExtractor struct {
+	disableBuiltin bool
+	rules          []*InsightRule
+}
+
+// newInsightExtractor builds the extractor described by config, returning
+// errors for the rules that could not be compiled.
+func newInsightExtractor(cfg config.InsightsConfig) (*insightExtractor, []error) {
+	rules, errs := compileInsightRules(cfg.Rules)
+	return &insightExtractor{disableBuiltin: cfg.DisableBuiltin, rules: rules}, errs
+}
+
 // insightModalState holds state for the insight extraction modal.
 type insightModalState struct {
 	insights  []Insight
@@ -65,8 +135,14 @@ var (
 	signalPhraseRe = regexp.MustCompile(`(?i)(critical finding|key takeaway|design decision|architectural decision)`)
 )
 
-// extractInsights scans assistant turns for insight patterns.
+// extractInsights scans assistant turns with the built-in heuristics only.
 func extractInsights(turns []Turn) []Insight {
+	return (&insightExtractor{}).extract(turns)
+}
+
+// extract scans assistant turns for insight patterns. Insights with the
+// same text are reported once, from the first extractor that found them.
+func (e *insightExtractor) extract(turns []Turn) []Insight {
 	var insights []Insight
 	seen := make(map[string]bool) // deduplicate by text
 
@@ -79,7 +155,7 @@ func extractInsights(turns []Turn) []Insight {
 				if block.Type != "text" || block.Text == "" {
 					continue
 				}
-				extracted := extractFromText(block.Text, turnIdx)
+				extracted := e.extractFromText(block.Text, turnIdx)
 				for _, ins := range extracted {
 					key := strings.TrimSpace(ins.Text)
 					if key == "" || seen[key] {
@@ -94,10 +170,22 @@ func extractInsights(turns []Turn) []Insight {
 	return insights
 }
 
-// extractFromText applies heuristics to a single text block.
-func extractFromText(text string, turnIdx int) []Insight {
-	var insights []Insight
+// extractFromText applies heuristics and rules to a single text block.
+func (e *insightExtractor) extractFromText(text string, turnIdx int) []Insight {
 	lines := strings.Split(text, "\n")
+	var insights []Insight
+	if !e.disableBuiltin {
+		insights = builtinInsights(lines, turnIdx)
+	}
+	for _, rule := range e.rules {
+		insights = append(insights, rule.extract(lines, turnIdx)...)
+	}
+	return insights
+}
+
+// builtinInsights runs the built-in heuristics over a text block's lines.
+func builtinInsights(lines []string, turnIdx int) []Insight {
+	var insights []Insight
 
 	// Pass 1: Extract ★ Insight blocks (delimited by ───── lines)
 	insights = append(insights, extractInsightBlocks(lines, turnIdx)...)
@@ -240,32 +328,84 @@ func extractSectionBullets(lines []string, turnIdx int) []Insight {
 		}
 
 		// Collect bullet points under this header
+		var bullets []string
+		bullets, i = collectBullets(lines, i+1)
+		for _, text := range bullets {
+			insights = append(insights, Insight{
+				Text:      text,
+				Source:    source,
+				TurnIndex: turnIdx,
+			})
+		}
+	}
+	return insights
+}
+
+// collectBullets gathers the bullet points starting at lines[i], skipping
+// blank lines, until the next header or non-bullet line. It returns the
+// bullet texts and the index of the line that ended the list.
+func collectBullets(lines []string, i int) ([]string, int) {
+	var bullets []string
+	for i < len(lines) {
+		bullet := strings.TrimSpace(lines[i])
+		if bullet == "" {
+			i++
+			continue
+		}
+		// Stop at next header or non-bullet content
+		if strings.HasPrefix(bullet, "#") {
+			break
+		}
+		if !strings.HasPrefix(bullet, "- ") && !strings.HasPrefix(bullet, "* ") && !strings.HasPrefix(bullet, "• ") {
+			break
+		}
+		if text := strings.TrimLeft(bullet, "-*• "); text != "" {
+			bullets = append(bullets, text)
+		}
 		i++
-		for i < len(lines) {
-			bullet := strings.TrimSpace(lines[i])
-			if bullet == "" {
-				i++
-				continue
-			}
-			// Stop at next header or non-bullet content
-			if strings.HasPrefix(bullet, "#") {
-				break
-			}
-			if strings.HasPrefix(bullet, "- ") || strings.HasPrefix(bullet, "* ") || strings.HasPrefix(bullet, "• ") {
-				text := strings.TrimLeft(bullet, "-*• ")
-				if text != "" {
-					insights = append(insights, Insight{
-						Text:      text,
-						Source:    source,
-						TurnIndex: turnIdx,
-					})
-				}
-			} else {
-				// Not a bullet — stop collecting
-				break
+	}
+	return bullets, i
+}
+
+// extract applies a user-defined rule to a text block's lines.
+func (r *InsightRule) extract(lines []string, turnIdx int) []Insight {
+	var insights []Insight
+	add := func(text string) {
+		if text = strings.TrimSpace(text); text != "" {
+			insights = append(insights, Insight{
+				Text:      text,
+				Source:    SourceCustom,
+				Rule:      r,
+				TurnIndex: turnIdx,
+			})
+		}
+	}
+
+	if r.Line != nil {
+		for _, line := range lines {
+			m := r.Line.FindStringSubmatch(strings.TrimSpace(line))
+			switch {
+			case m == nil:
+			case len(m) > 1 && m[1] != "":
+				add(m[1])
+			default:
+				add(strings.TrimSpace(line))
 			}
-			i++
 		}
+		return insights
+	}
+
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(trimmed, "#") || !r.Heading.MatchString(strings.TrimSpace(strings.TrimLeft(trimmed, "#"))) {
+			continue
+		}
+		var bullets []string
+		bullets, i = collectBullets(lines, i+1)
+		for _, text := range bullets {
+			add(text)
+		}
+		i-- // re-examine the line that ended the list; it may be a heading
 	}
 	return insights
 }
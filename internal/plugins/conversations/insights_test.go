@@ -0,0 +1,117 @@
+package conversations
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/config"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+	"github.com/toddwbucy/hermes/internal/plugin"
+)
+
+func assistantTurns(texts ...string) []Turn {
+	turns := []Turn{{Role: "user", Messages: []adapter.Message{{
+		ContentBlocks: []adapter.ContentBlock{{Type: "text", Text: "TODO: not from the assistant"}},
+	}}}}
+	for _, text := range texts {
+		turns = append(turns, Turn{Role: "assistant", Messages: []adapter.Message{{
+			ContentBlocks: []adapter.ContentBlock{{Type: "text", Text: text}},
+		}}})
+	}
+	return turns
+}
+
+const insightSample = `Done with the refactor.
+
+**Key observation** the cache key ignores the mtime.
+
+TODO: add a regression test for rewritten files
+TODO:
+
+## Risks
+- Migration needs downtime
+- Old clients keep polling
+
+## Decisions
+- Keep the JSON format`
+
+func TestExtractInsightsBuiltin(t *testing.T) {
+	insights := extractInsights(assistantTurns(insightSample))
+	var badges []string
+	for _, ins := range insights {
+		badges = append(badges, ins.Badge())
+	}
+	if got := strings.Join(badges, ","); got != "OBS,DEC" {
+		t.Errorf("badges = %s, want OBS,DEC", got)
+	}
+}
+
+func TestInsightRules(t *testing.T) {
+	e, errs := newInsightExtractor(config.InsightsConfig{
+		Rules: []config.InsightRuleConfig{
+			{Name: "todo", Badge: "TODO", Pattern: `^TODO:\s*(.*)$`},
+			{Name: "risks", Heading: `(?i)^risks?$`},
+			{Name: "broken", Pattern: `(`},
+			{Name: "both", Pattern: "x", Heading: "y"},
+		},
+	})
+	if len(errs) != 2 || len(e.rules) != 2 {
+		t.Fatalf("errs = %v, rules = %d", errs, len(e.rules))
+	}
+
+	insights := e.extract(assistantTurns(insightSample))
+	var got []string
+	for _, ins := range insights {
+		got = append(got, ins.Badge()+":"+ins.Text)
+	}
+	want := []string{
+		"OBS:**Key observation** the cache key ignores the mtime.",
+		"DEC:Keep the JSON format",
+		"TODO:add a regression test for rewritten files",
+		// An empty capture group falls back to the whole line
+		"TODO:TODO:",
+		"RIS:Migration needs downtime",
+		"RIS:Old clients keep polling",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("insights:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if insights[2].Source != SourceCustom || insights[2].Rule.Name != "todo" {
+		t.Errorf("custom insight = %+v", insights[2])
+	}
+
+	// Built-ins can be switched off, leaving only the rules
+	e.disableBuiltin = true
+	if insights := e.extract(assistantTurns(insightSample)); len(insights) != 4 {
+		t.Errorf("rules only: %d insights, want 4", len(insights))
+	}
+}
+
+func TestCreateInsightTasksCarriesTextAndThreshold(t *testing.T) {
+	p := New()
+	p.insightDuplicateThreshold = 0.9
+	p.turns = assistantTurns(insightSample)
+	e, _ := newInsightExtractor(config.InsightsConfig{
+		Rules: []config.InsightRuleConfig{{Name: "todo", Pattern: `^TODO:\s*(.+)$`}},
+	})
+	p.insightExtractor = e
+	p.openInsightModal()
+	for i := range p.insightModalState.insights {
+		p.insightModalState.insights[i].Selected = p.insightModalState.insights[i].Source == SourceCustom
+	}
+
+	p.ctx = &plugin.Context{Epoch: 3}
+	_, cmd := p.createInsightTasks()
+	msg, ok := cmd().(appmsg.CreateInsightTasksMsg)
+	if !ok || len(msg.Tasks) != 1 {
+		t.Fatalf("msg = %+v", msg)
+	}
+	task := msg.Tasks[0]
+	if task.Text != "add a regression test for rewritten files" || msg.DuplicateThreshold != 0.9 {
+		t.Errorf("task text %q, threshold %v", task.Text, msg.DuplicateThreshold)
+	}
+	if !strings.Contains(task.Description, "**Type**: TOD") || !strings.Contains(task.Description, "**Rule**: todo") {
+		t.Errorf("description missing rule badge:\n%s", task.Description)
+	}
+}
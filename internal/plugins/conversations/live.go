@@ -0,0 +1,433 @@
+package conversations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/adapter/pricing"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+	"github.com/toddwbucy/hermes/internal/plugin"
+	"github.com/toddwbucy/hermes/internal/styles"
+)
+
+const (
+	// compactWarnFraction is the context fill at which follow mode warns
+	// that the agent is about to auto-compact.
+	compactWarnFraction = 0.85
+
+	// contextHighFraction colors the gauge as a caution before the warning.
+	contextHighFraction = 0.7
+
+	// liveTickInterval refreshes elapsed times while a tool call runs.
+	liveTickInterval = time.Second
+
+	// maxLiveToolLines caps the tool calls shown in the live strip.
+	maxLiveToolLines = 4
+)
+
+// liveToolCall is a tool call in the latest turn, with its timing.
+type liveToolCall struct {
+	ID      string
+	Name    string
+	Summary string
+	Start   time.Time
+	End     time.Time // Zero while running
+	Done    bool
+	IsError bool
+}
+
+// elapsed returns the call's duration, or the time since it started when
+// it is still running.
+func (c liveToolCall) elapsed(now time.Time) time.Duration {
+	if c.Start.IsZero() {
+		return 0
+	}
+	end := c.End
+	if !c.Done {
+		end = now
+	}
+	if end.Before(c.Start) {
+		return 0
+	}
+	return end.Sub(c.Start)
+}
+
+// contextUsage is how full the context window was at the latest request.
+type contextUsage struct {
+	Model string
+	Used  int // Input plus cache tokens sent with the latest request
+	Limit int
+}
+
+// fraction returns Used as a fraction of Limit.
+func (u contextUsage) fraction() float64 {
+	if u.Limit <= 0 {
+		return 0
+	}
+	return float64(u.Used) / float64(u.Limit)
+}
+
+// liveState is follow mode: the transcript stays pinned to the newest
+// message, with the latest turn's tool calls and a context gauge above it.
+type liveState struct {
+	paused bool // User scrolled up; loads no longer move the view
+	ticks  bool // A tick is scheduled
+
+	sessionID string
+	promptIdx int // Index of the prompt that started the latest turn
+	calls     []liveToolCall
+	usage     contextUsage
+	hasUsage  bool
+	active    bool // Session is still being written to
+	turnIn    int
+	turnOut   int
+	delta     int // Output tokens added by the latest reload
+
+	compactWarned map[string]bool // Session IDs already warned about compaction
+	stripLines    int             // Lines the strip took at the last render
+}
+
+// LiveTickMsg re-renders elapsed times in follow mode.
+type LiveTickMsg struct {
+	Epoch uint64
+	Token int // Must match liveToken to be valid
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m LiveTickMsg) GetEpoch() uint64 { return m.Epoch }
+
+// latestPromptIndex returns the index of the last user message that is a
+// prompt rather than tool results, or -1.
+func latestPromptIndex(messages []adapter.Message) int {
+	for i := len(messages) - 1; i >= 0; i-- {
+		m := messages[i]
+		if m.Role != "user" {
+			continue
+		}
+		if isToolResultOnly(m) {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// liveToolCalls returns the tool calls made after messages[from], oldest
+// first. A call ends at the message carrying its result; adapters that put
+// the output on the call itself end it at the next message.
+func liveToolCalls(messages []adapter.Message, from int) []liveToolCall {
+	var calls []liveToolCall
+	byID := make(map[string]int)
+	for i := from + 1; i < len(messages); i++ {
+		msg := messages[i]
+		for _, b := range msg.ContentBlocks {
+			if b.Type != "tool_result" || b.ToolUseID == "" {
+				continue
+			}
+			if j, ok := byID[b.ToolUseID]; ok && !calls[j].Done {
+				calls[j].Done = true
+				calls[j].End = msg.Timestamp
+				calls[j].IsError = b.IsError
+			}
+		}
+		if msg.Role != "assistant" {
+			continue
+		}
+		var next time.Time
+		if i+1 < len(messages) {
+			next = messages[i+1].Timestamp
+		}
+		for _, b := range replayToolCalls(msg) {
+			call := liveToolCall{
+				ID:      b.ToolUseID,
+				Name:    b.ToolName,
+				Summary: extractToolCommand(b.ToolName, b.ToolInput, 60),
+				Start:   msg.Timestamp,
+			}
+			if b.ToolOutput != "" {
+				call.Done = true
+				call.End = next
+				if next.IsZero() {
+					call.End = msg.Timestamp
+				}
+				call.IsError = b.IsError
+			}
+			if call.ID != "" {
+				byID[call.ID] = len(calls)
+			}
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// latestContextUsage returns the prompt size of the newest assistant
+// message that reports token usage.
+func latestContextUsage(messages []adapter.Message, fallbackModel string) (contextUsage, bool) {
+	for i := len(messages) - 1; i >= 0; i-- {
+		m := messages[i]
+		if m.Role != "assistant" {
+			continue
+		}
+		used := m.InputTokens + m.CacheRead + m.CacheWrite
+		if used == 0 {
+			continue
+		}
+		model := m.Model
+		if model == "" {
+			model = fallbackModel
+		}
+		return contextUsage{Model: model, Used: used, Limit: pricing.ContextWindow(model)}, true
+	}
+	return contextUsage{}, false
+}
+
+// toggleFollow turns follow mode on or off for the main pane.
+func (p *Plugin) toggleFollow() (plugin.Plugin, tea.Cmd) {
+	p.liveToken++
+	p.hitRegionsDirty = true
+	if p.live != nil {
+		p.live = nil
+		return p, appmsg.ShowToast("Follow off", 2*time.Second)
+	}
+	p.live = &liveState{compactWarned: make(map[string]bool)}
+	p.pinToBottom()
+	cmd := p.refreshLive()
+	return p, tea.Batch(cmd, appmsg.ShowToast("Following (scroll up to pause, G to resume)", 2*time.Second))
+}
+
+// pauseFollow stops loads from moving the view until G is pressed.
+func (p *Plugin) pauseFollow() {
+	if p.live != nil {
+		p.live.paused = true
+	}
+}
+
+// resumeFollow pins the view to the bottom again.
+func (p *Plugin) resumeFollow() {
+	if p.live != nil && p.live.paused {
+		p.live.paused = false
+		p.pinToBottom()
+	}
+}
+
+// pinToBottom moves the cursor to the newest message or turn.
+func (p *Plugin) pinToBottom() {
+	if p.turnViewMode {
+		if len(p.turns) > 0 {
+			p.turnCursor = len(p.turns) - 1
+			p.ensureTurnCursorVisible()
+		}
+		return
+	}
+	visibleIndices := p.visibleMessageIndices()
+	if len(visibleIndices) > 0 {
+		p.messageCursor = visibleIndices[len(visibleIndices)-1]
+		p.messageScroll = 999999 // Clamped in renderer
+	}
+}
+
+// refreshLive recomputes the live strip after messages load, pins the view
+// unless paused, and warns once per session when compaction is close.
+func (p *Plugin) refreshLive() tea.Cmd {
+	l := p.live
+	if l == nil || p.loadedSession != p.selectedSession {
+		return nil
+	}
+
+	promptIdx := latestPromptIndex(p.messages)
+	turnIn, turnOut := 0, 0
+	for _, m := range p.messages[promptIdx+1:] {
+		turnIn += m.InputTokens + m.CacheRead + m.CacheWrite
+		turnOut += m.OutputTokens
+	}
+	if l.sessionID == p.selectedSession && l.promptIdx == promptIdx && turnOut > l.turnOut {
+		l.delta = turnOut - l.turnOut
+	} else {
+		l.delta = 0
+	}
+	l.sessionID = p.selectedSession
+	l.promptIdx = promptIdx
+	l.turnIn, l.turnOut = turnIn, turnOut
+	l.calls = liveToolCalls(p.messages, promptIdx)
+
+	var fallbackModel string
+	if p.sessionSummary != nil {
+		fallbackModel = p.sessionSummary.PrimaryModel
+	}
+	l.usage, l.hasUsage = latestContextUsage(p.messages, fallbackModel)
+	l.active = false
+	if s := p.findSelectedSession(); s != nil {
+		l.active = s.IsActive
+	}
+
+	if !l.paused {
+		p.pinToBottom()
+	}
+
+	var cmds []tea.Cmd
+	if l.hasUsage && l.usage.fraction() >= compactWarnFraction && !l.compactWarned[l.sessionID] {
+		l.compactWarned[l.sessionID] = true
+		text := fmt.Sprintf("Context %d%% full: auto-compaction is close", int(l.usage.fraction()*100))
+		cmds = append(cmds, appmsg.ShowToast(text, 4*time.Second))
+	}
+	cmds = append(cmds, p.scheduleLiveTick())
+	return tea.Batch(cmds...)
+}
+
+// hasRunningTool reports whether a tool call in the latest turn is still
+// running in a live session.
+func (l *liveState) hasRunningTool() bool {
+	if !l.active {
+		return false
+	}
+	for _, c := range l.calls {
+		if !c.Done {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleLiveTick schedules a re-render while a tool call is running.
+func (p *Plugin) scheduleLiveTick() tea.Cmd {
+	l := p.live
+	if l == nil || l.ticks || !l.hasRunningTool() {
+		return nil
+	}
+	l.ticks = true
+	token := p.liveToken
+	var epoch uint64
+	if p.ctx != nil {
+		epoch = p.ctx.Epoch
+	}
+	return tea.Tick(liveTickInterval, func(time.Time) tea.Msg {
+		return LiveTickMsg{Epoch: epoch, Token: token}
+	})
+}
+
+// handleLiveTick keeps ticking until no tool call is running.
+func (p *Plugin) handleLiveTick(msg LiveTickMsg) tea.Cmd {
+	if p.live == nil || msg.Token != p.liveToken {
+		return nil
+	}
+	p.live.ticks = false
+	return p.scheduleLiveTick()
+}
+
+// renderLiveStrip renders the follow-mode status above the transcript: the
+// follow state with the turn's token counts, the context gauge, and the
+// turn's most recent tool calls.
+func (p *Plugin) renderLiveStrip(width int, now time.Time) []string {
+	l := p.live
+	if l == nil || l.sessionID != p.selectedSession {
+		return nil
+	}
+
+	var state string
+	if l.paused {
+		state = styles.StatusModified.Render("❚❚ PAUSED") + styles.Muted.Render(" G:resume")
+	} else {
+		state = styles.StatusStaged.Render("● LIVE")
+	}
+	turn := fmt.Sprintf("  turn in:%s out:%s", formatK(l.turnIn), formatK(l.turnOut))
+	line := state + styles.Muted.Render(turn)
+	if l.delta > 0 {
+		line += " " + styles.StatusStaged.Render("▲+"+formatK(l.delta))
+	}
+	lines := []string{line}
+
+	if l.hasUsage {
+		lines = append(lines, renderContextGauge(l.usage, width))
+	}
+
+	calls := l.calls
+	if hidden := len(calls) - maxLiveToolLines; hidden > 0 {
+		lines = append(lines, styles.Subtle.Render(fmt.Sprintf("  +%d earlier tool call%s", hidden, plural(hidden))))
+		calls = calls[hidden:]
+	}
+	for _, c := range calls {
+		lines = append(lines, renderLiveToolCall(c, l.active, width, now))
+	}
+	return lines
+}
+
+// renderContextGauge renders the context window fill as a bar, warning
+// when auto-compaction is close.
+func renderContextGauge(u contextUsage, width int) string {
+	f := u.fraction()
+	barWidth := min(max(width-40, 10), 30)
+	filled := min(max(int(f*float64(barWidth)), 0), barWidth)
+
+	color := styles.Success
+	switch {
+	case f >= compactWarnFraction:
+		color = styles.Error
+	case f >= contextHighFraction:
+		color = styles.Warning
+	}
+	bar := lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		styles.Muted.Render(strings.Repeat("░", barWidth-filled))
+
+	line := styles.Muted.Render("ctx ") + bar +
+		styles.Muted.Render(fmt.Sprintf(" %s/%s %d%%", formatK(u.Used), formatK(u.Limit), int(f*100)))
+	if f >= compactWarnFraction {
+		line += " " + styles.StatusDeleted.Render("⚠ near auto-compact")
+	}
+	return line
+}
+
+// renderLiveToolCall renders one tool call with its state and elapsed time.
+func renderLiveToolCall(c liveToolCall, active bool, width int, now time.Time) string {
+	var icon, elapsed string
+	switch {
+	case c.Done && c.IsError:
+		icon = styles.StatusDeleted.Render("✗")
+		elapsed = formatElapsed(c.elapsed(now))
+	case c.Done:
+		icon = styles.StatusStaged.Render("✓")
+		elapsed = formatElapsed(c.elapsed(now))
+	case active:
+		icon = styles.StatusModified.Render("⟳")
+		elapsed = styles.StatusModified.Render(formatElapsed(c.elapsed(now)))
+	default:
+		icon = styles.Muted.Render("⋯")
+		elapsed = "no result"
+	}
+
+	name := c.Name
+	if name == "" {
+		name = "tool"
+	}
+	summary := c.Summary
+	maxSummary := width - lipgloss.Width(name) - lipgloss.Width(elapsed) - 8
+	if maxSummary < 4 {
+		summary = ""
+	} else {
+		summary = truncateStr(summary, maxSummary)
+	}
+	line := "  " + icon + " " + styles.Code.Render(name)
+	if summary != "" {
+		line += " " + styles.Muted.Render(summary)
+	}
+	return line + "  " + styles.Muted.Render(elapsed)
+}
+
+// formatElapsed formats a tool call duration, with tenths of a second for
+// short calls.
+func formatElapsed(d time.Duration) string {
+	switch {
+	case d < 10*time.Second:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
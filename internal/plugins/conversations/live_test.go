@@ -0,0 +1,115 @@
+package conversations
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+func liveTestMessages(start time.Time) []adapter.Message {
+	return []adapter.Message{
+		{ID: "m1", Role: "user", Content: "old prompt", Timestamp: start.Add(-time.Hour)},
+		{ID: "m2", Role: "assistant", Content: "done", Timestamp: start.Add(-time.Hour),
+			ToolUses: []adapter.ToolUse{{ID: "old", Name: "Read", Output: "x"}}},
+		{ID: "m3", Role: "user", Content: "run the tests", Timestamp: start},
+		{ID: "m4", Role: "assistant", Timestamp: start.Add(time.Second), Model: "claude-sonnet-4-5",
+			TokenUsage: adapter.TokenUsage{InputTokens: 10, CacheRead: 150000, CacheWrite: 20000, OutputTokens: 300},
+			ContentBlocks: []adapter.ContentBlock{
+				{Type: "tool_use", ToolUseID: "t1", ToolName: "Bash", ToolInput: `{"command":"go test ./..."}`},
+				{Type: "tool_use", ToolUseID: "t2", ToolName: "Read", ToolInput: `{"file_path":"main.go"}`},
+			}},
+		{ID: "m5", Role: "user", Timestamp: start.Add(4 * time.Second), ContentBlocks: []adapter.ContentBlock{
+			{Type: "tool_result", ToolUseID: "t2", ToolOutput: "package main"},
+		}},
+	}
+}
+
+func TestLiveToolCalls(t *testing.T) {
+	start := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	messages := liveTestMessages(start)
+
+	from := latestPromptIndex(messages)
+	if from != 2 {
+		t.Fatalf("latestPromptIndex = %d, want 2 (tool results are not prompts)", from)
+	}
+	calls := liveToolCalls(messages, from)
+	if len(calls) != 2 {
+		t.Fatalf("got %d calls, want only the latest turn's 2", len(calls))
+	}
+	now := start.Add(13 * time.Second)
+	if bash := calls[0]; bash.Done || bash.elapsed(now) != 12*time.Second || !strings.Contains(bash.Summary, "go test") {
+		t.Errorf("running call = %+v, elapsed %v", bash, bash.elapsed(now))
+	}
+	if read := calls[1]; !read.Done || read.elapsed(now) != 3*time.Second {
+		t.Errorf("finished call = %+v, elapsed %v", read, read.elapsed(now))
+	}
+
+	usage, ok := latestContextUsage(messages, "")
+	if !ok || usage.Used != 170010 || usage.Limit != 200000 {
+		t.Fatalf("usage = %+v, ok = %v", usage, ok)
+	}
+	if usage.fraction() < compactWarnFraction {
+		t.Errorf("fraction %.2f should be past the compaction warning", usage.fraction())
+	}
+}
+
+func TestFollowMode(t *testing.T) {
+	start := time.Now().Add(-10 * time.Second)
+	messages := liveTestMessages(start)
+
+	p := New()
+	p.adapters = map[string]adapter.Adapter{"mock": &mockAdapter{}}
+	p.width, p.height = 120, 40
+	p.sessions = []adapter.Session{{ID: "s1", Name: "Live", AdapterID: "mock", IsActive: true}}
+	p.selectedSession = "s1"
+	p.activePane = PaneMessages
+	_, _ = p.Update(MessagesLoadedMsg{SessionID: "s1", Messages: messages[:4], TotalCount: 4})
+
+	_, cmd := p.updateMessages(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if p.live == nil || cmd == nil {
+		t.Fatal("f should turn on follow mode")
+	}
+	if p.messageCursor != 3 {
+		t.Errorf("messageCursor = %d, want pinned to the newest message", p.messageCursor)
+	}
+	if !p.live.hasRunningTool() || !p.live.ticks {
+		t.Error("running tool call should schedule ticks")
+	}
+
+	view := p.View(120, 40)
+	for _, want := range []string{"LIVE", "ctx", "85%", "near auto-compact", "Bash"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	// Scrolling up pauses; new messages no longer move the cursor
+	_, _ = p.updateMessages(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	if !p.live.paused {
+		t.Fatal("k should pause follow mode")
+	}
+	cursor := p.messageCursor
+	grown := append(messages, adapter.Message{ID: "m6", Role: "assistant", Content: "All tests pass", Timestamp: start.Add(5 * time.Second),
+		TokenUsage: adapter.TokenUsage{InputTokens: 10, CacheRead: 150000, OutputTokens: 200}})
+	_, _ = p.Update(MessagesLoadedMsg{SessionID: "s1", Messages: grown, TotalCount: len(grown)})
+	if p.messageCursor != cursor {
+		t.Errorf("paused follow moved the cursor to %d", p.messageCursor)
+	}
+	if p.live.delta != 200 {
+		t.Errorf("delta = %d, want the new output tokens", p.live.delta)
+	}
+
+	// G resumes and pins to the newest message
+	_, _ = p.updateMessages(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if p.live.paused || p.messageCursor != 5 {
+		t.Errorf("G: paused=%v cursor=%d", p.live.paused, p.messageCursor)
+	}
+
+	_, _ = p.updateMessages(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if p.live != nil {
+		t.Error("second f should turn follow mode off")
+	}
+}
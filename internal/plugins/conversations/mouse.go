@@ -14,6 +14,16 @@ func (p *Plugin) handleMouse(msg tea.MouseMsg) (*Plugin, tea.Cmd) {
 		return p, cmd
 	}
 
+	// Tag and saved filter prompt buttons
+	if p.promptModal != nil {
+		return p, p.handlePromptModalMouse(msg)
+	}
+
+	// Annotation editor buttons
+	if p.showAnnotationModal {
+		return p, p.handleAnnotationModalMouse(msg)
+	}
+
 	action := p.mouseHandler.HandleMouse(msg)
 
 	switch action.Type {
@@ -99,6 +109,10 @@ func (p *Plugin) handleMouseClick(action mouse.MouseAction) (*Plugin, tea.Cmd) {
 		}
 		return p, nil
 
+	case regionReplayScrubber:
+		p.activePane = PaneMessages
+		return p, p.seekReplayToColumn(action.X-action.Region.Rect.X, action.Region.Rect.W)
+
 	case regionPaneDivider:
 		// Start drag for pane resizing
 		p.mouseHandler.StartDrag(action.X, action.Y, regionPaneDivider, p.sidebarWidth)
@@ -176,6 +190,10 @@ func (p *Plugin) handleMouseScroll(action mouse.MouseAction) (*Plugin, tea.Cmd)
 		if action.X < p.sidebarWidth+2 {
 			return p.scrollSidebar(action.Delta)
 		}
+		if p.replay != nil {
+			p.seekReplay(p.replay.pos + action.Delta)
+			return p, nil
+		}
 		if p.detailMode {
 			return p.scrollDetailPane(action.Delta)
 		}
@@ -186,7 +204,11 @@ func (p *Plugin) handleMouseScroll(action mouse.MouseAction) (*Plugin, tea.Cmd)
 	case regionSidebar, regionSessionItem:
 		return p.scrollSidebar(action.Delta)
 
-	case regionMainPane, regionTurnItem, regionMessageItem:
+	case regionMainPane, regionTurnItem, regionMessageItem, regionReplayScrubber:
+		if p.replay != nil {
+			p.seekReplay(p.replay.pos + action.Delta)
+			return p, nil
+		}
 		if p.detailMode {
 			return p.scrollDetailPane(action.Delta)
 		}
@@ -235,6 +257,9 @@ func (p *Plugin) scrollSidebar(delta int) (*Plugin, tea.Cmd) {
 
 // scrollMainPane scrolls the main messages pane.
 func (p *Plugin) scrollMainPane(delta int) (*Plugin, tea.Cmd) {
+	if delta < 0 {
+		p.pauseFollow()
+	}
 	if p.turnViewMode {
 		// Turn view: scroll by moving turn cursor
 		if len(p.turns) == 0 {
@@ -6,6 +6,7 @@ import (
 	"io"
 	"log"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,8 +14,11 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/adapter/archive"
+	"github.com/toddwbucy/hermes/internal/adapter/otlp"
 	"github.com/toddwbucy/hermes/internal/adapter/tieredwatcher"
 	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/config"
 	"github.com/toddwbucy/hermes/internal/modal"
 	"github.com/toddwbucy/hermes/internal/mouse"
 	appmsg "github.com/toddwbucy/hermes/internal/msg"
@@ -61,6 +65,8 @@ const (
 	regionMessageItem = "message-item" // Conversation flow: click to select (Data: msg index)
 	regionToolExpand  = "tool-expand"  // Conversation flow: toggle tool output (Data: tool_use_id)
 	regionShowMore    = "show-more"    // Conversation flow: expand long message (Data: msg ID)
+
+	regionReplayScrubber = "replay-scrubber" // Replay timeline: click to seek
 )
 
 // View represents the current view mode.
@@ -71,6 +77,7 @@ const (
 	ViewMessages
 	ViewAnalytics
 	ViewMessageDetail
+	ViewCompare
 )
 
 // FocusPane represents which pane is active in two-pane mode.
@@ -134,10 +141,32 @@ type Plugin struct {
 	detailTurn   *Turn // turn being viewed in detail
 	detailScroll int
 
+	// Replay mode state (nil when not replaying)
+	replay      *replayState
+	replayToken int // monotonically increasing token to cancel stale playback ticks
+
+	// Trace waterfall state (nil when not viewing spans)
+	trace *traceState
+
+	// Follow mode state (nil when not following)
+	live      *liveState
+	liveToken int // monotonically increasing token to cancel stale live ticks
+
 	// Analytics view state
 	analyticsScrollOff int
 	analyticsLines     []string // pre-rendered lines for scrolling
 
+	// Compare view state
+	compareBase      string             // session ID marked with X, awaiting a second session
+	compare          *SessionComparison // loaded comparison (nil while loading)
+	compareLoading   bool
+	compareErr       error
+	compareScrollOff int
+	compareLines     []string // pre-rendered lines for scrolling
+
+	// Tool analytics indexing state
+	toolIndexing bool // true while a background tool-index pass is running
+
 	// Layout state
 	activePane         FocusPane // Which pane is focused
 	sidebarRestore     FocusPane // Tracks pane focused before collapse; restored on expand via toggleSidebar()
@@ -241,6 +270,9 @@ type Plugin struct {
 	resumeSkipPermissions bool
 	resumeFocus           int
 	resumeSession         *adapter.Session
+	resumeForkMsgID       string // Set when forking: last message kept in the fork
+	resumeForkTurn        int    // 1-based turn forked after
+	resumeForkTurns       int    // Turns in the loaded conversation
 
 	// Content search state (td-6ac70a: cross-conversation search)
 	contentSearchMode  bool                // True when content search modal is open
@@ -250,6 +282,40 @@ type Plugin struct {
 	showInsightModal  bool
 	insightModalState *insightModalState
 
+	// Insight extraction rules and duplicate threshold from config
+	insightExtractor          *insightExtractor
+	insightDuplicateThreshold float64
+
+	// Message bookmarks and annotations, shared across projects
+	bookmarks             *BookmarkStore
+	showBookmarksModal    bool
+	bookmarksModalState   *bookmarksModalState
+	showAnnotationModal   bool
+	annotationModal       *modal.Modal
+	annotationModalWidth  int
+	annotationInput       textinput.Model
+	annotationTarget      *Bookmark
+	annotationFromBrowser bool // Return to the bookmarks browser on close
+
+	// User tags on sessions, shared across projects
+	tags        *TagStore
+	promptModal *promptModalState // Tag editor / saved filter name prompt
+
+	// Links between forked sessions and their origins
+	forks *ForkStore
+
+	// Storage view: disk usage, archiving and retention
+	showStorageModal  bool
+	storageModalState *storageModalState
+	archiveStore      *archive.Store
+	retention         config.RetentionConfig
+
+	// Cost and token budgets covering this project
+	budgets         []config.BudgetConfig
+	budgetAlerted   map[string]budgetLevel // Last level alerted per budget period
+	budgetBadge     app.HeaderBadgeMsg     // Badge last sent to the header
+	showBudgetModal bool
+
 	// Pending scroll target after messages load (td-b74d9f)
 	// Uses message ID (not index) to handle pagination correctly
 	pendingScrollMsgID  string // Target message ID to scroll to after load ("" = none)
@@ -295,6 +361,12 @@ func New() *Plugin {
 		sidebarRestore:      PaneSidebar,
 		warnedSessions:      make(map[string]bool),
 		skeleton:            ui.NewSkeleton(8, nil), // 8 placeholder rows
+		bookmarks:           LoadBookmarkStore(""),  // In-memory until Init
+		tags:                LoadTagStore(""),       // In-memory until Init
+		forks:               LoadForkStore(""),      // In-memory until Init
+		archiveStore:        archive.NewStore(""),   // Set in Init
+		budgetAlerted:       make(map[string]budgetLevel),
+		budgetBadge:         app.HeaderBadgeMsg{ID: budgetBadgeID}, // None shown
 	}
 	p.coalescer = NewEventCoalescer(0, coalesceChan)
 	return p
@@ -361,10 +433,32 @@ func (p *Plugin) resetState() {
 	p.detailTurn = nil
 	p.detailScroll = 0
 
+	// Replay mode state
+	p.replay = nil
+	p.replayToken = 0
+
+	// Trace waterfall state
+	p.trace = nil
+
+	// Follow mode state
+	p.live = nil
+	p.liveToken = 0
+
 	// Analytics view state
 	p.analyticsScrollOff = 0
 	p.analyticsLines = nil
 
+	// Compare view state
+	p.compareBase = ""
+	p.compare = nil
+	p.compareLoading = false
+	p.compareErr = nil
+	p.compareScrollOff = 0
+	p.compareLines = nil
+
+	// Tool analytics indexing state
+	p.toolIndexing = false
+
 	// Layout state - reset to defaults but preserve sidebarWidth (persisted)
 	p.activePane = PaneSidebar
 	p.sidebarRestore = PaneSidebar
@@ -430,6 +524,20 @@ func (p *Plugin) resetState() {
 	p.showInsightModal = false
 	p.insightModalState = nil
 
+	// Bookmark modal state
+	p.showBookmarksModal = false
+	p.bookmarksModalState = nil
+	p.showAnnotationModal = false
+	p.annotationModal = nil
+	p.annotationTarget = nil
+	p.annotationFromBrowser = false
+
+	// Storage view state
+	p.showStorageModal = false
+	p.storageModalState = nil
+	p.showBudgetModal = false
+	p.promptModal = nil
+
 	// Pending scroll state (td-b74d9f)
 	p.pendingScrollMsgID = ""
 	p.pendingScrollActive = false
@@ -460,12 +568,71 @@ func (p *Plugin) Init(ctx *plugin.Context) error {
 		p.defaultCategoryFilter = []string{adapter.SessionCategoryInteractive}
 	}
 
+	// Build insight extractors from config; bad rules are skipped
+	p.insightExtractor = &insightExtractor{}
+	if ctx.Config != nil {
+		insights := ctx.Config.Plugins.Conversations.Insights
+		var errs []error
+		p.insightExtractor, errs = newInsightExtractor(insights)
+		for _, err := range errs {
+			if ctx.Logger != nil {
+				ctx.Logger.Warn("conversations: invalid insight rule", "error", err)
+			}
+		}
+		p.insightDuplicateThreshold = insights.DuplicateThreshold
+	}
+
+	// Bookmarks are global, so they survive project switches
+	p.bookmarks = LoadBookmarkStore(defaultBookmarksPath(ctx.ConfigDir))
+
+	// Tags are global like bookmarks
+	p.tags = LoadTagStore(defaultTagsPath(ctx.ConfigDir))
+	p.forks = LoadForkStore(defaultForksPath(ctx.ConfigDir))
+
+	// Archives are shared across projects like bookmarks
+	p.archiveStore = archive.NewStore(archive.DefaultDir(ctx.ConfigDir))
+	p.retention = config.RetentionConfig{}
+	if ctx.Config != nil {
+		p.retention = ctx.Config.Plugins.Conversations.Retention
+	}
+
+	// Budgets are evaluated as sessions load and update; bad ones are skipped
+	p.budgets = nil
+	if ctx.Config != nil {
+		for _, b := range ctx.Config.Plugins.Conversations.Budgets {
+			if reason := budgetError(b); reason != "" {
+				if ctx.Logger != nil {
+					ctx.Logger.Warn("conversations: invalid budget", "budget", budgetLabel(b), "error", reason)
+				}
+				continue
+			}
+			p.budgets = append(p.budgets, b)
+		}
+	}
+
 	// Default workspace filter ON to show only sessions from current project (td-0ea560)
 	p.filters.WorkspaceCWD = ctx.WorkDir
 	p.filterActive = p.filters.IsActive()
 
+	// Saved filters are palette commands; the default one applies on startup
+	p.registerSavedFilterBindings()
+	if ctx.Config != nil && ctx.Config.Plugins.Conversations.DefaultFilter != "" {
+		name := ctx.Config.Plugins.Conversations.DefaultFilter
+		if sf, ok := p.findSavedFilter(name); ok {
+			p.filters = filtersFromSaved(sf, p.filters.WorkspaceCWD)
+			p.filterActive = p.filters.IsActive()
+		} else if ctx.Logger != nil {
+			ctx.Logger.Warn("conversations: default filter not found", "name", name)
+		}
+	}
+
 	p.adapters = make(map[string]adapter.Adapter)
 	for id, a := range ctx.Adapters {
+		// The OTLP receiver is configured here and starts in Detect
+		if receiver, ok := a.(*otlp.Adapter); ok && ctx.Config != nil {
+			rc := ctx.Config.Plugins.Conversations.OTLPReceiver
+			receiver.Configure(otlp.Options{Enabled: rc.Enabled, Port: rc.Port, StateDir: otlp.DefaultDir(ctx.ConfigDir)})
+		}
 		found, err := a.Detect(ctx.ProjectRoot)
 		if err != nil || !found {
 			continue
@@ -527,6 +694,45 @@ func (p *Plugin) closeWatchers() {
 	}
 }
 
+// applyPendingScroll moves the message cursor to the pending scroll target,
+// if one is set and present in the loaded messages (td-b74d9f).
+// Uses message ID (not index) to handle pagination correctly.
+func (p *Plugin) applyPendingScroll() {
+	if !p.pendingScrollActive || p.pendingScrollMsgID == "" {
+		return
+	}
+	p.pendingScrollActive = false
+	targetMsgID := p.pendingScrollMsgID
+	p.pendingScrollMsgID = ""
+
+	// Find the message by ID in the loaded messages
+	foundIdx := -1
+	for i, m := range p.messages {
+		if m.ID == targetMsgID {
+			foundIdx = i
+			break
+		}
+	}
+	if foundIdx < 0 {
+		return
+	}
+
+	// Find the corresponding visible index (skip tool-result-only messages)
+	visibleIndices := p.visibleMessageIndices()
+	for i, idx := range visibleIndices {
+		if idx >= foundIdx {
+			p.messageCursor = idx
+			p.ensureMessageCursorVisible()
+			break
+		}
+		// If we're at the last visible index, use it
+		if i == len(visibleIndices)-1 {
+			p.messageCursor = idx
+			p.ensureMessageCursorVisible()
+		}
+	}
+}
+
 // Update handles messages.
 func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -566,6 +772,25 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			return p.handleInsightModalKey(msg)
 		}
 
+		// Text prompts open over the session list and filter menu
+		if p.promptModal != nil {
+			return p.handlePromptModalKey(msg)
+		}
+
+		// Annotation editor sits above the bookmarks browser
+		if p.showAnnotationModal {
+			return p.handleAnnotationModalKey(msg)
+		}
+		if p.showBookmarksModal {
+			return p.handleBookmarksModalKey(msg)
+		}
+		if p.showStorageModal {
+			return p.handleStorageModalKey(msg)
+		}
+		if p.showBudgetModal {
+			return p.handleBudgetModalKey(msg)
+		}
+
 		// Handle content search modal first if open (td-6ac70a)
 		if p.contentSearchMode {
 			return p.handleContentSearchKey(msg)
@@ -580,6 +805,8 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		switch p.view {
 		case ViewAnalytics:
 			return p.updateAnalytics(msg)
+		case ViewCompare:
+			return p.updateCompare(msg)
 		default:
 			// Route based on active pane
 			if p.activePane == PaneMessages {
@@ -654,6 +881,14 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			if cmd := p.checkPiDiscoveryToast(); cmd != nil {
 				cmds = append(cmds, cmd)
 			}
+			// Index tool calls and file touches in the background
+			if cmd := p.indexToolStats(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			// Alert on budgets the loaded sessions push over a threshold
+			if cmd := p.checkBudgets(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 			// Schedule settle check for skeleton hide
 			if !p.initialLoadDone {
 				p.loadSettleToken++
@@ -749,6 +984,12 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		if settleCmd != nil {
 			cmds = append(cmds, settleCmd)
 		}
+		if indexCmd := p.indexToolStats(); indexCmd != nil {
+			cmds = append(cmds, indexCmd)
+		}
+		if budgetCmd := p.checkBudgets(); budgetCmd != nil {
+			cmds = append(cmds, budgetCmd)
+		}
 		p.updateTieredHotTargets()
 		if len(cmds) > 0 {
 			return p, tea.Batch(cmds...)
@@ -784,8 +1025,25 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		})
 		p.hasMoreSessions = len(p.sessions) > p.displayedCount
 		p.updateTieredHotTargets()
+		// Watcher updates carry new cost estimates
+		return p, p.checkBudgets()
+
+	case ToolIndexBuiltMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.toolIndexing = false
+		return p, nil
+
+	case appmsg.RunCommandMsg:
+		if name, ok := strings.CutPrefix(msg.CommandID, savedFilterCommandPrefix); ok {
+			return p, p.applySavedFilter(name)
+		}
 		return p, nil
 
+	case appmsg.OpenConversationMsg:
+		return p, p.openConversation(msg.AdapterID, msg.SessionID, msg.MessageID)
+
 	case LoadSettledMsg:
 		// Only settle if token matches (no new sessions arrived) (td-6cc19f)
 		if msg.Token == p.loadSettleToken && !p.initialLoadDone {
@@ -794,6 +1052,43 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		}
 		return p, nil
 
+	case StorageActionDoneMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p.handleStorageActionDone(msg)
+
+	case CompareLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) || p.view != ViewCompare {
+			return p, nil
+		}
+		p.compareLoading = false
+		p.compareErr = msg.Err
+		if msg.Err == nil {
+			c := msg.Comparison
+			p.compare = &c
+		}
+		return p, nil
+
+	case ReplayTickMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleReplayTick(msg)
+
+	case LiveTickMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleLiveTick(msg)
+
+	case TraceLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleTraceLoaded(msg)
+		return p, nil
+
 	case PreviewLoadMsg:
 		if plugin.IsStale(p.ctx, msg) {
 			return p, nil // Ignore stale message from previous project
@@ -910,41 +1205,13 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		p.hasOlderMsgs = (msg.Offset + len(msg.Messages)) < msg.TotalCount
 
 		// Process pending scroll request from content search (td-b74d9f)
-		// Uses message ID (not index) to handle pagination correctly
-		if p.pendingScrollActive && p.pendingScrollMsgID != "" {
-			p.pendingScrollActive = false
-			targetMsgID := p.pendingScrollMsgID
-			p.pendingScrollMsgID = ""
-
-			// Find the message by ID in the loaded messages
-			foundIdx := -1
-			for i, m := range p.messages {
-				if m.ID == targetMsgID {
-					foundIdx = i
-					break
-				}
-			}
+		p.applyPendingScroll()
 
-			// If found, scroll to it
-			if foundIdx >= 0 {
-				// Find the corresponding visible index (skip tool-result-only messages)
-				visibleIndices := p.visibleMessageIndices()
-				for i, idx := range visibleIndices {
-					if idx >= foundIdx {
-						p.messageCursor = idx
-						p.ensureMessageCursorVisible()
-						break
-					}
-					// If we're at the last visible index, use it
-					if i == len(visibleIndices)-1 {
-						p.messageCursor = idx
-						p.ensureMessageCursorVisible()
-					}
-				}
-			}
-		}
+		// Keep an open replay timeline in sync with live sessions
+		p.refreshReplaySteps()
 
-		return p, nil
+		// Live sessions grow an open trace waterfall in place
+		return p, tea.Batch(p.refreshTrace(), p.refreshLive())
 
 	case WatchStartedMsg:
 		// Watcher started, store channel and start listening
@@ -1028,8 +1295,12 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 					return app.ToastMsg{Message: "Error creating tasks: " + msg.Err.Error(), Duration: 3 * time.Second, IsError: true}
 				}
 			}
+			toast := fmt.Sprintf("Created %d insight tasks", msg.Count)
+			if n := len(msg.Skipped); n > 0 {
+				toast += fmt.Sprintf(", skipped %d already tracked", n)
+			}
 			return p, func() tea.Msg {
-				return app.ToastMsg{Message: fmt.Sprintf("Created %d insight tasks", msg.Count), Duration: 3 * time.Second}
+				return app.ToastMsg{Message: toast, Duration: 3 * time.Second}
 			}
 		}
 		return p, nil
@@ -1062,6 +1333,7 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 				msg.Query,
 				p.sessions,
 				p.adapters,
+				p.bookmarks.All(),
 				adapter.SearchOptions{
 					UseRegex:      p.contentSearchState.UseRegex,
 					CaseSensitive: p.contentSearchState.CaseSensitive,
@@ -1123,6 +1395,40 @@ func (p *Plugin) View(width, height int) string {
 		)
 	}
 
+	if p.promptModal != nil {
+		content := p.renderPromptModal(width, height)
+		return lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height).Render(content)
+	}
+
+	// Handle annotation editor and bookmarks browser overlays
+	if p.showAnnotationModal {
+		content := p.renderAnnotationModal(width, height)
+		return lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height).Render(content)
+	}
+	if p.showBookmarksModal && p.bookmarksModalState != nil {
+		background := p.renderTwoPane()
+		modalContent := p.renderBookmarksModal(width, height)
+		return lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height).Render(
+			ui.OverlayModal(background, modalContent, width, height),
+		)
+	}
+
+	if p.showStorageModal && p.storageModalState != nil {
+		background := p.renderTwoPane()
+		modalContent := p.renderStorageModal(width, height)
+		return lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height).Render(
+			ui.OverlayModal(background, modalContent, width, height),
+		)
+	}
+
+	if p.showBudgetModal {
+		background := p.renderTwoPane()
+		modalContent := p.renderBudgetModal(width, height)
+		return lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height).Render(
+			ui.OverlayModal(background, modalContent, width, height),
+		)
+	}
+
 	// Handle content search modal overlay (td-6ac70a, td-435ae6)
 	if p.contentSearchMode && p.contentSearchState != nil {
 		background := p.renderTwoPane()
@@ -1143,6 +1449,8 @@ func (p *Plugin) View(width, height int) string {
 		switch p.view {
 		case ViewAnalytics:
 			content = p.renderAnalytics()
+		case ViewCompare:
+			content = p.renderCompare()
 		default:
 			content = p.renderTwoPane()
 		}
@@ -1170,6 +1478,40 @@ func (p *Plugin) Commands() []plugin.Command {
 			{ID: "navigate", Name: "Nav", Description: "Navigate ↑/↓", Category: plugin.CategoryNavigation, Context: "conversations-insights", Priority: 4},
 		}
 	}
+	if p.promptModal != nil {
+		return []plugin.Command{
+			{ID: "save", Name: "Save", Description: "Save", Category: plugin.CategoryActions, Context: "conversations-prompt", Priority: 1},
+			{ID: "cancel", Name: "Cancel", Description: "Discard changes", Category: plugin.CategoryActions, Context: "conversations-prompt", Priority: 2},
+		}
+	}
+	if p.showAnnotationModal {
+		return []plugin.Command{
+			{ID: "save", Name: "Save", Description: "Save annotation", Category: plugin.CategoryActions, Context: "conversations-annotate", Priority: 1},
+			{ID: "cancel", Name: "Cancel", Description: "Discard changes", Category: plugin.CategoryActions, Context: "conversations-annotate", Priority: 2},
+		}
+	}
+	if p.showBookmarksModal {
+		return []plugin.Command{
+			{ID: "close", Name: "Close", Description: "Close bookmarks", Category: plugin.CategoryNavigation, Context: "conversations-bookmarks", Priority: 1},
+			{ID: "open", Name: "Open", Description: "Jump to bookmarked message", Category: plugin.CategoryActions, Context: "conversations-bookmarks", Priority: 2},
+			{ID: "annotate", Name: "Annotate", Description: "Edit annotation", Category: plugin.CategoryActions, Context: "conversations-bookmarks", Priority: 3},
+			{ID: "delete", Name: "Delete", Description: "Delete bookmark", Category: plugin.CategoryActions, Context: "conversations-bookmarks", Priority: 4},
+		}
+	}
+	if p.showStorageModal {
+		return []plugin.Command{
+			{ID: "close", Name: "Close", Description: "Close storage view", Category: plugin.CategoryNavigation, Context: "conversations-storage", Priority: 1},
+			{ID: "select", Name: "Select", Description: "Toggle session selection", Category: plugin.CategoryActions, Context: "conversations-storage", Priority: 2},
+			{ID: "select-flagged", Name: "Flagged", Description: "Select sessions past retention limits", Category: plugin.CategoryActions, Context: "conversations-storage", Priority: 2},
+			{ID: "archive", Name: "Archive", Description: "Archive selected sessions", Category: plugin.CategoryActions, Context: "conversations-storage", Priority: 3},
+			{ID: "delete", Name: "Delete", Description: "Delete selected sessions", Category: plugin.CategoryActions, Context: "conversations-storage", Priority: 3},
+		}
+	}
+	if p.showBudgetModal {
+		return []plugin.Command{
+			{ID: "close", Name: "Close", Description: "Close budgets", Category: plugin.CategoryNavigation, Context: "conversations-budget", Priority: 1},
+		}
+	}
 	// Content search mode commands (td-6ac70a, td-2467e8: updated shortcuts)
 	if p.contentSearchMode {
 		return []plugin.Command{
@@ -1193,6 +1535,25 @@ func (p *Plugin) Commands() []plugin.Command {
 			{ID: "cancel", Name: "Cancel", Description: "Cancel filter", Category: plugin.CategoryActions, Context: "conversations-filter", Priority: 1},
 		}
 	}
+	// Replay mode (right pane steps through the session timeline)
+	if p.replay != nil {
+		return []plugin.Command{
+			{ID: "replay-play", Name: "Play", Description: "Play/pause replay", Category: plugin.CategoryActions, Context: "conversations-replay", Priority: 1},
+			{ID: "replay-step", Name: "Step", Description: "Step backward/forward", Category: plugin.CategoryNavigation, Context: "conversations-replay", Priority: 2},
+			{ID: "replay-speed", Name: "Speed", Description: "Change playback speed (+/-)", Category: plugin.CategoryView, Context: "conversations-replay", Priority: 3},
+			{ID: "replay-seek", Name: "Seek", Description: "Jump to 0-90% of timeline", Category: plugin.CategoryNavigation, Context: "conversations-replay", Priority: 4},
+			{ID: "back", Name: "Exit", Description: "Exit replay", Category: plugin.CategoryNavigation, Context: "conversations-replay", Priority: 5},
+		}
+	}
+	// Trace mode (right pane shows the span waterfall)
+	if p.trace != nil {
+		return []plugin.Command{
+			{ID: "trace-collapse", Name: "Fold", Description: "Collapse/expand span children", Category: plugin.CategoryView, Context: "conversations-trace", Priority: 1},
+			{ID: "trace-error", Name: "Errors", Description: "Jump to next/previous error span (n/N)", Category: plugin.CategoryNavigation, Context: "conversations-trace", Priority: 2},
+			{ID: "trace-detail", Name: "Detail", Description: "Scroll span attributes (J/K)", Category: plugin.CategoryNavigation, Context: "conversations-trace", Priority: 3},
+			{ID: "back", Name: "Exit", Description: "Exit trace view", Category: plugin.CategoryNavigation, Context: "conversations-trace", Priority: 4},
+		}
+	}
 	// Detail mode (right pane shows turn detail)
 	if p.detailMode {
 		return []plugin.Command{
@@ -1206,8 +1567,16 @@ func (p *Plugin) Commands() []plugin.Command {
 			{ID: "toggle-view", Name: "View", Description: "Toggle conversation/turn view", Category: plugin.CategoryView, Context: "conversations-main", Priority: 1},
 			{ID: "detail", Name: "Detail", Description: "View turn details", Category: plugin.CategoryView, Context: "conversations-main", Priority: 2},
 			{ID: "expand", Name: "Expand", Description: "Expand selected item", Category: plugin.CategoryView, Context: "conversations-main", Priority: 3},
+			{ID: "toggle-tools", Name: "Tools", Description: "Toggle tool usage and files touched", Category: plugin.CategoryView, Context: "conversations-main", Priority: 3},
+			{ID: "replay", Name: "Replay", Description: "Replay session step by step", Category: plugin.CategoryView, Context: "conversations-main", Priority: 3},
+			{ID: "trace", Name: "Trace", Description: "Show span waterfall (T)", Category: plugin.CategoryView, Context: "conversations-main", Priority: 3},
+			{ID: "follow", Name: "Follow", Description: "Live tail: pin to newest message (f)", Category: plugin.CategoryView, Context: "conversations-main", Priority: 3},
 			{ID: "content-search", Name: "Find", Description: "Search content (F)", Category: plugin.CategorySearch, Context: "conversations-main", Priority: 3},
 			{ID: "extract-insights", Name: "Insights", Description: "Extract insights (I)", Category: plugin.CategoryActions, Context: "conversations-main", Priority: 3},
+			{ID: "fork", Name: "Fork", Description: "Fork from this turn into a new worktree (K)", Category: plugin.CategoryActions, Context: "conversations-main", Priority: 4},
+			{ID: "bookmark", Name: "Bookmark", Description: "Toggle bookmark on message (b)", Category: plugin.CategoryActions, Context: "conversations-main", Priority: 4},
+			{ID: "annotate", Name: "Annotate", Description: "Annotate message (a)", Category: plugin.CategoryActions, Context: "conversations-main", Priority: 4},
+			{ID: "bookmarks", Name: "Bookmarks", Description: "Browse bookmarks (B)", Category: plugin.CategoryNavigation, Context: "conversations-main", Priority: 4},
 			{ID: "back", Name: "Back", Description: "Return to sidebar", Category: plugin.CategoryNavigation, Context: "conversations-main", Priority: 4},
 			{ID: "open", Name: "Open", Description: "Open in CLI", Category: plugin.CategoryActions, Context: "conversations-main", Priority: 5},
 			{ID: "yank", Name: "Yank", Description: "Yank turn content", Category: plugin.CategoryActions, Context: "conversations-main", Priority: 6},
@@ -1219,7 +1588,13 @@ func (p *Plugin) Commands() []plugin.Command {
 			{ID: "back", Name: "Back", Description: "Return to conversations", Category: plugin.CategoryNavigation, Context: "analytics", Priority: 1},
 		}
 	}
-	return []plugin.Command{
+	if p.view == ViewCompare {
+		return []plugin.Command{
+			{ID: "back", Name: "Back", Description: "Return to conversations", Category: plugin.CategoryNavigation, Context: "conversations-compare", Priority: 1},
+			{ID: "swap", Name: "Swap", Description: "Swap left and right sessions", Category: plugin.CategoryView, Context: "conversations-compare", Priority: 2},
+		}
+	}
+	cmds := []plugin.Command{
 		{ID: "view-session", Name: "View", Description: "View session messages", Category: plugin.CategoryView, Context: "conversations-sidebar", Priority: 1},
 		{ID: "search", Name: "Search", Description: "Search conversations", Category: plugin.CategorySearch, Context: "conversations-sidebar", Priority: 2},
 		{ID: "filter", Name: "Filter", Description: "Filter by project", Category: plugin.CategorySearch, Context: "conversations-sidebar", Priority: 2},
@@ -1227,10 +1602,20 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "toggle-category", Name: "Category", Description: "Toggle category filter", Category: plugin.CategorySearch, Context: "conversations-sidebar", Priority: 3},
 		{ID: "toggle-workspace", Name: "Workspace", Description: "Toggle workspace filter", Category: plugin.CategorySearch, Context: "conversations-sidebar", Priority: 3},
 		{ID: "resume-in-workspace", Name: "Resume", Description: "Resume in workspace", Category: plugin.CategoryActions, Context: "conversations-sidebar", Priority: 3},
+		{ID: "compare", Name: "Compare", Description: "Mark/compare two sessions (X)", Category: plugin.CategoryView, Context: "conversations-sidebar", Priority: 3},
 		{ID: "yank-details", Name: "Copy Details", Description: "Copy session details", Category: plugin.CategoryActions, Context: "conversations-sidebar", Priority: 3},
+		{ID: "bookmarks", Name: "Bookmarks", Description: "Browse bookmarks (B)", Category: plugin.CategoryNavigation, Context: "conversations-sidebar", Priority: 4},
+		{ID: "storage", Name: "Storage", Description: "Disk usage, archive and prune (S)", Category: plugin.CategoryView, Context: "conversations-sidebar", Priority: 4},
+		{ID: "budgets", Name: "Budgets", Description: "Spend against cost and token budgets ($)", Category: plugin.CategoryView, Context: "conversations-sidebar", Priority: 4},
+		{ID: "tag", Name: "Tag", Description: "Edit session tags (T)", Category: plugin.CategoryActions, Context: "conversations-sidebar", Priority: 4},
 		{ID: "yank-resume", Name: "Copy Resume", Description: "Copy resume command", Category: plugin.CategoryActions, Context: "conversations-sidebar", Priority: 4},
 		{ID: "toggle-sidebar", Name: "Sidebar", Description: "Toggle sidebar visibility", Category: plugin.CategoryView, Context: "conversations-sidebar", Priority: 5},
 	}
+	// Saved filters are run from the palette via RunCommandMsg
+	for _, sf := range p.savedFilters() {
+		cmds = append(cmds, plugin.Command{ID: savedFilterCommandPrefix + sf.Name, Name: "Filter: " + sf.Name, Description: "Apply saved filter", Category: plugin.CategorySearch, Context: "conversations-sidebar", Priority: 6})
+	}
+	return cmds
 }
 
 // FocusContext returns the current focus context.
@@ -1239,6 +1624,21 @@ func (p *Plugin) FocusContext() string {
 	if p.showInsightModal {
 		return "conversations-insights"
 	}
+	if p.promptModal != nil {
+		return "conversations-prompt"
+	}
+	if p.showAnnotationModal {
+		return "conversations-annotate"
+	}
+	if p.showBookmarksModal {
+		return "conversations-bookmarks"
+	}
+	if p.showStorageModal {
+		return "conversations-storage"
+	}
+	if p.showBudgetModal {
+		return "conversations-budget"
+	}
 	// Content search modal takes precedence (td-6ac70a)
 	if p.contentSearchMode {
 		return "conversations-content-search"
@@ -1253,6 +1653,14 @@ func (p *Plugin) FocusContext() string {
 	if p.filterMode {
 		return "conversations-filter"
 	}
+	// Replay mode (right pane steps through the session timeline)
+	if p.replay != nil && p.activePane == PaneMessages {
+		return "conversations-replay"
+	}
+	// Trace mode (right pane shows the span waterfall)
+	if p.trace != nil && p.activePane == PaneMessages {
+		return "conversations-trace"
+	}
 	// Detail mode (right pane shows turn detail)
 	if p.detailMode {
 		return "turn-detail"
@@ -1260,6 +1668,8 @@ func (p *Plugin) FocusContext() string {
 	switch p.view {
 	case ViewAnalytics:
 		return "analytics"
+	case ViewCompare:
+		return "conversations-compare"
 	default:
 		// Return context based on active pane
 		if p.activePane == PaneSidebar {
@@ -1271,8 +1681,10 @@ func (p *Plugin) FocusContext() string {
 
 // ConsumesTextInput reports whether conversation UI currently has a focused
 // text-entry flow where app shortcuts should not intercept characters.
+// Replay counts too: its digit keys seek instead of switching plugins.
 func (p *Plugin) ConsumesTextInput() bool {
-	return p.searchMode || p.filterMode || p.contentSearchMode
+	return p.searchMode || p.filterMode || p.contentSearchMode || p.promptModal != nil ||
+		p.showAnnotationModal || p.replay != nil
 }
 
 // Diagnostics returns plugin health info.
@@ -1326,9 +1738,10 @@ func (p *Plugin) selectedSessionName() string {
 func (p *Plugin) copySessionToClipboard() tea.Cmd {
 	session := p.findSelectedSession()
 	messages := p.messages
+	bookmarks := p.sessionBookmarks()
 
 	return func() tea.Msg {
-		md := ExportSessionAsMarkdown(session, messages)
+		md := ExportSessionAsMarkdown(session, messages, bookmarks)
 		if err := CopyToClipboard(md); err != nil {
 			return app.ToastMsg{Message: "Copy failed: " + err.Error(), Duration: 2 * time.Second, IsError: true}
 		}
@@ -1340,10 +1753,11 @@ func (p *Plugin) copySessionToClipboard() tea.Cmd {
 func (p *Plugin) exportSessionToFile() tea.Cmd {
 	session := p.findSelectedSession()
 	messages := p.messages
+	bookmarks := p.sessionBookmarks()
 	workDir := p.ctx.WorkDir
 
 	return func() tea.Msg {
-		filename, err := ExportSessionToFile(session, messages, workDir)
+		filename, err := ExportSessionToFile(session, messages, bookmarks, workDir)
 		if err != nil {
 			return app.ToastMsg{Message: "Export failed: " + err.Error(), Duration: 2 * time.Second, IsError: true}
 		}
@@ -186,6 +186,30 @@ func (p *Plugin) updateSessions(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		// Open resume modal for workspace
 		return p, p.openResumeModal()
 
+	case "X":
+		// Mark session for comparison, or compare with the marked session
+		return p.markOrCompare()
+
+	case "B":
+		// Browse bookmarks across all sessions
+		return p.openBookmarksModal()
+
+	case "S":
+		// Disk usage report with archive and delete actions
+		return p.openStorageModal()
+
+	case "$":
+		// Spend against configured budgets
+		return p.openBudgetModal()
+
+	case "T":
+		// Edit user tags on the selected session
+		return p, p.editSelectedSessionTags()
+
+	case "f1", "f2", "f3", "f4", "f5", "f6", "f7", "f8", "f9":
+		// Apply a saved filter
+		return p, p.applySavedFilterAt(savedFilterIndex(msg.String()))
+
 	case "I":
 		// Open insight extraction modal (loads messages first if needed)
 		if p.selectedSession != "" {
@@ -451,11 +475,29 @@ func (p *Plugin) updateAnalytics(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 
 // updateMessages handles key events in message view (now uses turns).
 func (p *Plugin) updateMessages(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	// In replay mode, handle playback controls
+	if p.replay != nil {
+		return p.updateReplay(msg)
+	}
+
+	// In trace mode, navigate the span waterfall
+	if p.trace != nil {
+		return p.updateTrace(msg)
+	}
+
 	// In detail mode, handle detail-specific navigation
 	if p.detailMode {
 		return p.updateDetailMode(msg)
 	}
 
+	// Scrolling back pauses follow mode; G resumes it
+	switch msg.String() {
+	case "k", "up", "g", "ctrl+u", "p":
+		p.pauseFollow()
+	case "G":
+		p.resumeFollow()
+	}
+
 	switch msg.String() {
 	case "esc":
 		// Restore sidebar if hidden, otherwise return focus to sidebar
@@ -634,9 +676,21 @@ func (p *Plugin) updateMessages(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		}
 
 	case "t":
-		// Toggle tool impact summary
+		// Toggle tool usage and files-touched view
 		p.showToolSummary = !p.showToolSummary
 
+	case "f":
+		// Follow mode: live tail of the transcript
+		return p.toggleFollow()
+
+	case "P":
+		// Replay session step by step
+		return p.startReplay()
+
+	case "T":
+		// Show the span waterfall for trace-backed sessions
+		return p.startTrace()
+
 	case "v":
 		// Toggle between conversation flow and turn view
 		p.turnViewMode = !p.turnViewMode
@@ -767,9 +821,25 @@ func (p *Plugin) updateMessages(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		// Open insight extraction modal
 		return p.openInsightModal()
 
+	case "K":
+		// Fork the conversation after the selected turn into a new worktree
+		return p, p.openForkModal()
+
 	case "F":
 		// Open content search modal (td-6ac70a)
 		return p.openContentSearch()
+
+	case "b":
+		// Toggle bookmark on the selected message
+		return p, p.toggleBookmark()
+
+	case "a":
+		// Annotate the selected message (bookmarks it)
+		return p, p.annotateSelectedMessage()
+
+	case "B":
+		// Browse bookmarks across all sessions
+		return p.openBookmarksModal()
 	}
 
 	return p, nil
@@ -781,7 +851,11 @@ func (p *Plugin) openInsightModal() (plugin.Plugin, tea.Cmd) {
 		return p, appmsg.ShowToast("No conversation loaded", 2*time.Second)
 	}
 
-	insights := extractInsights(p.turns)
+	extractor := p.insightExtractor
+	if extractor == nil {
+		extractor = &insightExtractor{}
+	}
+	insights := extractor.extract(p.turns)
 	p.insightModalState = &insightModalState{
 		insights: insights,
 		cursor:   0,
@@ -873,7 +947,10 @@ func (p *Plugin) createInsightTasks() (plugin.Plugin, tea.Cmd) {
 		if sessionName != "" {
 			desc += fmt.Sprintf("- **Source**: conversation %q, turn %d\n", sessionName, ins.TurnIndex+1)
 		}
-		desc += fmt.Sprintf("- **Type**: %s\n", ins.Source.Badge())
+		desc += fmt.Sprintf("- **Type**: %s\n", ins.Badge())
+		if ins.Rule != nil {
+			desc += fmt.Sprintf("- **Rule**: %s\n", ins.Rule.Name)
+		}
 		desc += "\n## Prompt\n\n"
 		desc += "Review this insight and discuss whether it should be formalized into the knowledge graph. "
 		desc += "Consider: Is this validated? What nodes/edges would it create? Does it connect to existing knowledge?"
@@ -881,6 +958,7 @@ func (p *Plugin) createInsightTasks() (plugin.Plugin, tea.Cmd) {
 		tasks = append(tasks, appmsg.InsightTask{
 			Title:       title,
 			Description: desc,
+			Text:        ins.Text,
 		})
 	}
 
@@ -890,11 +968,13 @@ func (p *Plugin) createInsightTasks() (plugin.Plugin, tea.Cmd) {
 
 	// Emit the message for Persephone plugin to handle
 	epoch := p.ctx.Epoch
+	threshold := p.insightDuplicateThreshold
 	return p, func() tea.Msg {
 		return appmsg.CreateInsightTasksMsg{
-			Tasks:       tasks,
-			SessionName: sessionName,
-			Epoch:       epoch,
+			Tasks:              tasks,
+			SessionName:        sessionName,
+			DuplicateThreshold: threshold,
+			Epoch:              epoch,
 		}
 	}
 }
@@ -959,6 +1039,15 @@ func (p *Plugin) updateFilter(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 			return p, nil
 		}
 	}
+	for _, opt := range p.tagFilterOptions() {
+		if key == opt.key {
+			p.filters.ToggleTag(opt.id)
+			return p, nil
+		}
+	}
+	if i := savedFilterIndex(key); i >= 0 {
+		return p, p.applySavedFilterAt(i)
+	}
 
 	switch key {
 	case "esc":
@@ -1015,6 +1104,10 @@ func (p *Plugin) updateFilter(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 	case "x":
 		// Clear all filters
 		p.filters = SearchFilters{}
+
+	case "+":
+		// Save the current filters under a name
+		return p, p.promptSaveFilter()
 	}
 	return p, nil
 }
@@ -14,6 +14,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/toddwbucy/hermes/internal/adapter"
 	"github.com/toddwbucy/hermes/internal/adapter/tieredwatcher"
+	"github.com/toddwbucy/hermes/internal/adapter/toolstats"
 	"github.com/toddwbucy/hermes/internal/app"
 	"github.com/toddwbucy/hermes/internal/fdmonitor"
 )
@@ -249,6 +250,19 @@ func (p *Plugin) loadMessages(sessionID string) tea.Cmd {
 
 	offset := p.messageOffset
 	adapters := p.adapters // capture to avoid race in closure
+	// Snapshot session metadata for the tool index
+	var session *adapter.Session
+	for i := range p.sessions {
+		if p.sessions[i].ID == sessionID {
+			s := p.sessions[i]
+			session = &s
+			break
+		}
+	}
+	workDir := ""
+	if p.ctx != nil {
+		workDir = p.ctx.WorkDir
+	}
 	return func() tea.Msg {
 		if len(adapters) == 0 {
 			return MessagesLoadedMsg{Epoch: epoch}
@@ -262,6 +276,11 @@ func (p *Plugin) loadMessages(sessionID string) tea.Cmd {
 			return ErrorMsg{Err: err}
 		}
 
+		// Index the full message list before pagination trims it
+		if session != nil {
+			toolstats.Shared().Put(*session, toolstats.Compute(sessionID, sessionCWD(*session, workDir), messages))
+		}
+
 		totalCount := len(messages)
 		resultOffset := 0
 
@@ -433,12 +452,21 @@ func (p *Plugin) startWatcher() tea.Cmd {
 					return result, nil
 				}
 
-				tw, ch, err := tieredwatcher.New(tieredwatcher.Config{
+				twCfg := tieredwatcher.Config{
 					FilePattern: "",
 					Filter:      extFilter,
 					ExtractID:   extractID,
 					ScanDir:     scanDir,
-				})
+				}
+				// Adapters with one directory per session describe their own layout
+				if layout, ok := p.adapters[adapterID].(tieredwatcher.Layout); ok {
+					twCfg.ExtractID = layout.SessionIDFromPath
+					twCfg.Filter = func(path string) bool { return layout.SessionIDFromPath(path) != "" }
+					twCfg.ScanDir = layout.ScanSessionDir
+					twCfg.RootDirs = layout.SessionRootDirs()
+				}
+
+				tw, ch, err := tieredwatcher.New(twCfg)
 				if err != nil {
 					continue
 				}
@@ -26,6 +26,8 @@ func (p *Plugin) setSelectedSession(sessionID string) {
 	p.detailMode = false
 	p.detailTurn = nil
 	p.detailScroll = 0
+	p.replay = nil
+	p.trace = nil
 	p.expandedThinking = make(map[string]bool)
 	// Reset conversation flow view state
 	p.expandedMessages = make(map[string]bool)
@@ -123,7 +125,7 @@ func (p *Plugin) visibleSessions() []adapter.Session {
 	if p.filterActive && p.filters.IsActive() {
 		var filtered []adapter.Session
 		for _, s := range p.sessions {
-			if p.filters.Matches(s) {
+			if p.filters.Matches(s) && p.filters.MatchesTags(p.tags.Get(s.AdapterID, s.ID)) {
 				filtered = append(filtered, s)
 			}
 		}
@@ -161,6 +163,11 @@ func (p *Plugin) visibleMessageIndices() []int {
 
 // isToolResultOnlyMessage checks if a message contains only tool_result blocks.
 func (p *Plugin) isToolResultOnlyMessage(msg adapter.Message) bool {
+	return isToolResultOnly(msg)
+}
+
+// isToolResultOnly reports whether a message only carries tool results.
+func isToolResultOnly(msg adapter.Message) bool {
 	if len(msg.ContentBlocks) == 0 {
 		return false
 	}
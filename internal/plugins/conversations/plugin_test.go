@@ -9,7 +9,10 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/adapter/otlp"
 	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/config"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
 	"github.com/toddwbucy/hermes/internal/plugin"
 )
 
@@ -282,6 +285,28 @@ func TestVisibleSessions(t *testing.T) {
 	}
 }
 
+func TestOpenConversationMatchesAdapter(t *testing.T) {
+	p := New()
+	p.adapters = map[string]adapter.Adapter{"mock": &mockAdapter{}}
+	p.sessions = []adapter.Session{
+		{ID: "task-1", Name: "cline task", AdapterID: "cline"},
+		{ID: "task-1", Name: "mock task", AdapterID: "mock"},
+	}
+
+	_, _ = p.Update(appmsg.OpenConversationMsg{AdapterID: "mock", SessionID: "task-1", MessageID: "m1"})
+	if p.cursor != 1 || p.selectedSession != "task-1" || p.pendingScrollMsgID != "m1" {
+		t.Errorf("open: cursor=%d session=%q scroll=%q", p.cursor, p.selectedSession, p.pendingScrollMsgID)
+	}
+
+	_, cmd := p.Update(appmsg.OpenConversationMsg{AdapterID: "codex", SessionID: "task-1"})
+	if cmd == nil {
+		t.Fatal("a session from another adapter should not be opened")
+	}
+	if toast, ok := cmd().(app.ToastMsg); !ok || !toast.IsError {
+		t.Errorf("open from another adapter = %+v, want an error toast", toast)
+	}
+}
+
 func TestShortID(t *testing.T) {
 	tests := []struct {
 		id       string
@@ -3514,6 +3539,32 @@ func TestInitDoesNotApplyCategoryFilter(t *testing.T) {
 	}
 }
 
+// TestInitConfiguresOTLPReceiver verifies that Init passes the receiver
+// settings from config to the OTLP adapter before detecting it.
+func TestInitConfiguresOTLPReceiver(t *testing.T) {
+	cfg := config.Default()
+	cfg.Plugins.Conversations.OTLPReceiver = config.OTLPReceiverConfig{Enabled: true, Port: 0}
+	receiver := otlp.New()
+	defer func() { _ = receiver.Close() }()
+
+	p := New()
+	ctx := &plugin.Context{
+		WorkDir:   "/test/project",
+		ConfigDir: t.TempDir(),
+		Config:    cfg,
+		Adapters:  map[string]adapter.Adapter{"otlp": receiver},
+	}
+	if err := p.Init(ctx); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if p.adapters["otlp"] == nil {
+		t.Fatal("enabled OTLP adapter should be detected")
+	}
+	if receiver.Addr() == "" {
+		t.Error("receiver should be listening after Init")
+	}
+}
+
 // mockTargetedAdapter implements both adapter.Adapter and adapter.TargetedRefresher.
 type mockTargetedAdapter struct {
 	mockAdapter
@@ -0,0 +1,178 @@
+package conversations
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/modal"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+	"github.com/toddwbucy/hermes/internal/plugin"
+	"github.com/toddwbucy/hermes/internal/styles"
+	"github.com/toddwbucy/hermes/internal/ui"
+)
+
+const (
+	promptInputID  = "prompt-input"
+	promptSaveID   = "prompt-save"
+	promptCancelID = "prompt-cancel"
+)
+
+// promptModalState is a single-line text prompt, used to edit session tags
+// and to name saved filters.
+type promptModalState struct {
+	title  string
+	header string // Muted line above the input
+	input  textinput.Model
+	submit func(value string) tea.Cmd
+
+	modal      *modal.Modal
+	modalWidth int
+}
+
+// openPrompt opens a text prompt. submit runs with the entered text when
+// the prompt is saved.
+func (p *Plugin) openPrompt(title, header, placeholder, value string, submit func(string) tea.Cmd) tea.Cmd {
+	input := textinput.New()
+	input.Placeholder = placeholder
+	input.CharLimit = 200
+	input.SetValue(value)
+	input.Focus()
+	p.promptModal = &promptModalState{title: title, header: header, input: input, submit: submit}
+	return textinput.Blink
+}
+
+// ensurePromptModal builds or caches the prompt modal.
+func (p *Plugin) ensurePromptModal() {
+	state := p.promptModal
+	if state == nil {
+		return
+	}
+	modalW := 60
+	if maxW := p.width - 4; modalW > maxW {
+		modalW = max(maxW, 30)
+	}
+	if state.modal != nil && state.modalWidth == modalW {
+		return
+	}
+	state.modalWidth = modalW
+
+	state.modal = modal.New(state.title,
+		modal.WithWidth(modalW),
+		modal.WithPrimaryAction(promptSaveID),
+		modal.WithHints(false),
+	)
+	if state.header != "" {
+		state.modal.AddSection(modal.Text(styles.Muted.Render(state.header))).
+			AddSection(modal.Spacer())
+	}
+	state.modal.
+		AddSection(modal.Input(promptInputID, &state.input, modal.WithSubmitAction(promptSaveID))).
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Save ", promptSaveID, modal.BtnPrimary()),
+			modal.Btn(" Cancel ", promptCancelID),
+		))
+}
+
+// handlePromptModalKey handles key events when a prompt is open.
+func (p *Plugin) handlePromptModalKey(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	p.ensurePromptModal()
+	if p.promptModal == nil || p.promptModal.modal == nil {
+		p.promptModal = nil
+		return p, nil
+	}
+
+	action, cmd := p.promptModal.modal.HandleKey(msg)
+	switch action {
+	case promptSaveID:
+		return p, p.submitPrompt()
+	case promptCancelID, "cancel":
+		p.promptModal = nil
+		return p, nil
+	}
+	return p, cmd
+}
+
+// handlePromptModalMouse handles clicks on the prompt buttons.
+func (p *Plugin) handlePromptModalMouse(msg tea.MouseMsg) tea.Cmd {
+	p.ensurePromptModal()
+	if p.promptModal == nil || p.promptModal.modal == nil {
+		return nil
+	}
+	switch p.promptModal.modal.HandleMouse(msg, p.mouseHandler) {
+	case promptSaveID:
+		return p.submitPrompt()
+	case promptCancelID, "cancel":
+		p.promptModal = nil
+	}
+	return nil
+}
+
+// submitPrompt closes the prompt and runs its submit function.
+func (p *Plugin) submitPrompt() tea.Cmd {
+	state := p.promptModal
+	p.promptModal = nil
+	if state == nil || state.submit == nil {
+		return nil
+	}
+	return state.submit(state.input.Value())
+}
+
+// renderPromptModal renders the prompt over the two-pane view.
+func (p *Plugin) renderPromptModal(width, height int) string {
+	p.ensurePromptModal()
+	background := p.renderTwoPane()
+	if p.promptModal == nil || p.promptModal.modal == nil {
+		return background
+	}
+	rendered := p.promptModal.modal.Render(width, height, p.mouseHandler)
+	return ui.OverlayModal(background, rendered, width, height)
+}
+
+// editSelectedSessionTags opens the tag editor for the selected session.
+func (p *Plugin) editSelectedSessionTags() tea.Cmd {
+	session := p.findSelectedSession()
+	if session == nil {
+		return appmsg.ShowToast("No session selected", 2*time.Second)
+	}
+	adapterID, sessionID := session.AdapterID, session.ID
+	name := session.Name
+	if name == "" {
+		name = shortID(session.ID)
+	}
+	current := strings.Join(p.tags.Get(adapterID, sessionID), ", ")
+
+	return p.openPrompt("Tag Session", truncateStr(name, 50), "e.g. bug, review", current, func(value string) tea.Cmd {
+		tags := parseTags(value)
+		if err := p.tags.Set(adapterID, sessionID, tags); err != nil {
+			text := "Tag save failed: " + err.Error()
+			return func() tea.Msg {
+				return app.ToastMsg{Message: text, Duration: 2 * time.Second, IsError: true}
+			}
+		}
+		p.hitRegionsDirty = true
+		if len(tags) == 0 {
+			return appmsg.ShowToast("Tags cleared", 2*time.Second)
+		}
+		return appmsg.ShowToast("Tagged "+formatTags(tags), 2*time.Second)
+	})
+}
+
+// promptSaveFilter applies the filters being edited and asks for a name to
+// save them under.
+func (p *Plugin) promptSaveFilter() tea.Cmd {
+	p.filterMode = false
+	p.filterActive = p.filters.IsActive()
+	p.cursor = 0
+	p.scrollOff = 0
+	p.hitRegionsDirty = true
+
+	header := p.filters.String()
+	if header == "" {
+		header = "No filters set"
+	}
+	return p.openPrompt("Save Filter", header, "Filter name", "", p.saveCurrentFilter)
+}
@@ -0,0 +1,463 @@
+package conversations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+	"github.com/toddwbucy/hermes/internal/plugin"
+)
+
+// Replay pacing. Wall-clock gaps between steps are clamped to this range
+// before the speed multiplier is applied, so idle minutes don't stall
+// playback and bursts of tool calls stay readable.
+const (
+	replayMinGap = 300 * time.Millisecond
+	replayMaxGap = 3 * time.Second
+)
+
+// replaySpeeds are the selectable playback multipliers.
+var replaySpeeds = []float64{0.5, 1, 2, 4, 8}
+
+// replayDefaultSpeed is the index of 1× in replaySpeeds.
+const replayDefaultSpeed = 1
+
+// ReplayStepKind distinguishes message text from tool calls in a replay.
+type ReplayStepKind int
+
+const (
+	ReplayStepMessage ReplayStepKind = iota
+	ReplayStepTool
+)
+
+// ReplayStep is one point on the replay timeline: either a message's text or
+// a single tool call. Token and cost totals are cumulative through the step.
+type ReplayStep struct {
+	Kind      ReplayStepKind
+	MsgIndex  int // index into the source message slice
+	Timestamp time.Time
+	Role      string
+	Model     string
+	Text      string // message content (message steps)
+
+	// Tool call fields (tool steps)
+	ToolName   string
+	ToolInput  string
+	ToolResult string
+	IsError    bool
+
+	TokensIn  int     // running input tokens (incl. cache)
+	TokensOut int     // running output tokens
+	Cost      float64 // running estimated cost
+}
+
+// BuildReplaySteps flattens messages into timeline steps in timestamp order.
+// Each message contributes a text step (if it has visible text) followed by
+// one step per tool call; tool results are folded into their call's step.
+func BuildReplaySteps(messages []adapter.Message) []ReplayStep {
+	// Index tool results by tool_use_id so calls can show outcome
+	results := make(map[string]adapter.ContentBlock)
+	for _, msg := range messages {
+		for _, b := range msg.ContentBlocks {
+			if b.Type == "tool_result" && b.ToolUseID != "" {
+				results[b.ToolUseID] = b
+			}
+		}
+	}
+
+	var steps []ReplayStep
+	var last time.Time
+	for i, msg := range messages {
+		ts := msg.Timestamp
+		if ts.IsZero() {
+			ts = last // keep undated messages next to their neighbours
+		}
+		last = ts
+
+		tokensIn := msg.InputTokens + msg.CacheRead + msg.CacheWrite
+		tokensOut := msg.OutputTokens
+		cost := estimateTotalCost(msg.Model, msg.InputTokens, msg.OutputTokens, msg.CacheRead, msg.CacheWrite)
+		first := len(steps)
+
+		if text := replayText(msg); text != "" {
+			steps = append(steps, ReplayStep{
+				Kind:      ReplayStepMessage,
+				MsgIndex:  i,
+				Timestamp: ts,
+				Role:      msg.Role,
+				Model:     msg.Model,
+				Text:      text,
+			})
+		}
+
+		for _, call := range replayToolCalls(msg) {
+			step := ReplayStep{
+				Kind:       ReplayStepTool,
+				MsgIndex:   i,
+				Timestamp:  ts,
+				Role:       msg.Role,
+				Model:      msg.Model,
+				ToolName:   call.ToolName,
+				ToolInput:  call.ToolInput,
+				ToolResult: call.ToolOutput,
+			}
+			if res, ok := results[call.ToolUseID]; ok {
+				step.ToolResult = res.ToolOutput
+				step.IsError = res.IsError
+			}
+			steps = append(steps, step)
+		}
+
+		// Attribute the message's usage to its first step
+		if len(steps) > first {
+			steps[first].TokensIn = tokensIn
+			steps[first].TokensOut = tokensOut
+			steps[first].Cost = cost
+		} else if len(steps) > 0 {
+			steps[len(steps)-1].TokensIn += tokensIn
+			steps[len(steps)-1].TokensOut += tokensOut
+			steps[len(steps)-1].Cost += cost
+		}
+	}
+
+	sort.SliceStable(steps, func(a, b int) bool {
+		return steps[a].Timestamp.Before(steps[b].Timestamp)
+	})
+
+	// Convert per-step usage into running totals
+	for i := 1; i < len(steps); i++ {
+		steps[i].TokensIn += steps[i-1].TokensIn
+		steps[i].TokensOut += steps[i-1].TokensOut
+		steps[i].Cost += steps[i-1].Cost
+	}
+	return steps
+}
+
+// replayText returns a message's visible text, or "" for messages that only
+// carry tool results.
+func replayText(msg adapter.Message) string {
+	if len(msg.ContentBlocks) > 0 {
+		var parts []string
+		for _, b := range msg.ContentBlocks {
+			if b.Type == "text" && strings.TrimSpace(b.Text) != "" {
+				parts = append(parts, strings.TrimSpace(b.Text))
+			}
+		}
+		if len(parts) > 0 {
+			return strings.Join(parts, "\n\n")
+		}
+		// Blocks without text (tool calls/results only) have no text step
+		for _, b := range msg.ContentBlocks {
+			if b.Type == "tool_use" || b.Type == "tool_result" {
+				return ""
+			}
+		}
+	}
+	content := strings.TrimSpace(msg.Content)
+	if strings.HasPrefix(content, "[") && strings.HasSuffix(content, "]") && strings.Contains(content, "tool") {
+		return "" // synthetic "[1 tool result(s)]" placeholder
+	}
+	return strings.TrimSpace(stripXMLTags(content))
+}
+
+// replayToolCalls returns a message's tool calls, preferring content blocks
+// and falling back to ToolUses for adapters that only fill the latter.
+func replayToolCalls(msg adapter.Message) []adapter.ContentBlock {
+	var calls []adapter.ContentBlock
+	for _, b := range msg.ContentBlocks {
+		if b.Type == "tool_use" {
+			calls = append(calls, b)
+		}
+	}
+	if len(calls) > 0 {
+		return calls
+	}
+	for _, tu := range msg.ToolUses {
+		calls = append(calls, adapter.ContentBlock{
+			Type:       "tool_use",
+			ToolUseID:  tu.ID,
+			ToolName:   tu.Name,
+			ToolInput:  tu.Input,
+			ToolOutput: tu.Output,
+		})
+	}
+	return calls
+}
+
+// replayState holds the replay cursor and playback state.
+type replayState struct {
+	sessionID string
+	steps     []ReplayStep
+	pos       int
+	playing   bool
+	speedIdx  int
+}
+
+// ReplayTickMsg advances playback by one step.
+type ReplayTickMsg struct {
+	Epoch uint64
+	Token int // Must match replayToken to be valid
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m ReplayTickMsg) GetEpoch() uint64 { return m.Epoch }
+
+// speed returns the current playback multiplier.
+func (r *replayState) speed() float64 {
+	return replaySpeeds[r.speedIdx]
+}
+
+// elapsed returns wall time from the first step to the current step.
+func (r *replayState) elapsed() time.Duration {
+	if len(r.steps) == 0 {
+		return 0
+	}
+	return r.steps[r.pos].Timestamp.Sub(r.steps[0].Timestamp)
+}
+
+// gap returns wall time between the previous step and the current step.
+func (r *replayState) gap() time.Duration {
+	if r.pos == 0 || r.pos >= len(r.steps) {
+		return 0
+	}
+	return r.steps[r.pos].Timestamp.Sub(r.steps[r.pos-1].Timestamp)
+}
+
+// replayDelay returns how long to wait before showing the step after pos.
+func replayDelay(gap time.Duration, speed float64) time.Duration {
+	if gap < replayMinGap {
+		gap = replayMinGap
+	}
+	if gap > replayMaxGap {
+		gap = replayMaxGap
+	}
+	return time.Duration(float64(gap) / speed)
+}
+
+// startReplay enters replay mode for the loaded session, starting paused at
+// the first step.
+func (p *Plugin) startReplay() (plugin.Plugin, tea.Cmd) {
+	if p.selectedSession == "" || p.loadedSession != p.selectedSession || len(p.messages) == 0 {
+		return p, appmsg.ShowToast("No messages to replay", 2*time.Second)
+	}
+	steps := BuildReplaySteps(p.messages)
+	if len(steps) == 0 {
+		return p, appmsg.ShowToast("No messages to replay", 2*time.Second)
+	}
+	p.replay = &replayState{
+		sessionID: p.selectedSession,
+		steps:     steps,
+		speedIdx:  replayDefaultSpeed,
+	}
+	p.showToolSummary = false
+	p.hitRegionsDirty = true
+	return p, nil
+}
+
+// stopReplay leaves replay mode and selects the replayed message in the
+// conversation flow.
+func (p *Plugin) stopReplay() {
+	if p.replay == nil {
+		return
+	}
+	if p.replay.pos < len(p.replay.steps) {
+		idx := p.replay.steps[p.replay.pos].MsgIndex
+		if idx < len(p.messages) {
+			p.messageCursor = idx
+			p.ensureMessageCursorVisible()
+		}
+	}
+	p.replay = nil
+	p.replayToken++
+	p.hitRegionsDirty = true
+}
+
+// refreshReplaySteps rebuilds the timeline after messages change, keeping
+// the cursor on the same step. Replay ends if the session changed.
+func (p *Plugin) refreshReplaySteps() {
+	if p.replay == nil {
+		return
+	}
+	if p.replay.sessionID != p.loadedSession {
+		p.replay = nil
+		p.replayToken++
+		return
+	}
+	steps := BuildReplaySteps(p.messages)
+	if len(steps) == 0 {
+		p.replay = nil
+		p.replayToken++
+		return
+	}
+	p.replay.steps = steps
+	if p.replay.pos >= len(steps) {
+		p.replay.pos = len(steps) - 1
+	}
+}
+
+// seekReplay moves the replay cursor, clamped to the timeline.
+func (p *Plugin) seekReplay(pos int) {
+	r := p.replay
+	if r == nil || len(r.steps) == 0 {
+		return
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	if pos >= len(r.steps) {
+		pos = len(r.steps) - 1
+	}
+	r.pos = pos
+}
+
+// scheduleReplayTick schedules the next playback step.
+func (p *Plugin) scheduleReplayTick() tea.Cmd {
+	r := p.replay
+	if r == nil || !r.playing || r.pos >= len(r.steps)-1 {
+		return nil
+	}
+	p.replayToken++
+	token := p.replayToken
+	var epoch uint64
+	if p.ctx != nil {
+		epoch = p.ctx.Epoch
+	}
+	gap := r.steps[r.pos+1].Timestamp.Sub(r.steps[r.pos].Timestamp)
+	return tea.Tick(replayDelay(gap, r.speed()), func(time.Time) tea.Msg {
+		return ReplayTickMsg{Epoch: epoch, Token: token}
+	})
+}
+
+// handleReplayTick advances playback and schedules the next step.
+func (p *Plugin) handleReplayTick(msg ReplayTickMsg) tea.Cmd {
+	r := p.replay
+	if r == nil || !r.playing || msg.Token != p.replayToken {
+		return nil
+	}
+	p.seekReplay(r.pos + 1)
+	if r.pos >= len(r.steps)-1 {
+		r.playing = false
+		return nil
+	}
+	return p.scheduleReplayTick()
+}
+
+// toggleReplayPlay starts or pauses playback. Playing from the last step
+// restarts from the beginning.
+func (p *Plugin) toggleReplayPlay() tea.Cmd {
+	r := p.replay
+	if r == nil {
+		return nil
+	}
+	if r.playing {
+		r.playing = false
+		p.replayToken++ // cancel pending tick
+		return nil
+	}
+	if r.pos >= len(r.steps)-1 {
+		r.pos = 0
+	}
+	r.playing = true
+	return p.scheduleReplayTick()
+}
+
+// changeReplaySpeed steps through replaySpeeds by delta.
+func (p *Plugin) changeReplaySpeed(delta int) tea.Cmd {
+	r := p.replay
+	if r == nil {
+		return nil
+	}
+	idx := r.speedIdx + delta
+	if idx < 0 || idx >= len(replaySpeeds) {
+		return nil
+	}
+	r.speedIdx = idx
+	// Reschedule so the new speed applies to the pending step
+	return p.scheduleReplayTick()
+}
+
+// updateReplay handles key events in replay mode.
+func (p *Plugin) updateReplay(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	r := p.replay
+	var cmd tea.Cmd
+
+	switch msg.String() {
+	case "esc", "q", "P":
+		p.stopReplay()
+		return p, nil
+
+	case " ":
+		return p, p.toggleReplayPlay()
+
+	case "l", "right", "j", "down":
+		p.seekReplay(r.pos + 1)
+
+	case "h", "left", "k", "up":
+		p.seekReplay(r.pos - 1)
+
+	case "L", "]", "ctrl+d":
+		p.seekReplay(r.pos + 10)
+
+	case "H", "[", "ctrl+u":
+		p.seekReplay(r.pos - 10)
+
+	case "g", "home":
+		p.seekReplay(0)
+
+	case "G", "end":
+		p.seekReplay(len(r.steps) - 1)
+
+	case "+", "=":
+		return p, p.changeReplaySpeed(1)
+
+	case "-", "_":
+		return p, p.changeReplaySpeed(-1)
+
+	case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		// Jump to 0%..90% of the timeline
+		pct := int(msg.String()[0] - '0')
+		p.seekReplay((len(r.steps) - 1) * pct / 10)
+
+	default:
+		return p, nil
+	}
+
+	// Manual seeks restart the pending tick from the new position
+	if r.playing {
+		if r.pos >= len(r.steps)-1 {
+			r.playing = false
+			p.replayToken++
+		} else {
+			cmd = p.scheduleReplayTick()
+		}
+	}
+	return p, cmd
+}
+
+// seekReplayToColumn maps a click on the scrubber to a step.
+func (p *Plugin) seekReplayToColumn(col, width int) tea.Cmd {
+	r := p.replay
+	if r == nil || width <= 1 {
+		return nil
+	}
+	if col < 0 {
+		col = 0
+	}
+	if col >= width {
+		col = width - 1
+	}
+	p.seekReplay(col * (len(r.steps) - 1) / (width - 1))
+	if r.playing {
+		return p.scheduleReplayTick()
+	}
+	return nil
+}
+
+// positionLabel formats "step n/total".
+func (r *replayState) positionLabel() string {
+	return fmt.Sprintf("%d/%d", r.pos+1, len(r.steps))
+}
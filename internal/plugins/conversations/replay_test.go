@@ -0,0 +1,177 @@
+package conversations
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+func replayMessages(base time.Time) []adapter.Message {
+	return []adapter.Message{
+		{ID: "u1", Role: "user", Content: "fix the bug", Timestamp: base},
+		{
+			ID:        "a1",
+			Role:      "assistant",
+			Timestamp: base.Add(5 * time.Second),
+			TokenUsage: adapter.TokenUsage{
+				InputTokens:  100,
+				OutputTokens: 50,
+			},
+			ContentBlocks: []adapter.ContentBlock{
+				{Type: "text", Text: "Looking at it"},
+				{Type: "tool_use", ToolUseID: "t1", ToolName: "Read", ToolInput: `{"file_path":"a.go"}`},
+				{Type: "tool_use", ToolUseID: "t2", ToolName: "Bash", ToolInput: `{"command":"go test"}`},
+			},
+		},
+		{
+			ID:        "r1",
+			Role:      "user",
+			Content:   "[2 tool result(s)]",
+			Timestamp: base.Add(7 * time.Second),
+			ContentBlocks: []adapter.ContentBlock{
+				{Type: "tool_result", ToolUseID: "t1", ToolOutput: "package a"},
+				{Type: "tool_result", ToolUseID: "t2", ToolOutput: "FAIL", IsError: true},
+			},
+		},
+		{
+			ID:         "a2",
+			Role:       "assistant",
+			Content:    "Tests fail",
+			Timestamp:  base.Add(20 * time.Second),
+			TokenUsage: adapter.TokenUsage{InputTokens: 200, OutputTokens: 10},
+		},
+	}
+}
+
+func TestBuildReplaySteps(t *testing.T) {
+	base := time.Unix(1000, 0)
+	steps := BuildReplaySteps(replayMessages(base))
+
+	// u1 text, a1 text, Read, Bash, a2 text (tool result message has no step)
+	if len(steps) != 5 {
+		t.Fatalf("got %d steps, want 5: %+v", len(steps), steps)
+	}
+	if steps[2].Kind != ReplayStepTool || steps[2].ToolName != "Read" || steps[2].ToolResult != "package a" {
+		t.Errorf("steps[2] = %+v, want Read call with folded result", steps[2])
+	}
+	if !steps[3].IsError {
+		t.Errorf("steps[3] should carry the tool_result error flag")
+	}
+	if steps[3].MsgIndex != 1 {
+		t.Errorf("steps[3].MsgIndex = %d, want 1", steps[3].MsgIndex)
+	}
+
+	// Usage is counted once per message and accumulates
+	if steps[1].TokensIn != 100 || steps[3].TokensIn != 100 {
+		t.Errorf("running input after a1 = %d/%d, want 100", steps[1].TokensIn, steps[3].TokensIn)
+	}
+	if steps[4].TokensIn != 300 || steps[4].TokensOut != 60 {
+		t.Errorf("final totals = in:%d out:%d, want in:300 out:60", steps[4].TokensIn, steps[4].TokensOut)
+	}
+}
+
+func TestBuildReplaySteps_TimestampOrder(t *testing.T) {
+	base := time.Unix(1000, 0)
+	messages := []adapter.Message{
+		{ID: "late", Role: "assistant", Content: "second", Timestamp: base.Add(time.Minute)},
+		{ID: "undated", Role: "assistant", Content: "third"}, // stays after its predecessor
+		{ID: "early", Role: "user", Content: "first", Timestamp: base},
+	}
+	steps := BuildReplaySteps(messages)
+	if len(steps) != 3 {
+		t.Fatalf("got %d steps, want 3", len(steps))
+	}
+	got := []string{steps[0].Text, steps[1].Text, steps[2].Text}
+	want := []string{"first", "second", "third"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestReplayDelay(t *testing.T) {
+	if d := replayDelay(0, 1); d != replayMinGap {
+		t.Errorf("zero gap delay = %v, want %v", d, replayMinGap)
+	}
+	if d := replayDelay(time.Hour, 1); d != replayMaxGap {
+		t.Errorf("long gap delay = %v, want %v", d, replayMaxGap)
+	}
+	if d := replayDelay(time.Second, 2); d != 500*time.Millisecond {
+		t.Errorf("2x delay = %v, want 500ms", d)
+	}
+}
+
+func TestReplayPlayback(t *testing.T) {
+	p := New()
+	p.selectedSession = "s1"
+	p.loadedSession = "s1"
+	p.messages = replayMessages(time.Unix(1000, 0))
+
+	if _, cmd := p.startReplay(); cmd != nil || p.replay == nil {
+		t.Fatal("startReplay should enter replay mode")
+	}
+	p.activePane = PaneMessages
+	if got := p.FocusContext(); got != "conversations-replay" {
+		t.Errorf("FocusContext = %q, want conversations-replay", got)
+	}
+	if out := p.renderReplayContent(60, 20); !strings.Contains(out, "step 1/5") {
+		t.Errorf("replay header missing position:\n%s", out)
+	}
+
+	// Stale ticks are ignored
+	p.replay.playing = true
+	if cmd := p.handleReplayTick(ReplayTickMsg{Token: p.replayToken + 1}); cmd != nil || p.replay.pos != 0 {
+		t.Errorf("stale tick advanced replay to %d", p.replay.pos)
+	}
+
+	// A valid tick advances and schedules the next one
+	if cmd := p.handleReplayTick(ReplayTickMsg{Token: p.replayToken}); cmd == nil || p.replay.pos != 1 {
+		t.Errorf("tick: pos = %d, cmd nil = %v", p.replay.pos, cmd == nil)
+	}
+
+	// Playback stops at the last step
+	p.seekReplay(len(p.replay.steps) - 2)
+	p.handleReplayTick(ReplayTickMsg{Token: p.replayToken})
+	if p.replay.playing {
+		t.Error("replay should pause at the end")
+	}
+
+	// Digit keys seek by percentage; esc exits and selects the message
+	p.updateReplay(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'5'}})
+	if p.replay.pos != 2 {
+		t.Errorf("seek 50%% pos = %d, want 2", p.replay.pos)
+	}
+	p.updateReplay(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.replay != nil {
+		t.Fatal("esc should exit replay")
+	}
+	if p.messageCursor != 1 {
+		t.Errorf("messageCursor = %d, want 1 (message of last replayed step)", p.messageCursor)
+	}
+}
+
+func TestReplaySeekKeysReachPlugin(t *testing.T) {
+	p := New()
+	m := routedApp(t, p)
+	p.selectedSession = "s1"
+	p.loadedSession = "s1"
+	p.messages = replayMessages(time.Unix(1000, 0))
+	p.activePane = PaneMessages
+	if _, cmd := p.startReplay(); cmd != nil || p.replay == nil {
+		t.Fatal("startReplay should enter replay mode")
+	}
+
+	// The app binds 1-9 to plugin switching; replay needs them for seeking
+	m = sendKeys(m, "5")
+	if p.replay == nil || p.replay.pos != 2 {
+		t.Fatalf("seek 50%% through the app: replay = %+v", p.replay)
+	}
+	sendKeys(m, "q")
+	if p.replay != nil {
+		t.Error("q should still exit replay")
+	}
+}
@@ -74,16 +74,22 @@ func (p *Plugin) ensureResumeModal() {
 		}
 	}
 
-	p.resumeModal = modal.New("Resume in Workspace",
+	title, submit := "Resume in Workspace", " Resume "
+	if p.isResumeForkMode() {
+		title, submit = "Fork in Workspace", " Fork "
+	}
+
+	p.resumeModal = modal.New(title,
 		modal.WithWidth(modalW),
 		modal.WithPrimaryAction(resumeSubmitID),
 		modal.WithHints(false),
 	).
 		AddSection(p.resumeSessionInfoSection()).
 		AddSection(modal.Spacer()).
-		AddSection(modal.Text("Resume in:")).
-		AddSection(modal.List(resumeTypeListID, typeItems, &p.resumeType, modal.WithMaxVisible(2))).
-		AddSection(modal.Spacer()).
+		// Forks always get their own worktree
+		AddSection(modal.When(p.isResumeTypeSelectable, modal.Text("Resume in:"))).
+		AddSection(modal.When(p.isResumeTypeSelectable, modal.List(resumeTypeListID, typeItems, &p.resumeType, modal.WithMaxVisible(2)))).
+		AddSection(modal.When(p.isResumeTypeSelectable, modal.Spacer())).
 		// Worktree-specific fields (shown when type == worktree)
 		AddSection(modal.When(p.isResumeWorktreeMode, modal.Text("Branch name:"))).
 		AddSection(modal.When(p.isResumeWorktreeMode, modal.Input(resumeNameFieldID, &p.resumeNameInput, modal.WithSubmitOnEnter(false)))).
@@ -97,7 +103,7 @@ func (p *Plugin) ensureResumeModal() {
 		AddSection(modal.When(p.shouldShowResumeSkipPerms, modal.Checkbox(resumeSkipPermsID, "Auto-approve all actions", &p.resumeSkipPermissions))).
 		AddSection(modal.Spacer()).
 		AddSection(modal.Buttons(
-			modal.Btn(" Resume ", resumeSubmitID),
+			modal.Btn(submit, resumeSubmitID),
 			modal.Btn(" Cancel ", resumeCancelID),
 		))
 }
@@ -125,6 +131,9 @@ func (p *Plugin) resumeSessionInfoSection() modal.Section {
 				adapterName = p.resumeSession.AdapterID
 			}
 			content := fmt.Sprintf("Session: %s\nAgent: %s", name, adapterName)
+			if p.isResumeForkMode() {
+				content += fmt.Sprintf("\nFrom: turn %d of %d", p.resumeForkTurn, p.resumeForkTurns)
+			}
 			return modal.RenderedSection{Content: content}
 		},
 		func(msg tea.Msg, focusID string) (string, tea.Cmd) {
@@ -133,6 +142,11 @@ func (p *Plugin) resumeSessionInfoSection() modal.Section {
 	)
 }
 
+// isResumeTypeSelectable returns true when the shell/worktree choice is shown.
+func (p *Plugin) isResumeTypeSelectable() bool {
+	return !p.isResumeForkMode()
+}
+
 // isResumeWorktreeMode returns true when worktree type is selected.
 func (p *Plugin) isResumeWorktreeMode() bool {
 	return p.resumeType == resumeTypeWorktree
@@ -300,6 +314,9 @@ func (p *Plugin) resetResumeModal() {
 	p.resumeFocus = 0
 	p.resumeAgentIdx = 0
 	p.resumeSkipPermissions = false
+	p.resumeForkMsgID = ""
+	p.resumeForkTurn = 0
+	p.resumeForkTurns = 0
 }
 
 // executeResume sends the resume message to workspace plugin.
@@ -324,7 +341,12 @@ func (p *Plugin) executeResume() tea.Cmd {
 		ResumeCmd: resumeCmd,
 	}
 
-	if p.resumeType == resumeTypeShell {
+	if p.isResumeForkMode() {
+		// The fork is written into the worktree before the agent starts
+		msg.Prepare = p.forkPrepare(*session, p.resumeForkMsgID, p.resumeForkTurn)
+	}
+
+	if p.resumeType == resumeTypeShell && !p.isResumeForkMode() {
 		msg.Type = "shell"
 	} else {
 		msg.Type = "worktree"
@@ -0,0 +1,160 @@
+package conversations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/config"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+)
+
+// savedFilterCommandPrefix prefixes the palette command ID of each saved
+// filter; the rest of the ID is the filter's name.
+const savedFilterCommandPrefix = "saved-filter:"
+
+// maxSavedFilterKeys is how many saved filters get function keys (f1-f9).
+const maxSavedFilterKeys = 9
+
+// savedFilterKey returns the key bound to the i-th saved filter, or "".
+func savedFilterKey(i int) string {
+	if i < 0 || i >= maxSavedFilterKeys {
+		return ""
+	}
+	return fmt.Sprintf("f%d", i+1)
+}
+
+// savedFilterIndex returns the saved filter index for a function key, or -1.
+func savedFilterIndex(key string) int {
+	for i := range maxSavedFilterKeys {
+		if savedFilterKey(i) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// filtersFromSaved builds search filters from a saved filter. The
+// workspace filter is not part of saved filters and is passed through.
+func filtersFromSaved(sf config.SavedFilterConfig, workspaceCWD string) SearchFilters {
+	f := SearchFilters{
+		Adapters:     append([]string(nil), sf.Adapters...),
+		Models:       append([]string(nil), sf.Models...),
+		Categories:   append([]string(nil), sf.Categories...),
+		Tags:         normalizeTags(sf.Tags),
+		MinTokens:    sf.MinTokens,
+		MaxTokens:    sf.MaxTokens,
+		ActiveOnly:   sf.ActiveOnly,
+		WorkspaceCWD: workspaceCWD,
+	}
+	if sf.Date != "" {
+		f.SetDateRange(sf.Date)
+	}
+	return f
+}
+
+// savedFromFilters captures the current filters as a saved filter.
+func savedFromFilters(name string, f SearchFilters) config.SavedFilterConfig {
+	return config.SavedFilterConfig{
+		Name:       name,
+		Tags:       append([]string(nil), f.Tags...),
+		Adapters:   append([]string(nil), f.Adapters...),
+		Models:     append([]string(nil), f.Models...),
+		Categories: append([]string(nil), f.Categories...),
+		Date:       f.DateRange.Preset,
+		ActiveOnly: f.ActiveOnly,
+		MinTokens:  f.MinTokens,
+		MaxTokens:  f.MaxTokens,
+	}
+}
+
+// savedFilters returns the configured saved filters.
+func (p *Plugin) savedFilters() []config.SavedFilterConfig {
+	if p.ctx == nil || p.ctx.Config == nil {
+		return nil
+	}
+	return p.ctx.Config.Plugins.Conversations.SavedFilters
+}
+
+// findSavedFilter returns the saved filter with the given name.
+func (p *Plugin) findSavedFilter(name string) (config.SavedFilterConfig, bool) {
+	for _, sf := range p.savedFilters() {
+		if strings.EqualFold(sf.Name, name) {
+			return sf, true
+		}
+	}
+	return config.SavedFilterConfig{}, false
+}
+
+// registerSavedFilterBindings binds each saved filter so it shows up in
+// the command palette, with f1-f9 for the first nine.
+func (p *Plugin) registerSavedFilterBindings() {
+	if p.ctx == nil || p.ctx.Keymap == nil {
+		return
+	}
+	for i, sf := range p.savedFilters() {
+		p.ctx.Keymap.RegisterPluginBinding(savedFilterKey(i), savedFilterCommandPrefix+sf.Name, "conversations-sidebar")
+	}
+}
+
+// applySavedFilter replaces the current filters with a saved filter,
+// keeping the workspace filter.
+func (p *Plugin) applySavedFilter(name string) tea.Cmd {
+	sf, ok := p.findSavedFilter(name)
+	if !ok {
+		return appmsg.ShowToast(fmt.Sprintf("No saved filter %q", name), 2*time.Second)
+	}
+	p.filters = filtersFromSaved(sf, p.filters.WorkspaceCWD)
+	p.filterActive = p.filters.IsActive()
+	p.filterMode = false
+	p.cursor = 0
+	p.scrollOff = 0
+	p.hitRegionsDirty = true
+	return appmsg.ShowToast("Filter: "+sf.Name, 2*time.Second)
+}
+
+// applySavedFilterAt applies the i-th saved filter.
+func (p *Plugin) applySavedFilterAt(i int) tea.Cmd {
+	saved := p.savedFilters()
+	if i < 0 || i >= len(saved) {
+		return nil
+	}
+	return p.applySavedFilter(saved[i].Name)
+}
+
+// saveCurrentFilter saves the current filters under name, replacing any
+// saved filter of the same name, and writes the config.
+func (p *Plugin) saveCurrentFilter(name string) tea.Cmd {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return appmsg.ShowToast("Filter name is required", 2*time.Second)
+	}
+	if p.ctx == nil || p.ctx.Config == nil {
+		return appmsg.ShowToast("No config to save filters to", 2*time.Second)
+	}
+
+	conv := &p.ctx.Config.Plugins.Conversations
+	sf := savedFromFilters(name, p.filters)
+	replaced := false
+	for i := range conv.SavedFilters {
+		if strings.EqualFold(conv.SavedFilters[i].Name, name) {
+			conv.SavedFilters[i] = sf
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		conv.SavedFilters = append(conv.SavedFilters, sf)
+	}
+	p.registerSavedFilterBindings()
+
+	if err := config.Save(p.ctx.Config); err != nil {
+		text := "Filter saved for this session only: " + err.Error()
+		return func() tea.Msg {
+			return app.ToastMsg{Message: text, Duration: 4 * time.Second, IsError: true}
+		}
+	}
+	return appmsg.ShowToast(fmt.Sprintf("Saved filter %q", name), 2*time.Second)
+}
@@ -16,6 +16,7 @@ type SearchFilters struct {
 	Adapters     []string  // ["claude-code", "codex"]
 	Models       []string  // ["opus", "sonnet", "haiku"]
 	Categories   []string  // ["interactive", "cron", "system"]
+	Tags         []string  // User tags; sessions need any one of them
 	DateRange    DateRange // today, week, custom
 	MinTokens    int       // Sessions with > N tokens
 	MaxTokens    int       // Sessions with < N tokens
@@ -37,6 +38,7 @@ func (f *SearchFilters) IsActive() bool {
 		len(f.Adapters) > 0 ||
 		len(f.Models) > 0 ||
 		len(f.Categories) > 0 ||
+		len(f.Tags) > 0 ||
 		f.DateRange.Preset != "" ||
 		f.MinTokens > 0 ||
 		f.MaxTokens > 0 ||
@@ -103,6 +105,34 @@ func (f *SearchFilters) HasCategory(cat string) bool {
 	return slices.Contains(f.Categories, cat)
 }
 
+// ToggleTag toggles a tag in the filter list.
+func (f *SearchFilters) ToggleTag(tag string) {
+	if i := slices.Index(f.Tags, tag); i >= 0 {
+		f.Tags = slices.Delete(f.Tags, i, i+1)
+		return
+	}
+	f.Tags = append(f.Tags, tag)
+}
+
+// HasTag returns true if the tag is in the filter list.
+func (f *SearchFilters) HasTag(tag string) bool {
+	return slices.Contains(f.Tags, tag)
+}
+
+// MatchesTags checks a session's tags against the tag filter. Tags live
+// outside adapter.Session, so callers check them alongside Matches.
+func (f *SearchFilters) MatchesTags(tags []string) bool {
+	if len(f.Tags) == 0 {
+		return true
+	}
+	for _, t := range tags {
+		if f.HasTag(t) {
+			return true
+		}
+	}
+	return false
+}
+
 // SetDateRange sets the date range preset.
 func (f *SearchFilters) SetDateRange(preset string) {
 	if preset == "all" {
@@ -205,6 +235,9 @@ func (f *SearchFilters) String() string {
 	if len(f.Categories) > 0 {
 		parts = append(parts, "[category:"+strings.Join(f.Categories, ",")+"]")
 	}
+	if len(f.Tags) > 0 {
+		parts = append(parts, "[tag:"+strings.Join(f.Tags, ",")+"]")
+	}
 	if f.DateRange.Preset != "" {
 		parts = append(parts, "["+f.DateRange.Preset+"]")
 	}
@@ -0,0 +1,335 @@
+package conversations
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/adapter/archive"
+	"github.com/toddwbucy/hermes/internal/config"
+)
+
+// archiveAdapterID is the ID of the read-only adapter serving archives.
+const archiveAdapterID = "archive"
+
+// storageGroup totals the sessions in one row of the storage report.
+type storageGroup struct {
+	Label string
+	Count int
+	Bytes int64
+}
+
+// storageReport summarizes session disk usage.
+type storageReport struct {
+	Total          storageGroup
+	ByAdapter      []storageGroup
+	ByProject      []storageGroup
+	ByAge          []storageGroup
+	Candidates     int   // Sessions past a retention limit
+	CandidateBytes int64 // Their combined size
+}
+
+// storageAgeBuckets groups sessions by time since last update. The last
+// bucket catches everything older.
+var storageAgeBuckets = []struct {
+	label string
+	limit time.Duration
+}{
+	{"< 1 week", 7 * 24 * time.Hour},
+	{"1-4 weeks", 30 * 24 * time.Hour},
+	{"1-3 months", 90 * 24 * time.Hour},
+	{"> 3 months", 0},
+}
+
+// buildStorageReport totals sessions by adapter, project and age. Adapter
+// and project rows are sorted largest first.
+func buildStorageReport(sessions []adapter.Session, retention config.RetentionConfig, now time.Time) storageReport {
+	var r storageReport
+	byAdapter := make(map[string]*storageGroup)
+	byProject := make(map[string]*storageGroup)
+	r.ByAge = make([]storageGroup, len(storageAgeBuckets))
+	for i, b := range storageAgeBuckets {
+		r.ByAge[i].Label = b.label
+	}
+
+	add := func(groups map[string]*storageGroup, label string, size int64) {
+		g := groups[label]
+		if g == nil {
+			g = &storageGroup{Label: label}
+			groups[label] = g
+		}
+		g.Count++
+		g.Bytes += size
+	}
+
+	for i := range sessions {
+		s := &sessions[i]
+		r.Total.Count++
+		r.Total.Bytes += s.FileSize
+
+		name := s.AdapterName
+		if name == "" {
+			name = s.AdapterID
+		}
+		add(byAdapter, name, s.FileSize)
+		add(byProject, storageProject(s), s.FileSize)
+
+		age := now.Sub(s.UpdatedAt)
+		for j, b := range storageAgeBuckets {
+			if b.limit == 0 || age < b.limit {
+				r.ByAge[j].Count++
+				r.ByAge[j].Bytes += s.FileSize
+				break
+			}
+		}
+
+		if retentionReason(s, retention, now) != "" {
+			r.Candidates++
+			r.CandidateBytes += s.FileSize
+		}
+	}
+
+	r.ByAdapter = sortedGroups(byAdapter)
+	r.ByProject = sortedGroups(byProject)
+	return r
+}
+
+// storageProject returns the project label for a session: its worktree
+// name, or "main" for the current worktree.
+func storageProject(s *adapter.Session) string {
+	if s.WorktreeName != "" {
+		return s.WorktreeName
+	}
+	return "main"
+}
+
+func sortedGroups(groups map[string]*storageGroup) []storageGroup {
+	out := make([]storageGroup, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Bytes != out[j].Bytes {
+			return out[i].Bytes > out[j].Bytes
+		}
+		return out[i].Label < out[j].Label
+	})
+	return out
+}
+
+// retentionReason returns why a session is past a retention limit, or ""
+// if it is within all limits. Archived sessions only count against the
+// age limit.
+func retentionReason(s *adapter.Session, r config.RetentionConfig, now time.Time) string {
+	if r.MaxAgeDays > 0 && !s.UpdatedAt.IsZero() {
+		if days := int(now.Sub(s.UpdatedAt).Hours() / 24); days >= r.MaxAgeDays {
+			return fmt.Sprintf("idle %dd", days)
+		}
+	}
+	if r.MaxSizeMB > 0 && s.AdapterID != archiveAdapterID && s.SizeMB() >= float64(r.MaxSizeMB) {
+		return fmt.Sprintf("over %dMB", r.MaxSizeMB)
+	}
+	return ""
+}
+
+// sharedSessionPaths counts sessions per file, so sessions that share one
+// (e.g. Aider's chat history) are never removed individually.
+func sharedSessionPaths(sessions []adapter.Session) map[string]int {
+	counts := make(map[string]int)
+	for i := range sessions {
+		if sessions[i].Path != "" {
+			counts[sessions[i].Path]++
+		}
+	}
+	return counts
+}
+
+// storageBlocker returns why a session can't be archived (or deleted, when
+// archiving is false), or "" if it can.
+func storageBlocker(s *adapter.Session, shared map[string]int, archiving bool) string {
+	if s.AdapterID == archiveAdapterID {
+		if archiving {
+			return "already archived"
+		}
+		return ""
+	}
+	switch {
+	case s.IsActive:
+		return "active"
+	case s.Path == "":
+		return "no session file"
+	case shared[s.Path] > 1:
+		return "shared file"
+	}
+	return ""
+}
+
+// StorageActionDoneMsg reports the result of archiving or deleting sessions.
+type StorageActionDoneMsg struct {
+	Epoch   uint64
+	Archive bool     // Archived rather than deleted
+	Removed []string // IDs of sessions no longer in their adapter
+	Freed   int64    // Bytes of session files or archives removed
+	Skipped int      // Sessions that could not be processed
+	Err     error    // First failure, if any
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m StorageActionDoneMsg) GetEpoch() uint64 { return m.Epoch }
+
+// archiveSessions archives each session into store and then removes its
+// source file, or its whole directory for adapters that keep one per
+// session. A session is only removed once its archive is written.
+// currentRoot is recorded as the project root for sessions from the
+// current worktree.
+func archiveSessions(store *archive.Store, adapters map[string]adapter.Adapter, sessions []adapter.Session, currentRoot string) StorageActionDoneMsg {
+	result := StorageActionDoneMsg{Archive: true}
+	fail := func(err error) {
+		result.Skipped++
+		if result.Err == nil {
+			result.Err = err
+		}
+	}
+
+	for _, s := range sessions {
+		a := adapters[s.AdapterID]
+		if a == nil {
+			fail(fmt.Errorf("%s: adapter not loaded", s.AdapterID))
+			continue
+		}
+		messages, err := a.Messages(s.ID)
+		if err != nil {
+			fail(fmt.Errorf("read %s: %w", shortID(s.ID), err))
+			continue
+		}
+		usage, _ := a.Usage(s.ID)
+
+		root := s.WorktreePath
+		if root == "" {
+			root = currentRoot
+		}
+		dir, err := sessionDir(a, &s)
+		if err != nil {
+			fail(fmt.Errorf("archive %s: %w", shortID(s.ID), err))
+			continue
+		}
+		size := s.FileSize
+		if dir != "" {
+			size = dirSize(dir)
+			_, err = store.WriteDir(s, dir, root, messages, usage)
+		} else {
+			_, err = store.Write(s, root, messages, usage)
+		}
+		if err != nil {
+			fail(fmt.Errorf("archive %s: %w", shortID(s.ID), err))
+			continue
+		}
+		if err := removeSession(&s, dir); err != nil {
+			fail(fmt.Errorf("remove %s: %w", filepath.Base(s.Path), err))
+			continue
+		}
+		result.Removed = append(result.Removed, s.ID)
+		result.Freed += size
+	}
+	return result
+}
+
+// deleteSessions removes each session's file (or directory, for adapters
+// that keep one per session), or its archive for archived sessions.
+func deleteSessions(store *archive.Store, adapters map[string]adapter.Adapter, sessions []adapter.Session) StorageActionDoneMsg {
+	var result StorageActionDoneMsg
+	for _, s := range sessions {
+		var err error
+		size := s.FileSize
+		if s.AdapterID == archiveAdapterID {
+			err = store.Remove(s.ID)
+		} else if s.Path == "" {
+			err = archive.ErrNoSessionFile
+		} else {
+			var dir string
+			if dir, err = sessionDir(adapters[s.AdapterID], &s); err == nil {
+				if dir != "" {
+					size = dirSize(dir)
+				}
+				err = removeSession(&s, dir)
+			}
+		}
+		if err != nil {
+			result.Skipped++
+			if result.Err == nil {
+				result.Err = fmt.Errorf("delete %s: %w", shortID(s.ID), err)
+			}
+			continue
+		}
+		result.Removed = append(result.Removed, s.ID)
+		result.Freed += size
+	}
+	return result
+}
+
+// errNoSessionDir is returned when an adapter that keeps one directory per
+// session can't place a session's file in it.
+var errNoSessionDir = errors.New("session directory not found")
+
+// sessionDir returns the directory holding only s, for adapters that keep
+// one per session, or "" for sessions stored in a single file.
+func sessionDir(a adapter.Adapter, s *adapter.Session) (string, error) {
+	p, ok := a.(adapter.SessionDirProvider)
+	if !ok {
+		return "", nil
+	}
+	dir := p.SessionDir(s.ID)
+	// Never remove a directory that doesn't hold the session's own file
+	if dir == "" || filepath.Dir(s.Path) != filepath.Clean(dir) {
+		return "", errNoSessionDir
+	}
+	return dir, nil
+}
+
+// removeSession removes s's file, or dir with everything in it when set.
+// Files already gone are not an error.
+func removeSession(s *adapter.Session, dir string) error {
+	if dir != "" {
+		return os.RemoveAll(dir)
+	}
+	if err := os.Remove(s.Path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// dirSize totals the regular files under dir.
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// errNothingToDo is shown when no selected session can be processed.
+var errNothingToDo = errors.New("no selected session can be processed")
+
+// formatBytes formats a byte count in human-readable form.
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%dB", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
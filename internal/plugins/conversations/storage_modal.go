@@ -0,0 +1,523 @@
+package conversations
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/modal"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+	"github.com/toddwbucy/hermes/internal/plugin"
+	"github.com/toddwbucy/hermes/internal/styles"
+)
+
+// storageAction is an archive or delete request awaiting confirmation.
+type storageAction int
+
+const (
+	storageNone storageAction = iota
+	storageArchive
+	storageDelete
+)
+
+// storageNoteWidth is the width of the list's retention/blocker column.
+const storageNoteWidth = 20
+
+// storageModalState holds the storage view state.
+type storageModalState struct {
+	rows     []adapter.Session // Loaded sessions, largest first
+	cursor   int
+	selected map[string]bool // Session IDs
+	confirm  storageAction
+	targets  []adapter.Session // Sessions the pending action applies to
+	blocked  int               // Selected sessions the pending action skips
+	busy     bool
+}
+
+// openStorageModal opens the storage view over the loaded sessions.
+func (p *Plugin) openStorageModal() (plugin.Plugin, tea.Cmd) {
+	p.storageModalState = &storageModalState{selected: make(map[string]bool)}
+	p.refreshStorageModal()
+	p.showStorageModal = true
+	return p, nil
+}
+
+// refreshStorageModal rebuilds the session list from p.sessions, dropping
+// selections for sessions that are gone.
+func (p *Plugin) refreshStorageModal() {
+	state := p.storageModalState
+	if state == nil {
+		return
+	}
+	state.rows = append(state.rows[:0], p.sessions...)
+	sort.SliceStable(state.rows, func(i, j int) bool {
+		return state.rows[i].FileSize > state.rows[j].FileSize
+	})
+
+	present := make(map[string]bool, len(state.rows))
+	for _, s := range state.rows {
+		present[s.ID] = true
+	}
+	for id := range state.selected {
+		if !present[id] {
+			delete(state.selected, id)
+		}
+	}
+	state.cursor = min(state.cursor, len(state.rows)-1)
+	state.cursor = max(state.cursor, 0)
+}
+
+// closeStorageModal closes the storage view.
+func (p *Plugin) closeStorageModal() {
+	p.showStorageModal = false
+	p.storageModalState = nil
+}
+
+// handleStorageModalKey handles key events when the storage view is open.
+func (p *Plugin) handleStorageModalKey(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	state := p.storageModalState
+	if state == nil {
+		p.showStorageModal = false
+		return p, nil
+	}
+
+	if state.confirm != storageNone {
+		switch msg.String() {
+		case "y", "enter":
+			return p, p.runStorageAction()
+		case "n", "esc", "q":
+			state.confirm = storageNone
+			state.targets = nil
+		}
+		return p, nil
+	}
+
+	switch msg.String() {
+	case "esc", "q", "S":
+		p.closeStorageModal()
+
+	case "j", "down":
+		if state.cursor < len(state.rows)-1 {
+			state.cursor++
+		}
+
+	case "k", "up":
+		if state.cursor > 0 {
+			state.cursor--
+		}
+
+	case "g":
+		state.cursor = 0
+
+	case "G":
+		if len(state.rows) > 0 {
+			state.cursor = len(state.rows) - 1
+		}
+
+	case " ":
+		if state.cursor < len(state.rows) {
+			id := state.rows[state.cursor].ID
+			if state.selected[id] {
+				delete(state.selected, id)
+			} else {
+				state.selected[id] = true
+			}
+			if state.cursor < len(state.rows)-1 {
+				state.cursor++
+			}
+		}
+
+	case "r":
+		// Select sessions past a retention limit, or clear if already selected
+		p.toggleRetentionSelection()
+
+	case "enter":
+		if state.cursor < len(state.rows) {
+			s := state.rows[state.cursor]
+			p.closeStorageModal()
+			p.hitRegionsDirty = true
+			return p, p.openConversation(s.AdapterID, s.ID, "")
+		}
+
+	case "a":
+		return p, p.confirmStorageAction(storageArchive)
+
+	case "D":
+		return p, p.confirmStorageAction(storageDelete)
+	}
+
+	return p, nil
+}
+
+// toggleRetentionSelection selects every session past a retention limit,
+// or clears the selection when exactly those are already selected.
+func (p *Plugin) toggleRetentionSelection() {
+	state := p.storageModalState
+	if p.retention.MaxAgeDays == 0 && p.retention.MaxSizeMB == 0 {
+		return
+	}
+	now := time.Now()
+	flagged := make(map[string]bool)
+	for i := range state.rows {
+		if retentionReason(&state.rows[i], p.retention, now) != "" {
+			flagged[state.rows[i].ID] = true
+		}
+	}
+	if maps.Equal(flagged, state.selected) {
+		clear(state.selected)
+		return
+	}
+	state.selected = flagged
+}
+
+// confirmStorageAction asks for confirmation before archiving or deleting
+// the selected sessions, or the highlighted one when none are selected.
+func (p *Plugin) confirmStorageAction(action storageAction) tea.Cmd {
+	state := p.storageModalState
+	if state.busy {
+		return nil
+	}
+
+	var picked []adapter.Session
+	for _, s := range state.rows {
+		if state.selected[s.ID] {
+			picked = append(picked, s)
+		}
+	}
+	if len(picked) == 0 && state.cursor < len(state.rows) {
+		picked = append(picked, state.rows[state.cursor])
+	}
+
+	shared := sharedSessionPaths(p.sessions)
+	var targets []adapter.Session
+	reason := ""
+	for _, s := range picked {
+		if r := storageBlocker(&s, shared, action == storageArchive); r != "" {
+			if reason == "" {
+				reason = r
+			}
+			continue
+		}
+		targets = append(targets, s)
+	}
+	if len(targets) == 0 {
+		if reason == "" {
+			return appmsg.ShowToast(errNothingToDo.Error(), 2*time.Second)
+		}
+		return appmsg.ShowToast(fmt.Sprintf("Cannot %s: %s", storageVerb(action), reason), 2*time.Second)
+	}
+
+	state.confirm = action
+	state.targets = targets
+	state.blocked = len(picked) - len(targets)
+	return nil
+}
+
+// runStorageAction archives or deletes the confirmed sessions in the
+// background.
+func (p *Plugin) runStorageAction() tea.Cmd {
+	state := p.storageModalState
+	action := state.confirm
+	targets := state.targets
+	blocked := state.blocked
+	state.confirm = storageNone
+	state.targets = nil
+	state.busy = true
+
+	var epoch uint64
+	currentRoot := ""
+	if p.ctx != nil {
+		epoch = p.ctx.Epoch
+		currentRoot = p.ctx.WorkDir
+		if abs, err := filepath.Abs(currentRoot); err == nil {
+			currentRoot = abs
+		}
+	}
+	store := p.archiveStore
+	adapters := p.adapters
+
+	return func() tea.Msg {
+		var result StorageActionDoneMsg
+		if action == storageArchive {
+			result = archiveSessions(store, adapters, targets, currentRoot)
+		} else {
+			result = deleteSessions(store, adapters, targets)
+		}
+		result.Epoch = epoch
+		result.Skipped += blocked
+		return result
+	}
+}
+
+// handleStorageActionDone drops archived or deleted sessions from the list
+// and reloads, so archived sessions reappear through the archive adapter.
+func (p *Plugin) handleStorageActionDone(msg StorageActionDoneMsg) (plugin.Plugin, tea.Cmd) {
+	removed := make(map[string]bool, len(msg.Removed))
+	for _, id := range msg.Removed {
+		removed[id] = true
+	}
+	kept := make([]adapter.Session, 0, len(p.sessions))
+	for _, s := range p.sessions {
+		if !removed[s.ID] {
+			kept = append(kept, s)
+		}
+	}
+	p.sessions = kept
+	if removed[p.selectedSession] {
+		p.selectedSession = ""
+		p.loadedSession = ""
+		p.messages = nil
+		p.turns = nil
+		p.sessionSummary = nil
+	}
+	if p.cursor >= len(p.visibleSessions()) {
+		p.cursor = max(len(p.visibleSessions())-1, 0)
+	}
+	p.hitRegionsDirty = true
+
+	// The archive adapter only detects once a project has archives
+	if msg.Archive && len(msg.Removed) > 0 && p.ctx != nil {
+		if a, ok := p.ctx.Adapters[archiveAdapterID]; ok && p.adapters[archiveAdapterID] == nil {
+			// Copy so in-flight loads keep iterating the old map
+			next := maps.Clone(p.adapters)
+			if next == nil {
+				next = make(map[string]adapter.Adapter)
+			}
+			next[archiveAdapterID] = a
+			p.adapters = next
+		}
+	}
+
+	if state := p.storageModalState; state != nil {
+		state.busy = false
+		p.refreshStorageModal()
+	}
+
+	verb := "Deleted"
+	if msg.Archive {
+		verb = "Archived"
+	}
+	text := fmt.Sprintf("%s %d session%s, freed %s", verb, len(msg.Removed), plural(len(msg.Removed)), formatBytes(msg.Freed))
+	if msg.Skipped > 0 {
+		text += fmt.Sprintf(" (%d skipped)", msg.Skipped)
+	}
+	toast := appmsg.ShowToast(text, 3*time.Second)
+	if msg.Err != nil {
+		errText := text + ": " + msg.Err.Error()
+		toast = func() tea.Msg {
+			return app.ToastMsg{Message: errText, Duration: 4 * time.Second, IsError: true}
+		}
+	}
+	return p, tea.Batch(toast, p.loadSessions())
+}
+
+// storageVerb names an action for prompts.
+func storageVerb(action storageAction) string {
+	if action == storageArchive {
+		return "archive"
+	}
+	return "delete"
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+// renderStorageModal renders the storage view as an overlay string.
+func (p *Plugin) renderStorageModal(width, height int) string {
+	state := p.storageModalState
+	if state == nil {
+		return ""
+	}
+
+	modalWidth := min(max(width-8, 60), 110)
+	effectiveHeight := max(height-4, 16)
+	contentWidth := modalWidth - 6
+	now := time.Now()
+	report := buildStorageReport(state.rows, p.retention, now)
+
+	footer := " space:select  r:select flagged  a:archive  D:delete  enter:open  esc:close"
+	if state.confirm != storageNone {
+		footer = " y:confirm  n:cancel"
+	}
+
+	m := modal.New("Storage",
+		modal.WithWidth(modalWidth),
+		modal.WithHints(false),
+		modal.WithCustomFooter(footer),
+	).
+		AddSection(modal.Text(p.storageSummary(&report))).
+		AddSection(modal.Spacer()).
+		AddSection(storageGroupsSection(&report, contentWidth)).
+		AddSection(modal.Spacer())
+
+	switch {
+	case state.busy:
+		m.AddSection(modal.Text(styles.Muted.Render("Working...")))
+	case state.confirm != storageNone:
+		m.AddSection(modal.Text(storageConfirmText(state)))
+	default:
+		m.AddSection(p.storageListSection(state, effectiveHeight-22, contentWidth, now))
+	}
+
+	return m.Render(width, effectiveHeight, nil)
+}
+
+// storageSummary renders the totals and the configured retention limits.
+func (p *Plugin) storageSummary(r *storageReport) string {
+	line := fmt.Sprintf("%d sessions · %s", r.Total.Count, formatBytes(r.Total.Bytes))
+
+	var limits []string
+	if p.retention.MaxAgeDays > 0 {
+		limits = append(limits, fmt.Sprintf("idle ≥ %dd", p.retention.MaxAgeDays))
+	}
+	if p.retention.MaxSizeMB > 0 {
+		limits = append(limits, fmt.Sprintf("≥ %dMB", p.retention.MaxSizeMB))
+	}
+	if len(limits) == 0 {
+		line += styles.Muted.Render("  ·  no retention limits set")
+	} else {
+		line += styles.Muted.Render("  ·  retention: "+strings.Join(limits, ", ")) +
+			"  " + styles.StatusModified.Render(fmt.Sprintf("%d flagged (%s)", r.Candidates, formatBytes(r.CandidateBytes)))
+	}
+	if p.archiveStore != nil && p.archiveStore.Dir() != "" {
+		line += "\n" + styles.Muted.Render("Archives: "+p.archiveStore.Dir())
+	}
+	return line
+}
+
+// storageGroupsSection renders the adapter, project and age breakdowns side
+// by side.
+func storageGroupsSection(r *storageReport, contentWidth int) modal.Section {
+	return modal.Custom(
+		func(cw int, focusID, hoverID string) modal.RenderedSection {
+			colWidth := max((contentWidth-4)/3, 20)
+			column := func(title string, groups []storageGroup, limit int) string {
+				headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.TextSecondary)
+				lines := []string{headerStyle.Render(title)}
+				for i, g := range groups {
+					if i == limit {
+						lines = append(lines, styles.Muted.Render(fmt.Sprintf("+%d more", len(groups)-limit)))
+						break
+					}
+					stats := fmt.Sprintf("%4d %8s", g.Count, formatBytes(g.Bytes))
+					label := truncateStr(g.Label, max(colWidth-lipgloss.Width(stats)-1, 4))
+					pad := max(colWidth-lipgloss.Width(label)-lipgloss.Width(stats), 1)
+					lines = append(lines, label+strings.Repeat(" ", pad)+styles.Muted.Render(stats))
+				}
+				return lipgloss.NewStyle().Width(colWidth).Render(strings.Join(lines, "\n"))
+			}
+			content := lipgloss.JoinHorizontal(lipgloss.Top,
+				column("By adapter", r.ByAdapter, 5), "  ",
+				column("By project", r.ByProject, 5), "  ",
+				column("By age", r.ByAge, 5),
+			)
+			return modal.RenderedSection{Content: content}
+		},
+		nil,
+	)
+}
+
+// storageListSection renders the scrollable session list, largest first.
+func (p *Plugin) storageListSection(state *storageModalState, maxHeight, contentWidth int, now time.Time) modal.Section {
+	return modal.Custom(
+		func(cw int, focusID, hoverID string) modal.RenderedSection {
+			if len(state.rows) == 0 {
+				return modal.RenderedSection{Content: styles.Muted.Render("(no sessions loaded)")}
+			}
+			shared := sharedSessionPaths(state.rows)
+			visibleCount := min(max(maxHeight, 3), len(state.rows))
+
+			scrollOff := 0
+			if state.cursor >= visibleCount {
+				scrollOff = state.cursor - visibleCount + 1
+			}
+
+			var sb strings.Builder
+			for i := 0; i < visibleCount; i++ {
+				idx := scrollOff + i
+				if idx >= len(state.rows) {
+					break
+				}
+				s := &state.rows[idx]
+
+				box := "[ ]"
+				if state.selected[s.ID] {
+					box = "[x]"
+				}
+				flag := " "
+				note := retentionReason(s, p.retention, now)
+				if note != "" {
+					flag = styles.StatusModified.Render("●")
+				} else {
+					note = storageBlocker(s, shared, false)
+				}
+				if note == "" && s.AdapterID == archiveAdapterID {
+					note = "archived"
+				}
+
+				stats := fmt.Sprintf("%8s  %-9s", formatBytes(s.FileSize), formatTimeAgo(s.UpdatedAt))
+				note = truncateStr(note, storageNoteWidth)
+				name := s.Name
+				if name == "" {
+					name = shortID(s.ID)
+				}
+				// Fixed columns: cursor, checkbox, flag, icon, stats, note
+				maxNameW := max(contentWidth-lipgloss.Width(stats)-storageNoteWidth-14, 10)
+				name = truncateStr(name, maxNameW)
+				pad := max(maxNameW-lipgloss.Width(name), 0)
+				line := fmt.Sprintf("%s %s %s %s%s %s %s", box, flag, s.AdapterIcon, name, strings.Repeat(" ", pad), stats, styles.Muted.Render(note))
+
+				if idx == state.cursor {
+					line = styles.ListItemFocused.Render("▸ " + line)
+				} else {
+					line = "  " + line
+				}
+				if i > 0 {
+					sb.WriteString("\n")
+				}
+				sb.WriteString(line)
+			}
+
+			content := sb.String()
+			if scrollOff > 0 {
+				content = styles.Muted.Render("↑ more above") + "\n" + content
+			}
+			if scrollOff+visibleCount < len(state.rows) {
+				content = content + "\n" + styles.Muted.Render("↓ more below")
+			}
+			return modal.RenderedSection{Content: content}
+		},
+		nil,
+	)
+}
+
+// storageConfirmText describes the pending action.
+func storageConfirmText(state *storageModalState) string {
+	var total int64
+	for _, s := range state.targets {
+		total += s.FileSize
+	}
+	n := len(state.targets)
+	var text string
+	if state.confirm == storageArchive {
+		text = fmt.Sprintf("Archive %d session%s (%s)? Session files are compressed into the archive directory and removed from the agent's data directory.",
+			n, plural(n), formatBytes(total))
+	} else {
+		text = styles.StatusDeleted.Render(fmt.Sprintf("Delete %d session%s (%s)? This cannot be undone.", n, plural(n), formatBytes(total)))
+	}
+	if state.blocked > 0 {
+		text += "\n" + styles.Muted.Render(fmt.Sprintf("%d selected session%s will be skipped (active, shared or not file-backed).", state.blocked, plural(state.blocked)))
+	}
+	return text
+}
@@ -0,0 +1,214 @@
+package conversations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/adapter/archive"
+	"github.com/toddwbucy/hermes/internal/config"
+)
+
+func TestBuildStorageReport(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	const mb = 1024 * 1024
+	sessions := []adapter.Session{
+		{ID: "a", AdapterName: "Claude Code", FileSize: 300 * mb, UpdatedAt: now.Add(-time.Hour)},
+		{ID: "b", AdapterName: "Claude Code", FileSize: 10 * mb, UpdatedAt: now.AddDate(0, 0, -10), WorktreeName: "feature"},
+		{ID: "c", AdapterName: "Codex", FileSize: 5 * mb, UpdatedAt: now.AddDate(0, 0, -200)},
+		{ID: "d", AdapterID: archiveAdapterID, AdapterName: "Archive", FileSize: 400 * mb, UpdatedAt: now.AddDate(0, 0, -20)},
+	}
+	r := buildStorageReport(sessions, config.RetentionConfig{MaxAgeDays: 90, MaxSizeMB: 200}, now)
+
+	if r.Total.Count != 4 || r.Total.Bytes != 715*mb {
+		t.Errorf("total = %+v", r.Total)
+	}
+	if len(r.ByAdapter) != 3 || r.ByAdapter[0].Label != "Archive" || r.ByAdapter[1].Count != 2 {
+		t.Errorf("by adapter = %+v", r.ByAdapter)
+	}
+	if len(r.ByProject) != 2 || r.ByProject[0].Label != "main" || r.ByProject[1].Label != "feature" {
+		t.Errorf("by project = %+v", r.ByProject)
+	}
+	var ages []int
+	for _, g := range r.ByAge {
+		ages = append(ages, g.Count)
+	}
+	if len(ages) != 4 || ages[0] != 1 || ages[1] != 2 || ages[2] != 0 || ages[3] != 1 {
+		t.Errorf("by age = %v", ages)
+	}
+	// a is over the size limit and c past the age limit; archives are
+	// exempt from the size limit
+	if r.Candidates != 2 || r.CandidateBytes != 305*mb {
+		t.Errorf("candidates = %d (%d bytes)", r.Candidates, r.CandidateBytes)
+	}
+}
+
+func storageTestPlugin(t *testing.T) (*Plugin, string) {
+	t.Helper()
+	dir := t.TempDir()
+	write := func(name string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("{}\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	old := time.Now().AddDate(0, -6, 0)
+	shared := write("history.md")
+
+	p := New()
+	p.archiveStore = archive.NewStore(filepath.Join(dir, "archive"))
+	p.adapters = map[string]adapter.Adapter{"mock": &mockAdapter{}}
+	p.retention = config.RetentionConfig{MaxAgeDays: 30}
+	p.sessions = []adapter.Session{
+		{ID: "old", AdapterID: "mock", AdapterIcon: "◆", Name: "Old", Path: write("old.jsonl"), FileSize: 3, UpdatedAt: old},
+		{ID: "live", AdapterID: "mock", Path: write("live.jsonl"), UpdatedAt: old, IsActive: true},
+		{ID: "shared1", AdapterID: "mock", Path: shared, UpdatedAt: old},
+		{ID: "shared2", AdapterID: "mock", Path: shared, UpdatedAt: time.Now()},
+		{ID: "recent", AdapterID: "mock", Path: write("recent.jsonl"), UpdatedAt: time.Now()},
+	}
+	p.selectedSession = "old"
+	return p, dir
+}
+
+func TestStorageArchiveFlaggedSessions(t *testing.T) {
+	p, dir := storageTestPlugin(t)
+
+	_, _ = p.updateSessions(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	if !p.showStorageModal || p.FocusContext() != "conversations-storage" {
+		t.Fatal("S should open the storage view")
+	}
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if len(p.storageModalState.selected) != 3 {
+		t.Fatalf("r selected %v, want the 3 idle sessions", p.storageModalState.selected)
+	}
+
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	state := p.storageModalState
+	// Active sessions and sessions sharing a file are never archived
+	if state.confirm != storageArchive || len(state.targets) != 1 || state.targets[0].ID != "old" || state.blocked != 2 {
+		t.Fatalf("confirm = %v, targets = %+v, blocked = %d", state.confirm, state.targets, state.blocked)
+	}
+	_ = p.View(120, 50)
+
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd == nil || !state.busy {
+		t.Fatal("y should start archiving")
+	}
+	done, ok := cmd().(StorageActionDoneMsg)
+	if !ok || len(done.Removed) != 1 || done.Skipped != 2 || done.Err != nil {
+		t.Fatalf("done = %+v", done)
+	}
+	_, _ = p.Update(done)
+
+	if _, err := os.Stat(filepath.Join(dir, "old.jsonl")); !os.IsNotExist(err) {
+		t.Error("archived session file still in place")
+	}
+	entries, _ := p.archiveStore.List()
+	if len(entries) != 1 || entries[0].Manifest.Session.ID != "old" {
+		t.Fatalf("archives = %+v", entries)
+	}
+	if p.hasSession("old") || p.selectedSession != "" || state.busy {
+		t.Errorf("after archive: hasSession=%v selected=%q busy=%v", p.hasSession("old"), p.selectedSession, state.busy)
+	}
+	if len(state.rows) != 4 {
+		t.Errorf("storage rows = %d, want 4", len(state.rows))
+	}
+}
+
+func TestStorageDeleteNeedsConfirmation(t *testing.T) {
+	p, dir := storageTestPlugin(t)
+	_, _ = p.openStorageModal()
+
+	// Rows are largest first; move to the recent session
+	state := p.storageModalState
+	for state.rows[state.cursor].ID != "recent" {
+		_, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	}
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	if state.confirm != storageDelete {
+		t.Fatal("D should ask for confirmation")
+	}
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if state.confirm != storageNone || !p.showStorageModal {
+		t.Fatal("esc should cancel the confirmation, not close the view")
+	}
+
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	_, cmd := p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	_, _ = p.Update(cmd())
+	if _, err := os.Stat(filepath.Join(dir, "recent.jsonl")); !os.IsNotExist(err) {
+		t.Error("deleted session file still on disk")
+	}
+	if p.hasSession("recent") {
+		t.Error("deleted session still listed")
+	}
+}
+
+// taskDirAdapter keeps each session in its own directory, like Cline.
+type taskDirAdapter struct {
+	mockAdapter
+	root string
+}
+
+func (a *taskDirAdapter) SessionDir(sessionID string) string {
+	return filepath.Join(a.root, sessionID)
+}
+
+func TestStorageActionsTakeWholeSessionDir(t *testing.T) {
+	root := t.TempDir()
+	a := &taskDirAdapter{root: root}
+	adapters := map[string]adapter.Adapter{"mock": a}
+	task := func(id string) adapter.Session {
+		dir := filepath.Join(root, id)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for name, content := range map[string]string{
+			"api_conversation_history.json": "[]",
+			"ui_messages.json":              `[{"say":"api_req_started","text":"{\"cost\":0.5}"}]`,
+			"task_metadata.json":            "{}",
+		} {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return adapter.Session{ID: id, AdapterID: "mock", Path: filepath.Join(dir, "api_conversation_history.json"), FileSize: 2, EstCost: 0.5}
+	}
+	store := archive.NewStore(filepath.Join(t.TempDir(), "archive"))
+
+	s := task("t1")
+	dirBytes := dirSize(filepath.Join(root, "t1"))
+	done := archiveSessions(store, adapters, []adapter.Session{s}, "/work")
+	if done.Err != nil || len(done.Removed) != 1 || done.Freed != dirBytes || dirBytes <= s.FileSize {
+		t.Fatalf("archive = %+v", done)
+	}
+	if _, err := os.Stat(filepath.Join(root, "t1")); !os.IsNotExist(err) {
+		t.Error("task directory left behind after archiving")
+	}
+	entries, _ := store.List()
+	if len(entries) != 1 || len(entries[0].Manifest.Files) != 3 || entries[0].Manifest.Session.EstCost != 0.5 {
+		t.Fatalf("archives = %+v", entries)
+	}
+
+	done = deleteSessions(store, adapters, []adapter.Session{task("t2")})
+	if done.Err != nil || len(done.Removed) != 1 || done.Freed <= 2 {
+		t.Fatalf("delete = %+v", done)
+	}
+	if _, err := os.Stat(filepath.Join(root, "t2")); !os.IsNotExist(err) {
+		t.Error("task directory left behind after deleting")
+	}
+
+	// A session file outside its reported directory is never removed
+	stray := task("t3")
+	stray.ID = "elsewhere"
+	if done := deleteSessions(store, adapters, []adapter.Session{stray}); done.Skipped != 1 {
+		t.Errorf("stray delete = %+v", done)
+	}
+	if _, err := os.Stat(stray.Path); err != nil {
+		t.Error("stray session file removed")
+	}
+}
@@ -0,0 +1,229 @@
+package conversations
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	tagsFileName = "tags.json"
+	tagsVersion  = 1
+)
+
+// TagStore persists user tags on sessions to a JSON file, by default
+// ~/.config/hermes/tags.json. Tags are keyed by adapter and session ID so
+// they work across projects.
+type TagStore struct {
+	path string
+
+	mu    sync.RWMutex
+	items map[string]sessionTags
+}
+
+// sessionTags is one session's tags.
+type sessionTags struct {
+	AdapterID string   `json:"adapterId"`
+	SessionID string   `json:"sessionId"`
+	Tags      []string `json:"tags"`
+}
+
+func tagKey(adapterID, sessionID string) string {
+	return adapterID + "\x00" + sessionID
+}
+
+// tagFile is the on-disk format.
+type tagFile struct {
+	Version  int           `json:"version"`
+	Sessions []sessionTags `json:"sessions"`
+}
+
+// LoadTagStore reads tags from path. A missing or corrupt file yields an
+// empty store, like LoadBookmarkStore.
+func LoadTagStore(path string) *TagStore {
+	s := &TagStore{path: path, items: make(map[string]sessionTags)}
+	if path == "" {
+		return s
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Warn("tags: read failed", "err", err)
+		}
+		return s
+	}
+
+	var f tagFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		slog.Warn("tags: parse failed, starting empty", "err", err)
+		return s
+	}
+	for _, st := range f.Sessions {
+		st.Tags = normalizeTags(st.Tags)
+		if st.SessionID == "" || len(st.Tags) == 0 {
+			continue
+		}
+		s.items[tagKey(st.AdapterID, st.SessionID)] = st
+	}
+	return s
+}
+
+// defaultTagsPath returns the tags file under configDir, falling back to
+// ~/.config/hermes.
+func defaultTagsPath(configDir string) string {
+	bookmarks := defaultBookmarksPath(configDir)
+	if bookmarks == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(bookmarks), tagsFileName)
+}
+
+// Get returns a session's tags, sorted.
+func (s *TagStore) Get(adapterID, sessionID string) []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.items[tagKey(adapterID, sessionID)].Tags
+}
+
+// Set replaces a session's tags and saves the store. Empty tags remove
+// the entry.
+func (s *TagStore) Set(adapterID, sessionID string, tags []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := tagKey(adapterID, sessionID)
+	tags = normalizeTags(tags)
+	if len(tags) == 0 {
+		if _, ok := s.items[key]; !ok {
+			return nil
+		}
+		delete(s.items, key)
+	} else {
+		s.items[key] = sessionTags{AdapterID: adapterID, SessionID: sessionID, Tags: tags}
+	}
+	return s.saveLocked()
+}
+
+// All returns every tag in use, most used first.
+func (s *TagStore) All() []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, st := range s.items {
+		for _, t := range st.Tags {
+			counts[t]++
+		}
+	}
+	out := make([]string, 0, len(counts))
+	for t := range counts {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if counts[out[i]] != counts[out[j]] {
+			return counts[out[i]] > counts[out[j]]
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
+
+// saveLocked writes the store atomically. Caller must hold s.mu.
+func (s *TagStore) saveLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	f := tagFile{Version: tagsVersion, Sessions: make([]sessionTags, 0, len(s.items))}
+	for _, st := range s.items {
+		f.Sessions = append(f.Sessions, st)
+	}
+	sort.Slice(f.Sessions, func(i, j int) bool {
+		return tagKey(f.Sessions[i].AdapterID, f.Sessions[i].SessionID) <
+			tagKey(f.Sessions[j].AdapterID, f.Sessions[j].SessionID)
+	})
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Atomic write: temp file + rename
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+// parseTags splits user input on commas and whitespace.
+func parseTags(input string) []string {
+	return normalizeTags(strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	}))
+}
+
+// normalizeTags lowercases tags, strips a leading '#', and returns them
+// sorted without duplicates.
+func normalizeTags(tags []string) []string {
+	var out []string
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(t), "#"))
+		if t != "" {
+			out = append(out, t)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// formatTags renders tags for display, e.g. "#bug #review".
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return "#" + strings.Join(tags, " #")
+}
+
+// tagFilterKeys are the filter menu keys for tags, after the model keys 1-3.
+var tagFilterKeys = []string{"4", "5", "6", "7", "8", "9"}
+
+// tagFilterOption is a tag toggle in the filter menu.
+type tagFilterOption struct {
+	key string
+	id  string // Tag
+}
+
+// tagFilterOptions returns the tags offered in the filter menu, most used
+// first, followed by filtered tags no session has any more.
+func (p *Plugin) tagFilterOptions() []tagFilterOption {
+	tags := p.tags.All()
+	for _, t := range p.filters.Tags {
+		if !slices.Contains(tags, t) {
+			tags = append(tags, t)
+		}
+	}
+	var options []tagFilterOption
+	for i, t := range tags {
+		if i == len(tagFilterKeys) {
+			break
+		}
+		options = append(options, tagFilterOption{key: tagFilterKeys[i], id: t})
+	}
+	return options
+}
@@ -0,0 +1,196 @@
+package conversations
+
+import (
+	"io"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/config"
+	"github.com/toddwbucy/hermes/internal/keymap"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+	"github.com/toddwbucy/hermes/internal/plugin"
+)
+
+func TestTagStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hermes", tagsFileName)
+	s := LoadTagStore(path)
+
+	if err := s.Set("claude-code", "s1", parseTags("Bug, #review  bug")); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("codex", "s1", []string{"bug"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := LoadTagStore(path)
+	if got := reloaded.Get("claude-code", "s1"); !slices.Equal(got, []string{"bug", "review"}) {
+		t.Errorf("tags = %v, want [bug review]", got)
+	}
+	if got := reloaded.All(); !slices.Equal(got, []string{"bug", "review"}) {
+		t.Errorf("All = %v, want most used first", got)
+	}
+	if got := formatTags(reloaded.Get("claude-code", "s1")); got != "#bug #review" {
+		t.Errorf("formatTags = %q", got)
+	}
+
+	if err := reloaded.Set("codex", "s1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := LoadTagStore(path).Get("codex", "s1"); got != nil {
+		t.Errorf("cleared tags still on disk: %v", got)
+	}
+}
+
+func tagTestPlugin(t *testing.T) *Plugin {
+	p := New()
+	p.tags = LoadTagStore(filepath.Join(t.TempDir(), tagsFileName))
+	p.sessions = []adapter.Session{
+		{ID: "s1", Name: "Fix cache", AdapterID: "mock"},
+		{ID: "s2", Name: "Write docs", AdapterID: "mock"},
+	}
+	return p
+}
+
+// routedApp registers p in an app model, so keys sent through it meet the
+// app-level shortcuts before reaching the plugin.
+func routedApp(t *testing.T, p *Plugin) tea.Model {
+	t.Helper()
+	reg := plugin.NewRegistry(&plugin.Context{
+		WorkDir:   t.TempDir(),
+		ConfigDir: t.TempDir(),
+		Config:    config.Default(),
+		Logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	if err := reg.Register(p); err != nil {
+		t.Fatal(err)
+	}
+	km := keymap.NewRegistry()
+	keymap.RegisterDefaults(km)
+	return app.New(reg, km, config.Default(), "test", "", "", p.ID())
+}
+
+func sendKeys(m tea.Model, keys ...string) tea.Model {
+	for _, k := range keys {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+	return m
+}
+
+func TestTagKeyReachesPlugin(t *testing.T) {
+	p := New()
+	m := routedApp(t, p)
+	p.tags = LoadTagStore(filepath.Join(t.TempDir(), tagsFileName))
+	p.sessions = []adapter.Session{
+		{ID: "s1", Name: "Fix cache", AdapterID: "mock"},
+		{ID: "s2", Name: "Write docs", AdapterID: "mock"},
+	}
+	p.selectedSession = "s1"
+
+	// j first so the app picks up the sidebar's focus context
+	m = sendKeys(m, "j", "T")
+	if p.promptModal == nil {
+		t.Fatal("T should open the tag prompt, not an app shortcut")
+	}
+	// The modal finds its focusables on the first render and focuses on the next
+	_ = p.View(100, 40)
+	_ = p.View(100, 40)
+	sendKeys(m, "v", "1", "#")
+	if got := p.promptModal.input.Value(); got != "v1#" {
+		t.Errorf("tag input = %q, want the typed keys", got)
+	}
+}
+
+func TestTagSessionAndFilter(t *testing.T) {
+	p := tagTestPlugin(t)
+	p.cursor = 1
+	p.selectedSession = "s2"
+
+	_, _ = p.updateSessions(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")})
+	if p.promptModal == nil || p.FocusContext() != "conversations-prompt" {
+		t.Fatal("T should open the tag prompt")
+	}
+	p.promptModal.input.SetValue("docs, Later")
+	_ = p.View(100, 40)
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.promptModal != nil {
+		t.Error("enter should close the prompt")
+	}
+	if got := p.tags.Get("mock", "s2"); !slices.Equal(got, []string{"docs", "later"}) {
+		t.Fatalf("tags = %v", got)
+	}
+
+	// Tags are offered in the filter menu and filter the list
+	_, _ = p.updateSessions(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if menu := p.renderFilterMenu(60); !strings.Contains(menu, "Tags:") || !strings.Contains(menu, "docs") {
+		t.Errorf("filter menu missing tags:\n%s", menu)
+	}
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("4")})
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !slices.Equal(p.filters.Tags, []string{"docs"}) {
+		t.Fatalf("filters.Tags = %v", p.filters.Tags)
+	}
+	visible := p.visibleSessions()
+	if len(visible) != 1 || visible[0].ID != "s2" {
+		t.Errorf("visible = %v, want only the tagged session", visible)
+	}
+}
+
+func TestSavedFilters(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	config.SetTestConfigPath(configPath)
+	defer config.ResetTestConfigPath()
+
+	p := tagTestPlugin(t)
+	cfg := config.Default()
+	cfg.Plugins.Conversations.SavedFilters = []config.SavedFilterConfig{{Name: "Active", ActiveOnly: true}}
+	km := keymap.NewRegistry()
+	p.ctx = &plugin.Context{Config: cfg, Keymap: km}
+	p.registerSavedFilterBindings()
+	_ = p.tags.Set("mock", "s1", []string{"bug"})
+
+	// Save the current tag filter from the filter menu
+	p.filterMode = true
+	p.filters.ToggleTag("bug")
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if p.promptModal == nil || p.filterMode {
+		t.Fatal("+ should close the menu and prompt for a name")
+	}
+	p.promptModal.input.SetValue("Bugs")
+	_ = p.View(100, 40)
+	_, _ = p.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	saved := p.savedFilters()
+	if len(saved) != 2 || saved[1].Name != "Bugs" || !slices.Equal(saved[1].Tags, []string{"bug"}) {
+		t.Fatalf("saved filters = %+v", saved)
+	}
+	loaded, err := config.LoadFrom(configPath)
+	if err != nil || len(loaded.Plugins.Conversations.SavedFilters) != 2 {
+		t.Fatalf("saved config: %v, %+v", err, loaded)
+	}
+	if cmds := km.BindingsForContext("conversations-sidebar"); !slices.ContainsFunc(cmds, func(b keymap.Binding) bool {
+		return b.Key == "f2" && b.Command == savedFilterCommandPrefix+"Bugs"
+	}) {
+		t.Error("second saved filter should be bound to f2")
+	}
+
+	// f1 applies the first saved filter, replacing the tag filter
+	_, _ = p.updateSessions(tea.KeyMsg{Type: tea.KeyF1})
+	if !p.filters.ActiveOnly || len(p.filters.Tags) != 0 {
+		t.Errorf("f1 filters = %+v", p.filters)
+	}
+
+	// The palette runs saved filters through RunCommandMsg
+	_, _ = p.Update(appmsg.RunCommandMsg{CommandID: savedFilterCommandPrefix + "Bugs", Context: "conversations-sidebar"})
+	if p.filters.ActiveOnly || !p.filters.HasTag("bug") {
+		t.Errorf("palette filters = %+v", p.filters)
+	}
+	if visible := p.visibleSessions(); len(visible) != 1 || visible[0].ID != "s1" {
+		t.Errorf("visible = %v", visible)
+	}
+}
@@ -0,0 +1,293 @@
+package conversations
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/adapter/toolstats"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/styles"
+)
+
+// toolIndexMaxSessions caps how many sessions one background indexing pass
+// loads, so a large history doesn't stall startup.
+const toolIndexMaxSessions = 100
+
+// ToolIndexBuiltMsg signals that a background tool-index pass finished.
+type ToolIndexBuiltMsg struct {
+	Epoch   uint64
+	Indexed int
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m ToolIndexBuiltMsg) GetEpoch() uint64 { return m.Epoch }
+
+// sessionCWD returns the directory relative tool paths resolve against.
+func sessionCWD(s adapter.Session, workDir string) string {
+	if s.CWD != "" {
+		return s.CWD
+	}
+	if s.WorktreePath != "" {
+		return s.WorktreePath
+	}
+	return workDir
+}
+
+// indexToolStats loads messages for sessions missing from (or stale in) the
+// shared tool index and records their tool stats and file touches. Large
+// sessions are skipped; they are indexed when opened instead.
+func (p *Plugin) indexToolStats() tea.Cmd {
+	if p.toolIndexing || len(p.adapters) == 0 || p.ctx == nil {
+		return nil
+	}
+
+	index := toolstats.Shared()
+	var pending []adapter.Session
+	for _, s := range p.sessions {
+		if len(pending) >= toolIndexMaxSessions {
+			break
+		}
+		if s.MessageCount == 0 || s.SizeLevel() > 0 || !index.NeedsIndex(s) {
+			continue
+		}
+		pending = append(pending, s)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	p.toolIndexing = true
+	epoch := p.ctx.Epoch
+	workDir := p.ctx.WorkDir
+	adapters := p.adapters // capture to avoid race in closure
+	return func() tea.Msg {
+		indexed := 0
+		for _, s := range pending {
+			a := adapters[s.AdapterID]
+			if a == nil {
+				continue
+			}
+			messages, err := a.Messages(s.ID)
+			if err != nil {
+				continue
+			}
+			index.Put(s, toolstats.Compute(s.ID, sessionCWD(s, workDir), messages))
+			indexed++
+		}
+		return ToolIndexBuiltMsg{Epoch: epoch, Indexed: indexed}
+	}
+}
+
+// projectToolStats returns per-session stats for every indexed session in
+// the current project.
+func (p *Plugin) projectToolStats() []toolstats.SessionStats {
+	return toolstats.Shared().Stats(p.sessions)
+}
+
+// formatToolDuration formats a tool call duration with sub-second precision.
+func formatToolDuration(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	default:
+		return formatSessionDuration(d)
+	}
+}
+
+// renderToolTable renders tool stats as aligned rows with a header.
+func renderToolTable(stats []toolstats.ToolStat, width int) []string {
+	nameWidth := 18
+	if width < 50 {
+		nameWidth = 12
+	}
+
+	header := fmt.Sprintf(" %-*s %6s %6s %7s %7s", nameWidth, "Tool", "Calls", "Err%", "Avg", "Max")
+	lines := []string{styles.Muted.Render(header)}
+	for _, t := range stats {
+		name := t.Name
+		if len(name) > nameWidth {
+			name = name[:nameWidth-1] + "…"
+		}
+		errStyle := styles.Body
+		if t.Errors > 0 {
+			errStyle = styles.StatusDeleted
+		}
+		row := styles.Body.Render(fmt.Sprintf(" %-*s %6d ", nameWidth, name, t.Calls)) +
+			errStyle.Render(fmt.Sprintf("%5.0f%%", t.ErrorRate()*100)) +
+			styles.Subtitle.Render(fmt.Sprintf(" %7s %7s", formatToolDuration(t.AvgDuration()), formatToolDuration(t.MaxDuration)))
+		lines = append(lines, row)
+	}
+	return lines
+}
+
+// renderToolImpact renders the per-session tool usage view shown in the
+// main pane when the tool summary is toggled on.
+func (p *Plugin) renderToolImpact(contentWidth, height int) []string {
+	session := p.findSelectedSession()
+	if session == nil {
+		return []string{styles.Muted.Render("No session selected")}
+	}
+	stats, ok := toolstats.Shared().Get(session.AdapterID, session.ID)
+	if !ok {
+		return []string{styles.Muted.Render("Indexing tool calls...")}
+	}
+
+	var lines []string
+	summary := fmt.Sprintf("Tool Usage  %d calls │ %d errors", stats.TotalCalls(), stats.TotalErrors())
+	lines = append(lines, styles.Title.Render(summary))
+	if stats.TotalCalls() == 0 {
+		lines = append(lines, styles.Muted.Render("No tool calls in this session"))
+		return lines
+	}
+	lines = append(lines, renderToolTable(stats.Sorted(), contentWidth)...)
+
+	// Files touched in first-touch order, deduplicated by path
+	type fileEntry struct {
+		path  string
+		edits int
+		reads int
+	}
+	var files []*fileEntry
+	byPath := make(map[string]*fileEntry)
+	for _, touch := range stats.Files {
+		e := byPath[touch.Path]
+		if e == nil {
+			e = &fileEntry{path: touch.Path}
+			byPath[touch.Path] = e
+			files = append(files, e)
+		}
+		if touch.Op == toolstats.OpEdit {
+			e.edits++
+		} else {
+			e.reads++
+		}
+	}
+	if len(files) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, styles.Title.Render(fmt.Sprintf("Files Touched (%d)", len(files))))
+		workDir := ""
+		if p.ctx != nil {
+			workDir = p.ctx.WorkDir
+		}
+		for _, e := range files {
+			path := e.path
+			if workDir != "" {
+				if rel, err := filepath.Rel(workDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+					path = rel
+				}
+			}
+			marker := styles.Muted.Render(" ◦ ")
+			if e.edits > 0 {
+				marker = lipgloss.NewStyle().Foreground(styles.Warning).Render(" ✎ ")
+			}
+			counts := styles.Muted.Render(fmt.Sprintf(" (%d edit, %d read)", e.edits, e.reads))
+			maxPath := contentWidth - lipgloss.Width(counts) - 4
+			if maxPath > 3 && len(path) > maxPath {
+				path = "…" + path[len(path)-maxPath+1:]
+			}
+			lines = append(lines, marker+styles.Body.Render(path)+counts)
+		}
+	}
+
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	return lines
+}
+
+// projectToolUsageLines renders the project-wide tool usage section of the
+// analytics view.
+func (p *Plugin) projectToolUsageLines() []string {
+	var lines []string
+	lines = append(lines, styles.Title.Render(" Tool Usage (this project)"))
+	lines = append(lines, styles.Muted.Render(strings.Repeat("─", p.separatorWidth())))
+
+	sessions := p.projectToolStats()
+	if len(sessions) == 0 {
+		msg := " No indexed sessions yet"
+		if p.toolIndexing {
+			msg = " Indexing sessions..."
+		}
+		lines = append(lines, styles.Muted.Render(msg))
+		return lines
+	}
+
+	merged := toolstats.Aggregate(sessions)
+	calls, errs := 0, 0
+	for _, t := range merged {
+		calls += t.Calls
+		errs += t.Errors
+	}
+	rate := 0.0
+	if calls > 0 {
+		rate = float64(errs) / float64(calls) * 100
+	}
+	summary := fmt.Sprintf(" %d sessions  │  %s calls  │  %d errors (%.1f%%)",
+		len(sessions), formatLargeNumber(calls), errs, rate)
+	lines = append(lines, styles.Body.Render(summary))
+	lines = append(lines, renderToolTable(merged, p.width)...)
+
+	if slow := toolstats.Slowest(merged, 5); len(slow) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, styles.Title.Render(" Slowest Tools"))
+		lines = append(lines, styles.Muted.Render(strings.Repeat("─", p.separatorWidth())))
+		for _, t := range slow {
+			lines = append(lines, styles.Body.Render(fmt.Sprintf(" %-18s", t.Name))+
+				styles.Subtitle.Render(fmt.Sprintf(" avg %s  max %s  (%d timed)",
+					formatToolDuration(t.AvgDuration()), formatToolDuration(t.MaxDuration), t.Timed)))
+		}
+	}
+	return lines
+}
+
+// openConversation selects a session and scrolls to a message, for
+// cross-plugin links into transcripts. Sessions match on both adapter and
+// ID, since IDs are only unique within an adapter.
+func (p *Plugin) openConversation(adapterID, sessionID, messageID string) tea.Cmd {
+	found := false
+	for i := range p.sessions {
+		if p.sessions[i].AdapterID == adapterID && p.sessions[i].ID == sessionID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return func() tea.Msg {
+			return app.ToastMsg{Message: "Session not found", Duration: 2 * time.Second, IsError: true}
+		}
+	}
+
+	p.view = ViewSessions
+	for i, s := range p.visibleSessions() {
+		if s.AdapterID == adapterID && s.ID == sessionID {
+			p.cursor = i
+			p.ensureCursorVisible()
+			break
+		}
+	}
+
+	alreadyLoaded := p.loadedSession == sessionID && len(p.messages) > 0
+	p.setSelectedSession(sessionID)
+	p.activePane = PaneMessages
+	p.showToolSummary = false
+	p.pendingScrollMsgID = messageID
+	p.pendingScrollActive = messageID != ""
+
+	if alreadyLoaded {
+		p.applyPendingScroll()
+		return nil
+	}
+	return tea.Batch(
+		p.loadMessages(sessionID),
+		p.loadUsage(sessionID),
+	)
+}
@@ -0,0 +1,350 @@
+package conversations
+
+import (
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+	"github.com/toddwbucy/hermes/internal/plugin"
+)
+
+// TraceRow is one span in the waterfall, in depth-first tree order.
+type TraceRow struct {
+	Span        adapter.TraceSpan
+	Depth       int
+	HasChildren bool
+	Offset      time.Duration // start relative to the trace start
+	Duration    time.Duration // wall time; open spans run to the trace end
+	Open        bool          // span has not ended yet
+}
+
+// TraceWaterfall is a span tree flattened for rendering.
+type TraceWaterfall struct {
+	Rows   []TraceRow
+	Start  time.Time
+	Total  time.Duration // trace start to the latest span end
+	Errors int
+}
+
+// BuildTraceWaterfall arranges spans into a tree from their parent IDs and
+// flattens it depth-first, siblings ordered by start time. Spans whose
+// parent is missing from the trace are treated as roots. Subtrees under a
+// collapsed span ID are omitted, but still count toward the error total.
+func BuildTraceWaterfall(spans []adapter.TraceSpan, collapsed map[string]bool) TraceWaterfall {
+	var w TraceWaterfall
+	if len(spans) == 0 {
+		return w
+	}
+
+	byID := make(map[string]bool, len(spans))
+	var end time.Time
+	for i, s := range spans {
+		byID[s.SpanID] = true
+		if w.Start.IsZero() || s.Start.Before(w.Start) {
+			w.Start = s.Start
+		}
+		if s.End.After(end) {
+			end = s.End
+		}
+		if s.Start.After(end) {
+			end = s.Start
+		}
+		if spans[i].IsError() {
+			w.Errors++
+		}
+	}
+	w.Total = end.Sub(w.Start)
+
+	children := make(map[string][]int)
+	var roots []int
+	for i, s := range spans {
+		if s.ParentID == "" || s.ParentID == s.SpanID || !byID[s.ParentID] {
+			roots = append(roots, i)
+			continue
+		}
+		children[s.ParentID] = append(children[s.ParentID], i)
+	}
+	byStart := func(idx []int) {
+		sort.SliceStable(idx, func(a, b int) bool {
+			return spans[idx[a]].Start.Before(spans[idx[b]].Start)
+		})
+	}
+	byStart(roots)
+	for _, idx := range children {
+		byStart(idx)
+	}
+
+	visited := make(map[int]bool, len(spans))
+	var walk func(i, depth int, hidden bool)
+	walk = func(i, depth int, hidden bool) {
+		// Guards against parent cycles in malformed traces
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+
+		s := spans[i]
+		if !hidden {
+			row := TraceRow{
+				Span:        s,
+				Depth:       depth,
+				HasChildren: len(children[s.SpanID]) > 0,
+				Offset:      s.Start.Sub(w.Start),
+				Open:        s.End.IsZero(),
+			}
+			if row.Open {
+				row.Duration = end.Sub(s.Start)
+			} else {
+				row.Duration = s.Duration()
+			}
+			w.Rows = append(w.Rows, row)
+		}
+
+		// Collapsed subtrees are still walked so they are not mistaken
+		// for unreachable spans below
+		hidden = hidden || collapsed[s.SpanID]
+		for _, c := range children[s.SpanID] {
+			walk(c, depth+1, hidden)
+		}
+	}
+	for _, r := range roots {
+		walk(r, 0, false)
+	}
+	// Spans only reachable through a cycle have no root; list them flat
+	for i := range spans {
+		if !visited[i] {
+			walk(i, 0, false)
+		}
+	}
+	return w
+}
+
+// traceState holds the trace waterfall view for one session.
+type traceState struct {
+	sessionID    string
+	spans        []adapter.TraceSpan
+	waterfall    TraceWaterfall
+	collapsed    map[string]bool
+	cursor       int
+	scroll       int
+	detailScroll int
+	loading      bool
+	err          error
+}
+
+// TraceLoadedMsg delivers a session's spans for the trace view.
+type TraceLoadedMsg struct {
+	Epoch     uint64
+	SessionID string
+	Spans     []adapter.TraceSpan
+	Err       error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m TraceLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// selectedRow returns the row under the cursor.
+func (t *traceState) selectedRow() *TraceRow {
+	if t.cursor < 0 || t.cursor >= len(t.waterfall.Rows) {
+		return nil
+	}
+	return &t.waterfall.Rows[t.cursor]
+}
+
+// rebuild recomputes the waterfall, keeping the cursor on the same span.
+func (t *traceState) rebuild() {
+	selected := ""
+	if row := t.selectedRow(); row != nil {
+		selected = row.Span.SpanID
+	}
+	t.waterfall = BuildTraceWaterfall(t.spans, t.collapsed)
+	for i, row := range t.waterfall.Rows {
+		if row.Span.SpanID == selected {
+			t.cursor = i
+			return
+		}
+	}
+	t.moveCursor(0)
+}
+
+// moveCursor moves the cursor by delta rows, clamped to the waterfall.
+func (t *traceState) moveCursor(delta int) {
+	t.cursor += delta
+	if t.cursor >= len(t.waterfall.Rows) {
+		t.cursor = len(t.waterfall.Rows) - 1
+	}
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+	t.detailScroll = 0
+}
+
+// jumpToError moves the cursor to the next (dir > 0) or previous error
+// span, wrapping around. Returns false when no visible span has an error.
+func (t *traceState) jumpToError(dir int) bool {
+	n := len(t.waterfall.Rows)
+	for step := 1; step <= n; step++ {
+		i := ((t.cursor+dir*step)%n + n) % n
+		if t.waterfall.Rows[i].Span.IsError() {
+			t.cursor = i
+			t.detailScroll = 0
+			return true
+		}
+	}
+	return false
+}
+
+// ensureCursorVisible scrolls the span list so the cursor row is shown.
+func (t *traceState) ensureCursorVisible(visible int) {
+	if visible < 1 {
+		visible = 1
+	}
+	if t.cursor < t.scroll {
+		t.scroll = t.cursor
+	}
+	if t.cursor >= t.scroll+visible {
+		t.scroll = t.cursor - visible + 1
+	}
+	if maxScroll := len(t.waterfall.Rows) - visible; t.scroll > maxScroll {
+		t.scroll = max(maxScroll, 0)
+	}
+}
+
+// traceProviderFor returns the adapter serving a session if it exposes spans.
+func (p *Plugin) traceProviderFor(sessionID string) adapter.TraceProvider {
+	a := p.adapterForSession(sessionID)
+	if a == nil {
+		return nil
+	}
+	tp, _ := a.(adapter.TraceProvider)
+	return tp
+}
+
+// loadTrace fetches a session's spans in the background.
+func (p *Plugin) loadTrace(sessionID string) tea.Cmd {
+	var epoch uint64
+	if p.ctx != nil {
+		epoch = p.ctx.Epoch
+	}
+	tp := p.traceProviderFor(sessionID)
+	return func() tea.Msg {
+		if tp == nil {
+			return TraceLoadedMsg{Epoch: epoch, SessionID: sessionID}
+		}
+		spans, err := tp.TraceSpans(sessionID)
+		return TraceLoadedMsg{Epoch: epoch, SessionID: sessionID, Spans: spans, Err: err}
+	}
+}
+
+// startTrace opens the span waterfall for the selected session.
+func (p *Plugin) startTrace() (plugin.Plugin, tea.Cmd) {
+	if p.selectedSession == "" {
+		return p, appmsg.ShowToast("No session selected", 2*time.Second)
+	}
+	if p.traceProviderFor(p.selectedSession) == nil {
+		return p, appmsg.ShowToast("No span trace for this session", 2*time.Second)
+	}
+	p.trace = &traceState{
+		sessionID: p.selectedSession,
+		collapsed: make(map[string]bool),
+		loading:   true,
+	}
+	p.hitRegionsDirty = true
+	return p, p.loadTrace(p.selectedSession)
+}
+
+// stopTrace leaves the trace view.
+func (p *Plugin) stopTrace() {
+	p.trace = nil
+	p.hitRegionsDirty = true
+}
+
+// refreshTrace reloads spans after the traced session changes on disk, so
+// a running benchmark grows in place. The view closes if another session
+// was selected.
+func (p *Plugin) refreshTrace() tea.Cmd {
+	if p.trace == nil {
+		return nil
+	}
+	if p.trace.sessionID != p.loadedSession {
+		p.trace = nil
+		return nil
+	}
+	return p.loadTrace(p.trace.sessionID)
+}
+
+// handleTraceLoaded applies freshly loaded spans to the open trace view.
+func (p *Plugin) handleTraceLoaded(msg TraceLoadedMsg) {
+	t := p.trace
+	if t == nil || t.sessionID != msg.SessionID {
+		return
+	}
+	t.loading = false
+	t.err = msg.Err
+	if msg.Err != nil {
+		return
+	}
+	// Follow the newest span while the cursor sits on the last row
+	follow := len(t.waterfall.Rows) > 0 && t.cursor == len(t.waterfall.Rows)-1
+	t.spans = msg.Spans
+	t.rebuild()
+	if follow {
+		t.cursor = len(t.waterfall.Rows) - 1
+	}
+}
+
+// setCollapsed folds (true) or unfolds (false) the subtree under the cursor.
+func (t *traceState) setCollapsed(collapsed bool) {
+	row := t.selectedRow()
+	if row == nil || !row.HasChildren || t.collapsed[row.Span.SpanID] == collapsed {
+		return
+	}
+	t.collapsed[row.Span.SpanID] = collapsed
+	t.rebuild()
+}
+
+// updateTrace handles key events in the trace view.
+func (p *Plugin) updateTrace(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	t := p.trace
+	switch msg.String() {
+	case "esc", "q", "T":
+		p.stopTrace()
+	case "j", "down":
+		t.moveCursor(1)
+	case "k", "up":
+		t.moveCursor(-1)
+	case "ctrl+d":
+		t.moveCursor(10)
+	case "ctrl+u":
+		t.moveCursor(-10)
+	case "g", "home":
+		t.moveCursor(-len(t.waterfall.Rows))
+	case "G", "end":
+		t.moveCursor(len(t.waterfall.Rows))
+	case "enter", " ":
+		if row := t.selectedRow(); row != nil {
+			t.setCollapsed(!t.collapsed[row.Span.SpanID])
+		}
+	case "h", "left":
+		t.setCollapsed(true)
+	case "l", "right":
+		t.setCollapsed(false)
+	case "n":
+		if !t.jumpToError(1) {
+			return p, appmsg.ShowToast("No error spans", 2*time.Second)
+		}
+	case "N":
+		if !t.jumpToError(-1) {
+			return p, appmsg.ShowToast("No error spans", 2*time.Second)
+		}
+	case "J":
+		t.detailScroll++
+	case "K":
+		if t.detailScroll > 0 {
+			t.detailScroll--
+		}
+	}
+	return p, nil
+}
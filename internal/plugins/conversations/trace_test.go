@@ -0,0 +1,170 @@
+package conversations
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/adapter"
+)
+
+// traceAdapter is a mockAdapter that serves spans.
+type traceAdapter struct {
+	mockAdapter
+	spans []adapter.TraceSpan
+}
+
+func (a *traceAdapter) TraceSpans(sessionID string) ([]adapter.TraceSpan, error) {
+	return a.spans, nil
+}
+
+func traceSpans(base time.Time) []adapter.TraceSpan {
+	at := func(s float64) time.Time { return base.Add(time.Duration(s * float64(time.Second))) }
+	return []adapter.TraceSpan{
+		{SpanID: "llm2", ParentID: "chain", Name: "llm.stream", Kind: "LLM", Start: at(5), End: at(6.5), StatusCode: adapter.SpanStatusOK},
+		{SpanID: "root", Name: "benchmark.run", Kind: "AGENT", Start: at(0), End: at(10), StatusCode: adapter.SpanStatusUnset},
+		{SpanID: "chain", ParentID: "root", Name: "task_attempt", Kind: "CHAIN", Start: at(1), End: at(9), StatusCode: adapter.SpanStatusOK},
+		{SpanID: "llm1", ParentID: "chain", Name: "llm.stream", Kind: "LLM", Start: at(2), End: at(3.5), StatusCode: adapter.SpanStatusOK},
+		{
+			SpanID: "tool", ParentID: "llm1", Name: "tool.Move", Kind: "TOOL", Start: at(3.5), End: at(4),
+			StatusCode: adapter.SpanStatusError, StatusMessage: "blocked",
+			Attributes: map[string]string{"tool.name": "Move", "output.value": "wall"},
+		},
+		{SpanID: "orphan", ParentID: "missing", Name: "late", Start: at(8)},
+	}
+}
+
+func TestBuildTraceWaterfall(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	w := BuildTraceWaterfall(traceSpans(base), nil)
+
+	var order []string
+	for _, r := range w.Rows {
+		order = append(order, r.Span.SpanID)
+	}
+	if got := strings.Join(order, ","); got != "root,chain,llm1,tool,llm2,orphan" {
+		t.Errorf("row order = %s", got)
+	}
+	if w.Total != 10*time.Second || w.Errors != 1 {
+		t.Errorf("total/errors = %v/%d", w.Total, w.Errors)
+	}
+
+	tool := w.Rows[3]
+	if tool.Depth != 3 || tool.Offset != 3500*time.Millisecond || tool.Duration != 500*time.Millisecond {
+		t.Errorf("tool row = depth %d offset %v dur %v", tool.Depth, tool.Offset, tool.Duration)
+	}
+	if !w.Rows[1].HasChildren || w.Rows[3].HasChildren {
+		t.Error("HasChildren not set from parent links")
+	}
+
+	// Spans without an end run to the end of the trace
+	orphan := w.Rows[5]
+	if orphan.Depth != 0 || !orphan.Open || orphan.Duration != 2*time.Second {
+		t.Errorf("orphan row = %+v", orphan)
+	}
+
+	collapsed := BuildTraceWaterfall(traceSpans(base), map[string]bool{"chain": true})
+	if len(collapsed.Rows) != 3 || collapsed.Errors != 1 {
+		t.Errorf("collapsed rows = %d, errors = %d", len(collapsed.Rows), collapsed.Errors)
+	}
+}
+
+func TestBuildTraceWaterfallParentCycle(t *testing.T) {
+	base := time.Unix(0, 0)
+	spans := []adapter.TraceSpan{
+		{SpanID: "a", ParentID: "b", Start: base},
+		{SpanID: "b", ParentID: "a", Start: base.Add(time.Second)},
+	}
+	if w := BuildTraceWaterfall(spans, nil); len(w.Rows) != 2 {
+		t.Errorf("cycle rows = %d, want each span once", len(w.Rows))
+	}
+}
+
+func TestSpanBarExtent(t *testing.T) {
+	cases := []struct {
+		offset, dur  time.Duration
+		start, width int
+	}{
+		{0, 10 * time.Second, 0, 20},
+		{5 * time.Second, 0, 10, 1},
+		{9 * time.Second, 5 * time.Second, 18, 2},
+		{10 * time.Second, 0, 19, 1},
+	}
+	for _, c := range cases {
+		start, width := spanBarExtent(c.offset, c.dur, 10*time.Second, 20)
+		if start != c.start || width != c.width {
+			t.Errorf("spanBarExtent(%v, %v) = %d,%d, want %d,%d", c.offset, c.dur, start, width, c.start, c.width)
+		}
+	}
+}
+
+func TestTraceView(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	a := &traceAdapter{spans: traceSpans(base)}
+	p := New()
+	p.adapters = map[string]adapter.Adapter{"weaver": a}
+	p.sessions = []adapter.Session{{ID: "run1", Name: "run1", AdapterID: "weaver"}}
+	p.selectedSession = "run1"
+	p.loadedSession = "run1"
+	p.activePane = PaneMessages
+
+	_, cmd := p.startTrace()
+	if p.trace == nil || cmd == nil {
+		t.Fatal("startTrace should open the trace view and load spans")
+	}
+	if got := p.FocusContext(); got != "conversations-trace" {
+		t.Errorf("FocusContext = %q, want conversations-trace", got)
+	}
+	p.handleTraceLoaded(cmd().(TraceLoadedMsg))
+	if len(p.trace.waterfall.Rows) != 6 {
+		t.Fatalf("rows = %d, want 6", len(p.trace.waterfall.Rows))
+	}
+
+	// n jumps to the error span; its status and attributes show in the detail pane
+	p.updateTrace(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if row := p.trace.selectedRow(); row == nil || row.Span.SpanID != "tool" {
+		t.Fatalf("n selected %+v, want the error span", row)
+	}
+	out := p.renderTraceContent(100, 30)
+	for _, want := range []string{"6 spans", "1 errors", "ERROR: blocked", "output.value: wall", "tool.Move ✗"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("trace view missing %q:\n%s", want, out)
+		}
+	}
+
+	// Collapsing keeps the cursor on the collapsed span
+	p.trace.cursor = 1
+	p.updateTrace(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(p.trace.waterfall.Rows) != 3 || p.trace.selectedRow().Span.SpanID != "chain" {
+		t.Errorf("collapse: %d rows, cursor on %s", len(p.trace.waterfall.Rows), p.trace.selectedRow().Span.SpanID)
+	}
+	p.updateTrace(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	if len(p.trace.waterfall.Rows) != 6 {
+		t.Errorf("expand: %d rows, want 6", len(p.trace.waterfall.Rows))
+	}
+
+	// New spans from a live run are appended; the cursor follows the tail
+	p.trace.cursor = len(p.trace.waterfall.Rows) - 1
+	a.spans = append(traceSpans(base), adapter.TraceSpan{SpanID: "new", Name: "eval", Start: base.Add(11 * time.Second)})
+	p.handleTraceLoaded(p.refreshTrace()().(TraceLoadedMsg))
+	if row := p.trace.selectedRow(); row == nil || row.Span.SpanID != "new" {
+		t.Errorf("cursor should follow the newest span, got %+v", row)
+	}
+
+	// Switching sessions closes the view
+	p.loadedSession = "other"
+	if cmd := p.refreshTrace(); cmd != nil || p.trace != nil {
+		t.Error("trace view should close when another session loads")
+	}
+}
+
+func TestStartTraceWithoutProvider(t *testing.T) {
+	p := New()
+	p.adapters = map[string]adapter.Adapter{"mock": &mockAdapter{}}
+	p.sessions = []adapter.Session{{ID: "s1", AdapterID: "mock"}}
+	p.selectedSession = "s1"
+	if _, cmd := p.startTrace(); cmd == nil || p.trace != nil {
+		t.Error("sessions without spans should toast instead of opening the trace view")
+	}
+}
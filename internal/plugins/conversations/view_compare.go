@@ -0,0 +1,214 @@
+package conversations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/toddwbucy/hermes/internal/styles"
+)
+
+// renderCompare renders the side-by-side session comparison with scrolling.
+func (p *Plugin) renderCompare() string {
+	var lines []string
+	sepWidth := p.separatorWidth()
+
+	lines = append(lines, styles.Title.Render(" Compare Sessions"))
+	lines = append(lines, styles.Muted.Render(strings.Repeat("━", sepWidth)))
+
+	switch {
+	case p.compareErr != nil:
+		lines = append(lines, styles.StatusDeleted.Render(" Unable to load sessions: "+p.compareErr.Error()))
+	case p.compareLoading || p.compare == nil:
+		lines = append(lines, styles.Muted.Render(" Loading sessions..."))
+	default:
+		lines = append(lines, p.compareBodyLines(p.compare)...)
+	}
+
+	p.compareLines = lines
+
+	contentHeight := p.height - 2
+	if contentHeight < 1 {
+		contentHeight = 1
+	}
+	start := p.compareScrollOff
+	if start >= len(lines) {
+		start = len(lines) - 1
+		if start < 0 {
+			start = 0
+		}
+	}
+	end := start + contentHeight
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return strings.Join(lines[start:end], "\n")
+}
+
+// compareColumns returns the label and per-side column widths.
+func (p *Plugin) compareColumns() (labelW, colW int) {
+	labelW = 12
+	colW = (p.width - labelW - 6) / 2
+	if colW < 16 {
+		colW = 16
+	}
+	return labelW, colW
+}
+
+// compareRow renders a label and two side-by-side cells.
+func (p *Plugin) compareRow(label, left, right string, leftStyle, rightStyle lipgloss.Style) string {
+	labelW, colW := p.compareColumns()
+	cell := func(s string, st lipgloss.Style) string {
+		s = strings.ReplaceAll(s, "\n", " ")
+		if runes := []rune(s); len(runes) > colW {
+			s = string(runes[:colW-1]) + "…"
+		}
+		return st.Render(fmt.Sprintf("%-*s", colW, s))
+	}
+	return styles.Subtitle.Render(fmt.Sprintf(" %-*s", labelW, label)) +
+		cell(left, leftStyle) + styles.Muted.Render(" │ ") + cell(right, rightStyle)
+}
+
+// compareBodyLines renders every comparison section.
+func (p *Plugin) compareBodyLines(c *SessionComparison) []string {
+	var lines []string
+	sepWidth := p.separatorWidth()
+	section := func(title string) {
+		lines = append(lines, "")
+		lines = append(lines, styles.Title.Render(" "+title))
+		lines = append(lines, styles.Muted.Render(strings.Repeat("─", sepWidth)))
+	}
+
+	name := func(s CompareSide) string {
+		n := s.Session.Name
+		if n == "" {
+			n = shortID(s.Session.ID)
+		}
+		return adapterShortName(&s.Session) + " · " + n
+	}
+	lines = append(lines, p.compareRow("", name(c.Left), name(c.Right), styles.StatusStaged, styles.StatusInProgress))
+	if c.Left.Session.WorktreeName != "" || c.Right.Session.WorktreeName != "" {
+		lines = append(lines, p.compareRow("worktree", c.Left.Session.WorktreeName, c.Right.Session.WorktreeName, styles.Muted, styles.Muted))
+	}
+
+	// Metrics: lower is highlighted as better for every metric shown
+	section("Metrics")
+	l, r := c.Left, c.Right
+	lines = append(lines, p.compareMetric("tokens in", l.Summary.TotalTokensIn, r.Summary.TotalTokensIn, formatK))
+	lines = append(lines, p.compareMetric("tokens out", l.Summary.TotalTokensOut, r.Summary.TotalTokensOut, formatK))
+	lines = append(lines, p.compareMetricFloat("cost", l.Cost, r.Cost, formatCost))
+	lines = append(lines, p.compareMetric("duration", int(l.Duration.Seconds()), int(r.Duration.Seconds()), func(n int) string {
+		return formatSessionDuration(time.Duration(n) * time.Second)
+	}))
+	lines = append(lines, p.compareMetric("messages", l.Summary.MessageCount, r.Summary.MessageCount, itoa))
+	lines = append(lines, p.compareMetric("tool calls", l.ToolCalls, r.ToolCalls, itoa))
+	lines = append(lines, p.compareMetric("tool errors", l.ToolErrors, r.ToolErrors, itoa))
+	lines = append(lines, p.compareMetric("files edited", len(l.FilesEdited), len(r.FilesEdited), itoa))
+
+	// Files edited
+	section(fmt.Sprintf("Files Edited  (%d both · %d left only · %d right only)",
+		len(c.FilesBoth), len(c.FilesLeft), len(c.FilesRight)))
+	if len(c.FilesBoth)+len(c.FilesLeft)+len(c.FilesRight) == 0 {
+		lines = append(lines, styles.Muted.Render(" No file edits in either session"))
+	}
+	for _, f := range c.FilesBoth {
+		lines = append(lines, p.compareRow("both", f, f, styles.Body, styles.Body))
+	}
+	for _, f := range c.FilesLeft {
+		lines = append(lines, p.compareRow("left only", f, "", styles.StatusDeleted, styles.Muted))
+	}
+	for _, f := range c.FilesRight {
+		lines = append(lines, p.compareRow("right only", "", f, styles.Muted, styles.StatusStaged))
+	}
+
+	// Turn-by-turn alignment
+	n := len(l.Exchanges)
+	if len(r.Exchanges) > n {
+		n = len(r.Exchanges)
+	}
+	section(fmt.Sprintf("Turns  (%d vs %d)", len(l.Exchanges), len(r.Exchanges)))
+	for i := 0; i < n; i++ {
+		var le, re *Exchange
+		if i < len(l.Exchanges) {
+			le = &l.Exchanges[i]
+		}
+		if i < len(r.Exchanges) {
+			re = &r.Exchanges[i]
+		}
+		prompt := func(e *Exchange) string {
+			if e == nil {
+				return "—"
+			}
+			if e.Prompt == "" {
+				return "(no prompt)"
+			}
+			return "› " + e.Prompt
+		}
+		reply := func(e *Exchange) string {
+			if e == nil {
+				return ""
+			}
+			return fmt.Sprintf("%d tools · out:%s · %s", e.ToolCalls, formatK(e.TokensOut), e.Reply)
+		}
+		lines = append(lines, p.compareRow(fmt.Sprintf("#%d", i+1), prompt(le), prompt(re), styles.Body, styles.Body))
+		lines = append(lines, p.compareRow("", reply(le), reply(re), styles.Muted, styles.Muted))
+	}
+
+	// Final output diff
+	section("Final Output Diff  (- left  + right)")
+	switch {
+	case l.FinalOutput == "" && r.FinalOutput == "":
+		lines = append(lines, styles.Muted.Render(" No assistant output in either session"))
+	case l.FinalOutput == r.FinalOutput:
+		lines = append(lines, styles.Muted.Render(" Final outputs are identical"))
+	default:
+		maxW := p.width - 4
+		if maxW < 10 {
+			maxW = 10
+		}
+		for _, d := range c.OutputDiff {
+			text := string(d.Op) + " " + d.Text
+			if runes := []rune(text); len(runes) > maxW {
+				text = string(runes[:maxW-1]) + "…"
+			}
+			switch d.Op {
+			case '-':
+				lines = append(lines, " "+styles.DiffRemove.Render(text))
+			case '+':
+				lines = append(lines, " "+styles.DiffAdd.Render(text))
+			default:
+				lines = append(lines, " "+styles.Muted.Render(text))
+			}
+		}
+	}
+	return lines
+}
+
+// compareMetric renders an integer metric row, highlighting the lower value.
+func (p *Plugin) compareMetric(label string, left, right int, format func(int) string) string {
+	ls, rs := compareStyles(float64(left), float64(right))
+	return p.compareRow(label, format(left), format(right), ls, rs)
+}
+
+// compareMetricFloat renders a float metric row, highlighting the lower value.
+func (p *Plugin) compareMetricFloat(label string, left, right float64, format func(float64) string) string {
+	ls, rs := compareStyles(left, right)
+	return p.compareRow(label, format(left), format(right), ls, rs)
+}
+
+// compareStyles returns cell styles that highlight the lower of two values.
+func compareStyles(left, right float64) (lipgloss.Style, lipgloss.Style) {
+	better := lipgloss.NewStyle().Foreground(styles.Success)
+	switch {
+	case left < right:
+		return better, styles.Body
+	case right < left:
+		return styles.Body, better
+	default:
+		return styles.Body, styles.Body
+	}
+}
+
+// itoa formats an int for metric rows.
+func itoa(n int) string { return fmt.Sprintf("%d", n) }
@@ -181,6 +181,9 @@ func renderAdapterIcon(session adapter.Session) string {
 	case "amp":
 		// Sourcegraph orange
 		return lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5543")).Render(icon)
+	case "aider":
+		// Aider green
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("#14B014")).Render(icon)
 	default:
 		return styles.Muted.Render(icon)
 	}
@@ -198,6 +201,10 @@ func adapterAbbrev(session adapter.Session) string {
 		return "GC"
 	case "amp":
 		return "AM"
+	case "aider":
+		return "AD"
+	case "otlp":
+		return "OI"
 	default:
 		name := session.AdapterName
 		if name == "" {
@@ -712,7 +719,25 @@ func (p *Plugin) renderMessageBubble(msg adapter.Message, msgIndex int, maxWidth
 			}
 		}
 	}
+	// Bookmark marker and annotation
+	bookmark, bookmarked := p.messageBookmark(msg.ID)
+	if bookmarked {
+		if selected {
+			headerLine += " " + bookmarkMarker
+		} else {
+			headerLine += " " + bookmarkStyle.Render(bookmarkMarker)
+		}
+	}
 	lines = append(lines, headerLine)
+	if bookmarked && bookmark.Annotation != "" {
+		for _, line := range strings.Split(wrapToWidth(bookmark.Annotation, maxWidth-6), "\n") {
+			line = annotationMarker + " " + line
+			if !selected {
+				line = bookmarkStyle.Render(line)
+			}
+			lines = append(lines, "    "+line)
+		}
+	}
 
 	// Render content blocks (same for selected and non-selected)
 	if len(msg.ContentBlocks) > 0 {
@@ -1084,6 +1109,20 @@ func (p *Plugin) renderFilterMenu(height int) string {
 	}
 	sb.WriteString("\n")
 
+	// Tag filters
+	if tagOptions := p.tagFilterOptions(); len(tagOptions) > 0 {
+		sb.WriteString(styles.Subtitle.Render("Tags:"))
+		sb.WriteString("\n")
+		for _, opt := range tagOptions {
+			checkbox := "[ ]"
+			if p.filters.HasTag(opt.id) {
+				checkbox = "[✓]"
+			}
+			sb.WriteString(fmt.Sprintf("  %s %s #%s\n", styles.Code.Render(opt.key), checkbox, opt.id))
+		}
+		sb.WriteString("\n")
+	}
+
 	// Active only
 	activeCheck := "[ ]"
 	if p.filters.ActiveOnly {
@@ -1094,6 +1133,22 @@ func (p *Plugin) renderFilterMenu(height int) string {
 
 	// Clear filters
 	sb.WriteString(fmt.Sprintf("  %s Clear all filters\n", styles.Code.Render("x")))
+	sb.WriteString(fmt.Sprintf("  %s Save as...\n", styles.Code.Render("+")))
+
+	// Saved filters
+	if saved := p.savedFilters(); len(saved) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(styles.Subtitle.Render("Saved:"))
+		sb.WriteString("\n")
+		for i, sf := range saved {
+			key := savedFilterKey(i)
+			if key == "" {
+				sb.WriteString(styles.Muted.Render(fmt.Sprintf("  +%d more in the command palette\n", len(saved)-i)))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("  %s %s\n", styles.Code.Render(key), sf.Name))
+		}
+	}
 
 	return sb.String()
 }
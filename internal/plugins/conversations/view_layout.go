@@ -3,6 +3,7 @@ package conversations
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/toddwbucy/hermes/internal/adapter"
@@ -195,12 +196,20 @@ func (p *Plugin) registerSessionHitRegions(sidebarWidth, contentHeight int) {
 
 // registerTurnHitRegions registers mouse hit regions for visible turn items in the main pane.
 func (p *Plugin) registerTurnHitRegions(mainX, contentWidth, contentHeight int) {
-	if p.detailMode {
+	if p.replay != nil {
+		// Scrubber is the second line: panel border (1) + title (1); padding (1) on the left
+		p.mouseHandler.HitMap.AddRect(regionReplayScrubber, mainX+1, 2, contentWidth-2, 1, nil)
+		return
+	}
+	if p.detailMode || p.trace != nil {
 		return
 	}
 
 	// Y offset: panel border (1) + header lines (4: title, stats, resume cmd, separator)
 	headerY := 5
+	if p.live != nil {
+		headerY += p.live.stripLines // Follow mode status lines
+	}
 	currentY := headerY
 
 	if p.turnViewMode {
@@ -683,6 +692,16 @@ func (p *Plugin) renderMainPane(paneWidth, height int) string {
 		return styles.Muted.Render("Select a session to view messages")
 	}
 
+	// Replay mode replaces the transcript with the timeline player
+	if p.replay != nil {
+		return p.renderReplayContent(contentWidth, height)
+	}
+
+	// Trace mode replaces the transcript with the span waterfall
+	if p.trace != nil {
+		return p.renderTraceContent(contentWidth, height)
+	}
+
 	// If in detail mode, render the turn detail instead of turn list
 	if p.detailMode && p.detailTurn != nil {
 		return p.renderDetailPaneContent(contentWidth, height)
@@ -705,8 +724,26 @@ func (p *Plugin) renderMainPane(paneWidth, height int) string {
 		sessionName = session.Name
 	}
 
+	// User tags and fork relations follow the name when there is room
+	tagLabel := ""
+	forkLabel := ""
+	if session != nil {
+		tagLabel = formatTags(p.tags.Get(session.AdapterID, session.ID))
+		forkLabel = p.forkLabel(session)
+	}
+
 	// Calculate max length for session name (leave room for icon)
 	maxSessionLen := contentWidth - 4
+	if forkLabel != "" && maxSessionLen-lipgloss.Width(forkLabel)-2 >= 10 {
+		maxSessionLen -= lipgloss.Width(forkLabel) + 2
+	} else {
+		forkLabel = ""
+	}
+	if tagLabel != "" && maxSessionLen-len(tagLabel)-2 >= 10 {
+		maxSessionLen -= len(tagLabel) + 2
+	} else {
+		tagLabel = ""
+	}
 	if maxSessionLen < 10 {
 		maxSessionLen = 10
 	}
@@ -720,6 +757,14 @@ func (p *Plugin) renderMainPane(paneWidth, height int) string {
 		sb.WriteString(" ")
 	}
 	sb.WriteString(styles.Title.Render(sessionName))
+	if tagLabel != "" {
+		sb.WriteString("  ")
+		sb.WriteString(bookmarkStyle.Render(tagLabel))
+	}
+	if forkLabel != "" {
+		sb.WriteString("  ")
+		sb.WriteString(styles.Muted.Render(forkLabel))
+	}
 	sb.WriteString("\n")
 
 	// Header Line 2: Model badge │ msgs │ tokens │ cost │ date
@@ -821,6 +866,20 @@ func (p *Plugin) renderMainPane(paneWidth, height int) string {
 	if p.totalMessages > maxMessagesInMemory {
 		contentHeight--
 	}
+
+	// Follow mode status sits between the header and the transcript
+	if p.live != nil {
+		liveLines := p.renderLiveStrip(contentWidth, time.Now())
+		for _, line := range liveLines {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+		if len(liveLines) != p.live.stripLines {
+			p.live.stripLines = len(liveLines)
+			p.hitRegionsDirty = true
+		}
+		contentHeight -= len(liveLines)
+	}
 	if contentHeight < 1 {
 		contentHeight = 1
 	}
@@ -835,7 +894,13 @@ func (p *Plugin) renderMainPane(paneWidth, height int) string {
 		return sb.String()
 	}
 
-	if p.turnViewMode {
+	if p.showToolSummary {
+		// Tool usage and files touched for this session
+		for _, line := range p.renderToolImpact(contentWidth, contentHeight) {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	} else if p.turnViewMode {
 		// Turn-based view (metadata-focused)
 		if len(p.turns) == 0 {
 			sb.WriteString(styles.Muted.Render("No turns"))
@@ -1070,10 +1135,16 @@ func (p *Plugin) renderCompactTurn(turn Turn, turnIndex int, maxWidth int) []str
 		roleName = adapterShortName(session)
 	}
 
+	// Flag turns containing a bookmarked message
+	marker := ""
+	if p.turnBookmarked(turn) {
+		marker = " " + bookmarkMarker
+	}
+
 	// Build header line
 	if selected {
 		// For selected: plain text with background highlight
-		headerContent := fmt.Sprintf("[%s] %s%s", ts, roleName, statsStr)
+		headerContent := fmt.Sprintf("[%s] %s%s%s", ts, roleName, statsStr, marker)
 		lines = append(lines, p.styleTurnLine(headerContent, true, maxWidth))
 	} else {
 		// For unselected: colored role badge with muted styling
@@ -1083,10 +1154,11 @@ func (p *Plugin) renderCompactTurn(turn Turn, turnIndex int, maxWidth int) []str
 		} else {
 			roleStyle = styles.StatusStaged
 		}
-		styledHeader := fmt.Sprintf("[%s] %s%s",
+		styledHeader := fmt.Sprintf("[%s] %s%s%s",
 			styles.Muted.Render(ts),
 			roleStyle.Render(roleName),
-			styles.Muted.Render(statsStr))
+			styles.Muted.Render(statsStr),
+			bookmarkStyle.Render(marker))
 		lines = append(lines, styledHeader)
 	}
 
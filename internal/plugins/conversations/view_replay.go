@@ -0,0 +1,246 @@
+package conversations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/toddwbucy/hermes/internal/styles"
+)
+
+// replayPreviewLines is how many content lines past steps show; the current
+// step is rendered in full.
+const replayPreviewLines = 2
+
+// renderReplayContent renders the replay player in the main pane:
+// title, scrubber, running totals, then the timeline up to the current step.
+func (p *Plugin) renderReplayContent(contentWidth, height int) string {
+	r := p.replay
+	var sb strings.Builder
+
+	// Line 1: play state, session name, speed
+	state := "❚❚ Paused"
+	if r.playing {
+		state = "▶ Playing"
+	}
+	title := styles.Title.Render("Replay " + state)
+	speed := styles.Muted.Render(fmt.Sprintf("  %s×", formatReplaySpeed(r.speed())))
+	name := ""
+	if session := p.findSelectedSession(); session != nil && session.Name != "" {
+		name = session.Name
+		maxName := contentWidth - lipgloss.Width(title) - lipgloss.Width(speed) - 3
+		if maxName < 4 {
+			name = ""
+		} else if len(name) > maxName {
+			name = name[:maxName-3] + "..."
+		}
+	}
+	sb.WriteString(title)
+	if name != "" {
+		sb.WriteString(styles.Muted.Render(" · " + name))
+	}
+	sb.WriteString(speed)
+	sb.WriteString("\n")
+
+	// Line 2: timeline scrubber
+	sb.WriteString(p.renderReplayScrubber(contentWidth))
+	sb.WriteString("\n")
+
+	// Line 3: position, wall time, running totals
+	step := r.steps[r.pos]
+	parts := []string{
+		"step " + r.positionLabel(),
+		"+" + formatReplayElapsed(r.elapsed()),
+	}
+	if r.pos > 0 {
+		parts = append(parts, "Δ "+formatReplayElapsed(r.gap()))
+	}
+	parts = append(parts, fmt.Sprintf("in:%s out:%s", formatK(step.TokensIn), formatK(step.TokensOut)))
+	if step.Cost > 0 {
+		parts = append(parts, formatCost(step.Cost))
+	}
+	statsLine := strings.Join(parts, " │ ")
+	if lipgloss.Width(statsLine) > contentWidth {
+		statsLine = truncateReplayLine(statsLine, contentWidth)
+	}
+	sb.WriteString(styles.Muted.Render(statsLine))
+	sb.WriteString("\n")
+
+	sepWidth := contentWidth
+	if sepWidth > 60 {
+		sepWidth = 60
+	}
+	sb.WriteString(styles.Muted.Render(strings.Repeat("─", sepWidth)))
+	sb.WriteString("\n")
+
+	contentHeight := height - 4
+	if contentHeight < 1 {
+		contentHeight = 1
+	}
+
+	// Build the timeline bottom-up so the current step is always visible
+	var lines []string
+	for i := r.pos; i >= 0 && len(lines) < contentHeight; i-- {
+		stepLines := p.renderReplayStep(r.steps[i], i == r.pos, contentWidth)
+		lines = append(stepLines, lines...)
+	}
+	if len(lines) > contentHeight {
+		// Keep the current step's header when it overflows the pane
+		current := p.renderReplayStep(step, true, contentWidth)
+		if len(current) >= contentHeight {
+			lines = current[:contentHeight]
+		} else {
+			lines = lines[len(lines)-contentHeight:]
+		}
+	}
+	for _, line := range lines {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	return stripANSIBackground(sb.String())
+}
+
+// renderReplayScrubber renders the timeline bar. Played steps are filled,
+// the current step is marked, and columns containing tool errors are red.
+func (p *Plugin) renderReplayScrubber(width int) string {
+	r := p.replay
+	if width < 2 {
+		width = 2
+	}
+	n := len(r.steps)
+	cursorCol := 0
+	if n > 1 {
+		cursorCol = r.pos * (width - 1) / (n - 1)
+	}
+
+	// Mark columns that contain failing tool calls
+	errCols := make(map[int]bool)
+	for i, s := range r.steps {
+		if s.IsError {
+			col := 0
+			if n > 1 {
+				col = i * (width - 1) / (n - 1)
+			}
+			errCols[col] = true
+		}
+	}
+
+	played := lipgloss.NewStyle().Foreground(styles.Primary)
+	errStyle := styles.StatusDeleted
+	var sb strings.Builder
+	for col := 0; col < width; col++ {
+		switch {
+		case col == cursorCol:
+			sb.WriteString(lipgloss.NewStyle().Foreground(styles.Accent).Bold(true).Render("●"))
+		case errCols[col]:
+			sb.WriteString(errStyle.Render("┃"))
+		case col < cursorCol:
+			sb.WriteString(played.Render("━"))
+		default:
+			sb.WriteString(styles.Muted.Render("─"))
+		}
+	}
+	return sb.String()
+}
+
+// renderReplayStep renders one timeline step. The current step shows full
+// content; earlier steps show a short preview.
+func (p *Plugin) renderReplayStep(step ReplayStep, current bool, width int) []string {
+	prefix := "  "
+	if current {
+		prefix = "> "
+	}
+	ts := step.Timestamp.Local().Format("15:04:05")
+
+	var header string
+	switch step.Kind {
+	case ReplayStepTool:
+		label := "⚙ " + step.ToolName
+		if preview := extractToolCommand(step.ToolName, step.ToolInput, width-len(label)-16); preview != "" {
+			label += ": " + preview
+		} else if fp := extractFilePath(step.ToolInput); fp != "" {
+			label += ": " + fp
+		}
+		style := styles.Code
+		if step.IsError {
+			label += " ✗"
+			style = styles.StatusDeleted
+		}
+		header = fmt.Sprintf("%s[%s] ", prefix, ts) + style.Render(label)
+	default:
+		role := styles.StatusStaged.Render(adapterShortName(p.findSelectedSession()))
+		if step.Role == "user" {
+			role = styles.StatusInProgress.Render("you")
+		}
+		header = fmt.Sprintf("%s[%s] ", prefix, ts) + role
+		if step.Model != "" && step.Role != "user" {
+			if short := modelShortName(step.Model); short != "" {
+				header += " " + styles.Muted.Render(short)
+			}
+		}
+	}
+	header = truncateReplayLine(header, width)
+	if current {
+		header = styles.ListItemSelected.Render(header)
+	}
+	lines := []string{header}
+
+	body := step.Text
+	if step.Kind == ReplayStepTool {
+		body = step.ToolResult
+		if !current {
+			body = "" // past tool calls collapse to their header
+		}
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return lines
+	}
+
+	wrapped := wrapText(body, width-4)
+	if !current && len(wrapped) > replayPreviewLines {
+		wrapped = append(wrapped[:replayPreviewLines], "…")
+	}
+	bodyStyle := styles.Body
+	if !current {
+		bodyStyle = styles.Muted
+	} else if step.IsError {
+		bodyStyle = styles.StatusDeleted
+	}
+	for _, line := range wrapped {
+		lines = append(lines, "    "+bodyStyle.Render(line))
+	}
+	return lines
+}
+
+// formatReplaySpeed formats a speed multiplier without trailing zeros.
+func formatReplaySpeed(speed float64) string {
+	if speed == float64(int(speed)) {
+		return fmt.Sprintf("%d", int(speed))
+	}
+	return fmt.Sprintf("%.1f", speed)
+}
+
+// formatReplayElapsed formats wall time between steps with second precision.
+func formatReplayElapsed(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// truncateReplayLine truncates a (possibly styled) line to width cells.
+func truncateReplayLine(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(s)
+}
@@ -0,0 +1,270 @@
+package conversations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/toddwbucy/hermes/internal/adapter"
+	"github.com/toddwbucy/hermes/internal/styles"
+)
+
+// Trace view layout.
+const (
+	traceHeaderLines    = 3  // title, axis, separator
+	traceMinDetailLines = 6  // detail pane floor
+	traceMaxNameWidth   = 40 // span name column cap
+	traceDurationWidth  = 8
+)
+
+// renderTraceContent renders the span waterfall in the main pane: the span
+// tree with timing bars on top and the selected span's attributes below.
+func (p *Plugin) renderTraceContent(contentWidth, height int) string {
+	t := p.trace
+	var sb strings.Builder
+
+	// Line 1: title, span count, wall time, errors
+	title := styles.Title.Render("Trace")
+	if session := p.findSelectedSession(); session != nil && session.Name != "" {
+		title += styles.Muted.Render(" · " + session.Name)
+	}
+	stats := fmt.Sprintf("  %d spans · %s", len(t.spans), formatSpanDuration(t.waterfall.Total))
+	sb.WriteString(truncateReplayLine(title+styles.Muted.Render(stats), contentWidth))
+	if t.waterfall.Errors > 0 {
+		sb.WriteString(styles.StatusDeleted.Render(fmt.Sprintf(" · %d errors", t.waterfall.Errors)))
+	}
+	sb.WriteString("\n")
+
+	switch {
+	case t.err != nil:
+		sb.WriteString(styles.StatusDeleted.Render("Failed to load trace: " + t.err.Error()))
+		return sb.String()
+	case t.loading && len(t.spans) == 0:
+		sb.WriteString(styles.Muted.Render("Loading spans..."))
+		return sb.String()
+	case len(t.waterfall.Rows) == 0:
+		sb.WriteString(styles.Muted.Render("No spans recorded yet"))
+		return sb.String()
+	}
+
+	nameWidth := contentWidth * 2 / 5
+	if nameWidth > traceMaxNameWidth {
+		nameWidth = traceMaxNameWidth
+	}
+	barWidth := contentWidth - nameWidth - traceDurationWidth - 2
+	if barWidth < 4 {
+		barWidth = 4
+	}
+
+	// Line 2: time axis over the bar column
+	axisEnd := formatSpanDuration(t.waterfall.Total)
+	gap := barWidth - 1 - len(axisEnd)
+	if gap < 1 {
+		gap = 1
+	}
+	axis := strings.Repeat(" ", nameWidth+traceDurationWidth+2) + "0" + strings.Repeat(" ", gap) + axisEnd
+	sb.WriteString(styles.Muted.Render(axis))
+	sb.WriteString("\n")
+	sb.WriteString(styles.Muted.Render(strings.Repeat("─", contentWidth)))
+	sb.WriteString("\n")
+
+	detailHeight := height * 2 / 5
+	if detailHeight < traceMinDetailLines {
+		detailHeight = traceMinDetailLines
+	}
+	listHeight := height - traceHeaderLines - detailHeight - 1
+	if listHeight < 3 {
+		listHeight = 3
+	}
+	t.ensureCursorVisible(listHeight)
+
+	end := t.scroll + listHeight
+	if end > len(t.waterfall.Rows) {
+		end = len(t.waterfall.Rows)
+	}
+	for i := t.scroll; i < end; i++ {
+		line := p.renderTraceRow(t.waterfall.Rows[i], i == t.cursor, nameWidth, barWidth)
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	for i := end - t.scroll; i < listHeight; i++ {
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(styles.Muted.Render(strings.Repeat("─", contentWidth)))
+	sb.WriteString("\n")
+
+	if row := t.selectedRow(); row != nil {
+		detail := renderSpanDetail(row, contentWidth)
+		maxScroll := len(detail) - detailHeight
+		if maxScroll < 0 {
+			maxScroll = 0
+		}
+		if t.detailScroll > maxScroll {
+			t.detailScroll = maxScroll
+		}
+		detail = detail[t.detailScroll:]
+		if len(detail) > detailHeight {
+			detail = detail[:detailHeight]
+		}
+		sb.WriteString(strings.Join(detail, "\n"))
+	}
+
+	return stripANSIBackground(sb.String())
+}
+
+// renderTraceRow renders one waterfall row: the indented span name, its
+// duration and a bar positioned on the trace's time axis.
+func (p *Plugin) renderTraceRow(row TraceRow, selected bool, nameWidth, barWidth int) string {
+	marker := "  "
+	if row.HasChildren {
+		marker = "▾ "
+		if p.trace.collapsed[row.Span.SpanID] {
+			marker = "▸ "
+		}
+	}
+	name := strings.Repeat("  ", row.Depth) + marker + row.Span.Name
+	if row.Span.IsError() {
+		name += " ✗"
+	}
+	if w := lipgloss.Width(name); w > nameWidth {
+		name = truncateReplayLine(name, nameWidth-1) + "…"
+	} else {
+		name += strings.Repeat(" ", nameWidth-w)
+	}
+
+	dur := formatSpanDuration(row.Duration)
+	if row.Open {
+		dur = "…" + dur
+	}
+	dur = fmt.Sprintf("%*s", traceDurationWidth, dur)
+
+	start, width := spanBarExtent(row.Offset, row.Duration, p.trace.waterfall.Total, barWidth)
+	barChar := "█"
+	if row.Open {
+		barChar = "▒"
+	}
+	bar := strings.Repeat(" ", start) +
+		spanKindStyle(row.Span).Render(strings.Repeat(barChar, width)) +
+		strings.Repeat(" ", barWidth-start-width)
+
+	nameStyle := styles.Body
+	if row.Span.IsError() {
+		nameStyle = styles.StatusDeleted
+	}
+	if selected {
+		return styles.ListItemSelected.Render(name+" "+dur) + " " + bar
+	}
+	return nameStyle.Render(name) + " " + styles.Muted.Render(dur) + " " + bar
+}
+
+// spanBarExtent maps a span onto barWidth columns. Every span gets at
+// least one column so instantaneous spans stay visible.
+func spanBarExtent(offset, dur, total time.Duration, barWidth int) (start, width int) {
+	if total <= 0 {
+		return 0, 1
+	}
+	start = int(int64(barWidth) * int64(offset) / int64(total))
+	if start >= barWidth {
+		start = barWidth - 1
+	}
+	if start < 0 {
+		start = 0
+	}
+	width = int(int64(barWidth) * int64(dur) / int64(total))
+	if width < 1 {
+		width = 1
+	}
+	if start+width > barWidth {
+		width = barWidth - start
+	}
+	return start, width
+}
+
+// spanKindStyle colors bars by OpenInference span kind; errors are red.
+func spanKindStyle(span adapter.TraceSpan) lipgloss.Style {
+	if span.IsError() {
+		return lipgloss.NewStyle().Foreground(styles.Error)
+	}
+	switch span.Kind {
+	case "LLM":
+		return lipgloss.NewStyle().Foreground(styles.Primary)
+	case "TOOL":
+		return lipgloss.NewStyle().Foreground(styles.Accent)
+	case "AGENT", "CHAIN":
+		return lipgloss.NewStyle().Foreground(styles.Secondary)
+	default:
+		return lipgloss.NewStyle().Foreground(styles.TextMuted)
+	}
+}
+
+// renderSpanDetail renders the selected span's timing, status and
+// attributes, one entry per line.
+func renderSpanDetail(row *TraceRow, width int) []string {
+	s := row.Span
+	kind := s.Kind
+	if kind == "" {
+		kind = "span"
+	}
+	lines := []string{
+		styles.Title.Render(s.Name) + styles.Muted.Render(" ("+kind+")"),
+	}
+
+	status := styles.Muted.Render(s.StatusCode)
+	switch s.StatusCode {
+	case adapter.SpanStatusError:
+		status = styles.StatusDeleted.Render(s.StatusCode)
+		if s.StatusMessage != "" {
+			status += styles.StatusDeleted.Render(": " + s.StatusMessage)
+		}
+	case adapter.SpanStatusOK:
+		status = styles.StatusStaged.Render(s.StatusCode)
+	}
+	timing := fmt.Sprintf("+%s  %s", formatSpanDuration(row.Offset), formatSpanDuration(row.Duration))
+	if row.Open {
+		timing += " (running)"
+	}
+	lines = append(lines, styles.Muted.Render("status ")+status+styles.Muted.Render("  "+timing))
+	ids := "span " + s.SpanID
+	if s.ParentID != "" {
+		ids += "  parent " + s.ParentID
+	}
+	lines = append(lines, styles.Muted.Render(truncateReplayLine(ids, width)))
+
+	keys := make([]string, 0, len(s.Attributes))
+	for k := range s.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		value := strings.ReplaceAll(s.Attributes[k], "\n", " ")
+		prefix := k + ": "
+		wrapped := wrapText(value, max(width-len(prefix), 16))
+		if len(wrapped) == 0 {
+			wrapped = []string{""}
+		}
+		lines = append(lines, styles.Code.Render(prefix)+styles.Body.Render(wrapped[0]))
+		indent := strings.Repeat(" ", len(prefix))
+		for _, w := range wrapped[1:] {
+			lines = append(lines, indent+styles.Body.Render(w))
+		}
+	}
+	return lines
+}
+
+// formatSpanDuration formats span timings with millisecond precision below
+// a second.
+func formatSpanDuration(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "0ms"
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	default:
+		return formatReplayElapsed(d)
+	}
+}
@@ -33,6 +33,11 @@ func (p *Plugin) handleKey(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 		return p.handleInfoKey(msg)
 	}
 
+	// Handle sessions modal
+	if p.sessionsModal != nil {
+		return p.handleSessionsKey(msg)
+	}
+
 	// Handle blame mode
 	if p.blameMode {
 		return p.handleBlameKey(msg)
@@ -240,6 +245,13 @@ func (p *Plugin) handleTreeKey(key string) (plugin.Plugin, tea.Cmd) {
 			return p, p.fetchGitInfo(node.Path)
 		}
 
+	case "T":
+		// Show agent sessions that touched the file
+		node := p.tree.GetNode(p.treeCursor)
+		if node != nil && !node.IsDir {
+			return p.openSessionsModal(node.Path)
+		}
+
 	case "B":
 		// Show git blame for file
 		node := p.tree.GetNode(p.treeCursor)
@@ -537,6 +549,12 @@ func (p *Plugin) handlePreviewKey(key string) (plugin.Plugin, tea.Cmd) {
 			return p, p.fetchGitInfo(p.previewFile)
 		}
 
+	case "T":
+		// Show agent sessions that touched the file
+		if p.previewFile != "" {
+			return p.openSessionsModal(p.previewFile)
+		}
+
 	case "y", "alt+c":
 		// Copy selected text to clipboard, or entire file contents if no selection
 		if p.selection.HasSelection() {
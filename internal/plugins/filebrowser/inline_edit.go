@@ -3,9 +3,7 @@ package filebrowser
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
@@ -64,20 +62,14 @@ func (p *Plugin) enterInlineEditMode(path string, lineNo int) tea.Cmd {
 	}
 
 	return func() tea.Msg {
-		// Check if tmux is available
-		if _, err := exec.LookPath("tmux"); err != nil {
-			// Fall back to external editor
-			return nil
-		}
-
 		// Capture original mtime to detect changes later
 		var origMtime time.Time
 		if info, err := os.Stat(fullPath); err == nil {
 			origMtime = info.ModTime()
 		}
 
-		// Create a detached tmux session with the editor
-		// Use -x and -y to set initial size (will be resized later)
+		// Create a detached session with the editor
+		// Set the initial size (will be resized later)
 		// Pass TERM environment for proper color/theme support
 		// Include +lineNo for editors that support it (vim, nano, emacs, helix, etc.)
 		// Parse editor into command + args (handles "code -w", "emacs -nw", etc.)
@@ -101,12 +93,14 @@ func (p *Plugin) enterInlineEditMode(path string, lineNo int) tea.Cmd {
 				editorW, editorH = 80, 24
 			}
 		}
-		tmuxArgs := []string{"new-session", "-d", "-s", sessionName,
-			"-x", strconv.Itoa(editorW), "-y", strconv.Itoa(editorH), "-e", "TERM=" + term}
-		tmuxArgs = append(tmuxArgs, editorArgs...)
-
-		cmd := exec.Command("tmux", tmuxArgs...)
-		if err := cmd.Run(); err != nil {
+		err := tty.Current().NewSession(tty.SessionOptions{
+			Name:    sessionName,
+			Command: editorArgs,
+			Env:     map[string]string{"TERM": term},
+			Width:   editorW,
+			Height:  editorH,
+		})
+		if err != nil {
 			return msg.ToastMsg{
 				Message:  fmt.Sprintf("Failed to start editor: %v", err),
 				Duration: 3 * time.Second,
@@ -230,11 +224,11 @@ func (p *Plugin) reattachInlineEditSession() tea.Cmd {
 	return p.inlineEditor.Enter(p.inlineEditSession, "")
 }
 
-// exitInlineEditMode cleans up inline edit state and kills the tmux session.
+// exitInlineEditMode cleans up inline edit state and kills the editor session.
 func (p *Plugin) exitInlineEditMode() {
 	if p.inlineEditSession != "" {
-		// Kill the tmux session
-		_ = exec.Command("tmux", "kill-session", "-t", p.inlineEditSession).Run()
+		// Kill the editor session
+		_ = tty.Current().KillSession(p.inlineEditSession)
 	}
 	p.inlineEditMode = false
 	p.inlineEditSession = ""
@@ -245,15 +239,13 @@ func (p *Plugin) exitInlineEditMode() {
 	p.inlineEditor.Exit()
 }
 
-// isInlineEditSessionAlive checks if the tmux session for inline editing still exists.
+// isInlineEditSessionAlive checks if the session for inline editing still exists.
 // Returns false if the session has ended (vim quit).
 func (p *Plugin) isInlineEditSessionAlive() bool {
 	if p.inlineEditSession == "" {
 		return false
 	}
-	// Check if the tmux session exists using has-session
-	err := exec.Command("tmux", "has-session", "-t", p.inlineEditSession).Run()
-	return err == nil
+	return tty.Current().HasSession(p.inlineEditSession)
 }
 
 // attachToInlineEditSession attaches to the inline edit tmux session in full-screen mode.
@@ -314,11 +306,6 @@ func (p *Plugin) isInlineEditSupported(path string) bool {
 		return false
 	}
 
-	// Check if tmux is available
-	if _, err := exec.LookPath("tmux"); err != nil {
-		return false
-	}
-
 	// Don't support inline editing for binary files
 	if p.isBinary {
 		return false
@@ -440,7 +427,7 @@ func sendEditorSaveAndQuit(target, editor string) bool {
 
 	send := func(keys ...string) {
 		for _, k := range keys {
-			_ = exec.Command("tmux", "send-keys", "-t", target, k).Run()
+			_ = tty.Current().SendKeys(target, tty.KeySpec{Value: k})
 		}
 	}
 
@@ -714,21 +701,20 @@ func (p *Plugin) forwardMouseReleaseToInlineEditor(col, row int) tea.Cmd {
 	}
 }
 
-// isSessionAlive checks if a tmux session exists.
+// isSessionAlive checks if a session exists.
 func isSessionAlive(sessionName string) bool {
 	if sessionName == "" {
 		return false
 	}
-	err := exec.Command("tmux", "has-session", "-t", sessionName).Run()
-	return err == nil
+	return tty.Current().HasSession(sessionName)
 }
 
-// killSession kills a tmux session by name.
+// killSession kills a session by name.
 func killSession(sessionName string) {
 	if sessionName == "" {
 		return
 	}
-	_ = exec.Command("tmux", "kill-session", "-t", sessionName).Run()
+	_ = tty.Current().KillSession(sessionName)
 }
 
 // selectTreeItem selects the given tree item and loads its preview.
@@ -188,6 +188,11 @@ func (p *Plugin) handleMouse(msg tea.MouseMsg) (*Plugin, tea.Cmd) {
 		return p.handleInfoModalMouse(msg)
 	}
 
+	// Handle sessions modal if active
+	if p.sessionsModal != nil {
+		return p.handleSessionsModalMouse(msg)
+	}
+
 	// Handle blame modal if active
 	if p.blameMode {
 		return p.handleBlameModalMouse(msg)
@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/filesessions"
 	"github.com/toddwbucy/hermes/internal/image"
 	"github.com/toddwbucy/hermes/internal/markdown"
 	"github.com/toddwbucy/hermes/internal/modal"
@@ -192,6 +193,9 @@ type Plugin struct {
 	gitStatus      string
 	gitLastCommit  string
 
+	// Sessions that touched a file; nil when the modal is closed
+	sessionsModal *filesessions.Modal
+
 	// Blame view state
 	blameMode       bool
 	blameState      *BlameState
@@ -793,6 +797,7 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "new-tab", Name: "Tab+", Description: "Open file in new tab", Category: plugin.CategoryNavigation, Context: "file-browser-tree", Priority: 2},
 		{ID: "project-search", Name: "Find", Description: "Search in project", Category: plugin.CategorySearch, Context: "file-browser-tree", Priority: 2},
 		{ID: "info", Name: "Info", Description: "Show file info", Category: plugin.CategoryActions, Context: "file-browser-tree", Priority: 2},
+		{ID: "sessions", Name: "Sessions", Description: "Agent sessions that touched this file", Category: plugin.CategoryActions, Context: "file-browser-tree", Priority: 3},
 		{ID: "edit", Name: "Edit", Description: "Edit file inline", Category: plugin.CategoryActions, Context: "file-browser-tree", Priority: 2},
 		{ID: "edit-external", Name: "Edit+", Description: "Edit in full terminal", Category: plugin.CategoryActions, Context: "file-browser-tree", Priority: 2},
 		{ID: "blame", Name: "Blame", Description: "Show git blame", Category: plugin.CategoryView, Context: "file-browser-tree", Priority: 3},
@@ -817,6 +822,7 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "quick-open", Name: "Open", Description: "Quick open file by name", Category: plugin.CategorySearch, Context: "file-browser-preview", Priority: 1},
 		{ID: "project-search", Name: "Find", Description: "Search in project", Category: plugin.CategorySearch, Context: "file-browser-preview", Priority: 2},
 		{ID: "info", Name: "Info", Description: "Show file info", Category: plugin.CategoryActions, Context: "file-browser-preview", Priority: 2},
+		{ID: "sessions", Name: "Sessions", Description: "Agent sessions that touched this file", Category: plugin.CategoryActions, Context: "file-browser-preview", Priority: 3},
 		{ID: "edit", Name: "Edit", Description: "Edit file inline", Category: plugin.CategoryActions, Context: "file-browser-preview", Priority: 2},
 		{ID: "edit-external", Name: "Edit+", Description: "Edit in full terminal", Category: plugin.CategoryActions, Context: "file-browser-preview", Priority: 2},
 		{ID: "prev-tab", Name: "Tab←", Description: "Previous tab", Category: plugin.CategoryNavigation, Context: "file-browser-preview", Priority: 3},
@@ -855,6 +861,9 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "cancel", Name: "Cancel", Description: "Cancel jump", Category: plugin.CategoryActions, Context: "file-browser-line-jump", Priority: 1},
 		// Info modal commands
 		{ID: "close", Name: "Close", Description: "Close info modal", Category: plugin.CategoryActions, Context: "file-browser-info", Priority: 1},
+		// Sessions modal commands
+		{ID: "open", Name: "Open", Description: "Open session transcript", Category: plugin.CategoryNavigation, Context: "file-browser-sessions", Priority: 1},
+		{ID: "close", Name: "Close", Description: "Close sessions modal", Category: plugin.CategoryActions, Context: "file-browser-sessions", Priority: 1},
 		// Blame view commands
 		{ID: "close", Name: "Close", Description: "Close blame view", Category: plugin.CategoryActions, Context: "file-browser-blame", Priority: 1},
 		{ID: "view-commit", Name: "Details", Description: "View commit details", Category: plugin.CategoryActions, Context: "file-browser-blame", Priority: 2},
@@ -876,6 +885,9 @@ func (p *Plugin) FocusContext() string {
 	if p.infoMode {
 		return "file-browser-info"
 	}
+	if p.sessionsModal != nil {
+		return "file-browser-sessions"
+	}
 	if p.blameMode {
 		return "file-browser-blame"
 	}
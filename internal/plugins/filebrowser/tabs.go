@@ -355,6 +355,7 @@ func (p *Plugin) resetPreviewModes() {
 	p.infoMode = false
 	p.infoModal = nil
 	p.infoModalWidth = 0
+	p.sessionsModal = nil
 	p.blameMode = false
 	p.blameState = nil
 	p.blameModal = nil
@@ -83,6 +83,13 @@ func (p *Plugin) renderView() string {
 		return ui.OverlayModal(background, modal, p.width, p.height)
 	}
 
+	// Sessions modal is a full overlay - render modal over dimmed background
+	if p.sessionsModal != nil {
+		background := p.renderNormalPanes()
+		modal := p.renderSessionsModalContent()
+		return ui.OverlayModal(background, modal, p.width, p.height)
+	}
+
 	// Blame view is a full overlay - render modal over dimmed background
 	if p.blameMode {
 		background := p.renderNormalPanes()
@@ -0,0 +1,38 @@
+package filebrowser
+
+import (
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/filesessions"
+)
+
+// openSessionsModal shows the agent sessions that read or edited path.
+func (p *Plugin) openSessionsModal(path string) (*Plugin, tea.Cmd) {
+	m, cmd := filesessions.Open(filepath.Join(p.ctx.WorkDir, path))
+	p.sessionsModal = m
+	return p, cmd
+}
+
+// renderSessionsModalContent renders the file sessions modal.
+func (p *Plugin) renderSessionsModalContent() string {
+	return p.sessionsModal.Render(p.width, p.height, p.mouseHandler)
+}
+
+// handleSessionsKey handles key input in the file sessions modal.
+func (p *Plugin) handleSessionsKey(msg tea.KeyMsg) (*Plugin, tea.Cmd) {
+	cmd, done := p.sessionsModal.HandleKey(msg)
+	if done {
+		p.sessionsModal = nil
+	}
+	return p, cmd
+}
+
+// handleSessionsModalMouse handles mouse events in the file sessions modal.
+func (p *Plugin) handleSessionsModalMouse(msg tea.MouseMsg) (*Plugin, tea.Cmd) {
+	cmd, done := p.sessionsModal.HandleMouse(msg, p.mouseHandler)
+	if done {
+		p.sessionsModal = nil
+	}
+	return p, cmd
+}
@@ -12,6 +12,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/filesessions"
 	"github.com/toddwbucy/hermes/internal/modal"
 	"github.com/toddwbucy/hermes/internal/mouse"
 	"github.com/toddwbucy/hermes/internal/plugin"
@@ -41,6 +42,7 @@ const (
 	ViewModeConfirmStashPop                 // Confirm stash pop modal
 	ViewModePullConflict                    // Pull conflict resolution modal
 	ViewModeError                           // Generic error modal for git operation failures
+	ViewModeSessions                        // Agent sessions that touched a file
 )
 
 // FocusPane represents which pane is active in the three-pane view.
@@ -160,6 +162,9 @@ type Plugin struct {
 	errorDetail      string // full git command output
 	errorOfferPull   bool   // true when push was rejected due to remote ahead
 
+	// Sessions modal state (agent sessions that touched a file)
+	sessionsModal *filesessions.Modal
+
 	// Discard confirm state
 	discardFile       *FileEntry   // File being confirmed for discard
 	discardReturnMode ViewMode     // Mode to return to when modal closes
@@ -360,6 +365,8 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			return p.updateBranchPicker(msg)
 		case ViewModeError:
 			return p.updateErrorModal(msg)
+		case ViewModeSessions:
+			return p.updateSessionsModal(msg)
 		}
 
 	case tea.MouseMsg:
@@ -388,6 +395,8 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			return p.handleStashPopMouse(msg)
 		case ViewModeError:
 			return p.handleErrorModalMouse(msg)
+		case ViewModeSessions:
+			return p.handleSessionsModalMouse(msg)
 		}
 
 	case app.RefreshMsg:
@@ -889,6 +898,8 @@ func (p *Plugin) View(width, height int) string {
 			content = p.renderBranchPicker()
 		case ViewModeError:
 			content = p.renderErrorModal()
+		case ViewModeSessions:
+			content = p.renderSessionsModal()
 		default:
 			// Use three-pane layout for status view
 			content = p.renderThreePaneView()
@@ -941,6 +952,7 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "stash-pop", Name: "Pop", Description: "Pop latest stash", Category: plugin.CategoryGit, Context: "git-status", Priority: 4},
 		{ID: "stash-apply", Name: "Apply", Description: "Apply latest stash", Category: plugin.CategoryGit, Context: "git-status", Priority: 4},
 		{ID: "open-in-file-browser", Name: "Browse", Description: "Open file in file browser", Category: plugin.CategoryNavigation, Context: "git-status", Priority: 4},
+		{ID: "file-sessions", Name: "Sessions", Description: "Agent sessions that touched this file", Category: plugin.CategoryNavigation, Context: "git-status", Priority: 5},
 		{ID: "open-in-github", Name: "GitHub", Description: "Open commit in GitHub", Category: plugin.CategoryActions, Context: "git-status", Priority: 4},
 		{ID: "toggle-sidebar", Name: "Sidebar", Description: "Toggle sidebar visibility", Category: plugin.CategoryView, Context: "git-status", Priority: 5},
 		// git-status-commits context (recent commits in sidebar)
@@ -1008,6 +1020,9 @@ func (p *Plugin) Commands() []plugin.Command {
 		{ID: "pull-from-error", Name: "Pull", Description: "Pull from remote", Category: plugin.CategoryGit, Context: "git-error", Priority: 1},
 		{ID: "dismiss", Name: "Dismiss", Description: "Dismiss error", Category: plugin.CategoryNavigation, Context: "git-error", Priority: 1},
 		{ID: "yank-error", Name: "Yank", Description: "Copy error to clipboard", Category: plugin.CategoryActions, Context: "git-error", Priority: 2},
+		// git-sessions context (sessions that touched a file)
+		{ID: "open-session", Name: "Open", Description: "Open session transcript", Category: plugin.CategoryNavigation, Context: "git-sessions", Priority: 1},
+		{ID: "dismiss", Name: "Close", Description: "Close sessions list", Category: plugin.CategoryNavigation, Context: "git-sessions", Priority: 2},
 		// git-stash-pop context (stash pop confirmation modal)
 		{ID: "confirm-pop", Name: "Pop", Description: "Confirm stash pop", Category: plugin.CategoryGit, Context: "git-stash-pop", Priority: 1},
 		{ID: "dismiss", Name: "Cancel", Description: "Cancel stash pop", Category: plugin.CategoryNavigation, Context: "git-stash-pop", Priority: 2},
@@ -1039,6 +1054,8 @@ func (p *Plugin) FocusContext() string {
 		return "git-pull-conflict"
 	case ViewModeError:
 		return "git-error"
+	case ViewModeSessions:
+		return "git-sessions"
 	case ViewModeConfirmStashPop:
 		return "git-stash-pop"
 	default:
@@ -0,0 +1,67 @@
+package gitstatus
+
+import (
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/filesessions"
+	"github.com/toddwbucy/hermes/internal/plugin"
+	"github.com/toddwbucy/hermes/internal/ui"
+)
+
+// showSessionsModal lists the agent sessions that read or edited path
+// (relative to the repo root).
+func (p *Plugin) showSessionsModal(path string) tea.Cmd {
+	root := p.repoRoot
+	if root == "" {
+		root = p.ctx.WorkDir
+	}
+	m, cmd := filesessions.Open(filepath.Join(root, path))
+	if m == nil {
+		return cmd
+	}
+	p.sessionsModal = m
+	p.viewMode = ViewModeSessions
+	return cmd
+}
+
+// renderSessionsModal renders the sessions modal overlaid on the status view.
+func (p *Plugin) renderSessionsModal() string {
+	background := p.renderThreePaneView()
+	if p.sessionsModal == nil {
+		return background
+	}
+	modalContent := p.sessionsModal.Render(p.width, p.height, p.mouseHandler)
+	return ui.OverlayModal(background, modalContent, p.width, p.height)
+}
+
+// updateSessionsModal handles keyboard input for the sessions modal.
+func (p *Plugin) updateSessionsModal(m tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
+	if p.sessionsModal == nil {
+		return p.dismissSessionsModal()
+	}
+	cmd, done := p.sessionsModal.HandleKey(m)
+	if done {
+		p.dismissSessionsModal()
+	}
+	return p, cmd
+}
+
+// handleSessionsModalMouse handles mouse input for the sessions modal.
+func (p *Plugin) handleSessionsModalMouse(m tea.MouseMsg) (plugin.Plugin, tea.Cmd) {
+	if p.sessionsModal == nil {
+		return p, nil
+	}
+	cmd, done := p.sessionsModal.HandleMouse(m, p.mouseHandler)
+	if done {
+		p.dismissSessionsModal()
+	}
+	return p, cmd
+}
+
+// dismissSessionsModal closes the sessions modal.
+func (p *Plugin) dismissSessionsModal() (plugin.Plugin, tea.Cmd) {
+	p.viewMode = ViewModeStatus
+	p.sessionsModal = nil
+	return p, nil
+}
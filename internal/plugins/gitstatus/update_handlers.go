@@ -260,6 +260,12 @@ func (p *Plugin) updateStatus(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 			return p, p.openInFileBrowser(entry.Path)
 		}
 
+	case "T":
+		// Show agent sessions that touched the file
+		if !p.cursorOnCommit() && len(entries) > 0 && p.cursor < len(entries) {
+			return p, p.showSessionsModal(entries[p.cursor].Path)
+		}
+
 	case "c":
 		// Enter commit mode only if staged files exist
 		if p.tree.HasStagedFiles() {
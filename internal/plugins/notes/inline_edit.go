@@ -3,9 +3,7 @@ package notes
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
@@ -69,12 +67,6 @@ func (p *Plugin) enterInlineEditMode(noteID string) tea.Cmd {
 	}
 
 	return func() tea.Msg {
-		// Check if tmux is available
-		if _, err := exec.LookPath("tmux"); err != nil {
-			// Fall back to external editor
-			return nil
-		}
-
 		// Get editor dimensions
 		editorW, editorH := p.width, p.height
 		if editorW <= 0 || editorH <= 0 {
@@ -85,13 +77,15 @@ func (p *Plugin) enterInlineEditMode(noteID string) tea.Cmd {
 			}
 		}
 
-		// Create a detached tmux session with the editor
-		tmuxArgs := []string{"new-session", "-d", "-s", sessionName,
-			"-x", strconv.Itoa(editorW), "-y", strconv.Itoa(editorH), "-e", "TERM=" + term,
-			editor, notePath}
-
-		cmd := exec.Command("tmux", tmuxArgs...)
-		if err := cmd.Run(); err != nil {
+		// Create a detached session with the editor
+		err := tty.Current().NewSession(tty.SessionOptions{
+			Name:    sessionName,
+			Command: []string{editor, notePath},
+			Env:     map[string]string{"TERM": term},
+			Width:   editorW,
+			Height:  editorH,
+		})
+		if err != nil {
 			return msg.ToastMsg{
 				Message:  fmt.Sprintf("Failed to start editor: %v", err),
 				Duration: 3 * time.Second,
@@ -148,11 +142,11 @@ func (p *Plugin) handleInlineEditStarted(msg InlineEditStartedMsg) tea.Cmd {
 	)
 }
 
-// exitInlineEditMode cleans up inline edit state and kills the tmux session.
+// exitInlineEditMode cleans up inline edit state and kills the editor session.
 func (p *Plugin) exitInlineEditMode() {
 	if p.inlineEditSession != "" {
-		// Kill the tmux session
-		_ = exec.Command("tmux", "kill-session", "-t", p.inlineEditSession).Run()
+		// Kill the editor session
+		_ = tty.Current().KillSession(p.inlineEditSession)
 	}
 	p.inlineEditMode = false
 	p.inlineEditSession = ""
@@ -502,26 +496,16 @@ func (p *Plugin) processPendingClickActionWithSave(noteID, notePath string) (*Pl
 
 // isInlineEditSupported checks if inline editing can be used for notes.
 func (p *Plugin) isInlineEditSupported() bool {
-	// Check feature flag
-	if !features.IsEnabled(features.TmuxInlineEdit.Name) {
-		return false
-	}
-
-	// Check if tmux is available
-	if _, err := exec.LookPath("tmux"); err != nil {
-		return false
-	}
-
-	return true
+	// Check feature flag; without tmux the editor runs on a built-in PTY
+	return features.IsEnabled(features.TmuxInlineEdit.Name)
 }
 
-// isInlineEditSessionAlive checks if the tmux session for inline editing still exists.
+// isInlineEditSessionAlive checks if the session for inline editing still exists.
 func (p *Plugin) isInlineEditSessionAlive() bool {
 	if p.inlineEditSession == "" {
 		return false
 	}
-	err := exec.Command("tmux", "has-session", "-t", p.inlineEditSession).Run()
-	return err == nil
+	return tty.Current().HasSession(p.inlineEditSession)
 }
 
 // normalizeEditorName extracts the base editor name from a command string.
@@ -551,7 +535,7 @@ func sendEditorSaveAndQuit(target, editor string) bool {
 
 	send := func(keys ...string) {
 		for _, k := range keys {
-			_ = exec.Command("tmux", "send-keys", "-t", target, k).Run()
+			_ = tty.Current().SendKeys(target, tty.KeySpec{Value: k})
 		}
 	}
 
@@ -585,7 +569,7 @@ func sendEditorCursorToEnd(target, editor string) {
 
 	send := func(keys ...string) {
 		for _, k := range keys {
-			_ = exec.Command("tmux", "send-keys", "-t", target, k).Run()
+			_ = tty.Current().SendKeys(target, tty.KeySpec{Value: k})
 		}
 	}
 
@@ -83,12 +83,14 @@ type boardModel struct {
 	rowIdx    int // Selected row within column
 	scrollTop map[string]int
 	sortMode  SortMode
+	marked    map[string]bool // Open task keys marked for launching
 }
 
 func newBoardModel() *boardModel {
 	return &boardModel{
 		columns:   make(map[string][]persephoneData.Task),
 		scrollTop: make(map[string]int),
+		marked:    make(map[string]bool),
 	}
 }
 
@@ -186,6 +188,37 @@ func (b *boardModel) moveRight() {
 	}
 }
 
+// toggleMark marks or unmarks the selected task for launching. Only open
+// tasks can be marked.
+func (b *boardModel) toggleMark() bool {
+	t := b.selectedTask()
+	if t == nil || t.Status != persephoneData.StatusOpen {
+		return false
+	}
+	if b.marked[t.Key] {
+		delete(b.marked, t.Key)
+	} else {
+		b.marked[t.Key] = true
+	}
+	return true
+}
+
+// markedTasks returns the marked tasks that are still open, in board order.
+func (b *boardModel) markedTasks() []persephoneData.Task {
+	var out []persephoneData.Task
+	for _, t := range b.columns[persephoneData.StatusOpen] {
+		if b.marked[t.Key] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// clearMarks unmarks all tasks.
+func (b *boardModel) clearMarks() {
+	b.marked = make(map[string]bool)
+}
+
 // selectByIndex selects a task by its flat index across all columns.
 // Returns the task if found, nil otherwise.
 func (b *boardModel) selectByIndex(flatIdx int) *persephoneData.Task {
@@ -355,7 +388,7 @@ func (b *boardModel) view(width, height int, mh *mouse.Handler) string {
 		for j := scrollTop; j < endIdx; j++ {
 			t := tasks[j]
 			isSelected := isActive && j == b.rowIdx
-			cards = append(cards, renderTaskCard(t, colWidth, isSelected))
+			cards = append(cards, renderTaskCard(t, colWidth, isSelected, b.marked[t.Key]))
 
 			if mh != nil {
 				cardY := 2 + cardYOffset + (j-scrollTop)*cardHeight
@@ -467,8 +500,9 @@ func (b *boardModel) view(width, height int, mh *mouse.Handler) string {
 	return sb.String()
 }
 
-// renderTaskCard renders a single task as a card.
-func renderTaskCard(t persephoneData.Task, width int, selected bool) string {
+// renderTaskCard renders a single task as a card. Marked cards get a dot
+// before the key.
+func renderTaskCard(t persephoneData.Task, width int, selected, marked bool) string {
 	// Truncate key to short form
 	key := t.Key
 	if len(key) > 12 {
@@ -498,6 +532,9 @@ func renderTaskCard(t persephoneData.Task, width int, selected bool) string {
 	if badge != "" {
 		line1 = fmt.Sprintf("[%s] %s", key, badge)
 	}
+	if marked {
+		line1 = "● " + line1
+	}
 
 	cardStyle := lipgloss.NewStyle().Width(width - 2).Padding(0, 1)
 
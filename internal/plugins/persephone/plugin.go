@@ -8,6 +8,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/app"
 	"github.com/toddwbucy/hermes/internal/arango"
 	"github.com/toddwbucy/hermes/internal/mouse"
 	appmsg "github.com/toddwbucy/hermes/internal/msg"
@@ -218,6 +219,20 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		}
 		return p, p.createInsightTasks(msg)
 
+	case appmsg.TasksStartedMsg:
+		// Agents were launched on these tasks from the workspace plugin
+		if !p.connected || p.store == nil {
+			return p, nil
+		}
+		return p, p.startTasks(msg.Keys)
+
+	case tasksStartedMsg:
+		if msg.err != nil {
+			p.ctx.Logger.Warn("persephone: start tasks failed", "error", msg.err)
+			return p, tea.Batch(p.fetchTasks(), appmsg.ShowToast("Error: "+msg.err.Error(), 3*time.Second))
+		}
+		return p, p.fetchTasks()
+
 	case plugin.PluginFocusedMsg:
 		if p.connected {
 			return p, p.fetchTasks()
@@ -243,6 +258,12 @@ func (p *Plugin) handleKey(msg tea.KeyMsg) (plugin.Plugin, tea.Cmd) {
 			p.board.moveRight()
 		case "r":
 			return p, p.fetchTasks()
+		case " ":
+			if !p.board.toggleMark() {
+				return p, appmsg.ShowToast("Only open tasks can be launched", 2*time.Second)
+			}
+		case "L":
+			return p, p.launchTasks()
 		case "o":
 			p.board.cycleSort()
 			return p, appmsg.ShowToast(fmt.Sprintf("Sort: %s", p.board.sortMode.Label()), 2*time.Second)
@@ -449,6 +470,8 @@ func (p *Plugin) Commands() []plugin.Command {
 			{ID: "open", Name: "Open", Description: "View task detail", Context: pluginID, Priority: 2},
 			{ID: "refresh", Name: "Refresh", Description: "Refresh tasks", Context: pluginID, Priority: 3},
 			{ID: "sort", Name: "Sort", Description: "Cycle sort mode", Context: pluginID, Priority: 4},
+			{ID: "mark", Name: "Mark", Description: "Mark open task for launching", Context: pluginID, Priority: 5},
+			{ID: "launch", Name: "Launch", Description: "Launch agents on marked tasks", Context: pluginID, Priority: 6},
 		}
 	case viewDetail:
 		return []plugin.Command{
@@ -537,6 +560,10 @@ type taskStatusChangedMsg struct {
 	err       error
 }
 
+type tasksStartedMsg struct {
+	err error
+}
+
 type taskNoteAddedMsg struct {
 	taskKey string
 	epoch   uint64
@@ -591,12 +618,105 @@ func (p *Plugin) transitionTask(taskKey, newStatus, blockReason string) tea.Cmd
 	}
 }
 
+// launchTasks hands the marked open tasks, or the selected one if none are
+// marked, to the workspace plugin's fan-out launcher.
+func (p *Plugin) launchTasks() tea.Cmd {
+	tasks := p.board.markedTasks()
+	if len(tasks) == 0 {
+		if t := p.board.selectedTask(); t != nil && t.Status == persephoneData.StatusOpen {
+			tasks = append(tasks, *t)
+		}
+	}
+	if len(tasks) == 0 {
+		return appmsg.ShowToast("Mark open tasks to launch (space)", 2*time.Second)
+	}
+
+	store := p.store
+	p.board.clearMarks()
+	return tea.Batch(
+		app.FocusPlugin("workspace-manager"),
+		func() tea.Msg {
+			launch := make([]appmsg.LaunchTask, len(tasks))
+			for i, t := range tasks {
+				launch[i] = appmsg.LaunchTask{Key: t.Key, Title: t.Title, Description: t.Description, Acceptance: t.Acceptance}
+				// A task without a handoff, or one we can't read, launches without it
+				if handoff, err := store.LatestHandoff(t.Key); err == nil {
+					launch[i].Handoff = handoffText(handoff)
+				}
+			}
+			return appmsg.LaunchTasksMsg{Tasks: launch}
+		},
+	)
+}
+
+// handoffText formats a handoff for an agent prompt.
+func handoffText(h *persephoneData.Handoff) string {
+	if h == nil {
+		return ""
+	}
+	var sb strings.Builder
+	section := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		sb.WriteString(title + ":\n")
+		for _, item := range items {
+			sb.WriteString("- " + item + "\n")
+		}
+	}
+	section("Done", h.Done)
+	section("Remaining", h.Remaining)
+	section("Decisions", h.Decisions)
+	section("Uncertain", h.Uncertain)
+	if note := strings.TrimSpace(h.Note); note != "" {
+		sb.WriteString("Note: " + note + "\n")
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// startTasks moves tasks that are still open to in_progress. Tasks already
+// moved, e.g. by another variant of the same task, are left alone.
+func (p *Plugin) startTasks(keys []string) tea.Cmd {
+	store := p.store
+	return func() tea.Msg {
+		for _, key := range keys {
+			task, err := store.GetTask(key)
+			if err != nil {
+				return tasksStartedMsg{err: err}
+			}
+			if task.Status != persephoneData.StatusOpen {
+				continue
+			}
+			if err := store.TransitionTask(key, persephoneData.StatusInProgress, ""); err != nil {
+				return tasksStartedMsg{err: err}
+			}
+		}
+		return tasksStartedMsg{}
+	}
+}
+
 func (p *Plugin) createInsightTasks(msg appmsg.CreateInsightTasksMsg) tea.Cmd {
 	store := p.store
 	epoch := msg.Epoch
 	return func() tea.Msg {
+		// Existing tasks of any status count: a closed task means the
+		// insight was already discussed.
+		existing, err := store.ListTasks()
+		if err != nil {
+			return appmsg.InsightTasksCreatedMsg{Err: err, Epoch: epoch}
+		}
+
 		created := 0
+		var skipped []string
 		for _, it := range msg.Tasks {
+			text := it.Text
+			if text == "" {
+				text = it.Title
+			}
+			if dup, _ := persephoneData.FindSimilarTask(text, existing, msg.DuplicateThreshold); dup != nil {
+				skipped = append(skipped, it.Title)
+				continue
+			}
 			task := persephoneData.Task{
 				Title:       it.Title,
 				Description: it.Description,
@@ -605,11 +725,13 @@ func (p *Plugin) createInsightTasks(msg appmsg.CreateInsightTasksMsg) tea.Cmd {
 				Labels:      []string{"insight", "discussion", "prompt-queue"},
 			}
 			if _, err := store.CreateTask(task); err != nil {
-				return appmsg.InsightTasksCreatedMsg{Count: created, Err: err, Epoch: epoch}
+				return appmsg.InsightTasksCreatedMsg{Count: created, Skipped: skipped, Err: err, Epoch: epoch}
 			}
 			created++
+			// Later insights in the batch are checked against this one too
+			existing = append(existing, task)
 		}
-		return appmsg.InsightTasksCreatedMsg{Count: created, Epoch: epoch}
+		return appmsg.InsightTasksCreatedMsg{Count: created, Skipped: skipped, Epoch: epoch}
 	}
 }
 
@@ -7,7 +7,6 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +14,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/toddwbucy/hermes/internal/features"
+	"github.com/toddwbucy/hermes/internal/tty"
 )
 
 // paneCacheEntry holds cached capture output with timestamp
@@ -203,8 +203,7 @@ const (
 	// Hard cap on captured output size to avoid runaway memory for TUI-heavy panes.
 	defaultTmuxCaptureMaxBytes = 2 * 1024 * 1024
 
-	// Timeout for tmux capture commands to avoid blocking on hung sessions
-	tmuxCaptureTimeout      = 2 * time.Second
+	// Timeout for batched tmux capture to avoid blocking on hung sessions
 	tmuxBatchCaptureTimeout = 3 * time.Second
 
 	// Polling intervals - adaptive based on agent status and visibility
@@ -339,8 +338,7 @@ func (p *Plugin) StartAgent(wt *Worktree, agentType AgentType) tea.Cmd {
 		sessionName := tmuxSessionPrefix + sanitizeName(wt.Name)
 
 		// Check if session already exists
-		checkCmd := exec.Command("tmux", "has-session", "-t", sessionName)
-		if checkCmd.Run() == nil {
+		if tty.Current().HasSession(sessionName) {
 			// Session exists - reconnect to it instead of failing
 			paneID := getPaneID(sessionName)
 			return AgentStartedMsg{
@@ -353,27 +351,29 @@ func (p *Plugin) StartAgent(wt *Worktree, agentType AgentType) tea.Cmd {
 			}
 		}
 
-		// Create new detached session with working directory
-		args := []string{
-			"new-session",
-			"-d",              // Detached
-			"-s", sessionName, // Session name
-			"-c", wt.Path, // Working directory
+		if err := runWorktreeHook(p.ctx.WorkDir, HookPreAgentStart, wt.Path, wt.Branch); err != nil {
+			return AgentStartedMsg{Epoch: epoch, WorkspaceName: wt.Name, Err: err}
 		}
 
-		cmd := exec.Command("tmux", args...)
-		if err := cmd.Run(); err != nil {
+		// Create new detached session with working directory, keeping
+		// enough history for scrollback capture
+		backend := tty.Current()
+		err := backend.NewSession(tty.SessionOptions{
+			Name:         sessionName,
+			Dir:          wt.Path,
+			HistoryLimit: tmuxHistoryLimit,
+		})
+		if err != nil {
 			return AgentStartedMsg{Epoch: epoch, Err: fmt.Errorf("create session: %w", err)}
 		}
 
-		// Set history limit for scrollback capture
-		_ = exec.Command("tmux", "set-option", "-t", sessionName, "history-limit",
-			strconv.Itoa(tmuxHistoryLimit)).Run()
-
 		// Apply environment isolation to prevent conflicts (GOWORK, etc.)
+		// and point agent hooks at the worktree's status file
 		envOverrides := BuildEnvOverrides(p.ctx.WorkDir)
+		envOverrides[agentStatusEnv] = agentStatusPath(wt.Path)
+		resetAgentStatus(wt.Path)
 		if envCmd := GenerateSingleEnvCommand(envOverrides); envCmd != "" {
-			_ = exec.Command("tmux", "send-keys", "-t", sessionName, envCmd, "Enter").Run()
+			_ = backend.SendKeys(sessionName, tty.KeySpec{Value: envCmd}, tty.KeySpec{Value: "Enter"})
 		}
 
 		// Small delay to ensure env is set
@@ -383,10 +383,9 @@ func (p *Plugin) StartAgent(wt *Worktree, agentType AgentType) tea.Cmd {
 		agentCmd := p.getAgentCommandWithContext(agentType, wt)
 
 		// Send the agent command to start it
-		sendCmd := exec.Command("tmux", "send-keys", "-t", sessionName, agentCmd, "Enter")
-		if err := sendCmd.Run(); err != nil {
+		if err := backend.SendKeys(sessionName, tty.KeySpec{Value: agentCmd}, tty.KeySpec{Value: "Enter"}); err != nil {
 			// Try to kill the session if we failed to start the agent
-			_ = exec.Command("tmux", "kill-session", "-t", sessionName).Run()
+			_ = backend.KillSession(sessionName)
 			return AgentStartedMsg{Epoch: epoch, Err: fmt.Errorf("start agent: %w", err)}
 		}
 
@@ -413,7 +412,8 @@ func getAgentCommand(agentType AgentType) string {
 
 // buildAgentCommand builds the agent command with optional skip permissions and task context.
 // If there's task context, it writes a launcher script to avoid shell escaping issues.
-func (p *Plugin) buildAgentCommand(agentType AgentType, wt *Worktree, skipPerms bool, prompt *Prompt) string {
+// It fails only when the prompt template doesn't render.
+func (p *Plugin) buildAgentCommand(agentType AgentType, wt *Worktree, skipPerms bool, prompt *Prompt) (string, error) {
 	baseCmd := getAgentCommand(agentType)
 
 	// Apply skip permissions flag if requested
@@ -426,8 +426,11 @@ func (p *Plugin) buildAgentCommand(agentType AgentType, wt *Worktree, skipPerms
 	// Determine context to pass to agent
 	var ctx string
 	if prompt != nil {
-		// Use prompt template with ticket expansion
-		ctx = ExpandPromptTemplate(prompt.Body, wt.TaskID)
+		rendered, err := RenderPrompt(prompt.Body, promptData(prompt, wt), p.promptIncludeDirs())
+		if err != nil {
+			return "", fmt.Errorf("prompt %q: %w", prompt.Name, err)
+		}
+		ctx = rendered
 	} else if wt.TaskID != "" {
 		// No prompt selected but task selected: try to fetch full context
 		ctx = p.getTaskContext(wt.TaskID)
@@ -438,17 +441,51 @@ func (p *Plugin) buildAgentCommand(agentType AgentType, wt *Worktree, skipPerms
 	}
 
 	// No context - return simple command
-	if ctx == "" {
-		return baseCmd
+	if strings.TrimSpace(ctx) == "" {
+		return baseCmd, nil
 	}
 
 	// Write launcher script to avoid shell escaping issues with complex markdown
 	launcherCmd, err := p.writeAgentLauncher(wt.Path, agentType, baseCmd, ctx)
 	if err != nil {
 		// Fall back to simple command without context on error
-		return baseCmd
+		return baseCmd, nil
+	}
+	return launcherCmd, nil
+}
+
+// promptData collects what a prompt template can reference for a worktree.
+func promptData(prompt *Prompt, wt *Worktree) PromptData {
+	data := PromptData{
+		Ticket:     wt.TaskID,
+		Title:      wt.TaskTitle,
+		Branch:     wt.Branch,
+		BaseBranch: wt.BaseBranch,
+		Worktree:   wt.Path,
+		Vars:       prompt.VarValues(),
+		diffStat: func() string {
+			stat, _ := getDiffStatFromBase(wt.Path, wt.BaseBranch)
+			return stat
+		},
+	}
+	if t := prompt.Task; t != nil {
+		data.Ticket = t.Key
+		data.Title = t.Title
+		data.Description = t.Description
+		data.Acceptance = t.Acceptance
+		data.Handoff = t.Handoff
+	}
+	return data
+}
+
+// promptIncludeDirs returns where {{include}} looks for snippets: the
+// project's .hermes/prompts, then the global prompts directory.
+func (p *Plugin) promptIncludeDirs() []string {
+	home, _ := os.UserHomeDir()
+	return []string{
+		filepath.Join(p.ctx.WorkDir, ".hermes", "prompts"),
+		filepath.Join(home, ".config", "sidecar", "prompts"),
 	}
-	return launcherCmd
 }
 
 // writeAgentLauncher writes a launcher script that safely passes the prompt to the agent.
@@ -516,7 +553,8 @@ rm -f %q
 
 // getAgentCommandWithContext returns the agent command with optional task context (legacy, no skip perms).
 func (p *Plugin) getAgentCommandWithContext(agentType AgentType, wt *Worktree) string {
-	return p.buildAgentCommand(agentType, wt, false, nil)
+	cmd, _ := p.buildAgentCommand(agentType, wt, false, nil)
+	return cmd
 }
 
 // StartAgentWithOptions creates a tmux session and starts an agent with options.
@@ -527,8 +565,7 @@ func (p *Plugin) StartAgentWithOptions(wt *Worktree, agentType AgentType, skipPe
 		sessionName := tmuxSessionPrefix + sanitizeName(wt.Name)
 
 		// Check if session already exists
-		checkCmd := exec.Command("tmux", "has-session", "-t", sessionName)
-		if checkCmd.Run() == nil {
+		if tty.Current().HasSession(sessionName) {
 			// Session exists - reconnect to it instead of failing
 			paneID := getPaneID(sessionName)
 			return AgentStartedMsg{
@@ -541,40 +578,45 @@ func (p *Plugin) StartAgentWithOptions(wt *Worktree, agentType AgentType, skipPe
 			}
 		}
 
-		// Create new detached session with working directory
-		args := []string{
-			"new-session",
-			"-d",              // Detached
-			"-s", sessionName, // Session name
-			"-c", wt.Path, // Working directory
+		if err := runWorktreeHook(p.ctx.WorkDir, HookPreAgentStart, wt.Path, wt.Branch); err != nil {
+			return AgentStartedMsg{Epoch: epoch, WorkspaceName: wt.Name, Err: err}
 		}
 
-		cmd := exec.Command("tmux", args...)
-		if err := cmd.Run(); err != nil {
-			return AgentStartedMsg{Epoch: epoch, Err: fmt.Errorf("create session: %w", err)}
+		// Build the agent command with skip permissions and prompt if enabled.
+		// Done before the session exists so a broken template fails without one.
+		agentCmd, err := p.buildAgentCommand(agentType, wt, skipPerms, prompt)
+		if err != nil {
+			return AgentStartedMsg{Epoch: epoch, WorkspaceName: wt.Name, Err: err}
 		}
 
-		// Set history limit for scrollback capture
-		_ = exec.Command("tmux", "set-option", "-t", sessionName, "history-limit",
-			strconv.Itoa(tmuxHistoryLimit)).Run()
+		// Create new detached session with working directory, keeping
+		// enough history for scrollback capture
+		backend := tty.Current()
+		err = backend.NewSession(tty.SessionOptions{
+			Name:         sessionName,
+			Dir:          wt.Path,
+			HistoryLimit: tmuxHistoryLimit,
+		})
+		if err != nil {
+			return AgentStartedMsg{Epoch: epoch, Err: fmt.Errorf("create session: %w", err)}
+		}
 
 		// Apply environment isolation to prevent conflicts (GOWORK, etc.)
+		// and point agent hooks at the worktree's status file
 		envOverrides := BuildEnvOverrides(p.ctx.WorkDir)
+		envOverrides[agentStatusEnv] = agentStatusPath(wt.Path)
+		resetAgentStatus(wt.Path)
 		if envCmd := GenerateSingleEnvCommand(envOverrides); envCmd != "" {
-			_ = exec.Command("tmux", "send-keys", "-t", sessionName, envCmd, "Enter").Run()
+			_ = backend.SendKeys(sessionName, tty.KeySpec{Value: envCmd}, tty.KeySpec{Value: "Enter"})
 		}
 
 		// Small delay to ensure env is set
 		time.Sleep(100 * time.Millisecond)
 
-		// Build the agent command with skip permissions and prompt if enabled
-		agentCmd := p.buildAgentCommand(agentType, wt, skipPerms, prompt)
-
 		// Send the agent command to start it
-		sendCmd := exec.Command("tmux", "send-keys", "-t", sessionName, agentCmd, "Enter")
-		if err := sendCmd.Run(); err != nil {
+		if err := backend.SendKeys(sessionName, tty.KeySpec{Value: agentCmd}, tty.KeySpec{Value: "Enter"}); err != nil {
 			// Try to kill the session if we failed to start the agent
-			_ = exec.Command("tmux", "kill-session", "-t", sessionName).Run()
+			_ = backend.KillSession(sessionName)
 			return AgentStartedMsg{Epoch: epoch, Err: fmt.Errorf("start agent: %w", err)}
 		}
 
@@ -591,22 +633,16 @@ func (p *Plugin) StartAgentWithOptions(wt *Worktree, agentType AgentType, skipPe
 	}
 }
 
-// AttachToWorktreeDir creates a tmux session in the worktree directory and attaches to it.
+// AttachToWorktreeDir creates a session in the worktree directory and attaches to it.
 func (p *Plugin) AttachToWorktreeDir(wt *Worktree) tea.Cmd {
 	sessionName := tmuxSessionPrefix + sanitizeName(wt.Name)
 
 	// Check if session already exists
-	checkCmd := exec.Command("tmux", "has-session", "-t", sessionName)
-	if checkCmd.Run() != nil {
+	backend := tty.Current()
+	if !backend.HasSession(sessionName) {
 		// Session doesn't exist, create it
-		args := []string{
-			"new-session",
-			"-d",              // Detached
-			"-s", sessionName, // Session name
-			"-c", wt.Path, // Working directory
-		}
-		cmd := exec.Command("tmux", args...)
-		if err := cmd.Run(); err != nil {
+		err := backend.NewSession(tty.SessionOptions{Name: sessionName, Dir: wt.Path})
+		if err != nil {
 			return func() tea.Msg {
 				return TmuxAttachFinishedMsg{WorkspaceName: wt.Name, Err: fmt.Errorf("create session: %w", err)}
 			}
@@ -638,8 +674,8 @@ func sanitizeName(name string) string {
 	return name
 }
 
-// getPaneID retrieves the tmux pane ID for a session.
-// Returns pane IDs like "%12" which are globally unique and stable.
+// getPaneID retrieves the pane ID for a session.
+// tmux returns pane IDs like "%12" which are globally unique and stable.
 // Uses caching to avoid subprocess calls (pane IDs rarely change) (td-c2961e).
 func getPaneID(sessionName string) string {
 	// Check cache first
@@ -647,16 +683,10 @@ func getPaneID(sessionName string) string {
 		return paneID
 	}
 
-	cmd := exec.Command("tmux", "list-panes", "-t", sessionName, "-F", "#{pane_id}")
-	output, err := cmd.Output()
+	paneID, err := tty.Current().PaneID(sessionName)
 	if err != nil {
 		return ""
 	}
-	// Return first pane ID (sessions typically have one pane)
-	paneID := strings.TrimSpace(string(output))
-	if idx := strings.Index(paneID, "\n"); idx > 0 {
-		paneID = paneID[:idx]
-	}
 
 	// Cache for future lookups
 	if paneID != "" {
@@ -703,6 +733,7 @@ type AgentPollUnchangedMsg struct {
 	WorkspaceName  string
 	CurrentStatus WorktreeStatus // Status including session file re-check
 	WaitingFor    string         // Prompt text if waiting
+	WaitingCall   *ToolCall      // Raw tool call behind WaitingFor, from hook events
 	// Cursor position captured atomically (even when content unchanged)
 	CursorRow     int
 	CursorCol     int
@@ -806,14 +837,19 @@ func (p *Plugin) handlePollAgent(worktreeName string) tea.Cmd {
 		// Use hash-based change detection to skip processing if content unchanged
 		outputChanged := outputBuf == nil || outputBuf.Update(output)
 
-		// Detect status. Both detectors run; each is authoritative for what it's good at (td-2fca7d):
+		// Detect status. Events written by agent hooks are authoritative when present.
+		// Otherwise both detectors run; each is authoritative for what it's good at (td-2fca7d):
 		//   - tmux patterns: thinking, done, error (high-signal, session files can't detect these)
 		//   - session files: active vs waiting (reliable, tmux patterns are noisy for this)
 		// Session file detection ALWAYS runs (even when output unchanged) because the agent
 		// may finish while tmux output stays the same (td-2fca7d v8).
 		status := currentStatus
 		waitingFor := ""
-		if !interactiveCapture {
+		var waitingCall *ToolCall
+		if ev, ok := readAgentStatus(wtPath); ok {
+			status, waitingFor = ev.Status()
+			waitingCall = ev.waitingCall()
+		} else if !interactiveCapture {
 			if outputChanged {
 				// Tmux pattern detection only when output changes (same output = same patterns).
 				status = detectStatus(output)
@@ -847,6 +883,7 @@ func (p *Plugin) handlePollAgent(worktreeName string) tea.Cmd {
 				WorkspaceName:  worktreeName,
 				CurrentStatus: status,
 				WaitingFor:    waitingFor,
+				WaitingCall:   waitingCall,
 				CursorRow:     cursorRow,
 				CursorCol:     cursorCol,
 				CursorVisible: cursorVisible,
@@ -861,6 +898,7 @@ func (p *Plugin) handlePollAgent(worktreeName string) tea.Cmd {
 			Output:        output,
 			Status:        status,
 			WaitingFor:    waitingFor,
+			WaitingCall:   waitingCall,
 			CursorRow:     cursorRow,
 			CursorCol:     cursorCol,
 			CursorVisible: cursorVisible,
@@ -921,26 +959,7 @@ func capturePaneDirect(sessionName string) (string, error) {
 // capturePaneDirectWithJoin captures a single pane without caching.
 // When joinWrapped is false, tmux preserves wrapped lines for correct cursor alignment.
 func capturePaneDirectWithJoin(sessionName string, joinWrapped bool) (string, error) {
-	startLine := fmt.Sprintf("-%d", captureLineCount)
-	ctx, cancel := context.WithTimeout(context.Background(), tmuxCaptureTimeout)
-	defer cancel()
-	args := []string{"capture-pane", "-p", "-e"}
-	if joinWrapped {
-		args = append(args, "-J")
-	}
-	args = append(args, "-S", startLine, "-t", sessionName)
-	cmd := exec.CommandContext(ctx, "tmux", args...)
-	output, err := cmd.Output()
-	if ctx.Err() == context.DeadlineExceeded {
-		return "", fmt.Errorf("capture-pane: timeout after %s", tmuxCaptureTimeout)
-	}
-	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
-			return "", fmt.Errorf("capture-pane: %s", strings.TrimSpace(string(exitErr.Stderr)))
-		}
-		return "", fmt.Errorf("capture-pane: %w", err)
-	}
-	return string(output), nil
+	return tty.Current().Capture(sessionName, captureLineCount, joinWrapped)
 }
 
 // batchCaptureActiveSessions captures only recently-polled sidecar sessions (td-018f25).
@@ -951,8 +970,9 @@ func batchCaptureActiveSessions() (map[string]string, error) {
 	activeSessions := globalActiveRegistry.getActiveSessions()
 
 	// If only 0-1 active sessions, skip batch capture overhead
-	// Let caller use direct capture instead
-	if len(activeSessions) <= 1 {
+	// Let caller use direct capture instead. PTY captures are in-process,
+	// so there's nothing to batch.
+	if len(activeSessions) <= 1 || !tty.IsTmux() {
 		return nil, nil
 	}
 
@@ -1047,6 +1067,7 @@ func tailUTF8Safe(s string, n int) string {
 // This is the tmux-based fallback for agents without session file support (td-2fca7d).
 // For supported agents (Claude, Codex, Gemini, OpenCode), session file analysis runs
 // first in handlePollAgent and is more reliable than tmux pattern matching.
+// Events reported by agent hooks (see readAgentStatus) take precedence over both.
 func detectStatus(output string) WorktreeStatus {
 	// Check tail of output for status patterns (avoids splitting entire string)
 	checkText := tailUTF8Safe(output, statusCheckBytes)
@@ -1202,8 +1223,7 @@ func (p *Plugin) Approve(wt *Worktree) tea.Cmd {
 		}
 
 		// Send "y" followed by Enter
-		cmd := exec.Command("tmux", "send-keys", "-t", wt.Agent.TmuxSession, "y", "Enter")
-		err := cmd.Run()
+		err := tty.Current().SendKeys(wt.Agent.TmuxSession, tty.KeySpec{Value: "y"}, tty.KeySpec{Value: "Enter"})
 
 		return ApproveResultMsg{
 			WorkspaceName: wt.Name,
@@ -1219,8 +1239,7 @@ func (p *Plugin) Reject(wt *Worktree) tea.Cmd {
 			return RejectResultMsg{WorkspaceName: wt.Name, Err: fmt.Errorf("no agent running")}
 		}
 
-		cmd := exec.Command("tmux", "send-keys", "-t", wt.Agent.TmuxSession, "n", "Enter")
-		err := cmd.Run()
+		err := tty.Current().SendKeys(wt.Agent.TmuxSession, tty.KeySpec{Value: "n"}, tty.KeySpec{Value: "Enter"})
 
 		return RejectResultMsg{
 			WorkspaceName: wt.Name,
@@ -1229,14 +1248,24 @@ func (p *Plugin) Reject(wt *Worktree) tea.Cmd {
 	}
 }
 
-// ApproveAll approves all worktrees with pending prompts.
+// ApproveAll approves all worktrees with pending prompts, skipping prompts
+// the approval policy denies.
 func (p *Plugin) ApproveAll() tea.Cmd {
 	var cmds []tea.Cmd
+	skipped := 0
 	for _, wt := range p.worktrees {
 		if wt.Status == StatusWaiting && wt.Agent != nil {
+			if p.policyDenies(wt) {
+				skipped++
+				continue
+			}
 			cmds = append(cmds, p.Approve(wt))
 		}
 	}
+	if skipped > 0 {
+		p.toastMessage = fmt.Sprintf("Skipped %d prompt(s) denied by policy", skipped)
+		p.toastTime = time.Now()
+	}
 
 	if len(cmds) == 0 {
 		return nil
@@ -1252,15 +1281,9 @@ func (p *Plugin) SendText(wt *Worktree, text string) tea.Cmd {
 			return SendTextResultMsg{Err: fmt.Errorf("no agent running")}
 		}
 
-		// Use -l to send literal text (no key name lookup)
-		cmd := exec.Command("tmux", "send-keys", "-l", "-t", wt.Agent.TmuxSession, text)
-		if err := cmd.Run(); err != nil {
-			return SendTextResultMsg{Err: err}
-		}
-
-		// Send Enter separately
-		cmd = exec.Command("tmux", "send-keys", "-t", wt.Agent.TmuxSession, "Enter")
-		err := cmd.Run()
+		// Send literal text (no key name lookup), then Enter
+		err := tty.Current().SendKeys(wt.Agent.TmuxSession,
+			tty.KeySpec{Value: text, Literal: true}, tty.KeySpec{Value: "Enter"})
 
 		return SendTextResultMsg{
 			WorkspaceName: wt.Name,
@@ -1298,7 +1321,7 @@ func (p *Plugin) StopAgent(wt *Worktree) tea.Cmd {
 		sessionName := wt.Agent.TmuxSession
 
 		// Try graceful interrupt first (Ctrl+C)
-		_ = exec.Command("tmux", "send-keys", "-t", sessionName, "C-c").Run()
+		_ = tty.Current().SendKeys(sessionName, tty.KeySpec{Value: "C-c"})
 
 		// Wait briefly for graceful shutdown
 		time.Sleep(2 * time.Second)
@@ -1306,17 +1329,16 @@ func (p *Plugin) StopAgent(wt *Worktree) tea.Cmd {
 		// Check if still running
 		if sessionExists(sessionName) {
 			// Force kill
-			_ = exec.Command("tmux", "kill-session", "-t", sessionName).Run()
+			_ = tty.Current().KillSession(sessionName)
 		}
 
 		return AgentStoppedMsg{WorkspaceName: wt.Name}
 	}
 }
 
-// sessionExists checks if a tmux session exists.
+// sessionExists checks if a session exists.
 func sessionExists(name string) bool {
-	cmd := exec.Command("tmux", "has-session", "-t", name)
-	return cmd.Run() == nil
+	return tty.Current().HasSession(name)
 }
 
 // detectOrphanedWorktrees marks worktrees as orphaned if they have a saved
@@ -1352,23 +1374,15 @@ func (p *Plugin) detectOrphanedWorktrees() {
 // reconnectAgents finds and reconnects to existing tmux sessions on startup.
 func (p *Plugin) reconnectAgents() tea.Cmd {
 	return func() tea.Msg {
-		// Find existing sidecar-ws-* tmux sessions
-		cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
-		output, err := cmd.Output()
+		// Find existing sidecar-ws-* sessions
+		sessions, err := tty.Current().ListSessions()
 		if err != nil {
 			// No tmux server running, that's fine
 			return reconnectedAgentsMsg{Cmds: nil}
 		}
 
 		var pollingCmds []tea.Cmd
-		sessions := strings.Split(string(output), "\n")
-
 		for _, session := range sessions {
-			session = strings.TrimSpace(session)
-			if session == "" {
-				continue
-			}
-
 			// Only reconnect to sessions with our prefix
 			if !strings.HasPrefix(session, tmuxSessionPrefix) {
 				continue
@@ -1394,6 +1408,17 @@ func (p *Plugin) reconnectAgents() tea.Cmd {
 				OutputBuf:   NewOutputBuffer(outputBufferCap),
 			}
 
+			// A pane still piped from the last run keeps recording
+			target := agentTarget(agent)
+			logPath, _ := recordingPaths(wt.Path, recordingCurrent)
+			_, statErr := os.Stat(logPath)
+			if (statErr == nil && panePiped(target)) || p.recordAllAgents() {
+				if offset, err := pipePane(target, wt.Path); err == nil {
+					agent.Recording = true
+					agent.RecordedBytes = offset
+				}
+			}
+
 			wt.Agent = agent
 			p.agents[wt.Name] = agent
 
@@ -1414,7 +1439,7 @@ func (p *Plugin) Cleanup(removeSessions bool) error {
 		if removeSessions {
 			// Only kill sessions we created
 			if p.managedSessions[agent.TmuxSession] {
-				_ = exec.Command("tmux", "kill-session", "-t", agent.TmuxSession).Run()
+				_ = tty.Current().KillSession(agent.TmuxSession)
 				delete(p.managedSessions, agent.TmuxSession)
 				globalPaneCache.remove(agent.TmuxSession)
 				globalActiveRegistry.remove(agent.TmuxSession) // td-018f25
@@ -1427,18 +1452,12 @@ func (p *Plugin) Cleanup(removeSessions bool) error {
 
 // CleanupOrphanedSessions removes sessions that no longer have worktrees.
 func (p *Plugin) CleanupOrphanedSessions() error {
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
-	output, err := cmd.Output()
+	sessions, err := tty.Current().ListSessions()
 	if err != nil {
 		return nil // No tmux server
 	}
 
-	for _, session := range strings.Split(string(output), "\n") {
-		session = strings.TrimSpace(session)
-		if session == "" {
-			continue
-		}
-
+	for _, session := range sessions {
 		// Only cleanup sessions we explicitly created and tracked
 		if !p.managedSessions[session] {
 			continue
@@ -1448,7 +1467,7 @@ func (p *Plugin) CleanupOrphanedSessions() error {
 		// Use sanitized name lookup since session names are created with sanitizeName()
 		sanitizedName := strings.TrimPrefix(session, tmuxSessionPrefix)
 		if p.findWorktreeBySanitizedName(sanitizedName) == nil {
-			_ = exec.Command("tmux", "kill-session", "-t", session).Run()
+			_ = tty.Current().KillSession(session)
 			delete(p.managedSessions, session)
 			globalPaneCache.remove(session)
 			globalActiveRegistry.remove(session) // td-018f25
@@ -1463,20 +1482,16 @@ func (p *Plugin) validateManagedSessions() tea.Cmd {
 	return func() tea.Msg {
 		existing := make(map[string]bool)
 
-		// List all tmux sessions
-		cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
-		output, err := cmd.Output()
+		// List all sessions
+		sessions, err := tty.Current().ListSessions()
 		if err != nil {
 			// No tmux server, all sessions are gone
 			return validateManagedSessionsResultMsg{ExistingSessions: existing}
 		}
 
 		// Build set of existing sessions
-		for _, session := range strings.Split(string(output), "\n") {
-			session = strings.TrimSpace(session)
-			if session != "" {
-				existing[session] = true
-			}
+		for _, session := range sessions {
+			existing[session] = true
 		}
 
 		return validateManagedSessionsResultMsg{ExistingSessions: existing}
@@ -0,0 +1,243 @@
+package workspace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Structured status channel. Agent hooks (Claude Code hooks, Codex notify,
+// or the hermes-status helper) append one JSON event per line to a file in
+// the worktree; the latest event replaces pane-output heuristics.
+const (
+	agentStatusDir     = ".hermes"
+	agentStatusFile    = "status.jsonl"
+	agentStatusEnv     = "HERMES_STATUS_FILE" // Exported into agent sessions
+	agentStatusTailMax = 16 * 1024            // Bytes read from the end of the file
+)
+
+// Agent event kinds written by hermes-status.
+const (
+	EventPrompt     = "prompt"     // User submitted a prompt
+	EventToolStart  = "tool_start" // Tool call started
+	EventToolStop   = "tool_stop"  // Tool call finished
+	EventPermission = "permission" // Agent is asking to run a tool
+	EventIdle       = "idle"       // Turn finished, waiting for input
+	EventError      = "error"      // Agent reported an error
+	EventDone       = "done"       // Agent session ended
+)
+
+// AgentEvent is one structured status event.
+type AgentEvent struct {
+	Time    time.Time       `json:"ts"`
+	Event   string          `json:"event"`
+	Tool    string          `json:"tool,omitempty"`
+	Message string          `json:"message,omitempty"`
+	Source  string          `json:"source,omitempty"`  // "claude" or "codex" for raw hook payloads
+	Payload json.RawMessage `json:"payload,omitempty"` // Raw hook payload or tool input
+}
+
+// ToolCall is the tool and raw input behind a permission prompt, kept
+// alongside the display text so decisions can use the untruncated input.
+type ToolCall struct {
+	Name  string
+	Input json.RawMessage
+}
+
+// agentStatusPath returns the status file for a worktree.
+func agentStatusPath(worktreePath string) string {
+	return filepath.Join(worktreePath, agentStatusDir, agentStatusFile)
+}
+
+// resetAgentStatus clears events left by a previous agent run so a new
+// session starts on heuristics until its hooks report.
+func resetAgentStatus(worktreePath string) {
+	_ = os.Remove(agentStatusPath(worktreePath))
+}
+
+// readAgentStatus returns the latest event in a worktree's status file.
+func readAgentStatus(worktreePath string) (AgentEvent, bool) {
+	f, err := os.Open(agentStatusPath(worktreePath))
+	if err != nil {
+		return AgentEvent{}, false
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return AgentEvent{}, false
+	}
+	offset := info.Size() - agentStatusTailMax
+	if offset < 0 {
+		offset = 0
+	}
+	data := make([]byte, info.Size()-offset)
+	if _, err := f.ReadAt(data, offset); err != nil && err != io.EOF {
+		return AgentEvent{}, false
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+	for i := len(lines) - 1; i >= 0; i-- {
+		if i == 0 && offset > 0 {
+			break // Partial line at the start of the tail
+		}
+		ev, ok := parseAgentEvent(lines[i])
+		if !ok {
+			continue
+		}
+		if ev.Time.IsZero() {
+			ev.Time = info.ModTime()
+		}
+		return ev, true
+	}
+	return AgentEvent{}, false
+}
+
+// parseAgentEvent decodes a status line, normalizing raw Claude Code hook
+// and Codex notify payloads into event kinds.
+func parseAgentEvent(line []byte) (AgentEvent, bool) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return AgentEvent{}, false
+	}
+	var ev AgentEvent
+	if err := json.Unmarshal(line, &ev); err != nil {
+		return AgentEvent{}, false
+	}
+	switch ev.Source {
+	case "claude":
+		ev = normalizeClaudeHook(ev)
+	case "codex":
+		ev = normalizeCodexNotify(ev)
+	}
+	if ev.Event == "" {
+		return AgentEvent{}, false
+	}
+	return ev, true
+}
+
+// claudeHookPayload is the subset of a Claude Code hook's stdin we use.
+type claudeHookPayload struct {
+	HookEventName string          `json:"hook_event_name"`
+	ToolName      string          `json:"tool_name"`
+	ToolInput     json.RawMessage `json:"tool_input"`
+	Message       string          `json:"message"`
+}
+
+// normalizeClaudeHook maps a Claude Code hook payload to an event.
+func normalizeClaudeHook(ev AgentEvent) AgentEvent {
+	var h claudeHookPayload
+	if err := json.Unmarshal(ev.Payload, &h); err != nil {
+		ev.Event = ""
+		return ev
+	}
+	ev.Tool = h.ToolName
+	ev.Message = h.Message
+	switch h.HookEventName {
+	case "UserPromptSubmit":
+		ev.Event = EventPrompt
+	case "PreToolUse":
+		ev.Event = EventToolStart
+		ev.Payload = h.ToolInput
+	case "PostToolUse":
+		ev.Event = EventToolStop
+	case "PermissionRequest":
+		ev.Event = EventPermission
+		ev.Payload = h.ToolInput
+	case "Notification":
+		// Claude Code notifies both for permission prompts and idle input
+		if strings.Contains(strings.ToLower(h.Message), "permission") {
+			ev.Event = EventPermission
+		} else {
+			ev.Event = EventIdle
+		}
+	case "Stop", "SubagentStop":
+		ev.Event = EventIdle
+	case "SessionEnd":
+		ev.Event = EventDone
+	default:
+		ev.Event = ""
+	}
+	return ev
+}
+
+// normalizeCodexNotify maps a Codex notify payload to an event. Codex
+// only notifies when a turn completes.
+func normalizeCodexNotify(ev AgentEvent) AgentEvent {
+	var n struct {
+		Type    string `json:"type"`
+		Message string `json:"last-assistant-message"`
+	}
+	if err := json.Unmarshal(ev.Payload, &n); err != nil || n.Type != "agent-turn-complete" {
+		ev.Event = ""
+		return ev
+	}
+	ev.Event = EventIdle
+	ev.Message = n.Message
+	return ev
+}
+
+// Status maps the event to a worktree status and, when waiting, the prompt
+// to show.
+func (ev AgentEvent) Status() (WorktreeStatus, string) {
+	switch ev.Event {
+	case EventPrompt:
+		return StatusThinking, ""
+	case EventToolStart, EventToolStop:
+		return StatusActive, ""
+	case EventPermission:
+		return StatusWaiting, ev.permissionPrompt()
+	case EventIdle:
+		return StatusWaiting, "Waiting for input"
+	case EventError:
+		return StatusError, ""
+	case EventDone:
+		return StatusDone, ""
+	}
+	return StatusActive, ""
+}
+
+// waitingCall returns the tool call a permission event asks about, or nil
+// when the event doesn't name a tool.
+func (ev AgentEvent) waitingCall() *ToolCall {
+	if ev.Event != EventPermission || ev.Tool == "" {
+		return nil
+	}
+	return &ToolCall{Name: ev.Tool, Input: ev.Payload}
+}
+
+// permissionPrompt describes a permission request, e.g. "Allow Bash: go test ./...".
+func (ev AgentEvent) permissionPrompt() string {
+	if ev.Tool == "" {
+		if ev.Message != "" {
+			return ev.Message
+		}
+		return "Permission requested"
+	}
+	if detail := summarizeToolInput(ev.Payload); detail != "" {
+		return fmt.Sprintf("Allow %s: %s", ev.Tool, detail)
+	}
+	return "Allow " + ev.Tool
+}
+
+// summarizeToolInput picks the most telling field of a tool's input.
+func summarizeToolInput(input json.RawMessage) string {
+	if len(input) == 0 {
+		return ""
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(input, &fields); err != nil {
+		return ""
+	}
+	for _, key := range []string{"command", "file_path", "path", "pattern", "url"} {
+		if s, ok := fields[key].(string); ok && s != "" {
+			return truncateString(strings.Join(strings.Fields(s), " "), 60)
+		}
+	}
+	return ""
+}
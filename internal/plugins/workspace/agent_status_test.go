@@ -0,0 +1,97 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseAgentEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		wantStatus WorktreeStatus
+		wantWait   string
+	}{
+		{"generic tool start", `{"event":"tool_start","tool":"Bash"}`, StatusActive, ""},
+		{"generic permission", `{"event":"permission","tool":"Edit","payload":{"file_path":"main.go"}}`, StatusWaiting, "Allow Edit: main.go"},
+		{"generic error", `{"event":"error","message":"rate limited"}`, StatusError, ""},
+		{"claude prompt", `{"source":"claude","payload":{"hook_event_name":"UserPromptSubmit","prompt":"hi"}}`, StatusThinking, ""},
+		{"claude permission", `{"source":"claude","payload":{"hook_event_name":"PermissionRequest","tool_name":"Bash","tool_input":{"command":"rm -rf\nbuild"}}}`, StatusWaiting, "Allow Bash: rm -rf build"},
+		{"claude permission notification", `{"source":"claude","payload":{"hook_event_name":"Notification","message":"Claude needs your permission to use Bash"}}`, StatusWaiting, "Claude needs your permission to use Bash"},
+		{"claude idle notification", `{"source":"claude","payload":{"hook_event_name":"Notification","message":"Claude is waiting for your input"}}`, StatusWaiting, "Waiting for input"},
+		{"claude stop", `{"source":"claude","payload":{"hook_event_name":"Stop"}}`, StatusWaiting, "Waiting for input"},
+		{"claude session end", `{"source":"claude","payload":{"hook_event_name":"SessionEnd"}}`, StatusDone, ""},
+		{"codex turn complete", `{"source":"codex","payload":{"type":"agent-turn-complete","last-assistant-message":"done"}}`, StatusWaiting, "Waiting for input"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, ok := parseAgentEvent([]byte(tt.line))
+			if !ok {
+				t.Fatal("event not parsed")
+			}
+			status, waiting := ev.Status()
+			if status != tt.wantStatus || waiting != tt.wantWait {
+				t.Errorf("Status() = %v, %q; want %v, %q", status, waiting, tt.wantStatus, tt.wantWait)
+			}
+		})
+	}
+
+	for _, line := range []string{"", "not json", `{"source":"claude","payload":{"hook_event_name":"PreCompact"}}`, `{"source":"codex","payload":{"type":"other"}}`} {
+		if _, ok := parseAgentEvent([]byte(line)); ok {
+			t.Errorf("parseAgentEvent(%q) should be ignored", line)
+		}
+	}
+}
+
+func TestReadAgentStatus(t *testing.T) {
+	dir := t.TempDir()
+	if _, ok := readAgentStatus(dir); ok {
+		t.Fatal("missing status file should fall back to heuristics")
+	}
+
+	path := agentStatusPath(dir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Older events, then the latest one followed by a torn write
+	lines := strings.Repeat(`{"ts":"2026-01-01T00:00:00Z","event":"tool_start","tool":"Read"}`+"\n", 500) +
+		`{"ts":"2026-01-01T00:00:05Z","event":"idle"}` + "\n" + `{"event":"tool_st`
+	if err := os.WriteFile(path, []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ev, ok := readAgentStatus(dir)
+	if !ok || ev.Event != EventIdle || ev.Time.IsZero() {
+		t.Fatalf("readAgentStatus = %+v, %v", ev, ok)
+	}
+
+	resetAgentStatus(dir)
+	if _, ok := readAgentStatus(dir); ok {
+		t.Error("reset should clear the previous run's events")
+	}
+}
+
+func TestPermissionEventKeepsToolCall(t *testing.T) {
+	command := "go test ./internal/plugins/workspace/... -run TestSomething -count=1; rm -rf ~"
+	line := `{"source":"claude","payload":{"hook_event_name":"PermissionRequest","tool_name":"Bash","tool_input":{"command":"` + command + `"}}}`
+	ev, ok := parseAgentEvent([]byte(line))
+	if !ok {
+		t.Fatal("event not parsed")
+	}
+
+	// The prompt is cut for display; the call keeps the full input
+	if _, waiting := ev.Status(); strings.Contains(waiting, "rm -rf") {
+		t.Errorf("display prompt should be truncated, got %q", waiting)
+	}
+	call := ev.waitingCall()
+	if call == nil || call.Name != "Bash" || !strings.Contains(string(call.Input), command) {
+		t.Fatalf("waitingCall = %+v", call)
+	}
+
+	idle, _ := parseAgentEvent([]byte(`{"event":"idle"}`))
+	if idle.waitingCall() != nil {
+		t.Error("idle events have no tool call")
+	}
+}
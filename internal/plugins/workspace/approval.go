@@ -0,0 +1,398 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/styles"
+)
+
+// ApprovalAction is what the approval policy does with a permission prompt.
+type ApprovalAction string
+
+const (
+	ApprovalAllow ApprovalAction = "allow" // Send "y" automatically
+	ApprovalDeny  ApprovalAction = "deny"  // Send "n" automatically
+	ApprovalAsk   ApprovalAction = "ask"   // Leave the prompt for the user
+)
+
+const (
+	approvalAuditFile = "approvals.jsonl" // Under the worktree's .hermes/ directory
+	approvalAuditMax  = 50                // Decisions kept in memory per worktree
+)
+
+// ApprovalRule matches a parsed permission prompt. Patterns are
+// case-insensitive regular expressions; empty patterns match anything.
+type ApprovalRule struct {
+	Name            string         `json:"name,omitempty"`
+	Action          ApprovalAction `json:"action"`
+	Tool            string         `json:"tool,omitempty"`
+	Command         string         `json:"command,omitempty"`
+	Path            string         `json:"path,omitempty"`
+	OutsideWorktree bool           `json:"outsideWorktree,omitempty"` // Only match when a path leaves the worktree
+
+	toolRe, commandRe, pathRe *regexp.Regexp
+}
+
+// ApprovalPolicy decides permission prompts by the first matching rule.
+type ApprovalPolicy struct {
+	Default ApprovalAction `json:"default,omitempty"` // When no rule matches; "ask" if empty
+	Rules   []ApprovalRule `json:"rules"`
+}
+
+// configWithApprovalPolicy is the config structure for loading the policy.
+type configWithApprovalPolicy struct {
+	ApprovalPolicy *ApprovalPolicy `json:"approvalPolicy"`
+}
+
+// LoadApprovalPolicy loads the approval policy from the project's
+// .hermes/config.json, falling back to the global config directory. A
+// project policy replaces the global one. Returns nil when neither defines
+// a policy, which leaves every prompt to the user.
+func LoadApprovalPolicy(globalConfigDir, projectDir string) *ApprovalPolicy {
+	for _, dir := range []string{filepath.Join(projectDir, ".hermes"), globalConfigDir} {
+		if dir == "" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+		if err != nil {
+			continue
+		}
+		var cfg configWithApprovalPolicy
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			slog.Warn("approval policy: parse failed", "dir", dir, "err", err)
+			continue
+		}
+		if cfg.ApprovalPolicy != nil {
+			cfg.ApprovalPolicy.compile()
+			return cfg.ApprovalPolicy
+		}
+	}
+	return nil
+}
+
+// compile prepares rule patterns, dropping rules that cannot be used.
+func (pol *ApprovalPolicy) compile() {
+	rules := pol.Rules[:0]
+	for _, r := range pol.Rules {
+		switch r.Action {
+		case ApprovalAllow, ApprovalDeny, ApprovalAsk:
+		default:
+			slog.Warn("approval policy: unknown action, rule skipped", "rule", r.label(), "action", r.Action)
+			continue
+		}
+		var err error
+		if r.toolRe, err = compilePattern(r.Tool); err == nil {
+			if r.commandRe, err = compilePattern(r.Command); err == nil {
+				r.pathRe, err = compilePattern(r.Path)
+			}
+		}
+		if err != nil {
+			slog.Warn("approval policy: bad pattern, rule skipped", "rule", r.label(), "err", err)
+			continue
+		}
+		rules = append(rules, r)
+	}
+	pol.Rules = rules
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// label names a rule for the audit log.
+func (r *ApprovalRule) label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	var parts []string
+	for _, s := range []string{r.Tool, r.Command, r.Path} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	if r.OutsideWorktree {
+		parts = append(parts, "outside worktree")
+	}
+	return strings.Join(parts, " ")
+}
+
+// PermissionRequest is the tool call a permission prompt asks about.
+type PermissionRequest struct {
+	Tool    string
+	Command string // Shell command, for shell tools
+	Path    string // File path or URL, for other tools
+
+	// Heuristic marks requests read back from prompt text, which may be
+	// truncated or misparsed. They can be denied but never auto-allowed.
+	Heuristic bool
+}
+
+func (r PermissionRequest) empty() bool {
+	return r.Tool == "" && r.Command == "" && r.Path == ""
+}
+
+var (
+	// "Allow Bash: go test ./..." (hook events, see AgentEvent.permissionPrompt)
+	allowPromptRe = regexp.MustCompile(`^Allow ([A-Za-z][\w.-]*)(?::\s*(.+))?$`)
+	// "Bash(go test ./...)" (Claude Code's tool display)
+	toolCallPromptRe = regexp.MustCompile(`\b([A-Z][A-Za-z]+)\((.+)\)`)
+)
+
+// shellTools are tools whose argument is a shell command.
+var shellTools = map[string]bool{
+	"bash": true, "shell": true, "shell_command": true, "exec": true, "run_shell_command": true,
+}
+
+// permissionRequest returns the request an agent is waiting on: the full
+// tool call when a hook reported one, else a heuristic parse of the prompt.
+func permissionRequest(a *Agent) PermissionRequest {
+	if a.WaitingCall != nil {
+		return requestFromToolCall(*a.WaitingCall)
+	}
+	return parsePermissionPrompt(a.WaitingFor)
+}
+
+// requestFromToolCall reads the command or path from a tool's raw input.
+// Inputs without a readable argument come back heuristic.
+func requestFromToolCall(call ToolCall) PermissionRequest {
+	req := PermissionRequest{Tool: call.Name}
+	var fields map[string]any
+	if len(call.Input) > 0 && json.Unmarshal(call.Input, &fields) != nil {
+		req.Heuristic = true
+		return req
+	}
+	if shellTools[strings.ToLower(call.Name)] {
+		command, ok := fields["command"].(string)
+		req.Command = strings.TrimSpace(command)
+		req.Heuristic = !ok || req.Command == ""
+		return req
+	}
+	for _, key := range []string{"file_path", "path", "notebook_path", "url"} {
+		if s, ok := fields[key].(string); ok && s != "" {
+			req.Path = s
+			break
+		}
+	}
+	return req
+}
+
+// parsePermissionPrompt extracts the tool and its argument from a waiting
+// prompt. Prompts it cannot read come back empty; the rest are heuristic.
+func parsePermissionPrompt(text string) PermissionRequest {
+	text = strings.TrimSpace(text)
+	var tool, arg string
+	if m := allowPromptRe.FindStringSubmatch(text); m != nil {
+		tool, arg = m[1], m[2]
+	} else if m := toolCallPromptRe.FindStringSubmatch(text); m != nil {
+		tool, arg = m[1], m[2]
+	} else {
+		return PermissionRequest{}
+	}
+	req := PermissionRequest{Tool: tool, Heuristic: true}
+	if shellTools[strings.ToLower(tool)] {
+		req.Command = strings.TrimSpace(arg)
+	} else {
+		req.Path = strings.TrimSpace(arg)
+	}
+	return req
+}
+
+// shellControlRe finds operators that chain or redirect commands. An allow
+// rule written for "go test" must not approve "go test && rm -rf ~".
+var shellControlRe = regexp.MustCompile("[;&|<>`\\n]|\\$\\(")
+
+// Evaluate returns the action for a request and the rule that decided it
+// (nil for the default). Unparsed prompts are always left to the user, and
+// heuristic or chained requests are never allowed.
+func (pol *ApprovalPolicy) Evaluate(req PermissionRequest, worktreePath string) (ApprovalAction, *ApprovalRule) {
+	if pol == nil || req.empty() {
+		return ApprovalAsk, nil
+	}
+	for i := range pol.Rules {
+		r := &pol.Rules[i]
+		if !r.matches(req, worktreePath) {
+			continue
+		}
+		if r.Action == ApprovalAllow && !req.allowable() {
+			return ApprovalAsk, r
+		}
+		return r.Action, r
+	}
+	if pol.Default == ApprovalAllow || pol.Default == ApprovalDeny {
+		if pol.Default == ApprovalAllow && !req.allowable() {
+			return ApprovalAsk, nil
+		}
+		return pol.Default, nil
+	}
+	return ApprovalAsk, nil
+}
+
+// allowable reports whether the request is complete and unchained enough
+// to approve without the user.
+func (r PermissionRequest) allowable() bool {
+	return !r.Heuristic && !shellControlRe.MatchString(r.Command)
+}
+
+func (r *ApprovalRule) matches(req PermissionRequest, worktreePath string) bool {
+	if r.toolRe != nil && !r.toolRe.MatchString(req.Tool) {
+		return false
+	}
+	if r.commandRe != nil && (req.Command == "" || !r.commandRe.MatchString(req.Command)) {
+		return false
+	}
+	if r.pathRe != nil && (req.Path == "" || !r.pathRe.MatchString(req.Path)) {
+		return false
+	}
+	if r.OutsideWorktree && !touchesOutside(req, worktreePath) {
+		return false
+	}
+	return true
+}
+
+// touchesOutside reports whether the request's path, or any path-like
+// argument of its command, resolves outside the worktree.
+func touchesOutside(req PermissionRequest, worktreePath string) bool {
+	candidates := []string{}
+	if req.Path != "" && !strings.Contains(req.Path, "://") {
+		candidates = append(candidates, req.Path)
+	}
+	for _, field := range strings.Fields(req.Command) {
+		field = strings.Trim(field, `"'`)
+		if strings.HasPrefix(field, "/") || strings.HasPrefix(field, "~") || strings.Contains(field, "..") {
+			candidates = append(candidates, field)
+		}
+	}
+
+	root := filepath.Clean(worktreePath)
+	home, _ := os.UserHomeDir()
+	for _, c := range candidates {
+		if c == "~" || strings.HasPrefix(c, "~/") {
+			c = filepath.Join(home, strings.TrimPrefix(c, "~"))
+		}
+		if !filepath.IsAbs(c) {
+			c = filepath.Join(root, c)
+		}
+		c = filepath.Clean(c)
+		if c != root && !strings.HasPrefix(c, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ApprovalDecision is one automatic decision, kept for the audit log.
+type ApprovalDecision struct {
+	Time     time.Time      `json:"ts"`
+	Worktree string         `json:"worktree"`
+	Prompt   string         `json:"prompt"`
+	Action   ApprovalAction `json:"action"`
+	Rule     string         `json:"rule"`
+}
+
+// applyApprovalPolicy decides a worktree's waiting prompt once, sending the
+// answer to the agent. Prompts the policy leaves to the user are not logged.
+func (p *Plugin) applyApprovalPolicy(wt *Worktree) tea.Cmd {
+	if p.approvalPolicy == nil || wt == nil || wt.Agent == nil {
+		return nil
+	}
+	prompt := wt.Agent.WaitingFor
+	if wt.Status != StatusWaiting || prompt == "" {
+		delete(p.approvalDecided, wt.Name)
+		return nil
+	}
+	// Display prompts are truncated, so two calls can share one
+	key := prompt
+	if call := wt.Agent.WaitingCall; call != nil {
+		key += "\x00" + call.Name + "\x00" + string(call.Input)
+	}
+	if p.approvalDecided[wt.Name] == key {
+		return nil // Already decided; waiting for the agent to move on
+	}
+	p.approvalDecided[wt.Name] = key
+
+	action, rule := p.approvalPolicy.Evaluate(permissionRequest(wt.Agent), wt.Path)
+	if action == ApprovalAsk {
+		return nil
+	}
+	ruleLabel := "default"
+	if rule != nil {
+		ruleLabel = rule.label()
+	}
+	d := ApprovalDecision{Time: time.Now(), Worktree: wt.Name, Prompt: prompt, Action: action, Rule: ruleLabel}
+	p.approvalAudit[wt.Name] = append(p.approvalAudit[wt.Name], d)
+	if n := len(p.approvalAudit[wt.Name]); n > approvalAuditMax {
+		p.approvalAudit[wt.Name] = p.approvalAudit[wt.Name][n-approvalAuditMax:]
+	}
+
+	answer := p.Approve(wt)
+	if action == ApprovalDeny {
+		answer = p.Reject(wt)
+	}
+	return tea.Batch(answer, appendApprovalAudit(wt.Path, d))
+}
+
+// appendApprovalAudit appends a decision to the worktree's audit file.
+func appendApprovalAudit(worktreePath string, d ApprovalDecision) tea.Cmd {
+	return func() tea.Msg {
+		path := filepath.Join(worktreePath, agentStatusDir, approvalAuditFile)
+		data, err := json.Marshal(d)
+		if err != nil {
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			slog.Warn("approval audit: write failed", "err", err)
+			return nil
+		}
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			slog.Warn("approval audit: write failed", "err", err)
+			return nil
+		}
+		defer func() { _ = f.Close() }()
+		_, _ = f.Write(append(data, '\n'))
+		return nil
+	}
+}
+
+// policyDenies reports whether the policy would reject a worktree's prompt,
+// so bulk approval can skip it.
+func (p *Plugin) policyDenies(wt *Worktree) bool {
+	if p.approvalPolicy == nil || wt.Agent == nil {
+		return false
+	}
+	action, _ := p.approvalPolicy.Evaluate(permissionRequest(wt.Agent), wt.Path)
+	return action == ApprovalDeny
+}
+
+// renderApprovalAudit renders a worktree's latest automatic decisions for
+// the preview pane, newest last.
+func (p *Plugin) renderApprovalAudit(wt *Worktree, maxLines int) []string {
+	decisions := p.approvalAudit[wt.Name]
+	if len(decisions) == 0 || maxLines <= 0 {
+		return nil
+	}
+	if len(decisions) > maxLines {
+		decisions = decisions[len(decisions)-maxLines:]
+	}
+	lines := make([]string, 0, len(decisions))
+	for _, d := range decisions {
+		verdict := styles.StatusCompleted.Render("auto-allowed")
+		if d.Action == ApprovalDeny {
+			verdict = styles.StatusBlocked.Render("auto-denied")
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s", dimText(d.Time.Format("15:04:05")), verdict,
+			dimText(fmt.Sprintf("%s (%s)", d.Prompt, d.Rule))))
+	}
+	return lines
+}
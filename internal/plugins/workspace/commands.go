@@ -80,6 +80,36 @@ func (p *Plugin) Commands() []plugin.Command {
 			{ID: "cancel", Name: "Cancel", Description: "Close file picker", Context: "workspace-file-picker", Priority: 1},
 			{ID: "select", Name: "Jump", Description: "Jump to selected file", Context: "workspace-file-picker", Priority: 2},
 		}
+	case ViewModeNotifications:
+		return []plugin.Command{
+			{ID: "cancel", Name: "Close", Description: "Close notification center", Context: "workspace-notifications", Priority: 1},
+			{ID: "select", Name: "Jump", Description: "Jump to workspace", Context: "workspace-notifications", Priority: 2},
+			{ID: "clear", Name: "Clear", Description: "Clear notifications", Context: "workspace-notifications", Priority: 3},
+		}
+	case ViewModeFanOut:
+		return []plugin.Command{
+			{ID: "cancel", Name: "Cancel", Description: "Cancel launch", Context: "workspace-fan-out", Priority: 1},
+			{ID: "launch", Name: "Launch", Description: "Create workspaces and start agents", Context: "workspace-fan-out", Priority: 2},
+			{ID: "toggle-prompt", Name: "Prompt", Description: "Toggle prompt template", Context: "workspace-fan-out", Priority: 3},
+		}
+	case ViewModeMergeQueue:
+		startName := "Start"
+		if p.mergeQueueRunning {
+			startName = "Resume"
+		}
+		return []plugin.Command{
+			{ID: "cancel", Name: "Close", Description: "Close merge queue", Context: "workspace-merge-queue", Priority: 1},
+			{ID: "start", Name: startName, Description: "Land queued workspaces in order", Context: "workspace-merge-queue", Priority: 2},
+			{ID: "pause", Name: "Pause", Description: "Pause after the current item", Context: "workspace-merge-queue", Priority: 3},
+			{ID: "remove", Name: "Remove", Description: "Remove from queue", Context: "workspace-merge-queue", Priority: 4},
+		}
+	case ViewModeHistory:
+		return []plugin.Command{
+			{ID: "cancel", Name: "Close", Description: "Close output history", Context: "workspace-history", Priority: 1},
+			{ID: "search", Name: "Search", Description: "Search recorded output", Context: "workspace-history", Priority: 2},
+			{ID: "next-match", Name: "Next", Description: "Next match", Context: "workspace-history", Priority: 3},
+			{ID: "export", Name: "Export", Description: "Export as asciinema .cast", Context: "workspace-history", Priority: 4},
+		}
 	default:
 		// View toggle label changes based on current mode
 		viewToggleName := "Kanban"
@@ -169,6 +199,8 @@ func (p *Plugin) Commands() []plugin.Command {
 			{ID: "toggle-view", Name: viewToggleName, Description: "Toggle list/kanban view", Context: "workspace-list", Priority: 3},
 			{ID: "toggle-sidebar", Name: "Sidebar", Description: "Toggle sidebar visibility", Context: "workspace-list", Priority: 4},
 			{ID: "refresh", Name: "Refresh", Description: "Refresh workspace list", Context: "workspace-list", Priority: 5},
+			{ID: "notifications", Name: p.notificationsLabel(), Description: "Show agents that needed attention", Context: "workspace-list", Priority: 5},
+			{ID: "merge-queue", Name: p.mergeQueueLabel(), Description: "Show merge queue", Context: "workspace-list", Priority: 7},
 		}
 
 		// Shell-specific commands when shell is selected
@@ -202,6 +234,13 @@ func (p *Plugin) Commands() []plugin.Command {
 					plugin.Command{ID: "attach", Name: "Attach", Description: "Attach to session", Context: "workspace-list", Priority: 10},
 					plugin.Command{ID: "stop-agent", Name: "Stop", Description: "Stop agent", Context: "workspace-list", Priority: 11},
 				)
+				recordName := "Record"
+				if wt.Agent.Recording {
+					recordName = "Stop Rec"
+				}
+				cmds = append(cmds,
+					plugin.Command{ID: "toggle-recording", Name: recordName, Description: "Start or stop recording agent output", Context: "workspace-list", Priority: 15},
+				)
 				if wt.Status == StatusWaiting {
 					cmds = append(cmds,
 						plugin.Command{ID: "approve", Name: "Approve", Description: "Approve agent prompt", Context: "workspace-list", Priority: 12},
@@ -215,6 +254,8 @@ func (p *Plugin) Commands() []plugin.Command {
 				plugin.Command{ID: "delete-workspace", Name: "Delete", Description: "Delete selected workspace", Context: "workspace-list", Priority: 5},
 				plugin.Command{ID: "push", Name: "Push", Description: "Push branch to remote", Context: "workspace-list", Priority: 6},
 				plugin.Command{ID: "merge-workflow", Name: "Merge", Description: "Start merge workflow", Context: "workspace-list", Priority: 7},
+				plugin.Command{ID: "merge-queue-add", Name: "Enqueue", Description: "Add to or remove from merge queue", Context: "workspace-list", Priority: 7},
+				plugin.Command{ID: "output-history", Name: "History", Description: "Browse recorded agent output", Context: "workspace-list", Priority: 15},
 				plugin.Command{ID: "open-in-git", Name: "Git", Description: "Open in Git tab", Context: "workspace-list", Priority: 16},
 			)
 			// Task linking
@@ -264,6 +305,14 @@ func (p *Plugin) FocusContext() string {
 		return "workspace-fetch-pr"
 	case ViewModeFilePicker:
 		return "workspace-file-picker"
+	case ViewModeNotifications:
+		return "workspace-notifications"
+	case ViewModeFanOut:
+		return "workspace-fan-out"
+	case ViewModeMergeQueue:
+		return "workspace-merge-queue"
+	case ViewModeHistory:
+		return "workspace-history"
 	default:
 		if p.activePane == PanePreview {
 			return "workspace-preview"
@@ -285,6 +334,8 @@ func (p *Plugin) ConsumesTextInput() bool {
 		ViewModeTypeSelector,
 		ViewModeFetchPR:
 		return true
+	case ViewModeHistory:
+		return p.history != nil && p.history.searching
 	default:
 		return false
 	}
@@ -1,38 +1,303 @@
 package workspace
 
 import (
+	"errors"
+	"fmt"
+	"log/slog"
 	"os/exec"
 	"strings"
+	"sync"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// Conflict represents a file conflict between worktrees.
+// Conflict represents changes that are predicted to conflict when merged,
+// either between two worktrees or between a worktree and its base branch.
 type Conflict struct {
-	Worktrees []string // Names of worktrees with conflicting changes
-	Files     []string // List of conflicting files
+	Worktrees []string       // Names of worktrees with conflicting changes (one when Base is set)
+	Base      string         // Base branch the worktree conflicts with, if any
+	Files     []string       // List of conflicting files
+	Hunks     []ConflictHunk // Conflict regions, as they would appear after merging
 }
 
+// ConflictHunk is one conflicted region of a file, from the <<<<<<< marker
+// to the >>>>>>> marker.
+type ConflictHunk struct {
+	File  string
+	Lines []string
+}
+
+const (
+	// conflictCheckInterval is how often conflicts are re-predicted. Only
+	// worktrees whose commits changed are merged again.
+	conflictCheckInterval = 30 * time.Second
+
+	// Limits on the hunks kept per conflict, so a large conflicted file
+	// doesn't flood the preview.
+	maxConflictHunks     = 20
+	maxConflictHunkLines = 60
+)
+
 // ConflictsDetectedMsg signals that conflicts have been detected.
 type ConflictsDetectedMsg struct {
+	Epoch     uint64
 	Conflicts []Conflict
 	Err       error
 }
 
-// detectConflicts scans all worktrees for files modified in multiple worktrees.
-// Returns a list of conflicts where each conflict indicates which worktrees
-// are modifying the same files.
-func (p *Plugin) detectConflicts() []Conflict {
-	if len(p.worktrees) < 2 {
+// GetEpoch implements plugin.EpochMessage.
+func (m ConflictsDetectedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// conflictCheckMsg triggers a periodic conflict check.
+type conflictCheckMsg struct {
+	Epoch uint64
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m conflictCheckMsg) GetEpoch() uint64 { return m.Epoch }
+
+// mergePrediction is the cached result of test-merging two commits.
+type mergePrediction struct {
+	Files []string
+	Hunks []ConflictHunk
+}
+
+// conflictCache holds merge predictions keyed by the commits merged, so a
+// prediction is reused until either side gets a new commit.
+type conflictCache struct {
+	mu      sync.Mutex
+	entries map[string]mergePrediction
+}
+
+func newConflictCache() *conflictCache {
+	return &conflictCache{entries: make(map[string]mergePrediction)}
+}
+
+// errMergeTreeUnsupported means git is older than 2.38 and can't predict
+// merges with merge-tree --write-tree.
+var errMergeTreeUnsupported = errors.New("git merge-tree --write-tree unsupported")
+
+// conflictTarget is a worktree snapshot taken for conflict detection off
+// the UI goroutine.
+type conflictTarget struct {
+	wt   Worktree
+	head string
+	base string // Resolved base branch
+}
+
+// detectConflicts predicts merge conflicts with git merge-tree: each
+// worktree against its base branch, and each pair of worktrees against each
+// other. Worktrees without commits of their own are skipped. Falls back to
+// comparing modified file names when git can't predict merges.
+func detectConflicts(workDir string, worktrees []Worktree, cache *conflictCache, logger *slog.Logger) []Conflict {
+	var targets []conflictTarget
+	for _, wt := range worktrees {
+		if wt.IsMain || wt.IsMissing {
+			continue
+		}
+		head, err := gitRevParse(wt.Path, "HEAD")
+		if err != nil {
+			continue
+		}
+		base := resolveBaseBranch(&wt)
+		// Nothing of its own to merge
+		if isAncestor(wt.Path, head, base) {
+			continue
+		}
+		targets = append(targets, conflictTarget{wt: wt, head: head, base: base})
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	used := make(map[string]bool)
+	predict := func(a, b string) (mergePrediction, error) {
+		key := a + ".." + b
+		used[key] = true
+		cache.mu.Lock()
+		pred, ok := cache.entries[key]
+		cache.mu.Unlock()
+		if ok {
+			return pred, nil
+		}
+		pred, err := predictMerge(workDir, a, b)
+		if err != nil {
+			return pred, err
+		}
+		cache.mu.Lock()
+		cache.entries[key] = pred
+		cache.mu.Unlock()
+		return pred, nil
+	}
+
+	var conflicts []Conflict
+	for _, t := range targets {
+		baseHead, err := gitRevParse(t.wt.Path, t.base)
+		if err != nil {
+			continue
+		}
+		pred, err := predict(baseHead, t.head)
+		if errors.Is(err, errMergeTreeUnsupported) {
+			return detectFileOverlaps(worktrees, logger)
+		}
+		if err != nil {
+			logger.Debug("failed to predict merge", "worktree", t.wt.Name, "error", err)
+			continue
+		}
+		if len(pred.Files) > 0 {
+			conflicts = append(conflicts, Conflict{
+				Worktrees: []string{t.wt.Name},
+				Base:      t.base,
+				Files:     pred.Files,
+				Hunks:     pred.Hunks,
+			})
+		}
+	}
+	for i := 0; i < len(targets); i++ {
+		for j := i + 1; j < len(targets); j++ {
+			a, b := targets[i], targets[j]
+			pred, err := predict(a.head, b.head)
+			if err != nil {
+				logger.Debug("failed to predict merge", "worktrees", a.wt.Name+","+b.wt.Name, "error", err)
+				continue
+			}
+			if len(pred.Files) > 0 {
+				conflicts = append(conflicts, Conflict{
+					Worktrees: []string{a.wt.Name, b.wt.Name},
+					Files:     pred.Files,
+					Hunks:     pred.Hunks,
+				})
+			}
+		}
+	}
+
+	// Drop predictions for commits that are no longer checked out
+	cache.mu.Lock()
+	for key := range cache.entries {
+		if !used[key] {
+			delete(cache.entries, key)
+		}
+	}
+	cache.mu.Unlock()
+
+	return conflicts
+}
+
+// predictMerge test-merges two commits without touching any worktree and
+// returns the conflicted files and hunks.
+func predictMerge(workDir, a, b string) (mergePrediction, error) {
+	cmd := exec.Command("git", "merge-tree", "--write-tree", "--name-only", "--no-messages", a, b)
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return mergePrediction{}, err
+		}
+		switch exitErr.ExitCode() {
+		case 1:
+			// Conflicts; output lists them
+		case 129:
+			return mergePrediction{}, errMergeTreeUnsupported
+		default:
+			return mergePrediction{}, fmt.Errorf("git merge-tree: %s: %w", strings.TrimSpace(string(exitErr.Stderr)), err)
+		}
+	}
+
+	// Output: the merged tree's OID, then one conflicted path per line
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if err == nil || len(lines) < 2 {
+		return mergePrediction{}, nil
+	}
+	tree := lines[0]
+	var pred mergePrediction
+	seen := make(map[string]bool)
+	for _, file := range lines[1:] {
+		if file == "" || seen[file] {
+			continue
+		}
+		seen[file] = true
+		pred.Files = append(pred.Files, file)
+		if len(pred.Hunks) >= maxConflictHunks {
+			continue
+		}
+		// The merged tree holds the file with conflict markers
+		show := exec.Command("git", "cat-file", "blob", tree+":"+file)
+		show.Dir = workDir
+		content, err := show.Output()
+		if err != nil {
+			continue // Deleted on one side, binary, etc.: no markers to show
+		}
+		for _, h := range parseConflictHunks(file, string(content)) {
+			if len(pred.Hunks) >= maxConflictHunks {
+				break
+			}
+			pred.Hunks = append(pred.Hunks, h)
+		}
+	}
+	return pred, nil
+}
+
+// parseConflictHunks extracts the marked conflict regions from a merged
+// file.
+func parseConflictHunks(file, content string) []ConflictHunk {
+	var hunks []ConflictHunk
+	var cur *ConflictHunk
+	truncated := false
+	for _, line := range strings.Split(content, "\n") {
+		switch {
+		case cur == nil:
+			if strings.HasPrefix(line, "<<<<<<< ") {
+				cur = &ConflictHunk{File: file, Lines: []string{line}}
+			}
+		case strings.HasPrefix(line, ">>>>>>> "):
+			if truncated {
+				cur.Lines = append(cur.Lines, "…")
+			}
+			cur.Lines = append(cur.Lines, line)
+			hunks = append(hunks, *cur)
+			cur, truncated = nil, false
+		case len(cur.Lines) < maxConflictHunkLines:
+			cur.Lines = append(cur.Lines, line)
+		default:
+			truncated = true
+		}
+	}
+	return hunks
+}
+
+// gitRevParse resolves a revision to a commit hash.
+func gitRevParse(dir, rev string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// isAncestor reports whether commit is already contained in rev.
+func isAncestor(dir, commit, rev string) bool {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", commit, rev)
+	cmd.Dir = dir
+	return cmd.Run() == nil
+}
+
+// detectFileOverlaps finds files with uncommitted changes in more than one
+// worktree. It is the fallback when git can't predict merges.
+func detectFileOverlaps(worktrees []Worktree, logger *slog.Logger) []Conflict {
+	if len(worktrees) < 2 {
 		return nil // Need at least 2 worktrees for conflicts
 	}
 
 	// Build a map of modified files per worktree
 	filesByWorktree := make(map[string][]string)
-	for _, wt := range p.worktrees {
+	for _, wt := range worktrees {
 		files, err := getModifiedFiles(wt.Path)
 		if err != nil {
-			p.ctx.Logger.Debug("failed to get modified files",
+			logger.Debug("failed to get modified files",
 				"worktree", wt.Name, "error", err)
 			continue
 		}
@@ -68,10 +333,38 @@ func (p *Plugin) detectConflicts() []Conflict {
 
 // loadConflicts returns a command to detect conflicts across worktrees.
 func (p *Plugin) loadConflicts() tea.Cmd {
+	// Snapshot worktrees; detection runs off the UI goroutine
+	worktrees := make([]Worktree, 0, len(p.worktrees))
+	for _, wt := range p.worktrees {
+		worktrees = append(worktrees, *wt)
+	}
+	workDir, cache, logger, epoch := p.ctx.WorkDir, p.conflictCache, p.ctx.Logger, p.ctx.Epoch
 	return func() tea.Msg {
-		conflicts := p.detectConflicts()
-		return ConflictsDetectedMsg{Conflicts: conflicts}
+		conflicts := detectConflicts(workDir, worktrees, cache, logger)
+		return ConflictsDetectedMsg{Epoch: epoch, Conflicts: conflicts}
+	}
+}
+
+// scheduleConflictCheck schedules the next conflict check.
+func (p *Plugin) scheduleConflictCheck(delay time.Duration) tea.Cmd {
+	epoch := p.ctx.Epoch
+	return tea.Tick(delay, func(t time.Time) tea.Msg {
+		return conflictCheckMsg{Epoch: epoch}
+	})
+}
+
+// selectedConflicts returns the conflicts involving a worktree.
+func (p *Plugin) selectedConflicts(worktreeName string) []Conflict {
+	var out []Conflict
+	for _, c := range p.conflicts {
+		for _, name := range c.Worktrees {
+			if name == worktreeName {
+				out = append(out, c)
+				break
+			}
+		}
 	}
+	return out
 }
 
 // getModifiedFiles returns a list of files modified in the worktree.
@@ -1,8 +1,13 @@
 package workspace
 
 import (
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 )
 
@@ -209,3 +214,100 @@ func TestGetConflictingWorktrees(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectConflicts_MergeTree(t *testing.T) {
+	repo := t.TempDir()
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@test.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@test.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	// Ten-line file; each helper call rewrites one line and commits
+	edit := func(dir string, line int, text string) {
+		t.Helper()
+		path := filepath.Join(dir, "f.txt")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Split(string(content), "\n")
+		lines[line] = text
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git(dir, "commit", "-qam", text)
+	}
+
+	git(repo, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(repo, "f.txt"), []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git(repo, "add", ".")
+	git(repo, "commit", "-qm", "initial")
+
+	var worktrees []Worktree
+	for _, name := range []string{"a", "b", "c", "idle"} {
+		path := filepath.Join(t.TempDir(), name)
+		git(repo, "worktree", "add", "-q", "-b", name, path)
+		worktrees = append(worktrees, Worktree{Name: name, Path: path, Branch: name, BaseBranch: "main"})
+	}
+	edit(worktrees[0].Path, 0, "a first")
+	edit(worktrees[1].Path, 9, "b last")  // Same file as a, different region
+	edit(worktrees[2].Path, 0, "c first") // Same line as a
+	edit(repo, 9, "main last")            // Base moves under b
+
+	cache := newConflictCache()
+	conflicts := detectConflicts(repo, worktrees, cache, slog.Default())
+
+	byPair := make(map[string]Conflict)
+	for _, c := range conflicts {
+		byPair[strings.Join(c.Worktrees, "+")+"|"+c.Base] = c
+	}
+	if len(conflicts) != 2 {
+		t.Fatalf("conflicts = %+v, want a+c and b|main", conflicts)
+	}
+	ac, ok := byPair["a+c|"]
+	if !ok || len(ac.Files) != 1 || ac.Files[0] != "f.txt" || len(ac.Hunks) != 1 {
+		t.Fatalf("a+c = %+v", ac)
+	}
+	if hunk := strings.Join(ac.Hunks[0].Lines, "\n"); !strings.Contains(hunk, "a first\n=======\nc first") {
+		t.Errorf("hunk = %q", hunk)
+	}
+	if _, ok := byPair["b|main"]; !ok {
+		t.Errorf("b should conflict with main: %+v", conflicts)
+	}
+
+	// A new commit replaces that worktree's cached predictions
+	before := len(cache.entries)
+	edit(worktrees[2].Path, 0, "a first")
+	conflicts = detectConflicts(repo, worktrees, cache, slog.Default())
+	if len(conflicts) != 1 || conflicts[0].Base != "main" {
+		t.Errorf("after fix: %+v", conflicts)
+	}
+	if len(cache.entries) != before {
+		t.Errorf("cache has %d entries, want %d (stale pairs pruned)", len(cache.entries), before)
+	}
+}
+
+func TestParseConflictHunks(t *testing.T) {
+	content := "x\n<<<<<<< abc\nours\n=======\ntheirs\n>>>>>>> def\ny\n<<<<<<< abc\n" +
+		strings.Repeat("l\n", maxConflictHunkLines+5) + ">>>>>>> def\n"
+	hunks := parseConflictHunks("f", content)
+	if len(hunks) != 2 {
+		t.Fatalf("hunks = %d, want 2", len(hunks))
+	}
+	if got := strings.Join(hunks[0].Lines, "|"); got != "<<<<<<< abc|ours|=======|theirs|>>>>>>> def" {
+		t.Errorf("first hunk = %q", got)
+	}
+	// Long hunks are cut but keep the closing marker
+	long := hunks[1].Lines
+	if len(long) != maxConflictHunkLines+2 || long[len(long)-2] != "…" || long[len(long)-1] != ">>>>>>> def" {
+		t.Errorf("long hunk: %d lines, tail %q", len(long), long[len(long)-2:])
+	}
+}
@@ -0,0 +1,395 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	app "github.com/toddwbucy/hermes/internal/app"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+	"github.com/toddwbucy/hermes/internal/styles"
+	"github.com/toddwbucy/hermes/internal/ui"
+)
+
+const (
+	// fanOutStagger spaces agent startups in a batch so worktree setup
+	// and agent boot don't all compete at once.
+	fanOutStagger = 2 * time.Second
+
+	// batchFile records a worktree's launch batch, under .hermes/.
+	batchFile = "batch"
+)
+
+// fanOutState holds the fan-out launcher modal: the tasks handed over from
+// the Persephone board and the launch options.
+type fanOutState struct {
+	tasks     []appmsg.LaunchTask
+	prompts   []Prompt
+	chosen    []bool // Prompts to run; none means the task text is the prompt
+	cursor    int    // Prompt list cursor
+	agentIdx  int    // Into fanOutAgents
+	skipPerms bool
+}
+
+// fanOutItem is one worktree to create and start an agent in.
+type fanOutItem struct {
+	Batch     string
+	Task      appmsg.LaunchTask
+	Prompt    *Prompt
+	Branch    string
+	AgentType AgentType
+	SkipPerms bool
+}
+
+// fanOutAgents are the agent types the launcher offers; a batch without an
+// agent would just be empty worktrees.
+var fanOutAgents = AgentTypeOrder[:len(AgentTypeOrder)-1]
+
+// fanOutCreatedMsg reports one batch worktree created (or failed).
+type fanOutCreatedMsg struct {
+	Epoch    uint64
+	Item     fanOutItem
+	Worktree *Worktree
+	Err      error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m fanOutCreatedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// fanOutNextMsg starts the next queued batch item after the stagger delay.
+type fanOutNextMsg struct {
+	Epoch uint64
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m fanOutNextMsg) GetEpoch() uint64 { return m.Epoch }
+
+// openFanOut opens the launcher for tasks sent from the Persephone board.
+func (p *Plugin) openFanOut(tasks []appmsg.LaunchTask) {
+	if len(tasks) == 0 {
+		return
+	}
+	prompts := p.loadPrompts()
+	p.fanOut = &fanOutState{
+		tasks:   tasks,
+		prompts: prompts,
+		chosen:  make([]bool, len(prompts)),
+	}
+	p.viewMode = ViewModeFanOut
+}
+
+// fanOutItems expands the launcher selection into one item per task and chosen
+// prompt, so N tasks with one prompt and one task with N prompt variants
+// both fan out to N worktrees.
+func (p *Plugin) fanOutItems(s *fanOutState, batch string) []fanOutItem {
+	var prompts []*Prompt
+	for i := range s.prompts {
+		if s.chosen[i] {
+			prompts = append(prompts, &s.prompts[i])
+		}
+	}
+	agent := fanOutAgents[s.agentIdx]
+
+	var items []fanOutItem
+	for _, task := range s.tasks {
+		branch := p.deriveBranchName(task.Key, task.Title)
+		if len(prompts) == 0 {
+			items = append(items, fanOutItem{
+				Batch: batch, Task: task, Prompt: taskPrompt(task), Branch: branch,
+				AgentType: agent, SkipPerms: s.skipPerms,
+			})
+			continue
+		}
+		for _, pr := range prompts {
+			b := branch
+			if len(prompts) > 1 {
+				b += "-" + SanitizeBranchName(pr.Name)
+			}
+			items = append(items, fanOutItem{
+				Batch: batch, Task: task, Prompt: withTask(pr, task), Branch: b,
+				AgentType: agent, SkipPerms: s.skipPerms,
+			})
+		}
+	}
+	return items
+}
+
+// taskPromptBody is the prompt for a task launched without a prompt
+// template: its title, description, acceptance criteria and latest handoff.
+const taskPromptBody = `Task {{.Ticket}}: {{.Title}}
+{{- with trim .Description}}
+
+{{.}}
+{{- end}}
+{{- with trim .Acceptance}}
+
+Acceptance criteria:
+{{.}}
+{{- end}}
+{{- with .Handoff}}
+
+Latest handoff:
+{{.}}
+{{- end}}`
+
+// taskPrompt builds the agent prompt for a task launched without a
+// prompt template.
+func taskPrompt(task appmsg.LaunchTask) *Prompt {
+	return &Prompt{Name: task.Key, TicketMode: TicketNone, Body: taskPromptBody, Task: &task}
+}
+
+// withTask returns a copy of pr carrying the task's details, so a prompt
+// chosen for several tasks renders each with its own.
+func withTask(pr *Prompt, task appmsg.LaunchTask) *Prompt {
+	cp := *pr
+	cp.Task = &task
+	return &cp
+}
+
+// launchFanOut queues the launcher's items as a new batch and starts the
+// first one. Later items start fanOutStagger apart.
+func (p *Plugin) launchFanOut() tea.Cmd {
+	s := p.fanOut
+	p.fanOut = nil
+	p.viewMode = ViewModeList
+	if s == nil {
+		return nil
+	}
+	batch := time.Now().Format("20060102-150405")
+	items := p.fanOutItems(s, batch)
+	idle := len(p.fanOutQueue) == 0
+	p.fanOutQueue = append(p.fanOutQueue, items...)
+
+	p.toastMessage = fmt.Sprintf("Launching %d workspaces (batch %s)", len(items), batchLabel(batch))
+	p.toastTime = time.Now()
+	if !idle {
+		return nil // Joins the batch already starting up
+	}
+	return p.fanOutNext()
+}
+
+// fanOutNext creates the next queued worktree.
+func (p *Plugin) fanOutNext() tea.Cmd {
+	if len(p.fanOutQueue) == 0 {
+		return nil
+	}
+	item := p.fanOutQueue[0]
+	epoch := p.ctx.Epoch
+	return func() tea.Msg {
+		branch := uniqueBranchName(p.ctx.WorkDir, item.Branch)
+		wt, err := p.doCreateWorktree(branch, "", item.Task.Key, item.Task.Title, item.AgentType)
+		if err != nil {
+			return fanOutCreatedMsg{Epoch: epoch, Item: item, Worktree: wt, Err: err}
+		}
+		wt.BatchID = item.Batch
+		if err := saveBatch(wt.Path, item.Batch); err != nil {
+			p.ctx.Logger.Warn("failed to save batch", "path", wt.Path, "error", err)
+		}
+		// A failed post-create hook keeps the worktree but skips its agent
+		err = runWorktreeHook(p.ctx.WorkDir, HookPostCreate, wt.Path, wt.Branch)
+		return fanOutCreatedMsg{Epoch: epoch, Item: item, Worktree: wt, Err: err}
+	}
+}
+
+// handleFanOutCreated starts the agent in a new batch worktree, reports the
+// task as started, and schedules the next item.
+func (p *Plugin) handleFanOutCreated(msg fanOutCreatedMsg) tea.Cmd {
+	if len(p.fanOutQueue) > 0 {
+		p.fanOutQueue = p.fanOutQueue[1:]
+	}
+
+	var cmds []tea.Cmd
+	if msg.Err != nil {
+		if msg.Worktree != nil {
+			p.worktrees = append(p.worktrees, msg.Worktree)
+		}
+		errMsg := fmt.Sprintf("Launch %s failed: %v", msg.Item.Task.Key, msg.Err)
+		cmds = append(cmds, func() tea.Msg {
+			return app.ToastMsg{Message: errMsg, Duration: 5 * time.Second, IsError: true}
+		})
+	} else {
+		p.worktrees = append(p.worktrees, msg.Worktree)
+		p.toastMessage = fmt.Sprintf("Started %s (%d left)", msg.Worktree.Name, len(p.fanOutQueue))
+		p.toastTime = time.Now()
+		cmds = append(cmds,
+			p.StartAgentWithOptions(msg.Worktree, msg.Item.AgentType, msg.Item.SkipPerms, msg.Item.Prompt),
+			func() tea.Msg { return appmsg.TasksStartedMsg{Keys: []string{msg.Item.Task.Key}} },
+		)
+	}
+
+	if len(p.fanOutQueue) > 0 {
+		epoch := p.ctx.Epoch
+		cmds = append(cmds, tea.Tick(fanOutStagger, func(time.Time) tea.Msg {
+			return fanOutNextMsg{Epoch: epoch}
+		}))
+	}
+	return tea.Batch(cmds...)
+}
+
+// uniqueBranchName appends -2, -3, ... to name while a branch by that name
+// exists.
+func uniqueBranchName(workDir, name string) string {
+	candidate := name
+	for i := 2; branchExists(workDir, candidate); i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return candidate
+}
+
+// saveBatch records the batch a worktree was launched in.
+func saveBatch(worktreePath, batch string) error {
+	dir := filepath.Join(worktreePath, agentStatusDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, batchFile), []byte(batch+"\n"), 0644)
+}
+
+// loadBatch reads the batch a worktree was launched in, if any.
+func loadBatch(worktreePath string) string {
+	content, err := os.ReadFile(filepath.Join(worktreePath, agentStatusDir, batchFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// batchLabel shortens a batch ID (a launch timestamp) to its time of day,
+// e.g. "⧉14:05".
+func batchLabel(batch string) string {
+	if t, err := time.ParseInLocation("20060102-150405", batch, time.Local); err == nil {
+		return "⧉" + t.Format("15:04")
+	}
+	return "⧉" + batch
+}
+
+// batchSummaries describes each batch's progress, newest first, e.g.
+// "⧉14:05 3/5 ready".
+func (p *Plugin) batchSummaries() []string {
+	type progress struct{ done, total int }
+	byBatch := make(map[string]*progress)
+	var batches []string
+	for _, wt := range p.worktrees {
+		if wt.BatchID == "" {
+			continue
+		}
+		pr, ok := byBatch[wt.BatchID]
+		if !ok {
+			pr = &progress{}
+			byBatch[wt.BatchID] = pr
+			batches = append(batches, wt.BatchID)
+		}
+		pr.total++
+		if wt.Status == StatusDone {
+			pr.done++
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(batches)))
+
+	out := make([]string, len(batches))
+	for i, b := range batches {
+		out[i] = fmt.Sprintf("%s %d/%d ready", batchLabel(b), byBatch[b].done, byBatch[b].total)
+	}
+	return out
+}
+
+// handleFanOutKeys handles keys in the fan-out launcher.
+func (p *Plugin) handleFanOutKeys(msg tea.KeyMsg) tea.Cmd {
+	s := p.fanOut
+	if s == nil {
+		p.viewMode = ViewModeList
+		return nil
+	}
+	switch msg.String() {
+	case "esc", "q":
+		p.fanOut = nil
+		p.viewMode = ViewModeList
+	case "j", "down":
+		if s.cursor < len(s.prompts)-1 {
+			s.cursor++
+		}
+	case "k", "up":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+	case " ":
+		if s.cursor < len(s.chosen) {
+			s.chosen[s.cursor] = !s.chosen[s.cursor]
+		}
+	case "h", "left":
+		s.agentIdx = (s.agentIdx + len(fanOutAgents) - 1) % len(fanOutAgents)
+	case "l", "right":
+		s.agentIdx = (s.agentIdx + 1) % len(fanOutAgents)
+	case "s":
+		s.skipPerms = !s.skipPerms
+	case "enter":
+		return p.launchFanOut()
+	}
+	return nil
+}
+
+// renderFanOutModal renders the fan-out launcher over the list.
+func (p *Plugin) renderFanOutModal(background string) string {
+	s := p.fanOut
+	if s == nil {
+		return background
+	}
+	var sb strings.Builder
+	sb.WriteString(styles.ModalTitle.Render("Launch Agents"))
+	sb.WriteString("\n\n")
+
+	fmt.Fprintf(&sb, "Tasks (%d)\n", len(s.tasks))
+	for i, t := range s.tasks {
+		if i == 8 {
+			sb.WriteString(dimText(fmt.Sprintf("  … %d more", len(s.tasks)-i)) + "\n")
+			break
+		}
+		sb.WriteString("  " + styles.Muted.Render(t.Key) + " " + truncateString(t.Title, 50) + "\n")
+	}
+
+	agent := fanOutAgents[s.agentIdx]
+	sb.WriteString("\nAgent  ◂ " + AgentDisplayNames[agent] + " ▸\n")
+	check := "[ ]"
+	if s.skipPerms {
+		check = "[x]"
+	}
+	sb.WriteString("Skip permissions  " + check + "\n")
+
+	sb.WriteString("\nPrompt templates " + dimText("(none: task text)") + "\n")
+	if len(s.prompts) == 0 {
+		sb.WriteString(dimText("  No prompts configured") + "\n")
+	}
+	for i, pr := range s.prompts {
+		box := "[ ]"
+		if s.chosen[i] {
+			box = "[x]"
+		}
+		line := box + " " + pr.Name
+		if i == s.cursor {
+			sb.WriteString(styles.ListItemSelected.Render("▸ "+line) + "\n")
+		} else {
+			sb.WriteString("  " + line + "\n")
+		}
+	}
+
+	n := len(p.fanOutItems(s, ""))
+	fmt.Fprintf(&sb, "\n→ %d workspaces, started %s apart\n\n", n, fanOutStagger)
+	sb.WriteString(dimText("space prompt · h/l agent · s skip perms · enter launch · esc cancel"))
+
+	modalWidth := 72
+	if modalWidth > p.width-10 {
+		modalWidth = p.width - 10
+	}
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Width(modalWidth)
+
+	return ui.OverlayModal(background, modalStyle.Render(sb.String()), p.width, p.height)
+}
@@ -0,0 +1,138 @@
+package workspace
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
+	"github.com/toddwbucy/hermes/internal/plugin"
+)
+
+func TestFanOutItems(t *testing.T) {
+	p := &Plugin{}
+	tasks := []appmsg.LaunchTask{
+		{Key: "PER-1", Title: "Add login", Description: "OAuth flow", Acceptance: "- tests pass", Handoff: "Done:\n- schema"},
+		{Key: "PER-2", Title: "Fix logout"},
+	}
+	s := &fanOutState{
+		tasks:   tasks,
+		prompts: []Prompt{{Name: "Plan first"}, {Name: "TDD"}},
+		chosen:  []bool{false, false},
+	}
+
+	// No prompt chosen: one worktree per task, prompted with the task text
+	items := p.fanOutItems(s, "b")
+	if len(items) != 2 || items[0].Branch != "PER-1-add-login" || items[1].Branch != "PER-2-fix-logout" {
+		t.Fatalf("items = %+v", items)
+	}
+	body, err := RenderPrompt(items[0].Prompt.Body, promptData(items[0].Prompt, &Worktree{}), nil)
+	if err != nil {
+		t.Fatalf("render task prompt: %v", err)
+	}
+	for _, want := range []string{"PER-1: Add login", "OAuth flow", "Acceptance criteria:\n- tests pass", "Latest handoff:\nDone:\n- schema"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("task prompt %q missing %q", body, want)
+		}
+	}
+	if items[0].AgentType != fanOutAgents[0] {
+		t.Errorf("agent = %q", items[0].AgentType)
+	}
+
+	// One prompt: same branches, template prompt
+	s.chosen[1] = true
+	items = p.fanOutItems(s, "b")
+	if len(items) != 2 || items[0].Branch != "PER-1-add-login" || items[0].Prompt.Name != "TDD" {
+		t.Fatalf("items = %+v", items)
+	}
+	if items[0].Prompt.Task.Key != "PER-1" || items[1].Prompt.Task.Key != "PER-2" || s.prompts[1].Task != nil {
+		t.Errorf("each item should carry its own task, leaving the shared prompt alone")
+	}
+
+	// Several prompts: one variant per prompt, named apart
+	s.chosen[0] = true
+	items = p.fanOutItems(s, "b")
+	if len(items) != 4 || items[0].Branch != "PER-1-add-login-plan-first" || items[1].Branch != "PER-1-add-login-tdd" {
+		t.Fatalf("items = %+v", items)
+	}
+}
+
+func TestFanOutKeys(t *testing.T) {
+	p := &Plugin{}
+	p.fanOut = &fanOutState{
+		tasks:   []appmsg.LaunchTask{{Key: "PER-1"}},
+		prompts: []Prompt{{Name: "a"}, {Name: "b"}},
+		chosen:  []bool{false, false},
+	}
+	p.viewMode = ViewModeFanOut
+
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'j'}},
+		{Type: tea.KeySpace, Runes: []rune{' '}},
+		{Type: tea.KeyRunes, Runes: []rune{'h'}},
+		{Type: tea.KeyRunes, Runes: []rune{'s'}},
+	}
+	for _, k := range keys {
+		p.handleFanOutKeys(k)
+	}
+	s := p.fanOut
+	if s.chosen[0] || !s.chosen[1] {
+		t.Errorf("chosen = %v", s.chosen)
+	}
+	if s.agentIdx != len(fanOutAgents)-1 || !s.skipPerms {
+		t.Errorf("agentIdx %d, skipPerms %v", s.agentIdx, s.skipPerms)
+	}
+
+	p.handleFanOutKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.fanOut != nil || p.viewMode != ViewModeList {
+		t.Error("esc should close the launcher")
+	}
+}
+
+func TestHandleFanOutCreated(t *testing.T) {
+	p := &Plugin{ctx: &plugin.Context{}}
+	first := fanOutItem{Batch: "20260102-150405", Task: appmsg.LaunchTask{Key: "PER-1"}}
+	second := fanOutItem{Batch: "20260102-150405", Task: appmsg.LaunchTask{Key: "PER-2"}}
+	p.fanOutQueue = []fanOutItem{first, second}
+
+	// A failed item is dropped and the batch carries on
+	if cmd := p.handleFanOutCreated(fanOutCreatedMsg{Item: first, Err: errors.New("exists")}); cmd == nil {
+		t.Fatal("expected error toast and next-item tick")
+	}
+	if len(p.fanOutQueue) != 1 || len(p.worktrees) != 0 {
+		t.Fatalf("queue %d, worktrees %d", len(p.fanOutQueue), len(p.worktrees))
+	}
+
+	wt := &Worktree{Name: "PER-2-x", BatchID: second.Batch}
+	p.handleFanOutCreated(fanOutCreatedMsg{Item: second, Worktree: wt})
+	if len(p.fanOutQueue) != 0 || len(p.worktrees) != 1 {
+		t.Fatalf("queue %d, worktrees %d", len(p.fanOutQueue), len(p.worktrees))
+	}
+}
+
+func TestBatchSummaries(t *testing.T) {
+	p := &Plugin{worktrees: []*Worktree{
+		{Name: "a", BatchID: "20260102-090000", Status: StatusDone},
+		{Name: "b", BatchID: "20260102-090000", Status: StatusActive},
+		{Name: "c", BatchID: "20260102-140500", Status: StatusDone},
+		{Name: "d"},
+	}}
+	got := p.batchSummaries()
+	if len(got) != 2 || got[0] != "⧉14:05 1/1 ready" || got[1] != "⧉09:00 1/2 ready" {
+		t.Errorf("summaries = %q", got)
+	}
+}
+
+func TestBatchFileRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	if got := loadBatch(dir); got != "" {
+		t.Errorf("missing batch = %q", got)
+	}
+	if err := saveBatch(dir, "20260102-150405"); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadBatch(dir); got != "20260102-150405" {
+		t.Errorf("batch = %q", got)
+	}
+}
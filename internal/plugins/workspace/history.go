@@ -0,0 +1,302 @@
+package workspace
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/toddwbucy/hermes/internal/styles"
+	"github.com/toddwbucy/hermes/internal/ui"
+)
+
+// historyState is the output history viewer for one worktree's recording.
+type historyState struct {
+	name    string
+	path    string
+	target  string // tmux pane of the agent, for the .cast size
+	lines   []historyLine
+	loading bool
+	err     string
+	offset  int // First visible line
+
+	searching bool // Typing a query
+	query     string
+	matches   []int // Indexes of lines containing query
+	matchIdx  int
+}
+
+// historyLoadedMsg delivers a worktree's recorded output as lines.
+type historyLoadedMsg struct {
+	Epoch         uint64
+	WorkspaceName string
+	Lines         []historyLine
+	Err           error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m historyLoadedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// historyExportedMsg reports a recording exported to a .cast file.
+type historyExportedMsg struct {
+	Path string
+	Err  error
+}
+
+// openHistory opens the viewer for the selected worktree and loads its
+// recording.
+func (p *Plugin) openHistory(wt *Worktree) tea.Cmd {
+	if wt == nil {
+		return nil
+	}
+	p.history = &historyState{name: wt.Name, path: wt.Path, loading: true}
+	if wt.Agent != nil {
+		p.history.target = agentTarget(wt.Agent)
+	}
+	p.viewMode = ViewModeHistory
+
+	name, path, epoch := wt.Name, wt.Path, p.ctx.Epoch
+	return func() tea.Msg {
+		chunks, err := loadRecordedChunks(path)
+		return historyLoadedMsg{Epoch: epoch, WorkspaceName: name, Lines: recordedLines(chunks), Err: err}
+	}
+}
+
+// handleHistoryLoaded shows the loaded lines, scrolled to the end.
+func (p *Plugin) handleHistoryLoaded(msg historyLoadedMsg) {
+	h := p.history
+	if h == nil || h.name != msg.WorkspaceName {
+		return
+	}
+	h.loading = false
+	h.lines = msg.Lines
+	if msg.Err != nil {
+		h.err = msg.Err.Error()
+	}
+	h.offset = max(0, len(h.lines)-p.historyPageSize())
+}
+
+// historyPageSize is the number of lines the viewer shows.
+func (p *Plugin) historyPageSize() int {
+	return max(5, p.height-14)
+}
+
+// search finds the lines containing the query, ignoring case.
+func (h *historyState) search() {
+	h.matches = nil
+	h.matchIdx = 0
+	if h.query == "" {
+		return
+	}
+	q := strings.ToLower(h.query)
+	for i, l := range h.lines {
+		if strings.Contains(strings.ToLower(l.Text), q) {
+			h.matches = append(h.matches, i)
+		}
+	}
+	// Start from the first match at or below the top of the view
+	for i, m := range h.matches {
+		if m >= h.offset {
+			h.matchIdx = i
+			break
+		}
+	}
+}
+
+// showMatch scrolls the current match into view.
+func (h *historyState) showMatch(pageSize int) {
+	if len(h.matches) == 0 {
+		return
+	}
+	h.offset = h.matches[h.matchIdx] - pageSize/3
+	h.clamp(pageSize)
+}
+
+func (h *historyState) clamp(pageSize int) {
+	h.offset = max(0, min(h.offset, len(h.lines)-pageSize))
+}
+
+// handleHistoryKeys handles keys in the output history viewer.
+func (p *Plugin) handleHistoryKeys(msg tea.KeyMsg) tea.Cmd {
+	h := p.history
+	if h == nil {
+		p.viewMode = ViewModeList
+		return nil
+	}
+	page := p.historyPageSize()
+	key := msg.String()
+
+	if h.searching {
+		switch key {
+		case "esc":
+			h.searching = false
+		case "enter":
+			h.searching = false
+			h.search()
+			h.showMatch(page)
+		case "backspace":
+			if len(h.query) > 0 {
+				h.query = h.query[:len(h.query)-1]
+			}
+		default:
+			if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+				h.query += string(msg.Runes)
+			}
+		}
+		return nil
+	}
+
+	switch key {
+	case "esc", "q":
+		p.history = nil
+		p.viewMode = ViewModeList
+	case "j", "down":
+		h.offset++
+	case "k", "up":
+		h.offset--
+	case "ctrl+d", "pgdown":
+		h.offset += page / 2
+	case "ctrl+u", "pgup":
+		h.offset -= page / 2
+	case "g", "home":
+		h.offset = 0
+	case "G", "end":
+		h.offset = len(h.lines)
+	case "/":
+		h.searching = true
+		h.query = ""
+		h.matches = nil
+	case "n":
+		if len(h.matches) > 0 {
+			h.matchIdx = (h.matchIdx + 1) % len(h.matches)
+			h.showMatch(page)
+		}
+	case "N":
+		if len(h.matches) > 0 {
+			h.matchIdx = (h.matchIdx - 1 + len(h.matches)) % len(h.matches)
+			h.showMatch(page)
+		}
+	case "e":
+		return p.exportHistory()
+	}
+	h.clamp(page)
+	return nil
+}
+
+// exportHistory writes the recording as an asciinema v2 .cast file next to
+// the logs.
+func (p *Plugin) exportHistory() tea.Cmd {
+	h := p.history
+	if h == nil || h.loading {
+		return nil
+	}
+	name, path, target := h.name, h.path, h.target
+	return func() tea.Msg {
+		chunks, err := loadRecordedChunks(path)
+		if err != nil {
+			return historyExportedMsg{Err: err}
+		}
+		if len(chunks) == 0 {
+			return historyExportedMsg{Err: fmt.Errorf("nothing recorded for %s", name)}
+		}
+		width, height := paneSize(target)
+		castPath := filepath.Join(path, agentStatusDir, recordingDir,
+			sanitizeName(name)+"-"+time.Now().Format(recordingStampFormat)+".cast")
+		if err := writeCast(castPath, name, width, height, chunks); err != nil {
+			return historyExportedMsg{Err: err}
+		}
+		return historyExportedMsg{Path: castPath}
+	}
+}
+
+// historyTime formats a line's timestamp, with the date when it isn't today.
+func historyTime(t time.Time) string {
+	if t.IsZero() {
+		return strings.Repeat(" ", 8)
+	}
+	now := time.Now()
+	if t.Year() == now.Year() && t.YearDay() == now.YearDay() {
+		return t.Format("15:04:05")
+	}
+	return t.Format("01-02 15:04")
+}
+
+// renderHistoryModal renders the output history viewer over the list.
+func (p *Plugin) renderHistoryModal(background string) string {
+	h := p.history
+	modalWidth := max(40, p.width-10)
+	textWidth := modalWidth - 20
+
+	var sb strings.Builder
+	sb.WriteString(styles.ModalTitle.Render("Output History: " + h.name))
+	sb.WriteString("\n")
+
+	state := "not recording"
+	if wt := p.findWorktree(h.name); wt != nil && wt.Agent != nil && wt.Agent.Recording {
+		state = "recording"
+	}
+	sb.WriteString(styles.Muted.Render(fmt.Sprintf("%d lines · %s", len(h.lines), state)))
+	sb.WriteString("\n")
+	switch {
+	case h.searching:
+		sb.WriteString("/" + h.query + "█")
+	case h.query != "" && len(h.matches) == 0:
+		sb.WriteString(styles.StatusDeleted.Render("No match for " + h.query))
+	case h.query != "":
+		sb.WriteString(styles.Muted.Render(fmt.Sprintf("/%s  %d of %d", h.query, h.matchIdx+1, len(h.matches))))
+	}
+	sb.WriteString("\n\n")
+
+	page := p.historyPageSize()
+	switch {
+	case h.loading:
+		sb.WriteString(styles.Muted.Render("Loading..."))
+	case h.err != "":
+		sb.WriteString(styles.StatusDeleted.Render(h.err))
+	case len(h.lines) == 0:
+		sb.WriteString(styles.Muted.Render("Nothing recorded. Press w on a workspace with an agent to record it."))
+	}
+
+	current := -1
+	if len(h.matches) > 0 {
+		current = h.matches[h.matchIdx]
+	}
+	matched := make(map[int]bool, len(h.matches))
+	for _, m := range h.matches {
+		matched[m] = true
+	}
+	lastStamp := ""
+	end := min(len(h.lines), h.offset+page)
+	for i := h.offset; i < end; i++ {
+		l := h.lines[i]
+		// Only show the time when it changes
+		stamp := historyTime(l.Time)
+		shown := stamp
+		if stamp == lastStamp {
+			shown = strings.Repeat(" ", len(stamp))
+		}
+		lastStamp = stamp
+
+		text := truncateString(l.Text, textWidth)
+		switch {
+		case i == current:
+			text = styles.ListItemSelected.Render(text)
+		case matched[i]:
+			text = styles.StatusModified.Render(text)
+		}
+		sb.WriteString(styles.Muted.Render(shown) + "  " + text + "\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(dimText("j/k scroll · g/G top/end · / search · n/N next/prev · e export .cast · esc close"))
+
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Width(modalWidth)
+
+	return ui.OverlayModal(background, modalStyle.Render(sb.String()), p.width, p.height)
+}
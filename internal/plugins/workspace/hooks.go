@@ -0,0 +1,239 @@
+package workspace
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/tty"
+)
+
+// HookEvent is a point in a worktree's lifecycle that can run commands.
+type HookEvent string
+
+const (
+	HookPostCreate    HookEvent = "post-create"     // After the worktree is created; blocks the agent start
+	HookPreAgentStart HookEvent = "pre-agent-start" // Before a new agent session; blocks the agent
+	HookPreMerge      HookEvent = "pre-merge"       // Before pushing or merging; blocks the merge
+	HookPreDelete     HookEvent = "pre-delete"      // Before the worktree is removed; blocks the delete
+)
+
+var hookEvents = []HookEvent{HookPostCreate, HookPreAgentStart, HookPreMerge, HookPreDelete}
+
+const (
+	worktreeHooksFile  = "worktree-hooks" // Under the main repo's .hermes/ directory
+	hookDir            = "hooks"          // Scripts and logs, under the worktree's .hermes/ directory
+	hookTmuxSession    = "hermes-hooks"   // Detached session holding one window per hook run
+	defaultHookTimeout = 10 * time.Minute
+	hookPollInterval   = 200 * time.Millisecond
+)
+
+// WorktreeHooks holds the lifecycle commands for a project. Commands for an
+// event run in file order and stop at the first failure.
+type WorktreeHooks struct {
+	Commands map[HookEvent][]string
+	Timeout  time.Duration // Per event run
+}
+
+// HookError reports a hook that failed or timed out.
+type HookError struct {
+	Event   HookEvent
+	Reason  string // "exit status 1", "timed out after 10m0s", ...
+	Output  string // Captured output
+	LogPath string
+}
+
+func (e *HookError) Error() string {
+	return fmt.Sprintf("%s hook %s (log: %s)", e.Event, e.Reason, e.LogPath)
+}
+
+// parseWorktreeHooksFile reads .hermes/worktree-hooks from the main repo.
+// Each line is "<event>: <command>" or "timeout: <duration or seconds>".
+// Returns nil if the file doesn't exist. Skips comments (lines starting with #).
+func parseWorktreeHooksFile(mainRepoPath string) (*WorktreeHooks, error) {
+	path := filepath.Join(mainRepoPath, agentStatusDir, worktreeHooksFile)
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	hooks := &WorktreeHooks{Commands: make(map[HookEvent][]string), Timeout: defaultHookTimeout}
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%s:%d: expected \"<event>: <command>\"", worktreeHooksFile, lineNum)
+		}
+
+		if key == "timeout" {
+			timeout, err := parseHookTimeout(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", worktreeHooksFile, lineNum, err)
+			}
+			hooks.Timeout = timeout
+			continue
+		}
+
+		event := HookEvent(key)
+		if !slices.Contains(hookEvents, event) {
+			return nil, fmt.Errorf("%s:%d: unknown hook %q", worktreeHooksFile, lineNum, key)
+		}
+		hooks.Commands[event] = append(hooks.Commands[event], value)
+	}
+
+	return hooks, scanner.Err()
+}
+
+// parseHookTimeout accepts a Go duration ("5m") or a number of seconds.
+func parseHookTimeout(s string) (time.Duration, error) {
+	if secs, err := strconv.Atoi(s); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q", s)
+	}
+	return d, nil
+}
+
+// runWorktreeHook runs the project's commands for event in the worktree at
+// path. It blocks until they finish, so call it from a tea.Cmd. Returns nil
+// when the project defines no commands for the event.
+func runWorktreeHook(mainRepoPath string, event HookEvent, path, branch string) error {
+	hooks, err := parseWorktreeHooksFile(mainRepoPath)
+	if err != nil {
+		return &HookError{Event: event, Reason: "not run: " + err.Error(), LogPath: filepath.Join(agentStatusDir, worktreeHooksFile)}
+	}
+	if hooks == nil || len(hooks.Commands[event]) == 0 {
+		return nil
+	}
+
+	// Same isolated environment as the agent, plus setup-script variables
+	env := BuildEnvOverrides(mainRepoPath)
+	env["MAIN_WORKTREE"] = mainRepoPath
+	env["WORKTREE_BRANCH"] = branch
+	env["WORKTREE_PATH"] = path
+	env["HERMES_HOOK"] = string(event)
+
+	return runHook(event, hooks.Commands[event], path, env, hooks.Timeout, tty.IsTmux())
+}
+
+// runHook writes the commands to a script under path/.hermes/hooks and runs
+// it, in a window of the hermes-hooks tmux session when useTmux is set.
+// Output goes to the window and to <event>.log; the script records its exit
+// status in <event>.exit, which is polled until timeout.
+func runHook(event HookEvent, commands []string, path string, env map[string]string, timeout time.Duration, useTmux bool) error {
+	dir := filepath.Join(path, agentStatusDir, hookDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return &HookError{Event: event, Reason: "not run: " + err.Error()}
+	}
+	base := filepath.Join(dir, string(event))
+	script, logPath, exitPath := base+".sh", base+".log", base+".exit"
+	_ = os.Remove(exitPath)
+
+	// The command group runs under set -e in a subshell of the pipeline;
+	// pipefail makes its status, not tee's, the recorded one.
+	var sb strings.Builder
+	sb.WriteString("#!/bin/bash\n")
+	sb.WriteString("cd " + shellQuote(path) + " || exit 1\n")
+	if envCmd := GenerateSingleEnvCommand(env); envCmd != "" {
+		sb.WriteString(envCmd + "\n")
+	}
+	sb.WriteString("set -o pipefail\n{\nset -e\n")
+	for _, c := range commands {
+		sb.WriteString(c + "\n")
+	}
+	sb.WriteString("} 2>&1 | tee " + shellQuote(logPath) + "\n")
+	sb.WriteString("code=$?\n")
+	sb.WriteString("echo $code > " + shellQuote(exitPath+".tmp") + " && mv " + shellQuote(exitPath+".tmp") + " " + shellQuote(exitPath) + "\n")
+	// Keep a failed window open for inspection
+	sb.WriteString("if [ $code -ne 0 ]; then echo; echo \"" + string(event) + " hook failed (exit $code). Press enter to close.\"; read -r _; fi\n")
+	if err := os.WriteFile(script, []byte(sb.String()), 0755); err != nil {
+		return &HookError{Event: event, Reason: "not run: " + err.Error(), LogPath: logPath}
+	}
+
+	fail := func(reason string) error {
+		output, _ := os.ReadFile(logPath)
+		return &HookError{Event: event, Reason: reason, Output: string(output), LogPath: logPath}
+	}
+
+	if !useTmux {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, "bash", script)
+		// Children keep the pipes open after bash is killed
+		cmd.WaitDelay = 2 * time.Second
+		if err := cmd.Run(); err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fail(fmt.Sprintf("timed out after %s", timeout))
+		}
+	} else {
+		windowID, err := startHookWindow(sanitizeName(filepath.Base(path))+"-"+string(event), path, script)
+		if err != nil {
+			return &HookError{Event: event, Reason: "not run: " + err.Error(), LogPath: logPath}
+		}
+		deadline := time.Now().Add(timeout)
+		for {
+			if _, err := os.Stat(exitPath); err == nil {
+				break
+			}
+			if time.Now().After(deadline) {
+				_ = exec.Command("tmux", "kill-window", "-t", windowID).Run()
+				return fail(fmt.Sprintf("timed out after %s", timeout))
+			}
+			time.Sleep(hookPollInterval)
+		}
+	}
+
+	data, err := os.ReadFile(exitPath)
+	if err != nil {
+		return fail("exited without a status")
+	}
+	if code := strings.TrimSpace(string(data)); code != "0" {
+		return fail("exit status " + code)
+	}
+	return nil
+}
+
+// startHookWindow opens a detached window running script and returns its ID.
+// The hooks session is created on first use.
+func startHookWindow(name, dir, script string) (string, error) {
+	run := func(args ...string) (string, error) {
+		args = append(args, "-d", "-P", "-F", "#{window_id}", "-n", name, "-c", dir, "bash", script)
+		output, err := exec.Command("tmux", args...).CombinedOutput()
+		if err != nil {
+			return "", fmt.Errorf("tmux %s: %s: %w", args[0], strings.TrimSpace(string(output)), err)
+		}
+		return strings.TrimSpace(string(output)), nil
+	}
+	if !sessionExists(hookTmuxSession) {
+		if id, err := run("new-session", "-s", hookTmuxSession); err == nil {
+			return id, nil
+		}
+		// Another hook created the session first
+	}
+	return run("new-window", "-t", hookTmuxSession+":")
+}
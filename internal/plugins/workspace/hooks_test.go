@@ -0,0 +1,168 @@
+package workspace
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/toddwbucy/hermes/internal/plugin"
+	"github.com/toddwbucy/hermes/internal/tty"
+)
+
+func writeHooksFile(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, agentStatusDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, agentStatusDir, worktreeHooksFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseWorktreeHooksFile(t *testing.T) {
+	dir := t.TempDir()
+	if hooks, err := parseWorktreeHooksFile(dir); hooks != nil || err != nil {
+		t.Fatalf("missing file: %+v, %v", hooks, err)
+	}
+
+	writeHooksFile(t, dir, `# Setup
+post-create: npm ci
+post-create: cp "$MAIN_WORKTREE/.env" .env
+
+pre-delete: docker compose down -v
+timeout: 90
+`)
+	hooks, err := parseWorktreeHooksFile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[HookEvent][]string{
+		HookPostCreate: {"npm ci", `cp "$MAIN_WORKTREE/.env" .env`},
+		HookPreDelete:  {"docker compose down -v"},
+	}
+	if !reflect.DeepEqual(hooks.Commands, want) || hooks.Timeout != 90*time.Second {
+		t.Errorf("hooks = %+v", hooks)
+	}
+
+	for content, wantErr := range map[string]string{
+		"post-merge: make\n":      `unknown hook "post-merge"`,
+		"pre-merge make test\n":   "expected",
+		"timeout: soon\n":         "invalid timeout",
+		"pre-merge: ok\ntimeout:": "worktree-hooks:2",
+	} {
+		writeHooksFile(t, dir, content)
+		if _, err := parseWorktreeHooksFile(dir); err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("%q: err %v, want %q", content, err, wantErr)
+		}
+	}
+}
+
+func TestParseHookTimeout(t *testing.T) {
+	for s, want := range map[string]time.Duration{"30": 30 * time.Second, "5m": 5 * time.Minute} {
+		if got, err := parseHookTimeout(s); err != nil || got != want {
+			t.Errorf("%q = %v, %v", s, got, err)
+		}
+	}
+	for _, s := range []string{"0", "-1m", "later"} {
+		if _, err := parseHookTimeout(s); err == nil {
+			t.Errorf("%q should be rejected", s)
+		}
+	}
+}
+
+func TestRunHook(t *testing.T) {
+	dir := t.TempDir()
+	env := map[string]string{"WORKTREE_BRANCH": "feature x", "GOFLAGS": ""}
+
+	if err := runHook(HookPostCreate, []string{"echo branch=$WORKTREE_BRANCH", "pwd"}, dir, env, time.Minute, false); err != nil {
+		t.Fatalf("passing hook: %v", err)
+	}
+	log, err := os.ReadFile(filepath.Join(dir, agentStatusDir, hookDir, "post-create.log"))
+	if err != nil || !strings.Contains(string(log), "branch=feature x\n"+dir) {
+		t.Errorf("log = %q, %v", log, err)
+	}
+
+	// The first failing command stops the hook
+	err = runHook(HookPreMerge, []string{"echo checking", "exit 3", "echo unreachable"}, dir, env, time.Minute, false)
+	var hookErr *HookError
+	if !errors.As(err, &hookErr) || hookErr.Reason != "exit status 3" || hookErr.Event != HookPreMerge {
+		t.Fatalf("failing hook: %v", err)
+	}
+	if hookErr.Output != "checking\n" {
+		t.Errorf("output = %q", hookErr.Output)
+	}
+
+	err = runHook(HookPreDelete, []string{"sleep 5"}, dir, env, 100*time.Millisecond, false)
+	if !errors.As(err, &hookErr) || !strings.HasPrefix(hookErr.Reason, "timed out") {
+		t.Errorf("slow hook: %v", err)
+	}
+}
+
+func TestRunWorktreeHookWithoutHooks(t *testing.T) {
+	main := t.TempDir()
+	if err := runWorktreeHook(main, HookPostCreate, t.TempDir(), "b"); err != nil {
+		t.Errorf("no hooks file: %v", err)
+	}
+	writeHooksFile(t, main, "pre-delete: true\n")
+	if err := runWorktreeHook(main, HookPostCreate, t.TempDir(), "b"); err != nil {
+		t.Errorf("no commands for event: %v", err)
+	}
+	writeHooksFile(t, main, "bogus: true\n")
+	if err := runWorktreeHook(main, HookPostCreate, t.TempDir(), "b"); err == nil {
+		t.Error("a broken hooks file should block the step")
+	}
+}
+
+func TestResumeRunsWorktreeHooks(t *testing.T) {
+	main := filepath.Join(t.TempDir(), "main")
+	if err := os.Mkdir(main, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"commit", "--allow-empty", "-m", "initial"},
+	} {
+		cmd := exec.Command("git", append([]string{"-c", "user.email=test@test.com", "-c", "user.name=Test"}, args...)...)
+		cmd.Dir = main
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	writeHooksFile(t, main, "post-create: touch created\npre-agent-start: exit 3\n")
+	// An in-process backend keeps hooks and agent sessions out of the user's tmux
+	backend := tty.NewPTYBackend()
+	defer backend.Close()
+	defer tty.SetBackend(tty.SetBackend(backend))
+	p := New()
+	p.ctx = &plugin.Context{WorkDir: main, Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	msg := p.createWorktreeWithResume(ResumeConversationMsg{WorktreeName: "resumed", AgentType: AgentClaude, ResumeCmd: "claude --resume s1"})()
+	created, ok := msg.(worktreeResumeCreatedMsg)
+	if !ok || created.Err != nil || created.Worktree == nil {
+		t.Fatalf("resume create = %+v", msg)
+	}
+	if _, err := os.Stat(filepath.Join(created.Worktree.Path, "created")); err != nil {
+		t.Errorf("post-create hook did not run: %v", err)
+	}
+
+	// A failing pre-agent-start hook blocks the resumed agent
+	started := p.startAgentWithResumeCmd(created.Worktree, AgentClaude, false, created.ResumeCmd)().(AgentStartedMsg)
+	var hookErr *HookError
+	if !errors.As(started.Err, &hookErr) || hookErr.Event != HookPreAgentStart {
+		t.Errorf("resume start err = %v, want pre-agent-start hook error", started.Err)
+	}
+
+	// A failing post-create hook keeps the worktree but skips the agent
+	writeHooksFile(t, main, "post-create: exit 2\n")
+	msg = p.createWorktreeWithResume(ResumeConversationMsg{WorktreeName: "forked", AgentType: AgentClaude, ResumeCmd: "claude --resume s2"})()
+	if created := msg.(worktreeResumeCreatedMsg); !errors.As(created.Err, &hookErr) || created.Worktree == nil {
+		t.Errorf("failed post-create = %+v", created)
+	}
+}
@@ -3,8 +3,6 @@ package workspace
 import (
 	"fmt"
 	"os"
-	"os/exec"
-	"strconv"
 	"strings"
 	"time"
 
@@ -143,16 +141,9 @@ func (p *Plugin) getInteractivePasteKey() string {
 	return defaultPasteKey
 }
 
-// isSessionDeadError checks if an error indicates the tmux session/pane is gone.
+// isSessionDeadError checks if an error indicates the session/pane is gone.
 func isSessionDeadError(err error) bool {
-	if err == nil {
-		return false
-	}
-	errStr := err.Error()
-	return strings.Contains(errStr, "can't find pane") ||
-		strings.Contains(errStr, "no such session") ||
-		strings.Contains(errStr, "session not found") ||
-		strings.Contains(errStr, "pane not found")
+	return tty.IsSessionDeadError(err)
 }
 
 // MapKeyToTmux is a wrapper around tty.MapKeyToTmux for backward compatibility.
@@ -161,28 +152,15 @@ func MapKeyToTmux(msg tea.KeyMsg) (key string, useLiteral bool) {
 	return tty.MapKeyToTmux(msg)
 }
 
-// sendKeyToTmux sends a key to a tmux pane using send-keys.
-// Uses the tmux key name syntax (e.g., "Enter", "C-c", "Up").
+// sendKeyToTmux sends a key to a pane using tmux key name syntax
+// (e.g., "Enter", "C-c", "Up").
 func sendKeyToTmux(sessionName, key string) error {
-	cmd := exec.Command("tmux", "send-keys", "-t", sessionName, key)
-	return cmd.Run()
+	return tty.Current().SendKeys(sessionName, tty.KeySpec{Value: key})
 }
 
-// sendLiteralToTmux sends literal text to a tmux pane using send-keys -l.
-// This prevents tmux from interpreting special key names.
+// sendLiteralToTmux sends literal text to a pane, without key name lookup.
 func sendLiteralToTmux(sessionName, text string) error {
-	// tmux treats bare ; in argv as a command separator, so a literal
-	// semicolon never reaches send-keys. Fall back to hex encoding (-H)
-	// which bypasses tmux's command parser entirely.
-	if strings.Contains(text, ";") {
-		args := []string{"send-keys", "-t", sessionName, "-H"}
-		for _, b := range []byte(text) {
-			args = append(args, fmt.Sprintf("%02x", b))
-		}
-		return exec.Command("tmux", args...).Run()
-	}
-	cmd := exec.Command("tmux", "send-keys", "-l", "-t", sessionName, text)
-	return cmd.Run()
+	return tty.Current().SendKeys(sessionName, tty.KeySpec{Value: text, Literal: true})
 }
 
 // keySpec describes a key to send to tmux with ordering preserved.
@@ -228,19 +206,10 @@ func sendInteractivePasteInputCmd(sessionName, text string, bracketed bool) tea.
 	}
 }
 
-// sendPasteToTmux pastes multi-line text via tmux buffer.
-// Uses load-buffer + paste-buffer which works regardless of app paste mode state.
+// sendPasteToTmux pastes multi-line text. tmux pastes via a buffer, which
+// works regardless of app paste mode state.
 func sendPasteToTmux(sessionName, text string) error {
-	// Load text into tmux default buffer via stdin
-	loadCmd := exec.Command("tmux", "load-buffer", "-")
-	loadCmd.Stdin = strings.NewReader(text)
-	if err := loadCmd.Run(); err != nil {
-		return err
-	}
-
-	// Paste buffer into target pane
-	pasteCmd := exec.Command("tmux", "paste-buffer", "-t", sessionName)
-	return pasteCmd.Run()
+	return tty.Current().Paste(sessionName, text, false)
 }
 
 // Bracketed paste escape sequences
@@ -274,18 +243,7 @@ func detectBracketedPasteMode(output string) bool {
 // sendBracketedPasteToTmux sends text wrapped in bracketed paste sequences.
 // Used when the target app has enabled bracketed paste mode.
 func sendBracketedPasteToTmux(sessionName, text string) error {
-	// Send bracketed paste start sequence
-	if err := sendLiteralToTmux(sessionName, bracketedPasteStart); err != nil {
-		return err
-	}
-
-	// Send the actual text
-	if err := sendLiteralToTmux(sessionName, text); err != nil {
-		return err
-	}
-
-	// Send bracketed paste end sequence
-	return sendLiteralToTmux(sessionName, bracketedPasteEnd)
+	return tty.Current().Paste(sessionName, text, true)
 }
 
 func (p *Plugin) pasteClipboardToTmuxCmd() tea.Cmd {
@@ -617,55 +575,13 @@ func (p *Plugin) maybeResizeInteractivePane(paneWidth, paneHeight int) tea.Cmd {
 	return p.resizeInteractivePaneCmd()
 }
 
-// resizeTmuxPane resizes a tmux window/pane to the specified dimensions.
-// resize-window works for detached sessions; resize-pane is a fallback.
+// resizeTmuxPane resizes a pane to the specified dimensions.
 func (p *Plugin) resizeTmuxPane(paneID string, width, height int) {
-	if width <= 0 && height <= 0 {
-		return
-	}
-
-	args := []string{"resize-window", "-t", paneID}
-	if width > 0 {
-		args = append(args, "-x", strconv.Itoa(width))
-	}
-	if height > 0 {
-		args = append(args, "-y", strconv.Itoa(height))
-	}
-	cmd := exec.Command("tmux", args...)
-	if err := cmd.Run(); err == nil {
-		return
-	}
-
-	// Fallback for older tmux or attached clients that reject resize-window.
-	args = []string{"resize-pane", "-t", paneID}
-	if width > 0 {
-		args = append(args, "-x", strconv.Itoa(width))
-	}
-	if height > 0 {
-		args = append(args, "-y", strconv.Itoa(height))
-	}
-	_ = exec.Command("tmux", args...).Run()
+	_ = tty.Current().Resize(paneID, width, height)
 }
 
 func queryPaneSize(target string) (width, height int, ok bool) {
-	if target == "" {
-		return 0, 0, false
-	}
-
-	cmd := exec.Command("tmux", "display-message", "-t", target, "-p", "#{pane_width},#{pane_height}")
-	output, err := cmd.Output()
-	if err != nil {
-		return 0, 0, false
-	}
-
-	parts := strings.Split(strings.TrimSpace(string(output)), ",")
-	if len(parts) < 2 {
-		return 0, 0, false
-	}
-
-	width, _ = strconv.Atoi(parts[0])
-	height, _ = strconv.Atoi(parts[1])
-	return width, height, true
+	return tty.QueryPaneSize(target)
 }
 
 // resizeSelectedPaneCmd resizes the currently selected tmux pane to match the
@@ -701,7 +617,13 @@ func (p *Plugin) resizeForAttachCmd(target string) tea.Cmd {
 // attachWithResize resizes the tmux pane to full terminal, waits briefly for
 // tmux to process, then attaches. Centralizes resize-before-attach logic.
 func (p *Plugin) attachWithResize(target, sessionName, displayName string, onComplete func(error) tea.Msg) tea.Cmd {
-	c := exec.Command("tmux", "attach-session", "-t", sessionName)
+	c, err := tty.Current().AttachCommand(sessionName)
+	if err != nil {
+		// PTY sessions can't be attached; finish at once so state is restored
+		p.toastMessage = "Can't attach: " + err.Error()
+		p.toastTime = time.Now()
+		return func() tea.Msg { return onComplete(nil) }
+	}
 	termState, _ := term.GetState(int(os.Stdout.Fd()))
 	wrappedOnComplete := func(err error) tea.Msg {
 		if termState != nil {
@@ -1291,29 +1213,11 @@ func queryCursorPositionSync(target string) (row, col, paneHeight, paneWidth int
 	if target == "" {
 		return 0, 0, 0, 0, false, false
 	}
-
-	cmd := exec.Command("tmux", "display-message", "-t", target,
-		"-p", "#{cursor_x},#{cursor_y},#{cursor_flag},#{pane_height},#{pane_width}")
-	output, err := cmd.Output()
+	cursor, err := tty.Current().Cursor(target)
 	if err != nil {
 		return 0, 0, 0, 0, false, false
 	}
-
-	parts := strings.Split(strings.TrimSpace(string(output)), ",")
-	if len(parts) < 2 {
-		return 0, 0, 0, 0, false, false
-	}
-
-	col, _ = strconv.Atoi(parts[0])
-	row, _ = strconv.Atoi(parts[1])
-	visible = len(parts) < 3 || parts[2] != "0"
-	if len(parts) >= 4 {
-		paneHeight, _ = strconv.Atoi(parts[3])
-	}
-	if len(parts) >= 5 {
-		paneWidth, _ = strconv.Atoi(parts[4])
-	}
-	return row, col, paneHeight, paneWidth, visible, true
+	return cursor.Row, cursor.Col, cursor.Height, cursor.Width, cursor.Visible, true
 }
 
 // renderWithCursor overlays the cursor on content at the specified position.
@@ -1,5 +1,7 @@
 package workspace
 
+import "sort"
+
 // kanbanColumnOrder defines the order of columns in kanban view.
 var kanbanColumnOrder = []WorktreeStatus{StatusActive, StatusThinking, StatusWaiting, StatusDone, StatusPaused}
 
@@ -56,6 +58,10 @@ func (p *Plugin) getKanbanColumns() map[WorktreeStatus][]*Worktree {
 		}
 		columns[status] = append(columns[status], wt)
 	}
+	// Keep each fan-out batch together within a column
+	for _, items := range columns {
+		sort.SliceStable(items, func(i, j int) bool { return items[i].BatchID < items[j].BatchID })
+	}
 	return columns
 }
 
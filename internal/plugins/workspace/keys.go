@@ -3,7 +3,6 @@ package workspace
 import (
 	"fmt"
 	"math"
-	"os/exec"
 	"strings"
 	"time"
 
@@ -11,6 +10,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	appmsg "github.com/toddwbucy/hermes/internal/msg"
 	"github.com/toddwbucy/hermes/internal/state"
+	"github.com/toddwbucy/hermes/internal/tty"
 )
 
 // handleKeyPress processes key input based on current view mode.
@@ -42,6 +42,14 @@ func (p *Plugin) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		return p.handleFetchPRKeys(msg)
 	case ViewModeFilePicker:
 		return p.handleFilePickerKeys(msg)
+	case ViewModeNotifications:
+		return p.handleNotificationsKeys(msg)
+	case ViewModeFanOut:
+		return p.handleFanOutKeys(msg)
+	case ViewModeMergeQueue:
+		return p.handleMergeQueueKeys(msg)
+	case ViewModeHistory:
+		return p.handleHistoryKeys(msg)
 	case ViewModeInteractive:
 		return p.handleInteractiveKeys(msg)
 	}
@@ -172,6 +180,14 @@ func (p *Plugin) handlePromptPickerKeys(msg tea.KeyMsg) tea.Cmd {
 	pp := p.promptPicker
 	key := msg.String()
 
+	if pp.varPrompt != nil {
+		action, cmd := p.promptPickerModal.HandleKey(msg)
+		if action != "" {
+			return p.promptVarsAction(action)
+		}
+		return cmd
+	}
+
 	if len(pp.prompts) == 0 && key == "d" {
 		return func() tea.Msg { return PromptInstallDefaultsMsg{} }
 	}
@@ -351,10 +367,10 @@ func (p *Plugin) executeDelete() tea.Cmd {
 	deleteRemote := p.deleteRemoteBranchOpt && p.deleteHasRemote
 	workDir := p.ctx.WorkDir
 
-	// Kill tmux session if it exists (before deleting worktree)
+	// Kill session if it exists (before deleting worktree)
 	sessionName := tmuxSessionPrefix + sanitizeName(name)
 	if sessionExists(sessionName) {
-		_ = exec.Command("tmux", "kill-session", "-t", sessionName).Run()
+		_ = tty.Current().KillSession(sessionName)
 	}
 	delete(p.managedSessions, sessionName)
 	globalPaneCache.remove(sessionName)
@@ -372,6 +388,13 @@ func (p *Plugin) executeDelete() tea.Cmd {
 	return func() tea.Msg {
 		var warnings []string
 
+		// A failing pre-delete hook keeps the worktree
+		if !isMissing {
+			if err := runWorktreeHook(workDir, HookPreDelete, path, branch); err != nil {
+				return DeleteDoneMsg{Name: name, Err: err}
+			}
+		}
+
 		// Delete the worktree first
 		err := doDeleteWorktree(workDir, path, isMissing)
 		if err != nil {
@@ -878,6 +901,31 @@ func (p *Plugin) handleListKeys(msg tea.KeyMsg) tea.Cmd {
 			p.taskSearchLoading = true
 			return p.loadOpenTasks()
 		}
+	case "a":
+		// Open the notification center
+		p.openNotifications()
+		return nil
+	case "M":
+		// Add to or remove from the merge queue
+		if !p.shellSelected {
+			p.toggleMergeQueue(p.selectedWorktree())
+		}
+		return nil
+	case "Q":
+		p.openMergeQueue()
+		return nil
+	case "w":
+		// Start or stop recording the agent's output
+		if !p.shellSelected {
+			return p.toggleRecording(p.selectedWorktree())
+		}
+		return nil
+	case "H":
+		// Browse recorded agent output
+		if !p.shellSelected {
+			return p.openHistory(p.selectedWorktree())
+		}
+		return nil
 	case "F":
 		// Fetch remote PR as workspace
 		p.viewMode = ViewModeFetchPR
@@ -12,6 +12,7 @@ import (
 	"github.com/toddwbucy/hermes/internal/app"
 	"github.com/toddwbucy/hermes/internal/msg"
 	"github.com/toddwbucy/hermes/internal/plugins/gitstatus"
+	"github.com/toddwbucy/hermes/internal/tty"
 )
 
 // MergeWorkflowStep represents the current step in the merge workflow.
@@ -322,7 +323,10 @@ func truncateDiff(diff string, maxLines int) string {
 // pushForMerge pushes the branch for the merge workflow.
 func (p *Plugin) pushForMerge(wt *Worktree) tea.Cmd {
 	return func() tea.Msg {
-		err := doPush(wt.Path, wt.Branch, false, true)
+		err := runWorktreeHook(p.ctx.WorkDir, HookPreMerge, wt.Path, wt.Branch)
+		if err == nil {
+			err = doPush(wt.Path, wt.Branch, false, true)
+		}
 		return MergeStepCompleteMsg{
 			WorkspaceName: wt.Name,
 			Step:         MergeStepPush,
@@ -369,44 +373,42 @@ func parseExistingPRURL(output string) (string, bool) {
 // createPR creates a pull request using gh CLI.
 func (p *Plugin) createPR(wt *Worktree, title, body, targetBranch string) tea.Cmd {
 	return func() tea.Msg {
-		// Build gh pr create command
-		args := []string{"pr", "create",
-			"--title", title,
-			"--body", body,
-			"--base", targetBranch,
+		prURL, existing, err := doCreatePR(wt.Path, title, body, targetBranch)
+		return MergeStepCompleteMsg{
+			WorkspaceName:    wt.Name,
+			Step:            MergeStepCreatePR,
+			Data:            prURL,
+			Err:             err,
+			ExistingPRFound: existing,
 		}
+	}
+}
 
-		cmd := exec.Command("gh", args...)
-		cmd.Dir = wt.Path
-		output, err := cmd.CombinedOutput()
-
-		if err != nil {
-			// Check if PR already exists
-			outputStr := string(output)
-			if existingURL, found := parseExistingPRURL(outputStr); found {
-				return MergeStepCompleteMsg{
-					WorkspaceName:    wt.Name,
-					Step:            MergeStepCreatePR,
-					Data:            existingURL,
-					ExistingPRFound: true,
-				}
-			}
-			return MergeStepCompleteMsg{
-				WorkspaceName: wt.Name,
-				Step:         MergeStepCreatePR,
-				Err:          fmt.Errorf("gh pr create: %s: %w", strings.TrimSpace(outputStr), err),
-			}
-		}
+// doCreatePR creates a pull request for the branch checked out in workdir.
+// If one already exists, its URL is returned with existing set.
+func doCreatePR(workdir, title, body, targetBranch string) (prURL string, existing bool, err error) {
+	// Build gh pr create command
+	args := []string{"pr", "create",
+		"--title", title,
+		"--body", body,
+		"--base", targetBranch,
+	}
 
-		// Output should contain the PR URL
-		prURL := strings.TrimSpace(string(output))
+	cmd := exec.Command("gh", args...)
+	cmd.Dir = workdir
+	output, err := cmd.CombinedOutput()
 
-		return MergeStepCompleteMsg{
-			WorkspaceName: wt.Name,
-			Step:         MergeStepCreatePR,
-			Data:         prURL,
+	if err != nil {
+		// Check if PR already exists
+		outputStr := string(output)
+		if existingURL, found := parseExistingPRURL(outputStr); found {
+			return existingURL, true, nil
 		}
+		return "", false, fmt.Errorf("gh pr create: %s: %w", strings.TrimSpace(outputStr), err)
 	}
+
+	// Output should contain the PR URL
+	return strings.TrimSpace(string(output)), false, nil
 }
 
 // checkPRMerged checks if a PR has been merged using gh CLI.
@@ -446,71 +448,57 @@ func (p *Plugin) checkPRMerged(wt *Worktree) tea.Cmd {
 // performDirectMerge merges the branch directly to base without creating a PR.
 func (p *Plugin) performDirectMerge(wt *Worktree, targetBranch string) tea.Cmd {
 	return func() tea.Msg {
-		baseBranch := targetBranch
-		workDir := p.ctx.WorkDir
-		branch := wt.Branch
-
-		// 1. Fetch latest from origin
-		fetchCmd := exec.Command("git", "fetch", "origin", baseBranch)
-		fetchCmd.Dir = workDir
-		if output, err := fetchCmd.CombinedOutput(); err != nil {
-			return DirectMergeDoneMsg{
-				WorkspaceName: wt.Name,
-				BaseBranch:   baseBranch,
-				Err:          fmt.Errorf("fetch origin: %s: %w", strings.TrimSpace(string(output)), err),
-			}
+		err := runWorktreeHook(p.ctx.WorkDir, HookPreMerge, wt.Path, wt.Branch)
+		if err == nil {
+			err = doDirectMerge(p.ctx.WorkDir, wt.Branch, targetBranch)
 		}
-
-		// 2. Checkout base branch
-		checkoutCmd := exec.Command("git", "checkout", baseBranch)
-		checkoutCmd.Dir = workDir
-		if output, err := checkoutCmd.CombinedOutput(); err != nil {
-			return DirectMergeDoneMsg{
-				WorkspaceName: wt.Name,
-				BaseBranch:   baseBranch,
-				Err:          fmt.Errorf("checkout %s: %s: %w", baseBranch, strings.TrimSpace(string(output)), err),
-			}
+		return DirectMergeDoneMsg{
+			WorkspaceName: wt.Name,
+			BaseBranch:   targetBranch,
+			Err:          err,
 		}
+	}
+}
 
-		// 3. Pull latest
-		pullCmd := exec.Command("git", "pull", "origin", baseBranch)
-		pullCmd.Dir = workDir
-		if output, err := pullCmd.CombinedOutput(); err != nil {
-			return DirectMergeDoneMsg{
-				WorkspaceName: wt.Name,
-				BaseBranch:   baseBranch,
-				Err:          fmt.Errorf("pull origin %s: %s: %w", baseBranch, strings.TrimSpace(string(output)), err),
-			}
-		}
+// doDirectMerge merges branch into baseBranch in the main worktree at
+// workDir and pushes the result.
+func doDirectMerge(workDir, branch, baseBranch string) error {
+	// 1. Fetch latest from origin
+	fetchCmd := exec.Command("git", "fetch", "origin", baseBranch)
+	fetchCmd.Dir = workDir
+	if output, err := fetchCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("fetch origin: %s: %w", strings.TrimSpace(string(output)), err)
+	}
 
-		// 4. Merge the worktree branch
-		mergeMsg := fmt.Sprintf("Merge branch '%s'", branch)
-		mergeCmd := exec.Command("git", "merge", branch, "--no-ff", "-m", mergeMsg)
-		mergeCmd.Dir = workDir
-		if output, err := mergeCmd.CombinedOutput(); err != nil {
-			return DirectMergeDoneMsg{
-				WorkspaceName: wt.Name,
-				BaseBranch:   baseBranch,
-				Err:          fmt.Errorf("merge %s: %s: %w", branch, strings.TrimSpace(string(output)), err),
-			}
-		}
+	// 2. Checkout base branch
+	checkoutCmd := exec.Command("git", "checkout", baseBranch)
+	checkoutCmd.Dir = workDir
+	if output, err := checkoutCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("checkout %s: %s: %w", baseBranch, strings.TrimSpace(string(output)), err)
+	}
 
-		// 5. Push the merge
-		pushCmd := exec.Command("git", "push", "origin", baseBranch)
-		pushCmd.Dir = workDir
-		if output, err := pushCmd.CombinedOutput(); err != nil {
-			return DirectMergeDoneMsg{
-				WorkspaceName: wt.Name,
-				BaseBranch:   baseBranch,
-				Err:          fmt.Errorf("push origin %s: %s: %w", baseBranch, strings.TrimSpace(string(output)), err),
-			}
-		}
+	// 3. Pull latest
+	pullCmd := exec.Command("git", "pull", "origin", baseBranch)
+	pullCmd.Dir = workDir
+	if output, err := pullCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("pull origin %s: %s: %w", baseBranch, strings.TrimSpace(string(output)), err)
+	}
 
-		return DirectMergeDoneMsg{
-			WorkspaceName: wt.Name,
-			BaseBranch:   baseBranch,
-		}
+	// 4. Merge the worktree branch
+	mergeMsg := fmt.Sprintf("Merge branch '%s'", branch)
+	mergeCmd := exec.Command("git", "merge", branch, "--no-ff", "-m", mergeMsg)
+	mergeCmd.Dir = workDir
+	if output, err := mergeCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("merge %s: %s: %w", branch, strings.TrimSpace(string(output)), err)
+	}
+
+	// 5. Push the merge
+	pushCmd := exec.Command("git", "push", "origin", baseBranch)
+	pushCmd.Dir = workDir
+	if output, err := pushCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("push origin %s: %s: %w", baseBranch, strings.TrimSpace(string(output)), err)
 	}
+	return nil
 }
 
 // pullAfterMerge updates the local base branch to match remote after merge.
@@ -778,13 +766,15 @@ func (p *Plugin) performSelectedCleanup(wt *Worktree, state *MergeWorkflowState)
 		branch := wt.Branch
 
 		// Stop agent if running and clean up tracking (always do this)
-		_ = exec.Command("tmux", "kill-session", "-t", sessionName).Run()
+		_ = tty.Current().KillSession(sessionName)
 		delete(p.managedSessions, sessionName)
 		globalPaneCache.remove(sessionName)
 
 		// Delete local worktree if selected
 		if state.DeleteLocalWorktree {
-			if err := doDeleteWorktree(p.ctx.WorkDir, path, false); err != nil {
+			if err := runWorktreeHook(p.ctx.WorkDir, HookPreDelete, path, branch); err != nil {
+				results.Errors = append(results.Errors, fmt.Sprintf("Workspace: %v", err))
+			} else if err := doDeleteWorktree(p.ctx.WorkDir, path, false); err != nil {
 				results.Errors = append(results.Errors, fmt.Sprintf("Workspace: %v", err))
 			} else {
 				results.LocalWorktreeDeleted = true
@@ -0,0 +1,526 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/config"
+	"github.com/toddwbucy/hermes/internal/styles"
+	"github.com/toddwbucy/hermes/internal/ui"
+)
+
+// mergeQueueOutputLines is how much command output a log entry keeps.
+const mergeQueueOutputLines = 20
+
+// MergeQueueStep is where a merge queue item is in landing.
+type MergeQueueStep int
+
+const (
+	MergeQueueQueued MergeQueueStep = iota
+	MergeQueueRebasing
+	MergeQueueVerifying
+	MergeQueueLanding
+	MergeQueueDone
+	MergeQueueFailed
+)
+
+// String returns a display name for the step.
+func (s MergeQueueStep) String() string {
+	switch s {
+	case MergeQueueQueued:
+		return "queued"
+	case MergeQueueRebasing:
+		return "rebasing"
+	case MergeQueueVerifying:
+		return "verifying"
+	case MergeQueueLanding:
+		return "landing"
+	case MergeQueueDone:
+		return "landed"
+	case MergeQueueFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// MergeQueueItem is a worktree waiting to land, with its log.
+type MergeQueueItem struct {
+	Name string // Worktree name
+	Step MergeQueueStep
+	Log  []string
+}
+
+// logf appends a timestamped entry to the item's log.
+func (it *MergeQueueItem) logf(format string, args ...any) {
+	it.Log = append(it.Log, time.Now().Format("15:04:05")+" "+fmt.Sprintf(format, args...))
+}
+
+// MergeQueueStepMsg reports a merge queue step finished for an item.
+type MergeQueueStepMsg struct {
+	Epoch         uint64
+	WorkspaceName string
+	Step          MergeQueueStep
+	Log           []string // Command output worth keeping
+	PRURL         string   // Set when landing opened a PR
+	Err           error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m MergeQueueStepMsg) GetEpoch() uint64 { return m.Epoch }
+
+// mergeQueueConfig returns the merge queue settings.
+func (p *Plugin) mergeQueueConfig() config.MergeQueueConfig {
+	if p.ctx != nil && p.ctx.Config != nil {
+		return p.ctx.Config.Plugins.Workspace.MergeQueue
+	}
+	return config.Default().Plugins.Workspace.MergeQueue
+}
+
+// mergeQueueItem returns the queued item for a worktree, if any.
+func (p *Plugin) mergeQueueItem(name string) *MergeQueueItem {
+	for _, it := range p.mergeQueue {
+		if it.Name == name {
+			return it
+		}
+	}
+	return nil
+}
+
+// active reports whether an item is being rebased, verified, or
+// landed.
+func (it *MergeQueueItem) active() bool {
+	return it.Step == MergeQueueRebasing || it.Step == MergeQueueVerifying || it.Step == MergeQueueLanding
+}
+
+// toggleMergeQueue adds the worktree to the end of the merge queue, or
+// removes it if it is already queued.
+func (p *Plugin) toggleMergeQueue(wt *Worktree) {
+	if wt == nil || wt.IsMain {
+		return
+	}
+	p.toastTime = time.Now()
+	for i, it := range p.mergeQueue {
+		if it.Name != wt.Name {
+			continue
+		}
+		if it.active() {
+			p.toastMessage = wt.Name + " is landing"
+			return
+		}
+		p.mergeQueue = append(p.mergeQueue[:i], p.mergeQueue[i+1:]...)
+		p.toastMessage = "Removed " + wt.Name + " from merge queue"
+		return
+	}
+	item := &MergeQueueItem{Name: wt.Name}
+	item.logf("Queued")
+	p.mergeQueue = append(p.mergeQueue, item)
+	p.toastMessage = fmt.Sprintf("Queued %s for merge (#%d)", wt.Name, len(p.mergeQueue))
+}
+
+// mergeQueueLabel names the merge queue command, with the number of items
+// still to land.
+func (p *Plugin) mergeQueueLabel() string {
+	pending := 0
+	for _, it := range p.mergeQueue {
+		if it.Step != MergeQueueDone {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Sprintf("Queue (%d)", pending)
+	}
+	return "Queue"
+}
+
+// openMergeQueue opens the merge queue view.
+func (p *Plugin) openMergeQueue() {
+	if p.mergeQueueIdx >= len(p.mergeQueue) {
+		p.mergeQueueIdx = max(0, len(p.mergeQueue)-1)
+	}
+	p.viewMode = ViewModeMergeQueue
+}
+
+// startMergeQueue lands queued items in order, starting with the first one
+// not yet landed. A failed item is retried.
+func (p *Plugin) startMergeQueue() tea.Cmd {
+	if p.mergeQueueRunning {
+		p.mergeQueuePausing = false
+		return nil
+	}
+	for _, it := range p.mergeQueue {
+		if it.Step == MergeQueueDone {
+			continue
+		}
+		if it.Step == MergeQueueFailed {
+			it.logf("Retrying")
+		}
+		p.mergeQueueRunning = true
+		p.mergeQueuePausing = false
+		return p.runMergeQueueItem(it)
+	}
+	p.toastMessage = "Nothing to merge"
+	p.toastTime = time.Now()
+	return nil
+}
+
+// pauseMergeQueue stops the queue once the current item lands.
+func (p *Plugin) pauseMergeQueue() {
+	if p.mergeQueueRunning {
+		p.mergeQueuePausing = true
+		p.toastMessage = "Merge queue pauses after the current item"
+		p.toastTime = time.Now()
+	}
+}
+
+// runMergeQueueItem starts landing an item by rebasing it onto its base.
+func (p *Plugin) runMergeQueueItem(it *MergeQueueItem) tea.Cmd {
+	wt := p.findWorktree(it.Name)
+	switch {
+	case wt == nil:
+		return p.failMergeQueueItem(it, errors.New("workspace no longer exists"))
+	case wt.Agent != nil && (wt.Status == StatusActive || wt.Status == StatusThinking):
+		return p.failMergeQueueItem(it, errors.New("agent is still working"))
+	}
+
+	it.Step = MergeQueueRebasing
+	epoch := p.ctx.Epoch
+	path, name := wt.Path, wt.Name
+	return func() tea.Msg {
+		base := resolveBaseBranch(wt)
+		log, err := queueRebase(path, base)
+		return MergeQueueStepMsg{Epoch: epoch, WorkspaceName: name, Step: MergeQueueRebasing, Log: log, Err: err}
+	}
+}
+
+// handleMergeQueueStep records a finished step and starts the next one.
+// The queue stops on the first failure.
+func (p *Plugin) handleMergeQueueStep(msg MergeQueueStepMsg) tea.Cmd {
+	it := p.mergeQueueItem(msg.WorkspaceName)
+	if it == nil || it.Step != msg.Step {
+		return nil // Removed or reset while the step ran
+	}
+	it.Log = append(it.Log, msg.Log...)
+	if msg.Err != nil {
+		return p.failMergeQueueItem(it, msg.Err)
+	}
+
+	wt := p.findWorktree(it.Name)
+	if wt == nil {
+		return p.failMergeQueueItem(it, errors.New("workspace no longer exists"))
+	}
+	cfg := p.mergeQueueConfig()
+	epoch := p.ctx.Epoch
+
+	switch msg.Step {
+	case MergeQueueRebasing:
+		if cfg.VerifyCommand != "" {
+			it.Step = MergeQueueVerifying
+			path, command := wt.Path, cfg.VerifyCommand
+			timeout := time.Duration(cfg.VerifyTimeoutSeconds) * time.Second
+			return func() tea.Msg {
+				log, err := queueVerify(path, command, timeout)
+				return MergeQueueStepMsg{Epoch: epoch, WorkspaceName: it.Name, Step: MergeQueueVerifying, Log: log, Err: err}
+			}
+		}
+		fallthrough
+
+	case MergeQueueVerifying:
+		it.Step = MergeQueueLanding
+		workDir, method := p.ctx.WorkDir, cfg.Method
+		return func() tea.Msg {
+			if err := runWorktreeHook(workDir, HookPreMerge, wt.Path, wt.Branch); err != nil {
+				log := []string{stamp("pre-merge hook failed")}
+				var hookErr *HookError
+				if errors.As(err, &hookErr) {
+					log = append(log, outputTail(hookErr.Output)...)
+				}
+				return MergeQueueStepMsg{Epoch: epoch, WorkspaceName: it.Name, Step: MergeQueueLanding, Log: log, Err: err}
+			}
+			base := resolveBaseBranch(wt)
+			log, prURL, err := queueLand(workDir, wt, base, method)
+			return MergeQueueStepMsg{Epoch: epoch, WorkspaceName: it.Name, Step: MergeQueueLanding, Log: log, PRURL: prURL, Err: err}
+		}
+
+	case MergeQueueLanding:
+		it.Step = MergeQueueDone
+		if msg.PRURL != "" {
+			wt.PRURL = msg.PRURL
+			if err := savePRURL(wt.Path, msg.PRURL); err != nil {
+				p.ctx.Logger.Warn("failed to save PR URL", "path", wt.Path, "error", err)
+			}
+		}
+		return tea.Batch(p.refreshWorktrees(), p.nextMergeQueueItem())
+	}
+	return nil
+}
+
+// nextMergeQueueItem moves on to the next queued item, unless the queue is
+// pausing or empty.
+func (p *Plugin) nextMergeQueueItem() tea.Cmd {
+	if p.mergeQueuePausing {
+		p.mergeQueueRunning = false
+		p.mergeQueuePausing = false
+		p.toastMessage = "Merge queue paused"
+		p.toastTime = time.Now()
+		return nil
+	}
+	for _, it := range p.mergeQueue {
+		if it.Step == MergeQueueQueued {
+			return p.runMergeQueueItem(it)
+		}
+	}
+	p.mergeQueueRunning = false
+	p.toastMessage = "Merge queue finished"
+	p.toastTime = time.Now()
+	return nil
+}
+
+// failMergeQueueItem marks an item failed and stops the queue so later
+// items aren't landed on a base that is missing it.
+func (p *Plugin) failMergeQueueItem(it *MergeQueueItem, err error) tea.Cmd {
+	it.Step = MergeQueueFailed
+	it.logf("Failed: %v", err)
+	p.mergeQueueRunning = false
+	p.mergeQueuePausing = false
+	errMsg := fmt.Sprintf("Merge queue stopped: %s: %v", it.Name, err)
+	return func() tea.Msg {
+		return app.ToastMsg{Message: errMsg, Duration: 5 * time.Second, IsError: true}
+	}
+}
+
+// queueRebase rebases the worktree's branch onto the latest remote base.
+// A conflicting rebase is aborted so the worktree is left as it was.
+func queueRebase(path, base string) ([]string, error) {
+	log := []string{stamp("Rebasing onto origin/" + base)}
+	if out, err := queueGit(path, "status", "--porcelain"); err != nil {
+		return log, err
+	} else if out != "" {
+		return log, errors.New("uncommitted changes")
+	}
+	if out, err := queueGit(path, "fetch", "origin", base); err != nil {
+		return append(log, outputTail(out)...), fmt.Errorf("fetch origin %s: %w", base, err)
+	}
+	if out, err := queueGit(path, "rebase", "origin/"+base); err != nil {
+		conflicts, _ := queueGit(path, "diff", "--name-only", "--diff-filter=U")
+		_, _ = queueGit(path, "rebase", "--abort")
+		if conflicts != "" {
+			return append(log, "  "+strings.ReplaceAll(conflicts, "\n", "\n  ")), errors.New("rebase conflict")
+		}
+		return append(log, outputTail(out)...), fmt.Errorf("rebase: %w", err)
+	}
+	return log, nil
+}
+
+// queueVerify runs the verification command in the worktree.
+func queueVerify(path, command string, timeout time.Duration) ([]string, error) {
+	log := []string{stamp("Verifying: " + command)}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = path
+	// Children of the killed shell may hold the output pipe open
+	cmd.WaitDelay = 2 * time.Second
+	output, err := cmd.CombinedOutput()
+	log = append(log, outputTail(string(output))...)
+	if ctx.Err() == context.DeadlineExceeded {
+		return log, fmt.Errorf("verification timed out after %s", timeout)
+	}
+	if err != nil {
+		return log, fmt.Errorf("verification failed: %w", err)
+	}
+	return log, nil
+}
+
+// queueLand merges the rebased branch into base, or pushes it and opens a
+// pull request, depending on method.
+func queueLand(workDir string, wt *Worktree, base, method string) ([]string, string, error) {
+	if method == config.MergeQueueMethodPR {
+		log := []string{stamp("Pushing " + wt.Branch)}
+		// The rebase rewrote the branch, so a pushed copy is replaced
+		if err := doPush(wt.Path, wt.Branch, true, true); err != nil {
+			return log, "", err
+		}
+		log = append(log, stamp("Creating PR into "+base))
+		prURL, _, err := doCreatePR(wt.Path, wt.Branch, "Created from worktree manager merge queue", base)
+		if err != nil {
+			return log, "", err
+		}
+		return append(log, stamp("Opened "+prURL)), prURL, nil
+	}
+	log := []string{stamp("Merging into " + base)}
+	if err := doDirectMerge(workDir, wt.Branch, base); err != nil {
+		return log, "", err
+	}
+	return append(log, stamp("Merged and pushed "+base)), "", nil
+}
+
+// queueGit runs git in dir and returns its trimmed combined output.
+func queueGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
+
+// stamp prefixes a log entry with the time.
+func stamp(s string) string {
+	return time.Now().Format("15:04:05") + " " + s
+}
+
+// outputTail returns the last lines of command output, indented under the
+// log entry they belong to.
+func outputTail(output string) []string {
+	output = strings.TrimRight(output, "\n")
+	if output == "" {
+		return nil
+	}
+	lines := strings.Split(output, "\n")
+	if len(lines) > mergeQueueOutputLines {
+		lines = lines[len(lines)-mergeQueueOutputLines:]
+	}
+	for i, l := range lines {
+		lines[i] = "  " + l
+	}
+	return lines
+}
+
+// moveMergeQueueItem swaps the selected item with its neighbor. Items being
+// landed stay where they are.
+func (p *Plugin) moveMergeQueueItem(delta int) {
+	i, j := p.mergeQueueIdx, p.mergeQueueIdx+delta
+	if i < 0 || j < 0 || i >= len(p.mergeQueue) || j >= len(p.mergeQueue) {
+		return
+	}
+	if p.mergeQueue[i].active() || p.mergeQueue[j].active() {
+		return
+	}
+	p.mergeQueue[i], p.mergeQueue[j] = p.mergeQueue[j], p.mergeQueue[i]
+	p.mergeQueueIdx = j
+}
+
+// handleMergeQueueKeys handles keys in the merge queue view.
+func (p *Plugin) handleMergeQueueKeys(msg tea.KeyMsg) tea.Cmd {
+	count := len(p.mergeQueue)
+	switch msg.String() {
+	case "esc", "q":
+		p.viewMode = ViewModeList
+	case "j", "down":
+		if p.mergeQueueIdx < count-1 {
+			p.mergeQueueIdx++
+		}
+	case "k", "up":
+		if p.mergeQueueIdx > 0 {
+			p.mergeQueueIdx--
+		}
+	case "J":
+		p.moveMergeQueueItem(1)
+	case "K":
+		p.moveMergeQueueItem(-1)
+	case "x":
+		if p.mergeQueueIdx < count && !p.mergeQueue[p.mergeQueueIdx].active() {
+			p.mergeQueue = append(p.mergeQueue[:p.mergeQueueIdx], p.mergeQueue[p.mergeQueueIdx+1:]...)
+			if p.mergeQueueIdx >= len(p.mergeQueue) && p.mergeQueueIdx > 0 {
+				p.mergeQueueIdx--
+			}
+		}
+	case "c":
+		kept := p.mergeQueue[:0]
+		for _, it := range p.mergeQueue {
+			if it.Step != MergeQueueDone {
+				kept = append(kept, it)
+			}
+		}
+		p.mergeQueue = kept
+		p.mergeQueueIdx = 0
+	case "p":
+		p.pauseMergeQueue()
+	case "s", "enter":
+		return p.startMergeQueue()
+	}
+	return nil
+}
+
+// mergeQueueStepStyle colors an item's step.
+func mergeQueueStepStyle(s MergeQueueStep) lipgloss.Style {
+	switch s {
+	case MergeQueueDone:
+		return styles.StatusCompleted
+	case MergeQueueFailed:
+		return styles.StatusDeleted
+	case MergeQueueQueued:
+		return styles.Muted
+	default:
+		return styles.StatusInProgress
+	}
+}
+
+// renderMergeQueueModal renders the merge queue and the selected item's log
+// over the list.
+func (p *Plugin) renderMergeQueueModal(background string) string {
+	cfg := p.mergeQueueConfig()
+	var sb strings.Builder
+	sb.WriteString(styles.ModalTitle.Render("Merge Queue"))
+	sb.WriteString("\n")
+
+	state := "stopped"
+	if p.mergeQueueRunning {
+		state = "running"
+		if p.mergeQueuePausing {
+			state = "pausing"
+		}
+	}
+	verify := cfg.VerifyCommand
+	if verify == "" {
+		verify = "none"
+	}
+	sb.WriteString(styles.Muted.Render(fmt.Sprintf("%s · land by %s · verify: %s", state, cfg.Method, verify)))
+	sb.WriteString("\n\n")
+
+	if len(p.mergeQueue) == 0 {
+		sb.WriteString(styles.Muted.Render("Nothing queued. Press M on a workspace to queue it."))
+	}
+	for i, it := range p.mergeQueue {
+		line := fmt.Sprintf("%d. %-30s %s", i+1, truncateString(it.Name, 30), mergeQueueStepStyle(it.Step).Render(it.Step.String()))
+		if i == p.mergeQueueIdx {
+			sb.WriteString(styles.ListItemSelected.Render("▸ " + line))
+		} else {
+			sb.WriteString("  " + line)
+		}
+		sb.WriteString("\n")
+	}
+
+	if p.mergeQueueIdx < len(p.mergeQueue) {
+		it := p.mergeQueue[p.mergeQueueIdx]
+		sb.WriteString("\n" + styles.Subtitle.Render("Log: "+it.Name) + "\n")
+		// Show the end of the log, as much as fits
+		log := it.Log
+		if visible := p.height - len(p.mergeQueue) - 16; visible > 0 && len(log) > visible {
+			log = log[len(log)-visible:]
+		}
+		for _, l := range log {
+			sb.WriteString(styles.Muted.Render(truncateString(l, 80)) + "\n")
+		}
+	}
+	sb.WriteString("\n")
+	sb.WriteString(dimText("s start/retry · p pause · J/K reorder · x remove · c clear landed · esc close"))
+
+	modalWidth := 90
+	if modalWidth > p.width-10 {
+		modalWidth = p.width - 10
+	}
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Width(modalWidth)
+
+	return ui.OverlayModal(background, modalStyle.Render(sb.String()), p.width, p.height)
+}
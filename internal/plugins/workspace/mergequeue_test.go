@@ -0,0 +1,176 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/config"
+	"github.com/toddwbucy/hermes/internal/plugin"
+)
+
+func TestMergeQueueFlow(t *testing.T) {
+	cfg := config.Default()
+	cfg.Plugins.Workspace.MergeQueue.VerifyCommand = "make test"
+	p := &Plugin{
+		ctx:       &plugin.Context{Config: cfg},
+		worktrees: []*Worktree{{Name: "main", IsMain: true}, {Name: "a"}, {Name: "b"}},
+	}
+
+	p.toggleMergeQueue(p.worktrees[0])
+	p.toggleMergeQueue(p.worktrees[1])
+	p.toggleMergeQueue(p.worktrees[2])
+	if len(p.mergeQueue) != 2 || p.mergeQueueLabel() != "Queue (2)" {
+		t.Fatalf("queue = %d items, label %q", len(p.mergeQueue), p.mergeQueueLabel())
+	}
+
+	if p.startMergeQueue() == nil || !p.mergeQueueRunning || p.mergeQueue[0].Step != MergeQueueRebasing {
+		t.Fatalf("start: running %v, step %v", p.mergeQueueRunning, p.mergeQueue[0].Step)
+	}
+	// An in-flight item can't be dequeued
+	p.toggleMergeQueue(p.worktrees[1])
+	if len(p.mergeQueue) != 2 {
+		t.Error("landing item was removed")
+	}
+
+	// Rebase done -> verify -> land
+	p.handleMergeQueueStep(MergeQueueStepMsg{WorkspaceName: "a", Step: MergeQueueRebasing})
+	if p.mergeQueue[0].Step != MergeQueueVerifying {
+		t.Fatalf("after rebase: %v", p.mergeQueue[0].Step)
+	}
+	// Stale step results are ignored
+	p.handleMergeQueueStep(MergeQueueStepMsg{WorkspaceName: "a", Step: MergeQueueRebasing, Err: errors.New("late")})
+	if p.mergeQueue[0].Step != MergeQueueVerifying {
+		t.Fatalf("stale step applied: %v", p.mergeQueue[0].Step)
+	}
+
+	// A failed verification stops the queue and keeps the output
+	p.handleMergeQueueStep(MergeQueueStepMsg{
+		WorkspaceName: "a", Step: MergeQueueVerifying,
+		Log: []string{"  FAIL TestX"}, Err: errors.New("verification failed: exit status 1"),
+	})
+	a := p.mergeQueue[0]
+	if a.Step != MergeQueueFailed || p.mergeQueueRunning || p.mergeQueue[1].Step != MergeQueueQueued {
+		t.Fatalf("failure: a %v, running %v, b %v", a.Step, p.mergeQueueRunning, p.mergeQueue[1].Step)
+	}
+	if log := strings.Join(a.Log, "\n"); !strings.Contains(log, "FAIL TestX") || !strings.Contains(log, "Failed: verification failed") {
+		t.Errorf("log = %q", log)
+	}
+
+	// Starting again retries the failed item first
+	p.startMergeQueue()
+	if a.Step != MergeQueueRebasing {
+		t.Errorf("retry: %v", a.Step)
+	}
+}
+
+func TestMergeQueueSkipsBusyAgent(t *testing.T) {
+	p := &Plugin{
+		ctx:       &plugin.Context{},
+		worktrees: []*Worktree{{Name: "a", Agent: &Agent{}, Status: StatusActive}},
+	}
+	p.toggleMergeQueue(p.worktrees[0])
+	p.startMergeQueue()
+	if p.mergeQueue[0].Step != MergeQueueFailed || p.mergeQueueRunning {
+		t.Errorf("step %v, running %v", p.mergeQueue[0].Step, p.mergeQueueRunning)
+	}
+}
+
+func TestMergeQueueReorder(t *testing.T) {
+	p := &Plugin{mergeQueue: []*MergeQueueItem{{Name: "a"}, {Name: "b"}, {Name: "c", Step: MergeQueueDone}}}
+	p.moveMergeQueueItem(1)
+	if p.mergeQueue[0].Name != "b" || p.mergeQueueIdx != 1 {
+		t.Fatalf("order = %s%s, idx %d", p.mergeQueue[0].Name, p.mergeQueue[1].Name, p.mergeQueueIdx)
+	}
+	p.handleMergeQueueKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if len(p.mergeQueue) != 2 {
+		t.Errorf("clear landed left %d items", len(p.mergeQueue))
+	}
+	p.handleMergeQueueKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if len(p.mergeQueue) != 1 || p.mergeQueue[0].Name != "a" {
+		t.Errorf("remove left %+v", p.mergeQueue)
+	}
+}
+
+func TestQueueVerify(t *testing.T) {
+	dir := t.TempDir()
+	if log, err := queueVerify(dir, "echo ok", time.Minute); err != nil || !strings.Contains(strings.Join(log, "\n"), "  ok") {
+		t.Errorf("passing command: log %q, err %v", log, err)
+	}
+	if _, err := queueVerify(dir, "exit 3", time.Minute); err == nil {
+		t.Error("failing command should fail verification")
+	}
+	if _, err := queueVerify(dir, "sleep 5", 50*time.Millisecond); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("slow command: err %v", err)
+	}
+}
+
+func TestQueueRebase(t *testing.T) {
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin")
+	clone := filepath.Join(root, "clone")
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.email=test@test.com", "-c", "user.name=Test"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.Mkdir(origin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	git(origin, "init", "-b", "main")
+	write(filepath.Join(origin, "a.txt"), "one\n")
+	git(origin, "add", ".")
+	git(origin, "commit", "-m", "initial")
+	git(root, "clone", origin, clone)
+	git(clone, "config", "user.email", "test@test.com")
+	git(clone, "config", "user.name", "Test")
+	git(clone, "checkout", "-b", "feature")
+	write(filepath.Join(clone, "b.txt"), "feature\n")
+	git(clone, "add", ".")
+	git(clone, "commit", "-m", "feature")
+
+	// Base moved on without touching the branch's files: rebases cleanly
+	write(filepath.Join(origin, "c.txt"), "base\n")
+	git(origin, "add", ".")
+	git(origin, "commit", "-m", "base")
+	if log, err := queueRebase(clone, "main"); err != nil {
+		t.Fatalf("clean rebase: %v\n%s", err, strings.Join(log, "\n"))
+	}
+	if _, err := os.Stat(filepath.Join(clone, "c.txt")); err != nil {
+		t.Error("rebased branch is missing the base commit")
+	}
+
+	// Both sides change a.txt: the rebase is aborted and the file reported
+	write(filepath.Join(clone, "a.txt"), "feature\n")
+	git(clone, "commit", "-am", "feature a")
+	write(filepath.Join(origin, "a.txt"), "base\n")
+	git(origin, "commit", "-am", "base a")
+	log, err := queueRebase(clone, "main")
+	if err == nil || err.Error() != "rebase conflict" || !strings.Contains(strings.Join(log, "\n"), "a.txt") {
+		t.Fatalf("conflict: err %v, log %q", err, log)
+	}
+	if out, _ := queueGit(clone, "status", "--porcelain"); out != "" {
+		t.Errorf("worktree left dirty after abort: %q", out)
+	}
+
+	// Uncommitted changes are refused before touching the branch
+	write(filepath.Join(clone, "b.txt"), "dirty\n")
+	if _, err := queueRebase(clone, "main"); err == nil || err.Error() != "uncommitted changes" {
+		t.Errorf("dirty worktree: err %v", err)
+	}
+}
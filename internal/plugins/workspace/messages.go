@@ -32,6 +32,7 @@ type AgentOutputMsg struct {
 	Output       string
 	Status       WorktreeStatus
 	WaitingFor   string
+	WaitingCall  *ToolCall // Raw tool call behind WaitingFor, from hook events
 	// Cursor position captured atomically with output (only set in interactive mode)
 	CursorRow     int
 	CursorCol     int
@@ -100,6 +101,7 @@ type CreateDoneMsg struct {
 	SkipPerms bool      // Whether to skip permissions
 	Prompt    *Prompt   // Selected prompt template (nil if none)
 	Err       error
+	HookErr   error // post-create hook failed; the worktree exists but no agent is started
 }
 
 // DeleteWorktreeMsg requests worktree deletion.
@@ -218,6 +220,9 @@ type ResumeConversationMsg struct {
 	BaseBranch   string    // Base branch to create from
 	AgentType    AgentType // Agent to start (matches adapter or user selection)
 	SkipPerms    bool      // Whether to auto-approve agent actions
+	// Prepare, if set, runs in the new worktree before the agent starts and
+	// returns the command to resume with (used to fork a session into it).
+	Prepare func(workDir string) (string, error)
 }
 
 // cursorPositionMsg delivers async cursor position updates for interactive mode (td-648af4).
@@ -274,6 +274,9 @@ func (p *Plugin) handlePromptPickerModalMouse(msg tea.MouseMsg) tea.Cmd {
 	}
 
 	action := p.promptPickerModal.HandleMouse(msg, p.mouseHandler)
+	if p.promptPicker.varPrompt != nil {
+		return p.promptVarsAction(action)
+	}
 	switch action {
 	case "":
 		return nil
@@ -551,7 +554,7 @@ func (p *Plugin) handleMouseClick(action mouse.MouseAction) tea.Cmd {
 		}
 	case regionPreviewTab:
 		// Click on preview tab
-		if idx, ok := action.Region.Data.(int); ok && idx >= 0 && idx <= 2 {
+		if idx, ok := action.Region.Data.(int); ok && idx >= 0 && idx < previewTabCount {
 			prevTab := p.previewTab
 			p.previewTab = PreviewTab(idx)
 			p.previewOffset = 0
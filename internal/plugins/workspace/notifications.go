@@ -0,0 +1,199 @@
+package workspace
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/toddwbucy/hermes/internal/notify"
+	"github.com/toddwbucy/hermes/internal/styles"
+	"github.com/toddwbucy/hermes/internal/ui"
+)
+
+// notificationsMax caps the notification center history.
+const notificationsMax = 50
+
+// AgentNotification is an agent needing attention, listed in the
+// notification center.
+type AgentNotification struct {
+	Time     time.Time
+	Worktree string
+	Status   WorktreeStatus
+	Message  string
+}
+
+// needsAttention reports whether a status should notify.
+func needsAttention(s WorktreeStatus) bool {
+	return s == StatusWaiting || s == StatusDone || s == StatusError
+}
+
+// noteStatusChange records an agent that just started waiting, finished,
+// or failed in a worktree the user isn't looking at, and alerts over the
+// configured channels. Approval-policy answers should be applied first so
+// auto-answered prompts don't alert.
+func (p *Plugin) noteStatusChange(wt *Worktree, prev WorktreeStatus) tea.Cmd {
+	// Paused means no agent was tracked yet, e.g. reconnecting at startup
+	if wt == nil || wt.Status == prev || prev == StatusPaused || !needsAttention(wt.Status) {
+		return nil
+	}
+	if p.focused && p.selectedWorktree() == wt {
+		return nil
+	}
+
+	n := AgentNotification{Time: time.Now(), Worktree: wt.Name, Status: wt.Status}
+	if wt.Agent != nil {
+		n.Message = wt.Agent.WaitingFor
+	}
+	p.notifications = append([]AgentNotification{n}, p.notifications...)
+	p.notificationsUnread++
+	if len(p.notifications) > notificationsMax {
+		p.notifications = p.notifications[:notificationsMax]
+	}
+
+	notifier := p.notifier
+	if !notifier.Wants(wt.Status.String()) {
+		return nil
+	}
+	note := notify.Note{Title: "Hermes: " + wt.Name, Body: n.summary()}
+	return func() tea.Msg {
+		notifier.Send(n.Worktree, note)
+		return nil
+	}
+}
+
+// summary describes the event in one line.
+func (n AgentNotification) summary() string {
+	switch n.Status {
+	case StatusWaiting:
+		if n.Message != "" {
+			return n.Message
+		}
+		return "Waiting for input"
+	case StatusDone:
+		return "Agent finished"
+	case StatusError:
+		return "Agent failed"
+	}
+	return n.Status.String()
+}
+
+// openNotifications opens the notification center.
+func (p *Plugin) openNotifications() {
+	p.notificationIdx = 0
+	p.notificationsUnread = 0
+	p.viewMode = ViewModeNotifications
+}
+
+// notificationsLabel names the notification center command, with the
+// number of events since it was last opened.
+func (p *Plugin) notificationsLabel() string {
+	if p.notificationsUnread > 0 {
+		return fmt.Sprintf("Alerts (%d)", p.notificationsUnread)
+	}
+	return "Alerts"
+}
+
+// handleNotificationsKeys handles keys in the notification center.
+func (p *Plugin) handleNotificationsKeys(msg tea.KeyMsg) tea.Cmd {
+	count := len(p.notifications)
+	switch msg.String() {
+	case "esc", "q":
+		p.viewMode = ViewModeList
+	case "j", "down":
+		if p.notificationIdx < count-1 {
+			p.notificationIdx++
+		}
+	case "k", "up":
+		if p.notificationIdx > 0 {
+			p.notificationIdx--
+		}
+	case "g":
+		p.notificationIdx = 0
+	case "G":
+		if count > 0 {
+			p.notificationIdx = count - 1
+		}
+	case "c":
+		p.notifications = nil
+		p.notificationIdx = 0
+	case "enter":
+		if p.notificationIdx >= 0 && p.notificationIdx < count {
+			p.viewMode = ViewModeList
+			return p.jumpToWorktree(p.notifications[p.notificationIdx].Worktree)
+		}
+	}
+	return nil
+}
+
+// jumpToWorktree selects a worktree by name and shows its output.
+func (p *Plugin) jumpToWorktree(name string) tea.Cmd {
+	for i, wt := range p.worktrees {
+		if wt.Name != name {
+			continue
+		}
+		p.shellSelected = false
+		p.selectedIdx = i
+		p.previewTab = PreviewTabOutput
+		p.previewOffset = 0
+		p.autoScrollOutput = true
+		p.resetScrollBaseLineCount()
+		p.ensureVisible()
+		p.saveSelectionState()
+		return p.loadSelectedContent()
+	}
+	p.toastMessage = "Workspace " + name + " no longer exists"
+	p.toastTime = time.Now()
+	return nil
+}
+
+// renderNotificationsModal renders the notification center over the list.
+func (p *Plugin) renderNotificationsModal(background string) string {
+	var sb strings.Builder
+	sb.WriteString(styles.ModalTitle.Render("Notifications"))
+	sb.WriteString("\n\n")
+
+	if len(p.notifications) == 0 {
+		sb.WriteString(styles.Muted.Render("No agents have needed attention"))
+	}
+	// Window the list around the selection so it fits the screen
+	start, end := 0, len(p.notifications)
+	if visible := p.height - 12; visible > 0 && end > visible {
+		start = p.notificationIdx - visible/2
+		if start < 0 {
+			start = 0
+		}
+		if start+visible > end {
+			start = end - visible
+		}
+		end = start + visible
+	}
+	for i := start; i < end; i++ {
+		n := p.notifications[i]
+		line := fmt.Sprintf("%s %s %s  %s",
+			n.Status.Icon(), n.Worktree, styles.Muted.Render(n.Time.Format("15:04")), truncateString(n.summary(), 50))
+		if i == p.notificationIdx {
+			sb.WriteString(styles.ListItemSelected.Render("▸ " + line))
+		} else {
+			sb.WriteString("  " + line)
+		}
+		if i < end-1 {
+			sb.WriteString("\n")
+		}
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString(dimText("enter jump · c clear · esc close"))
+
+	modalWidth := 70
+	if modalWidth > p.width-10 {
+		modalWidth = p.width - 10
+	}
+	modalStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Width(modalWidth)
+
+	return ui.OverlayModal(background, modalStyle.Render(sb.String()), p.width, p.height)
+}
@@ -0,0 +1,92 @@
+package workspace
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/config"
+	"github.com/toddwbucy/hermes/internal/notify"
+	"github.com/toddwbucy/hermes/internal/plugin"
+)
+
+func TestNoteStatusChange(t *testing.T) {
+	selected := &Worktree{Name: "main", Status: StatusActive, Agent: &Agent{}}
+	background := &Worktree{Name: "feat", Status: StatusActive, Agent: &Agent{}}
+	p := &Plugin{
+		worktrees: []*Worktree{selected, background},
+		focused:   true,
+		notifier:  notify.New(config.NotificationsConfig{Statuses: []string{"waiting"}}),
+	}
+
+	// The worktree on screen doesn't notify
+	selected.Status = StatusDone
+	if p.noteStatusChange(selected, StatusActive) != nil || len(p.notifications) != 0 {
+		t.Error("selected worktree should not notify while focused")
+	}
+
+	// A background agent waiting notifies and is listed
+	background.Status = StatusWaiting
+	background.Agent.WaitingFor = "Allow Bash: go test ./..."
+	if p.noteStatusChange(background, StatusActive) == nil {
+		t.Error("background waiting agent should alert")
+	}
+	if len(p.notifications) != 1 || p.notifications[0].summary() != "Allow Bash: go test ./..." {
+		t.Fatalf("notifications = %+v", p.notifications)
+	}
+
+	// Unchanged status, reconnects, and unwanted statuses don't alert
+	if p.noteStatusChange(background, StatusWaiting) != nil {
+		t.Error("unchanged status should not alert")
+	}
+	if p.noteStatusChange(background, StatusPaused) != nil {
+		t.Error("status found on reconnect should not alert")
+	}
+	background.Status = StatusError
+	if p.noteStatusChange(background, StatusWaiting) != nil {
+		t.Error("status not in config should not alert")
+	}
+	// ...but is still listed, newest first
+	if len(p.notifications) != 2 || p.notifications[0].Status != StatusError {
+		t.Fatalf("notifications = %+v", p.notifications)
+	}
+	if p.notificationsLabel() != "Alerts (2)" {
+		t.Errorf("label = %q", p.notificationsLabel())
+	}
+}
+
+func TestNotificationCenterJump(t *testing.T) {
+	p := &Plugin{
+		ctx:       &plugin.Context{},
+		worktrees: []*Worktree{{Name: "main"}, {Name: "feat"}},
+		notifications: []AgentNotification{
+			{Worktree: "feat", Status: StatusDone},
+			{Worktree: "gone", Status: StatusError},
+		},
+		notificationsUnread: 2,
+		shellSelected:       true,
+	}
+
+	p.openNotifications()
+	if p.viewMode != ViewModeNotifications || p.notificationsLabel() != "Alerts" {
+		t.Fatalf("open: mode %v, label %q", p.viewMode, p.notificationsLabel())
+	}
+
+	p.handleNotificationsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.viewMode != ViewModeList || p.shellSelected || p.selectedIdx != 1 {
+		t.Errorf("jump: mode %v, shell %v, idx %d", p.viewMode, p.shellSelected, p.selectedIdx)
+	}
+
+	// Removed worktrees report instead of jumping
+	p.openNotifications()
+	p.handleNotificationsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	p.handleNotificationsKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if p.selectedIdx != 1 || p.toastMessage == "" {
+		t.Errorf("missing worktree: idx %d, toast %q", p.selectedIdx, p.toastMessage)
+	}
+
+	p.openNotifications()
+	p.handleNotificationsKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if len(p.notifications) != 0 {
+		t.Error("clear should empty the list")
+	}
+}
@@ -9,9 +9,11 @@ import (
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/config"
 	"github.com/toddwbucy/hermes/internal/markdown"
 	"github.com/toddwbucy/hermes/internal/modal"
 	"github.com/toddwbucy/hermes/internal/mouse"
+	"github.com/toddwbucy/hermes/internal/notify"
 	"github.com/toddwbucy/hermes/internal/plugin"
 	"github.com/toddwbucy/hermes/internal/ui"
 	"github.com/toddwbucy/hermes/internal/plugins/gitstatus"
@@ -112,6 +114,31 @@ type Plugin struct {
 	// Session tracking for safe cleanup
 	managedSessions map[string]bool
 
+	// Policy-driven answers to permission prompts
+	approvalPolicy  *ApprovalPolicy
+	approvalDecided map[string]string             // Worktree name -> prompt already answered
+	approvalAudit   map[string][]ApprovalDecision // Worktree name -> automatic decisions
+
+	// Alerts when background agents need attention
+	notifier            *notify.Notifier
+	notifications       []AgentNotification // Newest first
+	notificationIdx     int
+	notificationsUnread int
+
+	// Fan-out launcher for tasks sent from the Persephone board
+	fanOut      *fanOutState
+	fanOutQueue []fanOutItem // Batch items not yet created; head is in flight
+
+	// Merge queue: worktrees rebased, verified, and landed in order
+	mergeQueue        []*MergeQueueItem
+	mergeQueueIdx     int
+	mergeQueueRunning bool
+	mergeQueuePausing bool // Stop once the current item lands
+
+	// Agent output recording and the history viewer
+	recordingTicking bool // Indexing loop scheduled
+	history          *historyState
+
 	// View state
 	viewMode         ViewMode
 	activePane       FocusPane
@@ -170,7 +197,8 @@ type Plugin struct {
 	commitStatusWorktree string // Name of worktree for cached status
 
 	// Conflict detection state
-	conflicts []Conflict
+	conflicts     []Conflict
+	conflictCache *conflictCache // Merge predictions by commit pair
 
 	// Create modal state
 	createNameInput       textinput.Model
@@ -198,6 +226,7 @@ type Plugin struct {
 	promptPickerModal      *modal.Modal
 	promptPickerModalWidth int
 	promptPickerModalEmpty bool
+	promptPickerModalVars  bool
 
 	// Task search state for create modal
 	taskSearchInput    textinput.Model
@@ -328,6 +357,9 @@ func New() *Plugin {
 	return &Plugin{
 		worktrees:           make([]*Worktree, 0),
 		agents:              make(map[string]*Agent),
+		approvalDecided:     make(map[string]string),
+		approvalAudit:       make(map[string][]ApprovalDecision),
+		conflictCache:       newConflictCache(),
 		managedSessions:     make(map[string]bool),
 		shells:              make([]*ShellSession, 0),
 		pollGeneration:      make(map[string]int),
@@ -385,6 +417,29 @@ func (p *Plugin) Init(ctx *plugin.Context) error {
 	p.worktrees = make([]*Worktree, 0)
 	p.attachedSession = ""
 
+	// Approval policy is per project
+	p.approvalPolicy = LoadApprovalPolicy(ctx.ConfigDir, ctx.WorkDir)
+	p.approvalDecided = make(map[string]string)
+	p.approvalAudit = make(map[string][]ApprovalDecision)
+
+	notifyCfg := config.NotificationsConfig{}
+	if ctx.Config != nil {
+		notifyCfg = ctx.Config.Plugins.Workspace.Notifications
+	}
+	p.notifier = notify.New(notifyCfg)
+	p.notifications = nil
+	p.notificationsUnread = 0
+	p.fanOut = nil
+	p.fanOutQueue = nil
+	p.mergeQueue = nil
+	p.mergeQueueIdx = 0
+	p.mergeQueueRunning = false
+	p.mergeQueuePausing = false
+	p.conflicts = nil
+	p.conflictCache = newConflictCache()
+	p.recordingTicking = false
+	p.history = nil
+
 	// Reset poll generation counters (td-83dc22): invalidates any stale timers from previous project
 	p.pollGeneration = make(map[string]int)
 	p.shellPollGeneration = make(map[string]int)
@@ -463,6 +518,9 @@ func (p *Plugin) Start() tea.Cmd {
 	// Refresh worktrees - reconnectAgents will be called after worktrees are loaded
 	cmds = append(cmds, p.refreshWorktrees())
 
+	// Re-predict merge conflicts as worktrees get new commits
+	cmds = append(cmds, p.scheduleConflictCheck(conflictCheckInterval))
+
 	// Start shell polling for all existing shells (so preview shows content right away)
 	for _, shell := range p.shells {
 		if shell.Agent != nil {
@@ -739,6 +797,7 @@ func (p *Plugin) clearPromptPickerModal() {
 	p.promptPickerModal = nil
 	p.promptPickerModalWidth = 0
 	p.promptPickerModalEmpty = false
+	p.promptPickerModalVars = false
 }
 
 // initCreateModalBase initializes common create modal state.
@@ -777,10 +836,7 @@ func (p *Plugin) initCreateModalBase() {
 	p.taskSearchIdx = 0
 	p.taskSearchLoading = true
 
-	// Load prompts from global and project config
-	home, _ := os.UserHomeDir()
-	configDir := filepath.Join(home, ".config", "sidecar")
-	p.createPrompts = LoadPrompts(configDir, p.ctx.WorkDir)
+	p.createPrompts = p.loadPrompts()
 	p.createPromptIdx = -1
 	p.promptPicker = nil
 	p.clearPromptPickerModal()
@@ -827,6 +883,13 @@ func (p *Plugin) deriveBranchName(taskID, title string) string {
 	return taskID + "-" + sanitized
 }
 
+// loadPrompts loads prompts from global and project config.
+func (p *Plugin) loadPrompts() []Prompt {
+	home, _ := os.UserHomeDir()
+	configDir := filepath.Join(home, ".config", "sidecar")
+	return LoadPrompts(configDir, p.ctx.WorkDir)
+}
+
 // getSelectedPrompt returns the currently selected prompt, or nil if none.
 func (p *Plugin) getSelectedPrompt() *Prompt {
 	if p.createPromptIdx < 0 || p.createPromptIdx >= len(p.createPrompts) {
@@ -944,7 +1007,7 @@ func (p *Plugin) ensureVisible() {
 // cyclePreviewTab cycles through preview tabs.
 func (p *Plugin) cyclePreviewTab(delta int) tea.Cmd {
 	prevTab := p.previewTab
-	p.previewTab = PreviewTab((int(p.previewTab) + delta + 3) % 3)
+	p.previewTab = PreviewTab((int(p.previewTab) + delta + previewTabCount) % previewTabCount)
 	p.previewOffset = 0
 	p.autoScrollOutput = true // Reset auto-scroll when switching tabs
 	p.resetScrollBaseLineCount() // td-f7c8be: clear snapshot when switching tabs
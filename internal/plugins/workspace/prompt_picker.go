@@ -12,12 +12,14 @@ import (
 
 // PromptPicker is a modal for selecting a prompt template.
 type PromptPicker struct {
-	prompts       []Prompt        // all available prompts
-	filtered      []Prompt        // filtered by query
-	filterInput   textinput.Model // filter text input
-	selectedIdx   int             // highlighted row (0-based into filtered, -1 = none option)
-	hoverIdx      int             // hovered row for mouse feedback (-2 = no hover, -1 = none, 0+ = prompt)
-	filterFocused bool            // true when filter has keyboard focus (vs item list)
+	prompts       []Prompt          // all available prompts
+	filtered      []Prompt          // filtered by query
+	filterInput   textinput.Model   // filter text input
+	selectedIdx   int               // highlighted row (0-based into filtered, -1 = none option)
+	hoverIdx      int               // hovered row for mouse feedback (-2 = no hover, -1 = none, 0+ = prompt)
+	filterFocused bool              // true when filter has keyboard focus (vs item list)
+	varPrompt     *Prompt           // prompt whose variables are being asked for (nil while picking)
+	varInputs     []textinput.Model // one per varPrompt.Vars entry
 	width         int
 	height        int
 }
@@ -54,6 +56,40 @@ func NewPromptPicker(prompts []Prompt, width, height int) *PromptPicker {
 	return pp
 }
 
+// askVars switches the picker to asking for the prompt's variables,
+// pre-filled with earlier answers or their defaults.
+func (pp *PromptPicker) askVars(prompt Prompt) {
+	pp.varPrompt = &prompt
+	pp.varInputs = make([]textinput.Model, len(prompt.Vars))
+	for i, v := range prompt.Vars {
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.Width = 40
+		val, ok := prompt.Values[v.Name]
+		if !ok {
+			val = v.Default
+		}
+		ti.SetValue(val)
+		pp.varInputs[i] = ti
+	}
+}
+
+// backToList leaves the variables form for the prompt list.
+func (pp *PromptPicker) backToList() {
+	pp.varPrompt = nil
+	pp.varInputs = nil
+}
+
+// answeredPrompt returns the prompt being asked about with the values entered.
+func (pp *PromptPicker) answeredPrompt() *Prompt {
+	prompt := *pp.varPrompt
+	prompt.Values = make(map[string]string, len(prompt.Vars))
+	for i, v := range prompt.Vars {
+		prompt.Values[v.Name] = pp.varInputs[i].Value()
+	}
+	return &prompt
+}
+
 // Update handles input for the prompt picker.
 func (pp *PromptPicker) Update(msg tea.Msg) (*PromptPicker, tea.Cmd) {
 	switch msg := msg.(type) {
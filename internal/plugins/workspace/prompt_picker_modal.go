@@ -16,6 +16,9 @@ const (
 	promptPickerFilterID   = "prompt-picker-filter"
 	promptPickerItemPrefix = "prompt-picker-item-"
 	promptPickerNoneID     = "prompt-picker-item-none"
+	promptVarInputPrefix   = "prompt-var-"
+	promptVarsUseID        = "prompt-vars-use"
+	promptVarsBackID       = "prompt-vars-back"
 )
 
 var (
@@ -58,12 +61,20 @@ func (p *Plugin) ensurePromptPickerModal() {
 	}
 
 	isEmpty := len(p.promptPicker.prompts) == 0
-	if p.promptPickerModal != nil && p.promptPickerModalWidth == modalW && p.promptPickerModalEmpty == isEmpty {
+	askingVars := p.promptPicker.varPrompt != nil
+	if p.promptPickerModal != nil && p.promptPickerModalWidth == modalW &&
+		p.promptPickerModalEmpty == isEmpty && p.promptPickerModalVars == askingVars {
 		return
 	}
 
 	p.promptPickerModalWidth = modalW
 	p.promptPickerModalEmpty = isEmpty
+	p.promptPickerModalVars = askingVars
+
+	if askingVars {
+		p.promptPickerModal = p.promptVarsModal(modalW)
+		return
+	}
 
 	if isEmpty {
 		p.promptPickerModal = modal.New("Select Prompt",
@@ -110,11 +121,55 @@ func (p *Plugin) promptPickerSelectCmd() tea.Cmd {
 	}
 	if pp.selectedIdx < len(pp.filtered) {
 		prompt := pp.filtered[pp.selectedIdx]
+		if len(prompt.Vars) > 0 {
+			// Ask for the prompt's variables before handing it back
+			pp.askVars(prompt)
+			p.ensurePromptPickerModal()
+			return nil
+		}
 		return func() tea.Msg { return PromptSelectedMsg{Prompt: &prompt} }
 	}
 	return nil
 }
 
+// promptVarsModal builds the form asking for a prompt's variables.
+func (p *Plugin) promptVarsModal(modalW int) *modal.Modal {
+	pp := p.promptPicker
+	m := modal.New("Prompt: "+pp.varPrompt.Name,
+		modal.WithWidth(modalW),
+		modal.WithPrimaryAction(promptVarsUseID),
+		modal.WithHints(false),
+	)
+	for i, v := range pp.varPrompt.Vars {
+		label := v.Label
+		if label == "" {
+			label = v.Name
+		}
+		m.AddSection(modal.InputWithLabel(promptVarInputPrefix+strconv.Itoa(i), label+":", &pp.varInputs[i]))
+	}
+	return m.
+		AddSection(modal.Spacer()).
+		AddSection(modal.Buttons(
+			modal.Btn(" Use Prompt ", promptVarsUseID),
+			modal.Btn(" Back ", promptVarsBackID),
+		))
+}
+
+// promptVarsAction acts on a button or key from the variables form.
+func (p *Plugin) promptVarsAction(action string) tea.Cmd {
+	pp := p.promptPicker
+	switch action {
+	case "cancel", promptVarsBackID:
+		pp.backToList()
+		p.ensurePromptPickerModal()
+		p.syncPromptPickerFocus()
+	case promptVarsUseID:
+		prompt := pp.answeredPrompt()
+		return func() tea.Msg { return PromptSelectedMsg{Prompt: prompt} }
+	}
+	return nil
+}
+
 func (p *Plugin) promptPickerEmptySection() modal.Section {
 	return modal.Custom(func(contentWidth int, focusID, hoverID string) modal.RenderedSection {
 		var sb strings.Builder
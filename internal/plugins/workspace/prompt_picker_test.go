@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/mouse"
 )
 
 func TestPromptPickerDKeyEmptyPrompts(t *testing.T) {
@@ -143,3 +144,45 @@ func TestInstallDefaultsPreservesProjectPrompts(t *testing.T) {
 	}
 	t.Error("Missing 'Begin Work on Ticket' prompt in merged results")
 }
+
+func TestPromptPickerAsksForVars(t *testing.T) {
+	prompts := []Prompt{{
+		Name: "Review",
+		Body: "Review {{.Vars.area}}",
+		Vars: []PromptVar{{Name: "area", Label: "Area", Default: "auth"}},
+	}}
+	p := &Plugin{width: 100, height: 40, mouseHandler: mouse.NewHandler()}
+	p.promptPicker = NewPromptPicker(prompts, 100, 40)
+	p.promptPicker.selectedIdx = 0
+
+	// Choosing a prompt with vars opens the form instead of selecting it
+	if cmd := p.promptPickerSelectCmd(); cmd != nil {
+		t.Fatalf("expected the vars form, got %T", cmd())
+	}
+	if p.promptPicker.varPrompt == nil || p.promptPicker.varInputs[0].Value() != "auth" {
+		t.Fatal("vars form should open pre-filled with defaults")
+	}
+
+	p.ensurePromptPickerModal()
+	p.promptPickerModal.Render(p.width, p.height, p.mouseHandler)
+	p.promptPicker.varInputs[0].SetValue("billing")
+
+	cmd := p.handlePromptPickerKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should submit the form")
+	}
+	sel, ok := cmd().(PromptSelectedMsg)
+	if !ok || sel.Prompt == nil || sel.Prompt.Values["area"] != "billing" {
+		t.Fatalf("got %+v, want the prompt with area=billing", sel)
+	}
+
+	// Esc goes back to the list rather than closing the picker
+	p.promptPicker.askVars(prompts[0])
+	p.ensurePromptPickerModal()
+	if cmd := p.handlePromptPickerKeys(tea.KeyMsg{Type: tea.KeyEsc}); cmd != nil {
+		t.Fatalf("esc in the form should not close the picker, got %T", cmd())
+	}
+	if p.promptPicker.varPrompt != nil {
+		t.Error("esc should return to the prompt list")
+	}
+}
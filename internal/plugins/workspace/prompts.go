@@ -7,6 +7,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
 )
 
 // TicketMode defines how the task field behaves with a prompt.
@@ -20,10 +22,36 @@ const (
 
 // Prompt represents a configurable prompt template.
 type Prompt struct {
-	Name       string     `json:"name"`
-	TicketMode TicketMode `json:"ticketMode"`
-	Body       string     `json:"body"`
-	Source     string     `json:"-"` // "global" or "project" (set at load time)
+	Name       string      `json:"name"`
+	TicketMode TicketMode  `json:"ticketMode"`
+	Body       string      `json:"body"`
+	Vars       []PromptVar `json:"vars,omitempty"` // Asked for by the prompt picker before launch
+	Source     string      `json:"-"`              // "global" or "project" (set at load time)
+
+	// Set at launch time
+	Values map[string]string  `json:"-"` // Answers to Vars; unanswered ones use their default
+	Task   *appmsg.LaunchTask `json:"-"` // Full task details, when launched from the board
+}
+
+// PromptVar is a variable the user fills in when picking a prompt.
+// The body reads it as {{.Vars.name}}.
+type PromptVar struct {
+	Name    string `json:"name"`
+	Label   string `json:"label,omitempty"` // Defaults to Name
+	Default string `json:"default,omitempty"`
+}
+
+// VarValues returns the prompt's variables, with answers from Values
+// replacing defaults.
+func (p *Prompt) VarValues() map[string]string {
+	vals := make(map[string]string, len(p.Vars))
+	for _, v := range p.Vars {
+		vals[v.Name] = v.Default
+	}
+	for name, val := range p.Values {
+		vals[name] = val
+	}
+	return vals
 }
 
 // configWithPrompts is the config structure for loading prompts.
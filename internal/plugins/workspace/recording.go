@@ -0,0 +1,509 @@
+package workspace
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/tty"
+)
+
+// Agent pane recordings live in <worktree>/.hermes/output. tmux pipe-pane
+// appends the raw pane output (escape sequences included) to current.log;
+// Hermes adds a "<unix millis> <size>" line to current.idx whenever the log
+// has grown, which timestamps the output for the history viewer and .cast
+// export. Past a size limit current.* is renamed after the rotation time.
+const (
+	recordingDir          = "output"
+	recordingCurrent      = "current"
+	recordingStampFormat  = "20060102-150405"
+	recordingTickInterval = time.Second
+
+	defaultRecordingMaxFileMB = 10
+	defaultRecordingMaxFiles  = 5
+)
+
+// recordingMark records that a log had reached Offset bytes at Time.
+type recordingMark struct {
+	Time   time.Time
+	Offset int64
+}
+
+// recordingTarget is a recorded agent, snapshotted for the indexer.
+type recordingTarget struct {
+	Name   string
+	Path   string // Worktree path
+	Pane   string // tmux target
+	Offset int64  // Log size at the last mark
+}
+
+// recordingToggledMsg reports recording started or stopped for an agent.
+type recordingToggledMsg struct {
+	Epoch         uint64
+	WorkspaceName string
+	Recording     bool
+	Offset        int64
+	Err           error
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m recordingToggledMsg) GetEpoch() uint64 { return m.Epoch }
+
+// recordingTickMsg triggers the next indexing pass.
+type recordingTickMsg struct {
+	Epoch uint64
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m recordingTickMsg) GetEpoch() uint64 { return m.Epoch }
+
+// recordingIndexedMsg carries log sizes after an indexing pass.
+type recordingIndexedMsg struct {
+	Epoch   uint64
+	Offsets map[string]int64 // Worktree name -> log size
+}
+
+// GetEpoch implements plugin.EpochMessage.
+func (m recordingIndexedMsg) GetEpoch() uint64 { return m.Epoch }
+
+// recordingLimits returns the rotation size in bytes and the number of
+// rotated logs to keep.
+func (p *Plugin) recordingLimits() (int64, int) {
+	maxMB, maxFiles := defaultRecordingMaxFileMB, defaultRecordingMaxFiles
+	if p.ctx.Config != nil {
+		rec := p.ctx.Config.Plugins.Workspace.Recording
+		if rec.MaxFileMB > 0 {
+			maxMB = rec.MaxFileMB
+		}
+		if rec.MaxFiles > 0 {
+			maxFiles = rec.MaxFiles
+		}
+	}
+	return int64(maxMB) << 20, maxFiles
+}
+
+// recordAllAgents reports whether every agent is recorded from its start.
+func (p *Plugin) recordAllAgents() bool {
+	return p.ctx.Config != nil && p.ctx.Config.Plugins.Workspace.Recording.Enabled
+}
+
+// agentTarget returns the tmux target for an agent's pane.
+func agentTarget(a *Agent) string {
+	if a.TmuxPane != "" {
+		return a.TmuxPane
+	}
+	return a.TmuxSession
+}
+
+// toggleRecording starts or stops recording the selected worktree's agent.
+func (p *Plugin) toggleRecording(wt *Worktree) tea.Cmd {
+	if wt == nil || wt.Agent == nil {
+		p.toastMessage = "No agent to record"
+		p.toastTime = time.Now()
+		return nil
+	}
+	if wt.Agent.Recording {
+		return p.stopRecording(wt)
+	}
+	return p.startRecording(wt)
+}
+
+// startRecording pipes the agent's pane into its worktree's current log.
+func (p *Plugin) startRecording(wt *Worktree) tea.Cmd {
+	name, path, target, epoch := wt.Name, wt.Path, agentTarget(wt.Agent), p.ctx.Epoch
+	return func() tea.Msg {
+		offset, err := pipePane(target, path)
+		return recordingToggledMsg{Epoch: epoch, WorkspaceName: name, Recording: err == nil, Offset: offset, Err: err}
+	}
+}
+
+// stopRecording closes the agent's pane pipe. The log is kept.
+func (p *Plugin) stopRecording(wt *Worktree) tea.Cmd {
+	name, target, epoch := wt.Name, agentTarget(wt.Agent), p.ctx.Epoch
+	return func() tea.Msg {
+		err := tty.Current().PipeOutput(target, "")
+		return recordingToggledMsg{Epoch: epoch, WorkspaceName: name, Err: err}
+	}
+}
+
+// handleRecordingToggled records the new state and keeps the indexer running.
+func (p *Plugin) handleRecordingToggled(msg recordingToggledMsg) tea.Cmd {
+	if msg.Err != nil {
+		errMsg := "Recording failed: " + msg.Err.Error()
+		return func() tea.Msg {
+			return app.ToastMsg{Message: errMsg, Duration: 5 * time.Second, IsError: true}
+		}
+	}
+	wt := p.findWorktree(msg.WorkspaceName)
+	if wt == nil || wt.Agent == nil {
+		return nil
+	}
+	wt.Agent.Recording = msg.Recording
+	wt.Agent.RecordedBytes = msg.Offset
+	if msg.Recording {
+		p.toastMessage = "Recording " + wt.Name
+	} else {
+		p.toastMessage = "Stopped recording " + wt.Name
+	}
+	p.toastTime = time.Now()
+	return p.ensureRecordingTick()
+}
+
+// ensureRecordingTick starts the indexing loop unless it is running. The
+// loop stops by itself once no agent is recorded.
+func (p *Plugin) ensureRecordingTick() tea.Cmd {
+	if p.recordingTicking {
+		return nil
+	}
+	p.recordingTicking = true
+	epoch := p.ctx.Epoch
+	return tea.Tick(recordingTickInterval, func(time.Time) tea.Msg {
+		return recordingTickMsg{Epoch: epoch}
+	})
+}
+
+// indexRecordings returns a command that marks new output for every
+// recorded agent and rotates oversized logs.
+func (p *Plugin) indexRecordings() tea.Cmd {
+	var targets []recordingTarget
+	for _, wt := range p.worktrees {
+		if wt.Agent != nil && wt.Agent.Recording {
+			targets = append(targets, recordingTarget{Name: wt.Name, Path: wt.Path, Pane: agentTarget(wt.Agent), Offset: wt.Agent.RecordedBytes})
+		}
+	}
+	if len(targets) == 0 {
+		p.recordingTicking = false
+		return nil
+	}
+	maxBytes, maxFiles := p.recordingLimits()
+	epoch, logger := p.ctx.Epoch, p.ctx.Logger
+	return func() tea.Msg {
+		offsets := make(map[string]int64, len(targets))
+		for _, t := range targets {
+			offset, err := indexRecording(t, maxBytes, maxFiles, time.Now())
+			if err != nil {
+				logger.Warn("recording: index failed", "workspace", t.Name, "error", err)
+			}
+			offsets[t.Name] = offset
+		}
+		return recordingIndexedMsg{Epoch: epoch, Offsets: offsets}
+	}
+}
+
+// handleRecordingIndexed stores the log sizes and schedules the next pass.
+func (p *Plugin) handleRecordingIndexed(msg recordingIndexedMsg) tea.Cmd {
+	for name, offset := range msg.Offsets {
+		if wt := p.findWorktree(name); wt != nil && wt.Agent != nil {
+			wt.Agent.RecordedBytes = offset
+		}
+	}
+	p.recordingTicking = false
+	return p.ensureRecordingTick()
+}
+
+// recordingPaths returns the log and index paths of a recording segment.
+func recordingPaths(worktreePath, segment string) (string, string) {
+	base := filepath.Join(worktreePath, agentStatusDir, recordingDir, segment)
+	return base + ".log", base + ".idx"
+}
+
+// pipePane starts (or replaces) the pane pipe into the current log and
+// marks the log's size, so earlier output isn't stamped with later times.
+func pipePane(target, worktreePath string) (int64, error) {
+	if err := os.MkdirAll(filepath.Join(worktreePath, agentStatusDir, recordingDir), 0755); err != nil {
+		return 0, err
+	}
+	logPath, idxPath := recordingPaths(worktreePath, recordingCurrent)
+	if err := tty.Current().PipeOutput(target, logPath); err != nil {
+		return 0, err
+	}
+	var size int64
+	if info, err := os.Stat(logPath); err == nil {
+		size = info.Size()
+	}
+	return size, appendRecordingMark(idxPath, recordingMark{Time: time.Now(), Offset: size})
+}
+
+// indexRecording marks output written since t.Offset and rotates the log
+// once it reaches maxBytes. Returns the size of the current log.
+func indexRecording(t recordingTarget, maxBytes int64, maxFiles int, now time.Time) (int64, error) {
+	logPath, idxPath := recordingPaths(t.Path, recordingCurrent)
+	info, err := os.Stat(logPath)
+	if err != nil || info.Size() == t.Offset {
+		return t.Offset, nil // Nothing new (or no output yet)
+	}
+	size := info.Size()
+	if err := appendRecordingMark(idxPath, recordingMark{Time: now, Offset: size}); err != nil {
+		return t.Offset, err
+	}
+	if size < maxBytes {
+		return size, nil
+	}
+
+	// Rename first: cat keeps writing to the renamed file until the pipe
+	// is replaced, so nothing is lost in between
+	if err := rotateRecording(t.Path, now, maxFiles); err != nil {
+		return size, err
+	}
+	return pipePane(t.Pane, t.Path)
+}
+
+// rotateRecording renames the current log and index after now and removes
+// the oldest rotated logs beyond maxFiles.
+func rotateRecording(worktreePath string, now time.Time, maxFiles int) error {
+	logPath, idxPath := recordingPaths(worktreePath, recordingCurrent)
+	rotatedLog, rotatedIdx := recordingPaths(worktreePath, now.Format(recordingStampFormat))
+	if err := os.Rename(logPath, rotatedLog); err != nil {
+		return err
+	}
+	_ = os.Rename(idxPath, rotatedIdx)
+	pruneRecordings(worktreePath, maxFiles)
+	return nil
+}
+
+// recordingSegments returns a worktree's recorded segments, oldest first,
+// with the current one last.
+func recordingSegments(worktreePath string) []string {
+	matches, _ := filepath.Glob(filepath.Join(worktreePath, agentStatusDir, recordingDir, "*.log"))
+	var segments []string
+	hasCurrent := false
+	for _, m := range matches {
+		name := strings.TrimSuffix(filepath.Base(m), ".log")
+		if name == recordingCurrent {
+			hasCurrent = true
+			continue
+		}
+		segments = append(segments, name)
+	}
+	sort.Strings(segments) // Timestamp names sort by age
+	if hasCurrent {
+		segments = append(segments, recordingCurrent)
+	}
+	return segments
+}
+
+// pruneRecordings removes the oldest rotated logs beyond maxFiles.
+func pruneRecordings(worktreePath string, maxFiles int) {
+	rotated := recordingSegments(worktreePath)
+	if n := len(rotated); n > 0 && rotated[n-1] == recordingCurrent {
+		rotated = rotated[:n-1]
+	}
+	for len(rotated) > maxFiles {
+		logPath, idxPath := recordingPaths(worktreePath, rotated[0])
+		_ = os.Remove(logPath)
+		_ = os.Remove(idxPath)
+		rotated = rotated[1:]
+	}
+}
+
+// appendRecordingMark adds a line to an index file.
+func appendRecordingMark(idxPath string, m recordingMark) error {
+	f, err := os.OpenFile(idxPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = fmt.Fprintf(f, "%d %d\n", m.Time.UnixMilli(), m.Offset)
+	return err
+}
+
+// readRecordingMarks parses an index file, sorted by offset. Malformed lines
+// are skipped.
+func readRecordingMarks(idxPath string) []recordingMark {
+	f, err := os.Open(idxPath)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+
+	var marks []recordingMark
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		ms, err1 := strconv.ParseInt(fields[0], 10, 64)
+		offset, err2 := strconv.ParseInt(fields[1], 10, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		marks = append(marks, recordingMark{Time: time.UnixMilli(ms), Offset: offset})
+	}
+	sort.SliceStable(marks, func(i, j int) bool { return marks[i].Offset < marks[j].Offset })
+	return marks
+}
+
+// recordedChunk is a span of recorded output and when it was seen.
+type recordedChunk struct {
+	Time time.Time
+	Data []byte
+}
+
+// loadRecordedChunks reads every segment of a worktree's recording as
+// timestamped chunks, oldest first. Output past the last mark (written
+// after Hermes last looked) is stamped with the log's modification time.
+func loadRecordedChunks(worktreePath string) ([]recordedChunk, error) {
+	var chunks []recordedChunk
+	for _, segment := range recordingSegments(worktreePath) {
+		logPath, idxPath := recordingPaths(worktreePath, segment)
+		data, err := os.ReadFile(logPath)
+		if err != nil {
+			return nil, err
+		}
+		var start int64
+		for _, m := range readRecordingMarks(idxPath) {
+			end := min(m.Offset, int64(len(data)))
+			if end <= start {
+				continue
+			}
+			chunks = append(chunks, recordedChunk{Time: m.Time, Data: data[start:end]})
+			start = end
+		}
+		if start < int64(len(data)) {
+			modTime := time.Now()
+			if info, err := os.Stat(logPath); err == nil {
+				modTime = info.ModTime()
+			}
+			chunks = append(chunks, recordedChunk{Time: modTime, Data: data[start:]})
+		}
+	}
+	return chunks, nil
+}
+
+// historyLine is one line of recorded output as plain text.
+type historyLine struct {
+	Time time.Time // When the line's first byte was recorded
+	Text string
+}
+
+// recordedLines turns recorded chunks into plain lines. Carriage-return
+// redraws keep their last version, escape sequences are dropped, and runs
+// of blank lines collapse to one.
+func recordedLines(chunks []recordedChunk) []historyLine {
+	var lines []historyLine
+	var cur strings.Builder
+	var curTime time.Time
+	started := false
+	flush := func() {
+		text := cur.String()
+		if i := strings.LastIndex(strings.TrimRight(text, "\r"), "\r"); i >= 0 {
+			text = text[i+1:]
+		}
+		text = strings.TrimRight(ansi.Strip(text), " \t\r")
+		if text != "" || len(lines) == 0 || lines[len(lines)-1].Text != "" {
+			lines = append(lines, historyLine{Time: curTime, Text: text})
+		}
+		cur.Reset()
+		started = false
+	}
+	for _, c := range chunks {
+		data := c.Data
+		for len(data) > 0 {
+			if !started {
+				curTime = c.Time
+				started = true
+			}
+			i := bytes.IndexByte(data, '\n')
+			if i < 0 {
+				cur.Write(data)
+				break
+			}
+			cur.Write(data[:i])
+			flush()
+			data = data[i+1:]
+		}
+	}
+	if started {
+		flush()
+	}
+	return lines
+}
+
+// castHeader is the first line of an asciinema v2 recording.
+type castHeader struct {
+	Version   int    `json:"version"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+// writeCast writes chunks as an asciinema v2 .cast file. Chunk boundaries
+// are moved back to the start of a UTF-8 sequence so every event is valid
+// text.
+func writeCast(path, title string, width, height int, chunks []recordedChunk) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	header := castHeader{Version: 2, Width: width, Height: height, Title: title}
+	if len(chunks) > 0 {
+		header.Timestamp = chunks[0].Time.Unix()
+	}
+	if err := enc.Encode(header); err != nil {
+		return err
+	}
+
+	var carry []byte
+	var last float64
+	for i, c := range chunks {
+		data := append(carry, c.Data...)
+		carry = nil
+		if i < len(chunks)-1 {
+			// Hold back a rune split across chunks for the next event
+			for j := len(data) - 1; j >= 0 && j >= len(data)-utf8.UTFMax; j-- {
+				if utf8.RuneStart(data[j]) {
+					if !utf8.FullRune(data[j:]) {
+						carry = append([]byte(nil), data[j:]...)
+						data = data[:j]
+					}
+					break
+				}
+			}
+		}
+		if len(data) == 0 {
+			continue
+		}
+		// Times never go backwards, even across segments stamped by mtime
+		t := c.Time.Sub(chunks[0].Time).Seconds()
+		if t < last {
+			t = last
+		}
+		last = t
+		if err := enc.Encode([]any{float64(int64(t*1e6)) / 1e6, "o", string(data)}); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// paneSize returns a pane's size, or 80x24 when it can't be read.
+func paneSize(target string) (int, int) {
+	w, h, ok := queryPaneSize(target)
+	if !ok || w <= 0 || h <= 0 {
+		return 80, 24
+	}
+	return w, h
+}
+
+// panePiped reports whether a pane's output is already being piped.
+func panePiped(target string) bool {
+	return tty.Current().Piped(target)
+}
@@ -0,0 +1,244 @@
+package workspace
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/toddwbucy/hermes/internal/app"
+	"github.com/toddwbucy/hermes/internal/config"
+	"github.com/toddwbucy/hermes/internal/keymap"
+	"github.com/toddwbucy/hermes/internal/plugin"
+)
+
+func writeRecording(t *testing.T, wtPath, segment, log string, marks ...recordingMark) {
+	t.Helper()
+	logPath, idxPath := recordingPaths(wtPath, segment)
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(logPath, []byte(log), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range marks {
+		if err := appendRecordingMark(idxPath, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestIndexRecording(t *testing.T) {
+	wt := t.TempDir()
+	t0 := time.UnixMilli(1_700_000_000_000)
+	writeRecording(t, wt, recordingCurrent, "hello\n")
+	target := recordingTarget{Name: "a", Path: wt}
+
+	offset, err := indexRecording(target, 1<<20, 5, t0)
+	if err != nil || offset != 6 {
+		t.Fatalf("offset %d, err %v", offset, err)
+	}
+	// Unchanged logs add no marks
+	target.Offset = offset
+	if offset, _ = indexRecording(target, 1<<20, 5, t0.Add(time.Second)); offset != 6 {
+		t.Errorf("offset %d", offset)
+	}
+	_, idxPath := recordingPaths(wt, recordingCurrent)
+	if marks := readRecordingMarks(idxPath); !reflect.DeepEqual(marks, []recordingMark{{Time: t0, Offset: 6}}) {
+		t.Errorf("marks = %+v", marks)
+	}
+}
+
+func TestRotateRecording(t *testing.T) {
+	wt := t.TempDir()
+	t0 := time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)
+	for i := range 3 {
+		writeRecording(t, wt, recordingCurrent, "x", recordingMark{Time: t0, Offset: 1})
+		if err := rotateRecording(wt, t0.Add(time.Duration(i)*time.Minute), 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeRecording(t, wt, recordingCurrent, "y")
+
+	// The oldest rotation is pruned, and current sorts last
+	want := []string{"20260102-150505", "20260102-150605", recordingCurrent}
+	if got := recordingSegments(wt); !reflect.DeepEqual(got, want) {
+		t.Errorf("segments = %v, want %v", got, want)
+	}
+	if _, idxPath := recordingPaths(wt, "20260102-150505"); len(readRecordingMarks(idxPath)) != 1 {
+		t.Error("rotated index missing")
+	}
+}
+
+func TestRecordedLines(t *testing.T) {
+	wt := t.TempDir()
+	t0 := time.UnixMilli(1_700_000_000_000)
+	t1 := t0.Add(2 * time.Second)
+	writeRecording(t, wt, "20260102-150405", "\x1b[31mred\x1b[0m text\n", recordingMark{Time: t0, Offset: 21})
+	// Marked up to "spl"; the tail comes out stamped with the file's mtime
+	writeRecording(t, wt, recordingCurrent, "50%\r100%\n\n\n\nsplit line\n", recordingMark{Time: t0, Offset: 15})
+	logPath, _ := recordingPaths(wt, recordingCurrent)
+	if err := os.Chtimes(logPath, t1, t1); err != nil {
+		t.Fatal(err)
+	}
+
+	chunks, err := loadRecordedChunks(wt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 3 || !chunks[2].Time.Equal(t1) {
+		t.Fatalf("chunks = %+v", chunks)
+	}
+
+	var got []string
+	for _, l := range recordedLines(chunks) {
+		got = append(got, l.Text)
+	}
+	want := []string{"red text", "100%", "", "split line"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+	// A line split across chunks keeps the time it started
+	if lines := recordedLines(chunks); !lines[3].Time.Equal(t0) {
+		t.Errorf("split line time = %v", lines[3].Time)
+	}
+}
+
+func TestWriteCast(t *testing.T) {
+	t0 := time.UnixMilli(1_700_000_000_000)
+	chunks := []recordedChunk{
+		{Time: t0, Data: []byte("caf\xc3")}, // é split across chunks
+		{Time: t0.Add(1500 * time.Millisecond), Data: []byte("\xa9 \x1b[1mbold\x1b[0m\r\n")},
+		{Time: t0.Add(time.Second), Data: []byte("late")}, // Out of order mtime
+	}
+	path := filepath.Join(t.TempDir(), "run.cast")
+	if err := writeCast(path, "a", 120, 40, chunks); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	scanner := bufio.NewScanner(f)
+	scanner.Scan()
+	var header castHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		t.Fatal(err)
+	}
+	if header != (castHeader{Version: 2, Width: 120, Height: 40, Timestamp: t0.Unix(), Title: "a"}) {
+		t.Errorf("header = %+v", header)
+	}
+
+	var times []float64
+	var output strings.Builder
+	for scanner.Scan() {
+		var event []any
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+		times = append(times, event[0].(float64))
+		if event[1] != "o" {
+			t.Errorf("event type = %v", event[1])
+		}
+		output.WriteString(event[2].(string))
+	}
+	if output.String() != "café \x1b[1mbold\x1b[0m\r\nlate" {
+		t.Errorf("output = %q", output.String())
+	}
+	if !reflect.DeepEqual(times, []float64{0, 1.5, 1.5}) {
+		t.Errorf("times = %v", times)
+	}
+}
+
+func TestHistorySearch(t *testing.T) {
+	p := &Plugin{height: 40, viewMode: ViewModeHistory}
+	p.history = &historyState{name: "a"}
+	for i := range 100 {
+		text := "line"
+		if i%30 == 0 {
+			text = "Error: boom"
+		}
+		p.history.lines = append(p.history.lines, historyLine{Text: text})
+	}
+
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'/'}},
+		{Type: tea.KeyRunes, Runes: []rune("err")},
+		{Type: tea.KeyEnter},
+	}
+	for _, k := range keys {
+		p.handleHistoryKeys(k)
+	}
+	h := p.history
+	if !reflect.DeepEqual(h.matches, []int{0, 30, 60, 90}) || h.matchIdx != 0 {
+		t.Fatalf("matches %v, idx %d", h.matches, h.matchIdx)
+	}
+	p.handleHistoryKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if h.matchIdx != 1 || h.offset > 30 || h.offset+p.historyPageSize() <= 30 {
+		t.Errorf("after n: idx %d, offset %d", h.matchIdx, h.offset)
+	}
+	p.handleHistoryKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	p.handleHistoryKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	if h.matchIdx != 3 {
+		t.Errorf("N should wrap: idx %d", h.matchIdx)
+	}
+
+	p.handleHistoryKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	if p.history != nil || p.viewMode != ViewModeList {
+		t.Error("esc should close the viewer")
+	}
+}
+
+// routedApp registers p in an app model, so keys sent through it meet the
+// app-level shortcuts before reaching the plugin.
+func routedApp(t *testing.T, p *Plugin) tea.Model {
+	t.Helper()
+	reg := plugin.NewRegistry(&plugin.Context{
+		WorkDir:   t.TempDir(),
+		ConfigDir: t.TempDir(),
+		Config:    config.Default(),
+		Logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	if err := reg.Register(p); err != nil {
+		t.Fatal(err)
+	}
+	km := keymap.NewRegistry()
+	keymap.RegisterDefaults(km)
+	return app.New(reg, km, config.Default(), "test", "", "", p.ID())
+}
+
+func sendKeys(m tea.Model, keys ...string) tea.Model {
+	for _, k := range keys {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+	return m
+}
+
+func TestRecordingKeysReachPlugin(t *testing.T) {
+	p := New()
+	m := routedApp(t, p)
+	p.worktrees = []*Worktree{{Name: "a", Path: t.TempDir()}}
+
+	// j first so the app picks up the plugin's focus context
+	m = sendKeys(m, "j", "w")
+	if p.toastMessage != "No agent to record" {
+		t.Errorf("toast = %q, want the plugin's recording toast", p.toastMessage)
+	}
+
+	// Digits and app shortcuts typed into the history search stay in the query
+	p.viewMode = ViewModeHistory
+	p.history = &historyState{name: "a", lines: []historyLine{{Text: "exit 1"}}}
+	sendKeys(m, "/", "1", "W", "#", "?")
+	if p.history == nil || p.history.query != "1W#?" {
+		t.Errorf("history search should take the typed keys, got %+v", p.history)
+	}
+}
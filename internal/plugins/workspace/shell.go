@@ -314,8 +314,7 @@ func (p *Plugin) discoverTmuxSessionNames() []string {
 	projectName := filepath.Base(p.ctx.WorkDir)
 	basePrefix := shellSessionPrefix + sanitizeName(projectName)
 
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
-	output, err := cmd.Output()
+	sessions, err := tty.Current().ListSessions()
 	if err != nil {
 		return nil
 	}
@@ -323,13 +322,9 @@ func (p *Plugin) discoverTmuxSessionNames() []string {
 	var result []string
 	indexPattern := regexp.MustCompile(`^` + regexp.QuoteMeta(basePrefix) + `(?:-(\d+))?$`)
 
-	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-		if indexPattern.MatchString(line) {
-			result = append(result, line)
+	for _, name := range sessions {
+		if indexPattern.MatchString(name) {
+			result = append(result, name)
 		}
 	}
 
@@ -502,12 +497,6 @@ func (p *Plugin) generateShellSessionName() string {
 // createNewShell creates a new shell session. If customName is non-empty, it is
 // used as the display name instead of the auto-generated "Shell N".
 func (p *Plugin) createNewShell(customName string) tea.Cmd {
-	if !isTmuxInstalled() {
-		return func() tea.Msg {
-			return ShellCreatedMsg{Err: fmt.Errorf("tmux not installed: %s", getTmuxInstallInstructions())}
-		}
-	}
-
 	sessionName := p.generateShellSessionName()
 	displayName := strings.TrimSpace(customName)
 	if displayName == "" {
@@ -523,14 +512,7 @@ func (p *Plugin) createNewShell(customName string) tea.Cmd {
 		}
 
 		// Create new detached session in project directory
-		args := []string{
-			"new-session",
-			"-d",              // Detached
-			"-s", sessionName, // Session name
-			"-c", workDir, // Working directory
-		}
-		cmd := exec.Command("tmux", args...)
-		if err := cmd.Run(); err != nil {
+		if err := tty.Current().NewSession(tty.SessionOptions{Name: sessionName, Dir: workDir}); err != nil {
 			return ShellCreatedMsg{
 				SessionName: sessionName,
 				DisplayName: displayName,
@@ -554,12 +536,6 @@ func (p *Plugin) createShellWithAgent() tea.Cmd {
 	agentType := p.typeSelectorAgentType
 	skipPerms := p.typeSelectorSkipPerms
 
-	if !isTmuxInstalled() {
-		return func() tea.Msg {
-			return ShellCreatedMsg{Err: fmt.Errorf("tmux not installed: %s", getTmuxInstallInstructions())}
-		}
-	}
-
 	sessionName := p.generateShellSessionName()
 	displayName := strings.TrimSpace(customName)
 	if displayName == "" {
@@ -581,14 +557,7 @@ func (p *Plugin) createShellWithAgent() tea.Cmd {
 		}
 
 		// Create new detached session in project directory
-		args := []string{
-			"new-session",
-			"-d",              // Detached
-			"-s", sessionName, // Session name
-			"-c", workDir, // Working directory
-		}
-		cmd := exec.Command("tmux", args...)
-		if err := cmd.Run(); err != nil {
+		if err := tty.Current().NewSession(tty.SessionOptions{Name: sessionName, Dir: workDir}); err != nil {
 			return ShellCreatedMsg{
 				SessionName: sessionName,
 				DisplayName: displayName,
@@ -627,12 +596,13 @@ func (p *Plugin) recreateOrphanedShell(idx int) tea.Cmd {
 
 	return func() tea.Msg {
 		// Create new detached session
-		args := []string{"new-session", "-d", "-s", sessionName, "-c", workDir}
-		if previewWidth > 0 && previewHeight > 0 {
-			args = append(args, "-x", strconv.Itoa(previewWidth), "-y", strconv.Itoa(previewHeight))
-		}
-		cmd := exec.Command("tmux", args...)
-		if err := cmd.Run(); err != nil {
+		err := tty.Current().NewSession(tty.SessionOptions{
+			Name:   sessionName,
+			Dir:    workDir,
+			Width:  previewWidth,
+			Height: previewHeight,
+		})
+		if err != nil {
 			return ShellCreatedMsg{
 				SessionName: sessionName,
 				DisplayName: shell.Name,
@@ -675,9 +645,8 @@ func (p *Plugin) startAgentInShell(tmuxName string, agentType AgentType, skipPer
 			}
 		}
 
-		// Send the command to the shell's tmux session
-		cmd := exec.Command("tmux", "send-keys", "-t", tmuxName, baseCmd, "Enter")
-		if err := cmd.Run(); err != nil {
+		// Send the command to the shell's session
+		if err := tty.Current().SendKeys(tmuxName, tty.KeySpec{Value: baseCmd}, tty.KeySpec{Value: "Enter"}); err != nil {
 			return ShellAgentErrorMsg{
 				TmuxName: tmuxName,
 				Err:      fmt.Errorf("failed to start agent: %w", err),
@@ -734,12 +703,13 @@ func (p *Plugin) ensureShellAndAttachByIndex(idx int) tea.Cmd {
 	previewWidth, previewHeight := p.calculatePreviewDimensions()
 	return tea.Sequence(
 		func() tea.Msg {
-			args := []string{"new-session", "-d", "-s", sessionName, "-c", workDir}
-			if previewWidth > 0 && previewHeight > 0 {
-				args = append(args, "-x", strconv.Itoa(previewWidth), "-y", strconv.Itoa(previewHeight))
-			}
-			cmd := exec.Command("tmux", args...)
-			if err := cmd.Run(); err != nil {
+			err := tty.Current().NewSession(tty.SessionOptions{
+				Name:   sessionName,
+				Dir:    workDir,
+				Width:  previewWidth,
+				Height: previewHeight,
+			})
+			if err != nil {
 				return ShellCreatedMsg{
 					SessionName: sessionName,
 					DisplayName: shell.Name,
@@ -791,8 +761,7 @@ func (p *Plugin) killShellSessionByName(sessionName string) tea.Cmd {
 
 	return func() tea.Msg {
 		// Kill the session
-		cmd := exec.Command("tmux", "kill-session", "-t", sessionName)
-		_ = cmd.Run() // Ignore errors (session may already be dead)
+		_ = tty.Current().KillSession(sessionName) // Ignore errors (session may already be dead)
 
 		// Clean up pane cache
 		globalPaneCache.remove(sessionName)
@@ -955,15 +924,10 @@ func (p *Plugin) createShellWithResume(resumeCmd string) tea.Cmd {
 
 // sendResumeCommandToShell injects a command into the shell without executing it.
 func (p *Plugin) sendResumeCommandToShell(tmuxSession string, resumeCmd string) tea.Cmd {
-	if !isTmuxInstalled() {
-		return nil
-	}
-
 	return func() tea.Msg {
-		// Use tmux send-keys to type the command without pressing Enter
+		// Type the command without pressing Enter
 		// This lets the user review before executing
-		cmd := exec.Command("tmux", "send-keys", "-t", tmuxSession, resumeCmd)
-		if err := cmd.Run(); err != nil {
+		if err := tty.Current().SendKeys(tmuxSession, tty.KeySpec{Value: resumeCmd}); err != nil {
 			return shellResumeErrorMsg{Err: err}
 		}
 		return shellResumeInjectedMsg{TmuxSession: tmuxSession}
@@ -981,6 +945,7 @@ type shellResumeErrorMsg struct {
 }
 
 // worktreeResumeCreatedMsg signals that a worktree for resume was created (td-aa4136).
+// Worktree may be set alongside Err when the worktree exists but preparing it failed.
 type worktreeResumeCreatedMsg struct {
 	Worktree  *Worktree
 	ResumeCmd string
@@ -996,6 +961,7 @@ func (p *Plugin) createWorktreeWithResume(msg ResumeConversationMsg) tea.Cmd {
 	agentType := msg.AgentType
 	skipPerms := msg.SkipPerms
 	resumeCmd := msg.ResumeCmd
+	prepare := msg.Prepare
 
 	if name == "" {
 		return func() tea.Msg {
@@ -1009,6 +975,17 @@ func (p *Plugin) createWorktreeWithResume(msg ResumeConversationMsg) tea.Cmd {
 		if err != nil {
 			return worktreeResumeCreatedMsg{Err: err}
 		}
+		// A failed post-create hook keeps the worktree but skips its agent
+		if err := runWorktreeHook(p.ctx.WorkDir, HookPostCreate, wt.Path, wt.Branch); err != nil {
+			return worktreeResumeCreatedMsg{Worktree: wt, Err: fmt.Errorf("agent not started: %w", err)}
+		}
+		if prepare != nil {
+			cmd, err := prepare(wt.Path)
+			if err != nil {
+				return worktreeResumeCreatedMsg{Worktree: wt, Err: fmt.Errorf("fork session: %w", err)}
+			}
+			resumeCmd = cmd
+		}
 
 		return worktreeResumeCreatedMsg{
 			Worktree:  wt,
@@ -1026,8 +1003,7 @@ func (p *Plugin) startAgentWithResumeCmd(wt *Worktree, agentType AgentType, skip
 		sessionName := tmuxSessionPrefix + sanitizeName(wt.Name)
 
 		// Check if session already exists
-		checkCmd := exec.Command("tmux", "has-session", "-t", sessionName)
-		if checkCmd.Run() == nil {
+		if tty.Current().HasSession(sessionName) {
 			// Session exists - should not happen for new resume worktree
 			paneID := getPaneID(sessionName)
 			return AgentStartedMsg{
@@ -1040,37 +1016,37 @@ func (p *Plugin) startAgentWithResumeCmd(wt *Worktree, agentType AgentType, skip
 			}
 		}
 
-		// Create new detached session with working directory
-		args := []string{
-			"new-session",
-			"-d",              // Detached
-			"-s", sessionName, // Session name
-			"-c", wt.Path, // Working directory
+		if err := runWorktreeHook(p.ctx.WorkDir, HookPreAgentStart, wt.Path, wt.Branch); err != nil {
+			return AgentStartedMsg{Epoch: epoch, WorkspaceName: wt.Name, Err: err}
 		}
 
-		cmd := exec.Command("tmux", args...)
-		if err := cmd.Run(); err != nil {
+		// Create new detached session with working directory, keeping
+		// enough history for scrollback capture
+		backend := tty.Current()
+		err := backend.NewSession(tty.SessionOptions{
+			Name:         sessionName,
+			Dir:          wt.Path,
+			HistoryLimit: tmuxHistoryLimit,
+		})
+		if err != nil {
 			return AgentStartedMsg{Epoch: epoch, Err: fmt.Errorf("create session: %w", err)}
 		}
 
-		// Set history limit for scrollback capture
-		_ = exec.Command("tmux", "set-option", "-t", sessionName, "history-limit",
-			strconv.Itoa(tmuxHistoryLimit)).Run()
-
-		// Apply environment isolation
+		// Apply environment isolation and point agent hooks at the status file
 		envOverrides := BuildEnvOverrides(p.ctx.WorkDir)
+		envOverrides[agentStatusEnv] = agentStatusPath(wt.Path)
+		resetAgentStatus(wt.Path)
 		if envCmd := GenerateSingleEnvCommand(envOverrides); envCmd != "" {
-			_ = exec.Command("tmux", "send-keys", "-t", sessionName, envCmd, "Enter").Run()
+			_ = backend.SendKeys(sessionName, tty.KeySpec{Value: envCmd}, tty.KeySpec{Value: "Enter"})
 		}
 
 		// Small delay to ensure env is set
 		time.Sleep(100 * time.Millisecond)
 
 		// Send the resume command instead of the normal agent command
-		sendCmd := exec.Command("tmux", "send-keys", "-t", sessionName, resumeCmd, "Enter")
-		if err := sendCmd.Run(); err != nil {
+		if err := backend.SendKeys(sessionName, tty.KeySpec{Value: resumeCmd}, tty.KeySpec{Value: "Enter"}); err != nil {
 			// Try to kill the session if we failed to start the agent
-			_ = exec.Command("tmux", "kill-session", "-t", sessionName).Run()
+			_ = backend.KillSession(sessionName)
 			return AgentStartedMsg{Epoch: epoch, Err: fmt.Errorf("start agent with resume: %w", err)}
 		}
 
@@ -1,29 +1,121 @@
 package workspace
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
 )
 
+// maxIncludeDepth bounds nested {{include}}s so a snippet that includes
+// itself fails instead of recursing forever.
+const maxIncludeDepth = 8
+
 // ticketPattern matches {{ticket}} or {{ticket || 'fallback text'}}
 var ticketPattern = regexp.MustCompile(`\{\{ticket(?:\s*\|\|\s*'([^']*)')?\}\}`)
 
-// ExpandPromptTemplate expands template variables in a prompt body.
-// - {{ticket}} expands to taskID (returns empty if taskID is empty)
-// - {{ticket || 'default'}} expands to taskID, or 'default' if taskID is empty
-func ExpandPromptTemplate(body, taskID string) string {
-	return ticketPattern.ReplaceAllStringFunc(body, func(match string) string {
-		submatch := ticketPattern.FindStringSubmatch(match)
+// PromptData is the data a prompt template is rendered against.
+type PromptData struct {
+	Ticket      string            // Task key (e.g., "td-a1b2")
+	Title       string            // Task title
+	Description string            // Task description
+	Acceptance  string            // Task acceptance criteria
+	Handoff     string            // Latest handoff for the task
+	Branch      string            // Worktree branch
+	BaseBranch  string            // Branch the worktree was created from
+	Worktree    string            // Absolute worktree path
+	Vars        map[string]string // User-prompted variables
 
-		if taskID != "" {
-			return taskID
-		}
+	diffStat func() string
+}
+
+// DiffStat returns `git diff --stat` of the worktree against its base
+// branch. It's computed on use so prompts that don't need it skip the git call.
+func (d PromptData) DiffStat() string {
+	if d.diffStat == nil {
+		return ""
+	}
+	return d.diffStat()
+}
 
-		// Check for fallback value
-		if len(submatch) > 1 && submatch[1] != "" {
-			return submatch[1]
+// RenderPrompt renders a prompt body as a text/template against data.
+// {{include "file"}} renders a snippet from the first of includeDirs that
+// has it; snippet names must be relative and stay inside the directory.
+// The legacy {{ticket || 'fallback'}} form is still accepted.
+func RenderPrompt(body string, data PromptData, includeDirs []string) (string, error) {
+	r := &promptRenderer{data: data, dirs: includeDirs}
+	return r.render("prompt", body)
+}
+
+// promptRenderer renders one prompt and the snippets it includes.
+type promptRenderer struct {
+	data  PromptData
+	dirs  []string
+	depth int
+}
+
+func (r *promptRenderer) render(name, body string) (string, error) {
+	tmpl, err := template.New(name).
+		Option("missingkey=zero").
+		Funcs(template.FuncMap{
+			"ticket":  func() string { return r.data.Ticket },
+			"include": r.include,
+			"trim":    strings.TrimSpace,
+		}).
+		Parse(rewriteTicketFallbacks(body))
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, r.data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// include reads a snippet and renders it with the same data.
+func (r *promptRenderer) include(name string) (string, error) {
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("include %q: path must be relative to the prompts directory", name)
+	}
+	if r.depth >= maxIncludeDepth {
+		return "", fmt.Errorf("include %q: nested more than %d deep", name, maxIncludeDepth)
+	}
+	for _, dir := range r.dirs {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
 		}
+		r.depth++
+		defer func() { r.depth-- }()
+		return r.render(name, string(data))
+	}
+	return "", fmt.Errorf("include %q: not found in %s", name, strings.Join(r.dirs, ", "))
+}
 
-		// No fallback, return empty
-		return ""
+// rewriteTicketFallbacks turns {{ticket || 'x'}} into the equivalent
+// {{or ticket "x"}} so older prompt bodies still parse.
+func rewriteTicketFallbacks(body string) string {
+	return ticketPattern.ReplaceAllStringFunc(body, func(match string) string {
+		submatch := ticketPattern.FindStringSubmatch(match)
+		if len(submatch) < 2 || !strings.Contains(match, "||") {
+			return match
+		}
+		return "{{or ticket " + strconv.Quote(submatch[1]) + "}}"
 	})
 }
+
+// ExpandPromptTemplate expands template variables in a prompt body.
+// - {{ticket}} expands to taskID (returns empty if taskID is empty)
+// - {{ticket || 'default'}} expands to taskID, or 'default' if taskID is empty
+// Bodies that fail to render are returned unchanged.
+func ExpandPromptTemplate(body, taskID string) string {
+	out, err := RenderPrompt(body, PromptData{Ticket: taskID}, nil)
+	if err != nil {
+		return body
+	}
+	return out
+}
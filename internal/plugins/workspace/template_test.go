@@ -0,0 +1,117 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	data := PromptData{
+		Ticket:      "PER-7",
+		Title:       "Add login",
+		Description: "OAuth flow",
+		Branch:      "PER-7-add-login",
+		BaseBranch:  "main",
+		Worktree:    "/tmp/wt",
+		Vars:        map[string]string{"focus": "tests"},
+		diffStat:    func() string { return "1 file changed" },
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"task fields", "{{.Ticket}}: {{.Title}}", "PER-7: Add login"},
+		{"git fields", "{{.Branch}} from {{.BaseBranch}} in {{.Worktree}}", "PER-7-add-login from main in /tmp/wt"},
+		{"diff stat", "Changes: {{.DiffStat}}", "Changes: 1 file changed"},
+		{"var", "Focus on {{.Vars.focus}}", "Focus on tests"},
+		{"missing var is empty", "[{{.Vars.nope}}]", "[]"},
+		{"conditional set", "{{if .Description}}Details: {{.Description}}{{end}}", "Details: OAuth flow"},
+		{"conditional unset", "{{if .Acceptance}}AC{{else}}none{{end}}", "none"},
+		{"legacy ticket", "Work on {{ticket}}", "Work on PER-7"},
+		{"legacy fallback", "Review {{ticket || 'open reviews'}}", "Review PER-7"},
+		{"trim", "[{{trim \"  x \"}}]", "[x]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := RenderPrompt(tt.body, data, nil)
+			if err != nil {
+				t.Fatalf("RenderPrompt(%q): %v", tt.body, err)
+			}
+			if result != tt.expected {
+				t.Errorf("RenderPrompt(%q) = %q, want %q", tt.body, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderPromptSyntaxError(t *testing.T) {
+	if _, err := RenderPrompt("{{if .Title}}unclosed", PromptData{}, nil); err == nil {
+		t.Error("expected an error for an unclosed {{if}}")
+	}
+	// The legacy wrapper leaves bodies it can't render alone
+	if got := ExpandPromptTemplate("{{tickets}}", "td-1"); got != "{{tickets}}" {
+		t.Errorf("ExpandPromptTemplate = %q, want body unchanged", got)
+	}
+}
+
+func TestRenderPromptInclude(t *testing.T) {
+	project := t.TempDir()
+	global := t.TempDir()
+	write := func(dir, name, body string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(project, "rules.md", "Project rules for {{.Ticket}}. {{include \"shared/style.md\"}}")
+	write(global, "rules.md", "Global rules")
+	write(global, "shared/style.md", "Use gofmt.")
+	write(global, "loop.md", "{{include \"loop.md\"}}")
+	dirs := []string{project, global}
+	data := PromptData{Ticket: "PER-1"}
+
+	// Project snippets shadow global ones, and snippets can include others
+	got, err := RenderPrompt(`{{include "rules.md"}}`, data, dirs)
+	if err != nil {
+		t.Fatalf("include: %v", err)
+	}
+	if got != "Project rules for PER-1. Use gofmt." {
+		t.Errorf("include = %q", got)
+	}
+
+	for _, tt := range []struct {
+		name, body, errPart string
+	}{
+		{"missing", `{{include "nope.md"}}`, "not found"},
+		{"absolute", `{{include "/etc/passwd"}}`, "must be relative"},
+		{"escape", `{{include "../secret"}}`, "must be relative"},
+		{"recursive", `{{include "loop.md"}}`, "nested more than"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RenderPrompt(tt.body, data, dirs)
+			if err == nil || !strings.Contains(err.Error(), tt.errPart) {
+				t.Errorf("err = %v, want one containing %q", err, tt.errPart)
+			}
+		})
+	}
+}
+
+func TestPromptVarValues(t *testing.T) {
+	pr := &Prompt{
+		Vars:   []PromptVar{{Name: "focus", Default: "tests"}, {Name: "depth", Default: "shallow"}},
+		Values: map[string]string{"depth": "deep"},
+	}
+	vals := pr.VarValues()
+	if vals["focus"] != "tests" || vals["depth"] != "deep" {
+		t.Errorf("VarValues = %v", vals)
+	}
+}
@@ -38,6 +38,10 @@ const (
 	ViewModeFilePicker                     // Diff file picker modal
 	ViewModeInteractive                    // Interactive mode (tmux input passthrough)
 	ViewModeFetchPR                        // Fetch remote PR modal
+	ViewModeNotifications                  // Notification center modal
+	ViewModeFanOut                         // Fan-out launcher modal
+	ViewModeMergeQueue                     // Merge queue modal
+	ViewModeHistory                        // Output history viewer
 )
 
 // FocusPane represents which pane is active in the split view.
@@ -55,6 +59,9 @@ const (
 	PreviewTabOutput PreviewTab = iota // Agent output
 	PreviewTabDiff                     // Git diff
 	PreviewTabTask                     // TD task info
+	PreviewTabConflicts                // Predicted merge conflicts
+
+	previewTabCount = 4
 )
 
 // DiffViewMode specifies the diff rendering mode.
@@ -222,6 +229,7 @@ type Worktree struct {
 	TaskID          string         // Linked td task (e.g., "td-a1b2")
 	TaskTitle       string         // Task title (used as fallback if td show fails)
 	PRURL           string         // URL of open PR (if any)
+	BatchID         string         // Fan-out launch batch (empty if launched alone)
 	ChosenAgentType AgentType      // Agent selected at creation (persists even when agent not running)
 	Agent           *Agent         // nil if no agent running
 	Status          WorktreeStatus // Derived from agent state
@@ -254,7 +262,12 @@ type Agent struct {
 	LastOutput  time.Time     // Last time output was detected
 	OutputBuf   *OutputBuffer // Last N lines of output
 	Status      AgentStatus
-	WaitingFor  string // Prompt text if waiting
+	WaitingFor  string    // Prompt text if waiting
+	WaitingCall *ToolCall // Tool call behind WaitingFor, when a hook reported it
+
+	// Output recording (see recording.go)
+	Recording     bool  // Pane output is piped to .hermes/output
+	RecordedBytes int64 // Size of the current log at its last index mark
 
 	// Runaway detection fields (td-018f25)
 	// Track recent poll times to detect continuous output that would cause CPU spikes.
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	app "github.com/toddwbucy/hermes/internal/app"
+	appmsg "github.com/toddwbucy/hermes/internal/msg"
 	"github.com/toddwbucy/hermes/internal/plugin"
 	"github.com/toddwbucy/hermes/internal/plugins/gitstatus"
 )
@@ -65,6 +66,13 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 				selectedName = p.worktrees[p.selectedIdx].Name
 			}
 
+			// Keep agent status across the reload so transitions (and the
+			// alerts they raise) aren't lost to a momentary paused state
+			prevStatus := make(map[string]WorktreeStatus, len(p.worktrees))
+			for _, wt := range p.worktrees {
+				prevStatus[wt.Name] = wt.Status
+			}
+
 			p.worktrees = msg.Worktrees
 
 			// Restore selection by finding the worktree with the same name
@@ -96,6 +104,7 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			for _, wt := range p.worktrees {
 				if agent, ok := p.agents[wt.Name]; ok {
 					wt.Agent = agent
+					wt.Status = prevStatus[wt.Name]
 				}
 			}
 			// Load stats, task links, and agent types for each worktree
@@ -112,6 +121,8 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 				wt.PRURL = loadPRURL(wt.Path)
 				// Load base branch from .hermes-base file
 				wt.BaseBranch = loadBaseBranch(wt.Path)
+				// Load fan-out batch from .hermes/batch
+				wt.BatchID = loadBatch(wt.Path)
 			}
 			// Detect conflicts across worktrees
 			cmds = append(cmds, p.loadConflicts())
@@ -127,10 +138,53 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		}
 
 	case ConflictsDetectedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
 		if msg.Err == nil {
 			p.conflicts = msg.Conflicts
 		}
 
+	case conflictCheckMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, tea.Batch(p.loadConflicts(), p.scheduleConflictCheck(conflictCheckInterval))
+
+	case recordingToggledMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleRecordingToggled(msg)
+
+	case recordingTickMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.indexRecordings()
+
+	case recordingIndexedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleRecordingIndexed(msg)
+
+	case historyLoadedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		p.handleHistoryLoaded(msg)
+
+	case historyExportedMsg:
+		if msg.Err != nil {
+			errMsg := "Export failed: " + msg.Err.Error()
+			return p, func() tea.Msg {
+				return app.ToastMsg{Message: errMsg, Duration: 5 * time.Second, IsError: true}
+			}
+		}
+		p.toastMessage = "Exported " + msg.Path
+		p.toastTime = time.Now()
+
 	case StatsLoadedMsg:
 		// Discard stale messages from previous project
 		if plugin.IsStale(p.ctx, msg) {
@@ -193,7 +247,12 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			cmds = append(cmds, p.loadSelectedContent())
 
 			// Start agent or attach based on selection
-			if msg.AgentType != AgentNone && msg.AgentType != "" {
+			if msg.HookErr != nil {
+				errMsg := "Agent not started: " + msg.HookErr.Error()
+				cmds = append(cmds, func() tea.Msg {
+					return app.ToastMsg{Message: errMsg, Duration: 5 * time.Second, IsError: true}
+				})
+			} else if msg.AgentType != AgentNone && msg.AgentType != "" {
 				cmds = append(cmds, p.StartAgentWithOptions(msg.Worktree, msg.AgentType, msg.SkipPerms, msg.Prompt))
 			} else {
 				// "None" selected - attach to worktree directory
@@ -211,6 +270,7 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			for i, pr := range p.createPrompts {
 				if pr.Name == msg.Prompt.Name {
 					p.createPromptIdx = i
+					p.createPrompts[i].Values = msg.Prompt.Values
 					break
 				}
 			}
@@ -370,11 +430,22 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			// Start polling for output
 			cmds = append(cmds, p.scheduleAgentPoll(msg.WorkspaceName, pollIntervalInitial))
 
+			// Record from the start when configured
+			if wt := p.findWorktree(msg.WorkspaceName); wt != nil && p.recordAllAgents() {
+				cmds = append(cmds, p.startRecording(wt))
+			}
+
 			// If this is a resume operation, enter interactive mode (td-aa4136)
 			if p.pendingResumeWorktree == msg.WorkspaceName {
 				p.pendingResumeWorktree = ""
 				cmds = append(cmds, p.enterInteractiveMode())
 			}
+		} else {
+			p.pendingResumeWorktree = ""
+			errMsg := "Agent start failed: " + msg.Err.Error()
+			cmds = append(cmds, func() tea.Msg {
+				return app.ToastMsg{Message: errMsg, Duration: 5 * time.Second, IsError: true}
+			})
 		}
 
 	case pollAgentMsg:
@@ -395,11 +466,18 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 	case AgentOutputMsg:
 		// Update state (content already stored by Update() in handlePollAgent)
 		if wt := p.findWorktree(msg.WorkspaceName); wt != nil && wt.Agent != nil {
+			prev := wt.Status
 			wt.Agent.LastOutput = time.Now()
 			wt.Agent.WaitingFor = msg.WaitingFor
+			wt.Agent.WaitingCall = msg.WaitingCall
 			wt.Status = msg.Status
 			// Track poll time for runaway detection (td-018f25)
 			wt.Agent.RecordPollTime()
+			if cmd := p.applyApprovalPolicy(wt); cmd != nil {
+				cmds = append(cmds, cmd)
+			} else if cmd := p.noteStatusChange(wt, prev); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
 		// Update bracketed paste mode and cursor position if in interactive mode (td-79ab6163)
 		if p.viewMode == ViewModeInteractive && !p.shellSelected {
@@ -466,8 +544,15 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			// Update status from session file re-check (td-2fca7d v8).
 			// Session files may change even when tmux output is unchanged
 			// (e.g., agent finishes but terminal output stays the same).
+			prev := wt.Status
 			wt.Status = msg.CurrentStatus
 			wt.Agent.WaitingFor = msg.WaitingFor
+			wt.Agent.WaitingCall = msg.WaitingCall
+			if cmd := p.applyApprovalPolicy(wt); cmd != nil {
+				cmds = append(cmds, cmd)
+			} else if cmd := p.noteStatusChange(wt, prev); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
 		// Content unchanged - use longer interval based on current status
 		interval := pollIntervalIdle
@@ -692,6 +777,9 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 	case worktreeResumeCreatedMsg:
 		// Worktree created for resume - start agent with resume command (td-aa4136)
 		if msg.Err != nil {
+			if msg.Worktree != nil {
+				p.worktrees = append(p.worktrees, msg.Worktree)
+			}
 			return p, func() tea.Msg {
 				return app.ToastMsg{Message: msg.Err.Error(), Duration: 5 * time.Second, IsError: true}
 			}
@@ -984,6 +1072,7 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			// Clear waiting state, force immediate poll
 			if wt := p.findWorktree(msg.WorkspaceName); wt != nil && wt.Agent != nil {
 				wt.Agent.WaitingFor = ""
+				wt.Agent.WaitingCall = nil
 				wt.Status = StatusActive
 			}
 			cmds = append(cmds, p.scheduleAgentPoll(msg.WorkspaceName, 0))
@@ -994,6 +1083,7 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 			// Clear waiting state, force immediate poll
 			if wt := p.findWorktree(msg.WorkspaceName); wt != nil && wt.Agent != nil {
 				wt.Agent.WaitingFor = ""
+				wt.Agent.WaitingCall = nil
 				wt.Status = StatusActive
 			}
 			cmds = append(cmds, p.scheduleAgentPoll(msg.WorkspaceName, 0))
@@ -1263,6 +1353,10 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 		p.detectOrphanedWorktrees()
 		// Start periodic session validation to prevent memory leaks (td-41695b)
 		pollingCmds := append(msg.Cmds, p.scheduleSessionValidation(60*time.Second))
+		// Keep indexing recordings that outlived the last run
+		if cmd := p.ensureRecordingTick(); cmd != nil {
+			pollingCmds = append(pollingCmds, cmd)
+		}
 		return p, tea.Batch(pollingCmds...)
 
 	case validateManagedSessionsMsg:
@@ -1282,6 +1376,29 @@ func (p *Plugin) Update(msg tea.Msg) (plugin.Plugin, tea.Cmd) {
 	case OpenCreateModalWithTaskMsg:
 		return p, p.openCreateModalWithTask(msg.TaskID, msg.TaskTitle)
 
+	case appmsg.LaunchTasksMsg:
+		// Tasks sent from the Persephone board
+		p.openFanOut(msg.Tasks)
+		return p, nil
+
+	case fanOutCreatedMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleFanOutCreated(msg)
+
+	case MergeQueueStepMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.handleMergeQueueStep(msg)
+
+	case fanOutNextMsg:
+		if plugin.IsStale(p.ctx, msg) {
+			return p, nil
+		}
+		return p, p.fanOutNext()
+
 	case ResumeConversationMsg:
 		// Handle resume from conversations plugin (td-aa4136)
 		return p.handleResumeConversation(msg)
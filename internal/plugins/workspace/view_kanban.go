@@ -32,7 +32,16 @@ func (p *Plugin) renderKanbanView(width, height int) string {
 	listTab := "List"
 	kanbanTab := "[Kanban]"
 	viewToggle := styles.Muted.Render(listTab + "|" + kanbanTab)
-	headerLine := header + strings.Repeat(" ", max(1, innerWidth-len("Workspaces")-len(listTab)-len(kanbanTab)-1)) + viewToggle
+	gap := innerWidth - len("Workspaces") - len(listTab) - len(kanbanTab) - 1
+	// Fan-out batch progress, as much as fits between title and toggle
+	batches := ""
+	for _, s := range p.batchSummaries() {
+		if lipgloss.Width(batches)+lipgloss.Width(s)+3 > gap {
+			break
+		}
+		batches += "  " + s
+	}
+	headerLine := header + styles.Muted.Render(batches) + strings.Repeat(" ", max(1, gap-lipgloss.Width(batches))) + viewToggle
 	lines = append(lines, headerLine)
 	lines = append(lines, strings.Repeat(horizSep, innerWidth))
 
@@ -256,6 +265,13 @@ func (p *Plugin) renderKanbanCardLine(wt *Worktree, lineIdx, width int, isSelect
 			agentStr = "  " + string(wt.ChosenAgentType)
 		}
 		content = agentStr
+		// Fan-out batch badge, when it fits
+		if wt.BatchID != "" {
+			badge := batchLabel(wt.BatchID)
+			if lipgloss.Width(content)+lipgloss.Width(badge)+2 <= width {
+				content += strings.Repeat(" ", width-lipgloss.Width(content)-lipgloss.Width(badge)-1) + badge
+			}
+		}
 	case 2:
 		// Line 2: Task ID (rune-safe for Unicode)
 		if wt.TaskID != "" {
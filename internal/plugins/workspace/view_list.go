@@ -82,6 +82,18 @@ func (p *Plugin) View(width, height int) string {
 	case ViewModeFilePicker:
 		background := p.renderListView(width, height)
 		return p.renderFilePickerModal(background)
+	case ViewModeNotifications:
+		background := p.renderListView(width, height)
+		return p.renderNotificationsModal(background)
+	case ViewModeFanOut:
+		background := p.renderListView(width, height)
+		return p.renderFanOutModal(background)
+	case ViewModeMergeQueue:
+		background := p.renderListView(width, height)
+		return p.renderMergeQueueModal(background)
+	case ViewModeHistory:
+		background := p.renderListView(width, height)
+		return p.renderHistoryModal(background)
 	default:
 		return p.renderListView(width, height)
 	}
@@ -114,7 +126,7 @@ func (p *Plugin) renderListView(width, height int) string {
 		// Shell has no tabs - it shows primer/output directly
 		if !p.shellSelected {
 			// X starts at panelOverhead/2 (1 for border + 1 for panel padding)
-			tabWidths := []int{10, 8, 8} // " Output " + padding, " Diff " + padding, " Task " + padding
+			tabWidths := []int{10, 8, 8, 13} // " Output " + padding, " Diff " + padding, " Task " + padding, " Conflicts " + padding
 			tabX := panelOverhead / 2
 			for i, tabWidth := range tabWidths {
 				p.mouseHandler.HitMap.AddRect(regionPreviewTab, tabX, 1, tabWidth, 1, i)
@@ -173,7 +185,7 @@ func (p *Plugin) renderListView(width, height int) string {
 		previewPaneX := sidebarW + dividerWidth + panelOverhead/2
 		// Tab widths: text is " Output " (8), " Diff " (6), " Task " (6)
 		// Plus BarChip Padding(0,1) adds 2 chars = 10, 8, 8 visual width
-		tabWidths := []int{10, 8, 8}
+		tabWidths := []int{10, 8, 8, 13}
 		tabX := previewPaneX
 		for i, tabWidth := range tabWidths {
 			p.mouseHandler.HitMap.AddRect(regionPreviewTab, tabX, 1, tabWidth, 1, i)
@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/x/ansi"
 	"github.com/toddwbucy/hermes/internal/features"
 	"github.com/toddwbucy/hermes/internal/styles"
+	"github.com/toddwbucy/hermes/internal/tty"
 	"github.com/toddwbucy/hermes/internal/ui"
 )
 
@@ -64,6 +65,8 @@ func (p *Plugin) renderPreviewContent(width, height int) string {
 		content = p.renderDiffContent(width, contentHeight)
 	case PreviewTabTask:
 		content = p.renderTaskContent(width, contentHeight)
+	case PreviewTabConflicts:
+		content = p.renderConflictsContent(width, contentHeight)
 	}
 
 	lines = append(lines, content)
@@ -95,16 +98,15 @@ func (p *Plugin) renderWelcomeGuide(width, height int) string {
 	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
 	warningStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Warning)
 
-	// Check if tmux is installed
-	if !isTmuxInstalled() {
-		lines = append(lines, warningStyle.Render("⚠ tmux Required"))
+	// Without tmux, sessions run on the built-in terminal
+	if !tty.IsTmux() {
+		lines = append(lines, warningStyle.Render("⚠ Built-in Terminal"))
 		lines = append(lines, "")
-		lines = append(lines, dimText("Workspaces and shell sessions require tmux to be installed."))
+		lines = append(lines, dimText("Agents and shells run inside hermes and stop when it exits."))
+		lines = append(lines, dimText("Use interactive mode to type into them; attaching needs tmux."))
 		lines = append(lines, "")
-		lines = append(lines, sectionStyle.Render("Install tmux:"))
+		lines = append(lines, sectionStyle.Render("Install tmux for persistent sessions:"))
 		lines = append(lines, dimText("  "+getTmuxInstallInstructions()))
-		lines = append(lines, "")
-		lines = append(lines, dimText("After installing, restart hermes to use this feature."))
 		return strings.Join(lines, "\n")
 	}
 
@@ -189,7 +191,7 @@ func (p *Plugin) truncateAllLines(content string, maxWidth int) string {
 
 // renderTabs renders the preview pane tab header.
 func (p *Plugin) renderTabs(width int) string {
-	tabs := []string{"Output", "Diff", "Task"}
+	tabs := []string{"Output", "Diff", "Task", "Conflicts"}
 	var rendered []string
 
 	for i, tab := range tabs {
@@ -235,6 +237,11 @@ func (p *Plugin) renderOutputContent(width, height int) string {
 		} else {
 			hint = dimText(fmt.Sprintf("t to attach • %s to detach", detach))
 		}
+		// Latest automatic approval decisions sit under the hint
+		if audit := p.renderApprovalAudit(wt, 3); len(audit) > 0 && height > len(audit)+2 {
+			hint += "\n" + strings.Join(audit, "\n")
+			height -= len(audit)
+		}
 	}
 	height-- // Reserve line for hint
 
@@ -610,16 +617,15 @@ func (p *Plugin) renderShellPrimer(width, height int) string {
 	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
 	warningStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Warning)
 
-	// Check if tmux is installed
-	if !isTmuxInstalled() {
-		lines = append(lines, warningStyle.Render("⚠ tmux Required"))
+	// Without tmux, the shell runs on the built-in terminal
+	if !tty.IsTmux() {
+		lines = append(lines, warningStyle.Render("⚠ Built-in Terminal"))
 		lines = append(lines, "")
-		lines = append(lines, dimText("The project shell requires tmux to be installed."))
+		lines = append(lines, dimText("Shells run inside hermes and stop when it exits."))
+		lines = append(lines, dimText("Use interactive mode to type into a shell; attaching needs tmux."))
 		lines = append(lines, "")
-		lines = append(lines, sectionStyle.Render("Install tmux:"))
+		lines = append(lines, sectionStyle.Render("Install tmux for persistent sessions:"))
 		lines = append(lines, dimText("  "+getTmuxInstallInstructions()))
-		lines = append(lines, "")
-		lines = append(lines, dimText("After installing, restart hermes to use this feature."))
 		return strings.Join(lines, "\n")
 	}
 
@@ -864,3 +870,85 @@ func (p *Plugin) renderTaskContent(width, height int) string {
 
 	return strings.Join(lines, "\n")
 }
+
+// renderConflictsContent renders the merge conflicts predicted for the
+// selected worktree, with each conflicted region as it would appear after
+// merging.
+func (p *Plugin) renderConflictsContent(width, height int) string {
+	wt := p.selectedWorktree()
+	if wt == nil {
+		return dimText("No worktree selected")
+	}
+	conflicts := p.selectedConflicts(wt.Name)
+	if len(conflicts) == 0 {
+		return dimText("No predicted conflicts with the base branch or other workspaces")
+	}
+
+	var lines []string
+	for _, c := range conflicts {
+		// Name the sides in the order they were merged
+		ours, theirs := c.Base, wt.Name
+		if c.Base == "" {
+			ours, theirs = c.Worktrees[0], c.Worktrees[1]
+		}
+		other := ours
+		if other == wt.Name {
+			other = theirs
+		}
+		title := "⚠ Conflicts with " + other
+		if c.Base != "" {
+			title += " (base)"
+		}
+		lines = append(lines, styles.StatusModified.Render(title))
+		noun := "files"
+		if len(c.Files) == 1 {
+			noun = "file"
+		}
+		lines = append(lines, dimText(fmt.Sprintf("  %d %s: %s", len(c.Files), noun, strings.Join(c.Files, ", "))))
+		if len(c.Hunks) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		file := ""
+		for _, h := range c.Hunks {
+			if h.File != file {
+				file = h.File
+				lines = append(lines, "", styles.DiffHeader.Render(file))
+			}
+			side := 0 // 0 ours, 1 theirs
+			for _, l := range h.Lines {
+				l = ui.ExpandTabs(l, tabStopWidth)
+				switch {
+				case strings.HasPrefix(l, "<<<<<<< "):
+					lines = append(lines, styles.Muted.Render("<<<<<<< "+ours))
+				case strings.HasPrefix(l, ">>>>>>> "):
+					lines = append(lines, styles.Muted.Render(">>>>>>> "+theirs))
+				case l == "=======":
+					side = 1
+					lines = append(lines, styles.Muted.Render(l))
+				case side == 0:
+					lines = append(lines, styles.DiffRemove.Render(l))
+				default:
+					lines = append(lines, styles.DiffAdd.Render(l))
+				}
+			}
+		}
+		lines = append(lines, "")
+	}
+
+	// Scroll from the top
+	if p.previewOffset > len(lines)-height {
+		p.previewOffset = max(0, len(lines)-height)
+	}
+	lines = lines[p.previewOffset:]
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for i, l := range lines {
+		if lipgloss.Width(l) > width {
+			lines[i] = p.truncateCache.Truncate(l, width, "")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
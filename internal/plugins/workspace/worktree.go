@@ -248,7 +248,11 @@ func (p *Plugin) createWorktree() tea.Cmd {
 
 	return func() tea.Msg {
 		wt, err := p.doCreateWorktree(name, baseBranch, taskID, taskTitle, agentType)
-		return CreateDoneMsg{Worktree: wt, AgentType: agentType, SkipPerms: skipPerms, Prompt: prompt, Err: err}
+		var hookErr error
+		if err == nil {
+			hookErr = runWorktreeHook(p.ctx.WorkDir, HookPostCreate, wt.Path, wt.Branch)
+		}
+		return CreateDoneMsg{Worktree: wt, AgentType: agentType, SkipPerms: skipPerms, Prompt: prompt, Err: err, HookErr: hookErr}
 	}
 }
 
@@ -0,0 +1,142 @@
+package tty
+
+import (
+	"errors"
+	"os/exec"
+	"sync"
+)
+
+// Backend names, as used in config.
+const (
+	BackendAuto = "auto" // tmux when installed, otherwise pty
+	BackendTmux = "tmux" // Sessions live in the tmux server and survive restarts
+	BackendPTY  = "pty"  // Sessions live in-process and end with Hermes
+)
+
+// ErrSessionNotFound is returned for targets that don't name a running session.
+var ErrSessionNotFound = errors.New("session not found")
+
+// ErrAttachUnsupported is returned by backends that can't hand the terminal
+// over to a session.
+var ErrAttachUnsupported = errors.New("attach needs the tmux backend; use interactive mode instead")
+
+// Backend runs terminal sessions and exposes their screens. Targets are
+// session names or the pane IDs returned by PaneID. Methods block, so call
+// them from a tea.Cmd.
+type Backend interface {
+	// Name returns the backend's config name.
+	Name() string
+
+	// NewSession starts a detached session running opts.Command, or the
+	// user's shell when it is empty.
+	NewSession(opts SessionOptions) error
+	// HasSession reports whether a session is running.
+	HasSession(name string) bool
+	// KillSession ends a session and everything running in it.
+	KillSession(name string) error
+	// ListSessions returns the names of all running sessions.
+	ListSessions() ([]string, error)
+	// PaneID returns a stable target for the session's pane.
+	PaneID(session string) (string, error)
+
+	// SendKeys sends keys in order. Values are tmux key names ("Enter",
+	// "C-c", "Up"); anything else, and literal keys, is typed as text.
+	SendKeys(target string, keys ...KeySpec) error
+	// Paste inserts text in one write, wrapped in bracketed paste sequences
+	// when bracketed is set.
+	Paste(target, text string, bracketed bool) error
+
+	// Capture returns the visible screen preceded by up to scrollback lines
+	// of history, one line per row with ANSI styles. joinWrapped joins lines
+	// the terminal soft-wrapped.
+	Capture(target string, scrollback int, joinWrapped bool) (string, error)
+	// Cursor returns the pane's cursor position and size.
+	Cursor(target string) (CursorState, error)
+	// Resize sets the pane size. Non-positive dimensions are left unchanged.
+	Resize(target string, width, height int) error
+
+	// PipeOutput appends everything the pane prints from now on to path,
+	// replacing any previous pipe. An empty path stops piping.
+	PipeOutput(target, path string) error
+	// Piped reports whether the pane's output is being piped.
+	Piped(target string) bool
+
+	// AttachCommand returns a command that takes over the terminal to show
+	// the session, for tea.ExecProcess.
+	AttachCommand(session string) (*exec.Cmd, error)
+}
+
+// SessionOptions configures a new session.
+type SessionOptions struct {
+	Name          string
+	Dir           string            // Working directory
+	Command       []string          // Program and arguments; empty runs the user's shell
+	Env           map[string]string // Added to the inherited environment
+	Width, Height int               // Initial size; zero uses the backend default
+	HistoryLimit  int               // Scrollback lines kept; zero uses the backend default
+}
+
+// CursorState is a pane's cursor position (0-indexed) and size.
+type CursorState struct {
+	Row, Col      int
+	Visible       bool
+	Width, Height int
+}
+
+var (
+	backendMu sync.Mutex
+	backend   Backend
+	ptyShared *PTYBackend
+)
+
+// Configure selects the process-wide backend by config name. Unknown names
+// and "auto" pick tmux when it is installed and the PTY backend otherwise.
+// PTY sessions are kept when switching away and back.
+func Configure(name string) Backend {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+
+	if name != BackendTmux && name != BackendPTY {
+		name = BackendPTY
+		if _, err := exec.LookPath("tmux"); err == nil {
+			name = BackendTmux
+		}
+	}
+	if name == BackendTmux {
+		backend = tmuxBackend{}
+	} else {
+		if ptyShared == nil {
+			ptyShared = NewPTYBackend()
+		}
+		backend = ptyShared
+	}
+	return backend
+}
+
+// Current returns the process-wide backend, selecting one automatically if
+// Configure hasn't been called.
+func Current() Backend {
+	backendMu.Lock()
+	b := backend
+	backendMu.Unlock()
+	if b == nil {
+		return Configure(BackendAuto)
+	}
+	return b
+}
+
+// SetBackend replaces the process-wide backend and returns the previous one.
+// Tests use it to run against a PTYBackend.
+func SetBackend(b Backend) Backend {
+	backendMu.Lock()
+	defer backendMu.Unlock()
+	prev := backend
+	backend = b
+	return prev
+}
+
+// IsTmux reports whether the process-wide backend is tmux, for features that
+// only tmux provides.
+func IsTmux() bool {
+	return Current().Name() == BackendTmux
+}
@@ -1,4 +1,5 @@
-// Package tty provides an embeddable interactive tmux component for sending
-// keystrokes, mouse events, and clipboard paste to a tmux pane while capturing
-// and rendering its output.
+// Package tty provides an embeddable interactive terminal component for sending
+// keystrokes, mouse events, and clipboard paste to a session while capturing
+// and rendering its output. Sessions run on a Backend: tmux, or in-process
+// PTYs with a VT emulator when tmux isn't available.
 package tty
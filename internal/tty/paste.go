@@ -59,10 +59,10 @@ func SendBracketedPasteToTmux(sessionName, text string) error {
 	return SendLiteralToTmux(sessionName, BracketedPasteEnd)
 }
 
-// PasteClipboardToTmuxCmd returns a tea.Cmd that pastes clipboard content to a tmux session.
+// PasteClipboardCmd returns a tea.Cmd that pastes clipboard content to a session.
 // The bracketed parameter determines whether to use bracketed paste mode.
 // Returns a PasteResultMsg with the result.
-func PasteClipboardToTmuxCmd(sessionName string, bracketed bool) tea.Cmd {
+func PasteClipboardCmd(sessionName string, bracketed bool) tea.Cmd {
 	return func() tea.Msg {
 		text, err := clipboard.ReadAll()
 		if err != nil {
@@ -72,12 +72,7 @@ func PasteClipboardToTmuxCmd(sessionName string, bracketed bool) tea.Cmd {
 			return PasteResultMsg{Empty: true}
 		}
 
-		if bracketed {
-			err = SendBracketedPasteToTmux(sessionName, text)
-		} else {
-			err = SendPasteToTmux(sessionName, text)
-		}
-		if err != nil {
+		if err = Current().Paste(sessionName, text, bracketed); err != nil {
 			return PasteResultMsg{Err: err, SessionDead: IsSessionDeadError(err)}
 		}
 
@@ -85,17 +80,11 @@ func PasteClipboardToTmuxCmd(sessionName string, bracketed bool) tea.Cmd {
 	}
 }
 
-// SendPasteInputCmd sends paste text to tmux asynchronously.
+// SendPasteInputCmd sends paste text to a session asynchronously.
 // Used for multi-character terminal input (not clipboard paste which is already async).
 func SendPasteInputCmd(sessionName, text string, bracketed bool) tea.Cmd {
 	return func() tea.Msg {
-		var err error
-		if bracketed {
-			err = SendBracketedPasteToTmux(sessionName, text)
-		} else {
-			err = SendPasteToTmux(sessionName, text)
-		}
-		if err != nil {
+		if err := Current().Paste(sessionName, text, bracketed); err != nil {
 			if IsSessionDeadError(err) {
 				return SessionDeadMsg{}
 			}
@@ -0,0 +1,364 @@
+package tty
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/x/vt"
+	"github.com/creack/pty"
+)
+
+// PTY session defaults, matching tmux's.
+const (
+	ptyDefaultWidth   = 80
+	ptyDefaultHeight  = 24
+	ptyDefaultHistory = 2000
+	ptyKillWait       = 2 * time.Second
+)
+
+// PTYBackend runs sessions on pseudo-terminals inside the Hermes process,
+// with a VT emulator keeping each screen and its scrollback. Sessions end
+// when their program exits or Hermes does. A session has a single pane,
+// addressed by the session name. Safe for concurrent use.
+type PTYBackend struct {
+	mu       sync.Mutex
+	sessions map[string]*ptySession
+}
+
+// ptySession is one program on a PTY. Output is read into the emulator;
+// input goes through the emulator too, so keys are encoded for the modes
+// the program has set.
+type ptySession struct {
+	name string
+	pty  *os.File
+	cmd  *exec.Cmd
+	done chan struct{} // Closed once the program has exited
+
+	mu      sync.Mutex // Guards the fields below
+	emu     *vt.Emulator
+	visible bool     // Cursor visibility
+	pipe    *os.File // PipeOutput destination
+}
+
+// NewPTYBackend creates a backend with no sessions.
+func NewPTYBackend() *PTYBackend {
+	return &PTYBackend{sessions: make(map[string]*ptySession)}
+}
+
+func (b *PTYBackend) Name() string { return BackendPTY }
+
+func (b *PTYBackend) NewSession(opts SessionOptions) error {
+	if opts.Name == "" {
+		return errors.New("session name required")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.sessions[opts.Name]; ok {
+		return fmt.Errorf("duplicate session: %s", opts.Name)
+	}
+
+	width, height := opts.Width, opts.Height
+	if width <= 0 || height <= 0 {
+		width, height = ptyDefaultWidth, ptyDefaultHeight
+	}
+	history := opts.HistoryLimit
+	if history <= 0 {
+		history = ptyDefaultHistory
+	}
+
+	command := opts.Command
+	if len(command) == 0 {
+		shell := os.Getenv("SHELL")
+		if shell == "" {
+			shell = "/bin/sh"
+		}
+		command = []string{shell}
+	}
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Dir = opts.Dir
+	cmd.Env = append(os.Environ(), "TERM=xterm-256color")
+	keys := make([]string, 0, len(opts.Env))
+	for k := range opts.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		cmd.Env = append(cmd.Env, k+"="+opts.Env[k])
+	}
+
+	// StartWithSize makes the PTY the controlling terminal, so C-c and
+	// hangups reach the program's process group.
+	f, err := pty.StartWithSize(cmd, &pty.Winsize{Cols: uint16(width), Rows: uint16(height)})
+	if err != nil {
+		return fmt.Errorf("start %s: %w", command[0], err)
+	}
+
+	s := &ptySession{
+		name:    opts.Name,
+		pty:     f,
+		cmd:     cmd,
+		done:    make(chan struct{}),
+		emu:     vt.NewEmulator(width, height),
+		visible: true,
+	}
+	s.emu.SetScrollbackSize(history)
+	s.emu.SetCallbacks(vt.Callbacks{
+		// Called from emu.Write, with s.mu held
+		CursorVisibility: func(visible bool) { s.visible = visible },
+	})
+	b.sessions[opts.Name] = s
+
+	go s.copyInput()
+	go b.readOutput(s)
+	return nil
+}
+
+// copyInput forwards keys and the emulator's replies to the program.
+func (s *ptySession) copyInput() {
+	_, _ = io.Copy(s.pty, s.emu)
+	// Keep draining once the PTY is closed, so replies never block emu.Write
+	_, _ = io.Copy(io.Discard, s.emu)
+}
+
+// readOutput feeds the program's output to the emulator and any pipe until
+// the program exits, then removes the session.
+func (b *PTYBackend) readOutput(s *ptySession) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := s.pty.Read(buf)
+		if n > 0 {
+			s.mu.Lock()
+			_, _ = s.emu.Write(buf[:n])
+			if s.pipe != nil {
+				_, _ = s.pipe.Write(buf[:n])
+			}
+			s.mu.Unlock()
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	_ = s.cmd.Wait()
+	_ = s.pty.Close()
+	s.mu.Lock()
+	// Ends copyInput. Emulator.Close would race with the Read in progress.
+	if w, ok := s.emu.InputPipe().(io.Closer); ok {
+		_ = w.Close()
+	}
+	if s.pipe != nil {
+		_ = s.pipe.Close()
+		s.pipe = nil
+	}
+	s.mu.Unlock()
+
+	b.mu.Lock()
+	if b.sessions[s.name] == s {
+		delete(b.sessions, s.name)
+	}
+	b.mu.Unlock()
+	close(s.done)
+}
+
+// session looks up a running session by name.
+func (b *PTYBackend) session(target string) (*ptySession, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s, ok := b.sessions[target]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotFound, target)
+	}
+	return s, nil
+}
+
+func (b *PTYBackend) HasSession(name string) bool {
+	_, err := b.session(name)
+	return err == nil
+}
+
+// KillSession hangs up the session's terminal and kills the program if it
+// hasn't exited shortly after.
+func (b *PTYBackend) KillSession(name string) error {
+	s, err := b.session(name)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	delete(b.sessions, name)
+	b.mu.Unlock()
+
+	_ = s.pty.Close()
+	// Shells may not notice the closed master until they next write, so
+	// deliver the hangup directly
+	_ = s.cmd.Process.Signal(syscall.SIGHUP)
+	select {
+	case <-s.done:
+	case <-time.After(ptyKillWait):
+		_ = s.cmd.Process.Kill()
+	}
+	return nil
+}
+
+func (b *PTYBackend) ListSessions() ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	names := make([]string, 0, len(b.sessions))
+	for name := range b.sessions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (b *PTYBackend) PaneID(session string) (string, error) {
+	if _, err := b.session(session); err != nil {
+		return "", err
+	}
+	return session, nil
+}
+
+func (b *PTYBackend) SendKeys(target string, keys ...KeySpec) error {
+	s, err := b.session(target)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, k := range keys {
+		if key, ok := parseTmuxKey(k.Value); ok && !k.Literal {
+			s.emu.SendKey(key)
+		} else {
+			s.emu.SendText(k.Value)
+		}
+	}
+	return nil
+}
+
+// Paste pastes text, bracketed whenever the program has enabled bracketed
+// paste; the emulator knows the mode, so bracketed is ignored.
+func (b *PTYBackend) Paste(target, text string, bracketed bool) error {
+	s, err := b.session(target)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emu.Paste(text)
+	return nil
+}
+
+// Capture renders the screen and scrollback. The emulator doesn't track
+// soft wraps, so joinWrapped is ignored, and the alternate screen has no
+// scrollback.
+func (b *PTYBackend) Capture(target string, scrollback int, joinWrapped bool) (string, error) {
+	s, err := b.session(target)
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var sb strings.Builder
+	if scrollback > 0 && !s.emu.IsAltScreen() {
+		history := s.emu.Scrollback().Lines()
+		if len(history) > scrollback {
+			history = history[len(history)-scrollback:]
+		}
+		for _, line := range history {
+			sb.WriteString(line.Render())
+			sb.WriteByte('\n')
+		}
+	}
+	sb.WriteString(s.emu.Render())
+	sb.WriteByte('\n')
+	return sb.String(), nil
+}
+
+func (b *PTYBackend) Cursor(target string) (CursorState, error) {
+	s, err := b.session(target)
+	if err != nil {
+		return CursorState{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pos := s.emu.CursorPosition()
+	return CursorState{
+		Row:     pos.Y,
+		Col:     pos.X,
+		Visible: s.visible,
+		Width:   s.emu.Width(),
+		Height:  s.emu.Height(),
+	}, nil
+}
+
+func (b *PTYBackend) Resize(target string, width, height int) error {
+	s, err := b.session(target)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if width <= 0 {
+		width = s.emu.Width()
+	}
+	if height <= 0 {
+		height = s.emu.Height()
+	}
+	if width == s.emu.Width() && height == s.emu.Height() {
+		return nil
+	}
+	s.emu.Resize(width, height)
+	return pty.Setsize(s.pty, &pty.Winsize{Cols: uint16(width), Rows: uint16(height)})
+}
+
+func (b *PTYBackend) PipeOutput(target, path string) error {
+	s, err := b.session(target)
+	if err != nil {
+		return err
+	}
+	var f *os.File
+	if path != "" {
+		if f, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pipe != nil {
+		_ = s.pipe.Close()
+	}
+	s.pipe = f
+	return nil
+}
+
+func (b *PTYBackend) Piped(target string) bool {
+	s, err := b.session(target)
+	if err != nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pipe != nil
+}
+
+// AttachCommand is unsupported: the sessions' only screen is the one Hermes
+// renders in interactive mode.
+func (b *PTYBackend) AttachCommand(session string) (*exec.Cmd, error) {
+	return nil, ErrAttachUnsupported
+}
+
+// Close kills every session, for shutdown and tests.
+func (b *PTYBackend) Close() {
+	names, _ := b.ListSessions()
+	for _, name := range names {
+		_ = b.KillSession(name)
+	}
+}
@@ -0,0 +1,214 @@
+package tty
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/vt"
+)
+
+// waitFor polls cond until it holds or a few seconds pass.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// newTestPTY returns a backend running a plain sh session named "s".
+func newTestPTY(t *testing.T) *PTYBackend {
+	t.Helper()
+	b := NewPTYBackend()
+	t.Cleanup(b.Close)
+	err := b.NewSession(SessionOptions{
+		Name:    "s",
+		Dir:     t.TempDir(),
+		Command: []string{"sh"},
+		Env:     map[string]string{"PS1": "$ ", "GREETING": "hello"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func captureContains(b *PTYBackend, target, want string) func() bool {
+	return func() bool {
+		output, err := b.Capture(target, 100, false)
+		return err == nil && strings.Contains(output, want)
+	}
+}
+
+func TestParseTmuxKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want vt.KeyPressEvent
+		ok   bool
+	}{
+		{"Enter", vt.KeyPressEvent{Code: vt.KeyEnter}, true},
+		{"enter", vt.KeyPressEvent{Code: vt.KeyEnter}, true},
+		{"BSpace", vt.KeyPressEvent{Code: vt.KeyBackspace}, true},
+		{"NPage", vt.KeyPressEvent{Code: vt.KeyPgDown}, true},
+		{"F12", vt.KeyPressEvent{Code: vt.KeyF12}, true},
+		{"C-c", vt.KeyPressEvent{Code: 'c', Mod: vt.ModCtrl}, true},
+		{"C-C", vt.KeyPressEvent{Code: 'c', Mod: vt.ModCtrl}, true},
+		{"M-/", vt.KeyPressEvent{Code: '/', Mod: vt.ModAlt}, true},
+		{"C-M-x", vt.KeyPressEvent{Code: 'x', Mod: vt.ModCtrl | vt.ModAlt}, true},
+		{"BTab", vt.KeyPressEvent{Code: vt.KeyTab, Mod: vt.ModShift}, true},
+		{"x", vt.KeyPressEvent{}, false},
+		{":wq", vt.KeyPressEvent{}, false},
+		{"echo hi", vt.KeyPressEvent{}, false},
+		{"C-", vt.KeyPressEvent{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseTmuxKey(tt.name)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseTmuxKey(%q) = %+v, %v; want %+v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPTYBackend_Session(t *testing.T) {
+	b := newTestPTY(t)
+
+	if !b.HasSession("s") || b.HasSession("other") {
+		t.Fatal("HasSession")
+	}
+	if names, _ := b.ListSessions(); len(names) != 1 || names[0] != "s" {
+		t.Errorf("ListSessions = %v", names)
+	}
+	if pane, err := b.PaneID("s"); err != nil || pane != "s" {
+		t.Errorf("PaneID = %q, %v", pane, err)
+	}
+	if err := b.NewSession(SessionOptions{Name: "s"}); err == nil {
+		t.Error("duplicate session should fail")
+	}
+
+	// Non-key strings are typed, like tmux send-keys
+	if err := b.SendKeys("s", KeySpec{Value: "echo $GREETING-$((1+2))"}, KeySpec{Value: "Enter"}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "echo output", captureContains(b, "s", "\nhello-3\n"))
+
+	cursor, err := b.Cursor("s")
+	if err != nil || cursor.Width != ptyDefaultWidth || cursor.Height != ptyDefaultHeight || cursor.Row < 2 {
+		t.Errorf("Cursor = %+v, %v", cursor, err)
+	}
+
+	// Resizing reaches the program
+	if err := b.Resize("s", 100, 30); err != nil {
+		t.Fatal(err)
+	}
+	_ = b.SendKeys("s", KeySpec{Value: "stty size"}, KeySpec{Value: "Enter"})
+	waitFor(t, "new size", captureContains(b, "s", "30 100"))
+
+	_, err = b.Capture("missing", 0, false)
+	if !IsSessionDeadError(err) {
+		t.Errorf("Capture(missing) err = %v", err)
+	}
+
+	if err := b.KillSession("s"); err != nil {
+		t.Fatal(err)
+	}
+	if b.HasSession("s") {
+		t.Error("session survived KillSession")
+	}
+}
+
+func TestPTYBackend_PipeOutput(t *testing.T) {
+	b := newTestPTY(t)
+	path := filepath.Join(t.TempDir(), "out.log")
+
+	if err := b.PipeOutput("s", path); err != nil {
+		t.Fatal(err)
+	}
+	if !b.Piped("s") {
+		t.Error("Piped = false")
+	}
+	_ = b.SendKeys("s", KeySpec{Value: "printf '\\033[1mpiped\\033[0m\\n'"}, KeySpec{Value: "Enter"})
+	waitFor(t, "piped output", func() bool {
+		data, _ := os.ReadFile(path)
+		return strings.Contains(string(data), "\x1b[1mpiped\x1b[0m")
+	})
+
+	if err := b.PipeOutput("s", ""); err != nil || b.Piped("s") {
+		t.Errorf("stop piping: %v", err)
+	}
+}
+
+func TestPTYBackend_ProgramExitEndsSession(t *testing.T) {
+	b := newTestPTY(t)
+	_ = b.SendKeys("s", KeySpec{Value: "exit"}, KeySpec{Value: "Enter"})
+	waitFor(t, "session end", func() bool { return !b.HasSession("s") })
+}
+
+// runCmd runs cmd and any batched commands, returning the messages.
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		var msgs []tea.Msg
+		for _, c := range msg {
+			msgs = append(msgs, runCmd(c)...)
+		}
+		return msgs
+	case nil:
+		return nil
+	default:
+		return []tea.Msg{msg}
+	}
+}
+
+func TestModel_PTYBackend(t *testing.T) {
+	b := newTestPTY(t)
+	defer SetBackend(SetBackend(b))
+
+	m := New(nil)
+	m.Width, m.Height = 60, 20
+	m.Enter("s", "")
+	if cursor, _ := b.Cursor("s"); cursor.Width != 60 || cursor.Height != 20 {
+		t.Errorf("Enter should resize the pane: %+v", cursor)
+	}
+
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("echo typed")},
+		{Type: tea.KeyEnter},
+	}
+	for _, k := range keys {
+		runCmd(m.Update(k))
+	}
+
+	// Poll until the capture shows the output
+	waitFor(t, "rendered output", func() bool {
+		m.State.PollGeneration++
+		for _, msg := range runCmd(m.Update(PollTickMsg{Target: "s", Generation: m.State.PollGeneration})) {
+			if capture, ok := msg.(CaptureResultMsg); ok {
+				m.Update(capture)
+			}
+		}
+		return strings.Contains(m.View(), "\ntyped")
+	})
+	if m.State.PaneWidth != 60 || !m.State.CursorVisible {
+		t.Errorf("state = %+v", m.State)
+	}
+
+	// The session ending leaves interactive mode
+	_ = b.KillSession("s")
+	m.State.PollGeneration++
+	for _, msg := range runCmd(m.Update(PollTickMsg{Target: "s", Generation: m.State.PollGeneration})) {
+		m.Update(msg)
+	}
+	if m.IsActive() {
+		t.Error("model should exit when the session is gone")
+	}
+}
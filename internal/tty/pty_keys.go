@@ -0,0 +1,85 @@
+package tty
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/x/vt"
+)
+
+// ptyNamedKeys maps lowercased tmux key names to emulator keys.
+var ptyNamedKeys = map[string]rune{
+	"enter":    vt.KeyEnter,
+	"bspace":   vt.KeyBackspace,
+	"dc":       vt.KeyDelete,
+	"tab":      vt.KeyTab,
+	"space":    vt.KeySpace,
+	"escape":   vt.KeyEscape,
+	"up":       vt.KeyUp,
+	"down":     vt.KeyDown,
+	"left":     vt.KeyLeft,
+	"right":    vt.KeyRight,
+	"home":     vt.KeyHome,
+	"end":      vt.KeyEnd,
+	"ic":       vt.KeyInsert,
+	"ppage":    vt.KeyPgUp,
+	"pageup":   vt.KeyPgUp,
+	"pgup":     vt.KeyPgUp,
+	"npage":    vt.KeyPgDown,
+	"pagedown": vt.KeyPgDown,
+	"pgdn":     vt.KeyPgDown,
+	"f1":       vt.KeyF1,
+	"f2":       vt.KeyF2,
+	"f3":       vt.KeyF3,
+	"f4":       vt.KeyF4,
+	"f5":       vt.KeyF5,
+	"f6":       vt.KeyF6,
+	"f7":       vt.KeyF7,
+	"f8":       vt.KeyF8,
+	"f9":       vt.KeyF9,
+	"f10":      vt.KeyF10,
+	"f11":      vt.KeyF11,
+	"f12":      vt.KeyF12,
+}
+
+// parseTmuxKey converts a tmux key name ("Enter", "C-c", "M-x", "BTab") to
+// an emulator key event. Like tmux, names are case-insensitive apart from
+// single characters; ok is false for strings that aren't key names, which
+// send-keys types as text.
+func parseTmuxKey(name string) (key vt.KeyPressEvent, ok bool) {
+	var mod vt.KeyMod
+	for len(name) > 2 && name[1] == '-' {
+		switch name[0] {
+		case 'C', 'c':
+			mod |= vt.ModCtrl
+		case 'M', 'm':
+			mod |= vt.ModAlt
+		case 'S', 's':
+			mod |= vt.ModShift
+		default:
+			return key, false
+		}
+		name = name[2:]
+	}
+
+	if strings.EqualFold(name, "BTab") {
+		return vt.KeyPressEvent{Code: vt.KeyTab, Mod: mod | vt.ModShift}, true
+	}
+	if code, found := ptyNamedKeys[strings.ToLower(name)]; found {
+		return vt.KeyPressEvent{Code: code, Mod: mod}, true
+	}
+	if r, size := utf8.DecodeRuneInString(name); mod != 0 && size == len(name) && r != utf8.RuneError {
+		if mod&vt.ModCtrl != 0 {
+			r = toLowerASCII(r) // C-A is C-a
+		}
+		return vt.KeyPressEvent{Code: r, Mod: mod}, true
+	}
+	return key, false
+}
+
+func toLowerASCII(r rune) rune {
+	if r >= 'A' && r <= 'Z' {
+		return r + ('a' - 'A')
+	}
+	return r
+}
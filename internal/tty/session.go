@@ -1,6 +1,7 @@
 package tty
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
 	"strconv"
@@ -9,11 +10,14 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// IsSessionDeadError checks if an error indicates the tmux session/pane is gone.
+// IsSessionDeadError checks if an error indicates the session/pane is gone.
 func IsSessionDeadError(err error) bool {
 	if err == nil {
 		return false
 	}
+	if errors.Is(err, ErrSessionNotFound) {
+		return true
+	}
 	errStr := err.Error()
 	return strings.Contains(errStr, "can't find pane") ||
 		strings.Contains(errStr, "no such session") ||
@@ -45,21 +49,13 @@ func SendLiteralToTmux(sessionName, text string) error {
 	return cmd.Run()
 }
 
-// SendKeysCmd sends keys to tmux asynchronously.
+// SendKeysCmd sends keys through the current backend asynchronously.
 // Keys are sent in order within a single goroutine to prevent reordering.
 // Returns SessionDeadMsg if the session has ended.
 func SendKeysCmd(sessionName string, keys ...KeySpec) tea.Cmd {
 	return func() tea.Msg {
-		for _, k := range keys {
-			var err error
-			if k.Literal {
-				err = SendLiteralToTmux(sessionName, k.Value)
-			} else {
-				err = SendKeyToTmux(sessionName, k.Value)
-			}
-			if err != nil && IsSessionDeadError(err) {
-				return SessionDeadMsg{}
-			}
+		if err := Current().SendKeys(sessionName, keys...); IsSessionDeadError(err) {
+			return SessionDeadMsg{}
 		}
 		return nil
 	}
@@ -97,37 +93,28 @@ func ResizeTmuxPane(paneID string, width, height int) {
 
 // SetWindowSizeManual sets the tmux window-size option to "manual" for a session.
 // This prevents tmux from auto-constraining window size based on attached clients,
-// allowing resize-window commands to stick reliably.
+// allowing resize-window commands to stick reliably. PTY sessions have no
+// clients, so it does nothing for them.
 func SetWindowSizeManual(sessionName string) {
+	if !IsTmux() {
+		return
+	}
 	_ = exec.Command("tmux", "set-option", "-t", sessionName, "window-size", "manual").Run()
 }
 
-// QueryPaneSize queries the current size of a tmux pane.
+// QueryPaneSize queries the current size of a pane.
 func QueryPaneSize(target string) (width, height int, ok bool) {
 	if target == "" {
 		return 0, 0, false
 	}
-
-	cmd := exec.Command("tmux", "display-message", "-t", target, "-p", "#{pane_width},#{pane_height}")
-	output, err := cmd.Output()
+	cursor, err := Current().Cursor(target)
 	if err != nil {
 		return 0, 0, false
 	}
-
-	parts := strings.Split(strings.TrimSpace(string(output)), ",")
-	if len(parts) < 2 {
-		return 0, 0, false
-	}
-
-	width, err1 := strconv.Atoi(parts[0])
-	height, err2 := strconv.Atoi(parts[1])
-	if err1 != nil || err2 != nil {
-		return 0, 0, false
-	}
-	return width, height, true
+	return cursor.Width, cursor.Height, true
 }
 
-// SendSGRMouse sends an SGR mouse event to a tmux pane.
+// SendSGRMouse sends an SGR mouse event to a pane.
 // button is the mouse button (0=left, 1=middle, 2=right).
 // col and row are 1-indexed coordinates.
 // release indicates if this is a button release event.
@@ -140,7 +127,7 @@ func SendSGRMouse(sessionName string, button, col, row int, release bool) error
 		suffix = "m"
 	}
 	seq := fmt.Sprintf("\x1b[<%d;%d;%d%s", button, col, row, suffix)
-	return SendLiteralToTmux(sessionName, seq)
+	return Current().SendKeys(sessionName, KeySpec{Value: seq, Literal: true})
 }
 
 // CapturePaneOutput captures the current output of a tmux pane.
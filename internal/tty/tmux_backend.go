@@ -0,0 +1,174 @@
+package tty
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tmuxCaptureTimeout bounds capture-pane so a wedged server can't stall polling.
+const tmuxCaptureTimeout = 2 * time.Second
+
+// tmuxBackend runs sessions in the user's tmux server.
+type tmuxBackend struct{}
+
+// runTmux runs a tmux command and returns its output. Errors carry tmux's
+// message, so IsSessionDeadError can recognize them.
+func runTmux(args ...string) (string, error) {
+	output, err := exec.Command("tmux", args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("tmux %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("tmux %s: %w", args[0], err)
+	}
+	return string(output), nil
+}
+
+func (tmuxBackend) Name() string { return BackendTmux }
+
+func (tmuxBackend) NewSession(opts SessionOptions) error {
+	args := []string{"new-session", "-d", "-s", opts.Name}
+	if opts.Dir != "" {
+		args = append(args, "-c", opts.Dir)
+	}
+	if opts.Width > 0 && opts.Height > 0 {
+		args = append(args, "-x", strconv.Itoa(opts.Width), "-y", strconv.Itoa(opts.Height))
+	}
+	keys := make([]string, 0, len(opts.Env))
+	for k := range opts.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "-e", k+"="+opts.Env[k])
+	}
+	args = append(args, opts.Command...)
+	if _, err := runTmux(args...); err != nil {
+		return err
+	}
+	if opts.HistoryLimit > 0 {
+		_, _ = runTmux("set-option", "-t", opts.Name, "history-limit", strconv.Itoa(opts.HistoryLimit))
+	}
+	return nil
+}
+
+func (tmuxBackend) HasSession(name string) bool {
+	return exec.Command("tmux", "has-session", "-t", name).Run() == nil
+}
+
+func (tmuxBackend) KillSession(name string) error {
+	_, err := runTmux("kill-session", "-t", name)
+	return err
+}
+
+func (tmuxBackend) ListSessions() ([]string, error) {
+	output, err := runTmux("list-sessions", "-F", "#{session_name}")
+	if err != nil {
+		return nil, err
+	}
+	var sessions []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			sessions = append(sessions, line)
+		}
+	}
+	return sessions, nil
+}
+
+// PaneID returns the session's first pane ID, like "%12". Pane IDs are
+// globally unique and stable.
+func (tmuxBackend) PaneID(session string) (string, error) {
+	output, err := runTmux("list-panes", "-t", session, "-F", "#{pane_id}")
+	if err != nil {
+		return "", err
+	}
+	paneID, _, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	return paneID, nil
+}
+
+func (tmuxBackend) SendKeys(target string, keys ...KeySpec) error {
+	for _, k := range keys {
+		var err error
+		if k.Literal {
+			err = SendLiteralToTmux(target, k.Value)
+		} else {
+			err = SendKeyToTmux(target, k.Value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tmuxBackend) Paste(target, text string, bracketed bool) error {
+	if bracketed {
+		return SendBracketedPasteToTmux(target, text)
+	}
+	return SendPasteToTmux(target, text)
+}
+
+func (tmuxBackend) Capture(target string, scrollback int, joinWrapped bool) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tmuxCaptureTimeout)
+	defer cancel()
+	args := []string{"capture-pane", "-p", "-e"}
+	if joinWrapped {
+		args = append(args, "-J")
+	}
+	if scrollback > 0 {
+		args = append(args, "-S", fmt.Sprintf("-%d", scrollback))
+	}
+	args = append(args, "-t", target)
+	output, err := exec.CommandContext(ctx, "tmux", args...).Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("capture-pane: timeout after %s", tmuxCaptureTimeout)
+	}
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("capture-pane: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("capture-pane: %w", err)
+	}
+	return string(output), nil
+}
+
+func (tmuxBackend) Cursor(target string) (CursorState, error) {
+	row, col, height, width, visible, ok := QueryCursorPositionSync(target)
+	if !ok {
+		return CursorState{}, fmt.Errorf("tmux display-message: can't read cursor for %s", target)
+	}
+	return CursorState{Row: row, Col: col, Visible: visible, Width: width, Height: height}, nil
+}
+
+func (tmuxBackend) Resize(target string, width, height int) error {
+	ResizeTmuxPane(target, width, height)
+	return nil
+}
+
+func (tmuxBackend) PipeOutput(target, path string) error {
+	args := []string{"pipe-pane", "-t", target}
+	if path != "" {
+		args = append(args, "cat >> "+shellQuote(path))
+	}
+	_, err := runTmux(args...)
+	return err
+}
+
+func (tmuxBackend) Piped(target string) bool {
+	output, err := runTmux("display-message", "-p", "-t", target, "#{pane_pipe}")
+	return err == nil && strings.TrimSpace(output) == "1"
+}
+
+func (tmuxBackend) AttachCommand(session string) (*exec.Cmd, error) {
+	return exec.Command("tmux", "attach-session", "-t", session), nil
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -36,15 +36,15 @@ func DefaultConfig() Config {
 	}
 }
 
-// State tracks the interactive mode state for a tmux session.
+// State tracks the interactive mode state for a session.
 type State struct {
 	// Active indicates whether interactive mode is currently active.
 	Active bool
 
-	// TargetPane is the tmux pane ID (e.g., "%12") receiving input.
+	// TargetPane is the pane ID (e.g., "%12" for tmux) receiving input.
 	TargetPane string
 
-	// TargetSession is the tmux session name for the active pane.
+	// TargetSession is the session name for the active pane.
 	TargetSession string
 
 	// LastKeyTime tracks when the last key was sent for polling decay.
@@ -85,7 +85,8 @@ type State struct {
 	PollGeneration int
 }
 
-// Model is an embeddable component that provides interactive tmux functionality.
+// Model is an embeddable component that provides interactive terminal functionality
+// for a session of the current Backend.
 // Plugins embed this Model and delegate Update/View when interactive mode is active.
 type Model struct {
 	Config Config
@@ -131,7 +132,7 @@ func (m *Model) IsActive() bool {
 	return m.State != nil && m.State.Active
 }
 
-// Enter enters interactive mode for the specified tmux session/pane.
+// Enter enters interactive mode for the specified session/pane.
 // Returns a tea.Cmd to start polling for output.
 func (m *Model) Enter(sessionName, paneID string) tea.Cmd {
 	m.State = &State{
@@ -149,7 +150,7 @@ func (m *Model) Enter(sessionName, paneID string) tea.Cmd {
 		target = sessionName
 	}
 	if target != "" && m.Width > 0 && m.Height > 0 {
-		ResizeTmuxPane(target, m.Width, m.Height)
+		_ = Current().Resize(target, m.Width, m.Height)
 	}
 
 	// Return command to trigger initial poll
@@ -235,7 +236,7 @@ func (m *Model) View() string {
 	return content
 }
 
-// GetTarget returns the current tmux target (pane ID or session name).
+// GetTarget returns the current target (pane ID or session name).
 func (m *Model) GetTarget() string {
 	if !m.IsActive() {
 		return ""
@@ -333,7 +334,7 @@ func (m *Model) handleKey(msg tea.KeyMsg) tea.Cmd {
 	// Paste key
 	if msg.String() == m.Config.PasteKey {
 		m.State.LastKeyTime = time.Now()
-		return PasteClipboardToTmuxCmd(m.State.TargetSession, m.State.BracketedPasteEnabled)
+		return PasteClipboardCmd(m.State.TargetSession, m.State.BracketedPasteEnabled)
 	}
 
 	// Update last key time
@@ -347,16 +348,11 @@ func (m *Model) handleKey(msg tea.KeyMsg) tea.Cmd {
 		bracketed := m.State.BracketedPasteEnabled
 		if pendingEscape {
 			cmds = append(cmds, func() tea.Msg {
-				if err := SendKeyToTmux(sessionName, "Escape"); err != nil && IsSessionDeadError(err) {
+				backend := Current()
+				if err := backend.SendKeys(sessionName, KeySpec{"Escape", false}); IsSessionDeadError(err) {
 					return SessionDeadMsg{}
 				}
-				var err error
-				if bracketed {
-					err = SendBracketedPasteToTmux(sessionName, text)
-				} else {
-					err = SendPasteToTmux(sessionName, text)
-				}
-				if err != nil && IsSessionDeadError(err) {
+				if err := backend.Paste(sessionName, text, bracketed); IsSessionDeadError(err) {
 					return SessionDeadMsg{}
 				}
 				return nil
@@ -368,7 +364,7 @@ func (m *Model) handleKey(msg tea.KeyMsg) tea.Cmd {
 		return tea.Batch(cmds...)
 	}
 
-	// Map key to tmux format and send
+	// Map key to tmux key names (understood by every backend) and send
 	key, useLiteral := MapKeyToTmux(msg)
 	if key == "" {
 		if pendingEscape {
@@ -440,7 +436,7 @@ func (m *Model) handleEscapeTimer() tea.Cmd {
 		return nil
 	}
 
-	// Timer fired with pending Escape: forward it to tmux
+	// Timer fired with pending Escape: forward it to the session
 	m.State.EscapePressed = false
 	m.State.LastKeyTime = time.Now()
 
@@ -450,7 +446,7 @@ func (m *Model) handleEscapeTimer() tea.Cmd {
 	)
 }
 
-// handleCaptureResult processes captured output from tmux.
+// handleCaptureResult processes captured output from the session.
 func (m *Model) handleCaptureResult(msg CaptureResultMsg) tea.Cmd {
 	if !m.IsActive() || m.State.OutputBuf == nil {
 		return nil
@@ -503,22 +499,24 @@ func (m *Model) handlePollTick(msg PollTickMsg) tea.Cmd {
 	}
 
 	// Capture output and cursor position atomically
+	scrollback := m.Config.ScrollbackLines
 	return func() tea.Msg {
-		output, err := CapturePaneOutput(target, m.Config.ScrollbackLines)
+		backend := Current()
+		output, err := backend.Capture(target, scrollback, false)
 		if err != nil {
 			return CaptureResultMsg{Target: target, Err: err}
 		}
 
-		row, col, paneHeight, paneWidth, visible, _ := QueryCursorPositionSync(target)
+		cursor, _ := backend.Cursor(target)
 
 		return CaptureResultMsg{
 			Target:        target,
 			Output:        output,
-			CursorRow:     row,
-			CursorCol:     col,
-			CursorVisible: visible,
-			PaneHeight:    paneHeight,
-			PaneWidth:     paneWidth,
+			CursorRow:     cursor.Row,
+			CursorCol:     cursor.Col,
+			CursorVisible: cursor.Visible,
+			PaneHeight:    cursor.Height,
+			PaneWidth:     cursor.Width,
 		}
 	}
 }
@@ -574,7 +572,7 @@ func (m *Model) SetDimensions(width, height int) tea.Cmd {
 		if ok && actualWidth == width && actualHeight == height {
 			return nil
 		}
-		ResizeTmuxPane(target, width, height)
+		_ = Current().Resize(target, width, height)
 		return PaneResizedMsg{}
 	}
 }
@@ -605,7 +603,7 @@ func (m *Model) ResizeAndPollImmediate(width, height int) tea.Cmd {
 		if ok && actualWidth == width && actualHeight == height {
 			return nil
 		}
-		ResizeTmuxPane(target, width, height)
+		_ = Current().Resize(target, width, height)
 		return PaneResizedMsg{}
 	}
 